@@ -11,6 +11,7 @@ import (
 	"github.com/vulnetix/cli/v3/internal/display"
 	"github.com/vulnetix/cli/v3/internal/managedfile"
 	aifw "github.com/vulnetix/cli/v3/pkg/aifirewall"
+	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/vdb"
 )
 
@@ -71,7 +72,7 @@ func addAiFirewallWiringFlags(cmd *cobra.Command) {
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().String("gateway-url", aifw.DefaultGatewayURL, "AI Firewall gateway URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 }
 
 // aiFirewallContext gathers everything a wiring command needs: the org's policy
@@ -713,7 +714,7 @@ func renderAiFirewallInstall(ctx *display.Context, o aifw.Options, fwctx *aiFire
 		{Key: "Organization", Value: o.OrgUUID},
 		{Key: "Gateway", Value: o.Gateway},
 		{Key: "Providers", Value: strings.Join(providerSlugs(targets), ", ")},
-		{Key: "API key", Value: managedfile.MaskSecret(o.APIKey)},
+		{Key: "API key", Value: auth.MaskSecret(o.APIKey)},
 	}) + "\n")
 
 	b.WriteString("\n" + display.Subheader(t, "Actions") + "\n")
@@ -835,7 +836,7 @@ stripped from it. A file you wrote is never deleted.`,
 	cmd.Flags().Bool("dry-run", false, "Show planned changes without writing files")
 	cmd.Flags().String("gateway-url", aifw.DefaultGatewayURL, "AI Firewall gateway URL (host to detect and strip)")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	cmd.ValidArgs = clientIDs()
 	return cmd
 }
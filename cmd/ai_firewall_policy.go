@@ -8,8 +8,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vulnetix/cli/v3/internal/display"
-	"github.com/vulnetix/cli/v3/internal/managedfile"
 	aifw "github.com/vulnetix/cli/v3/pkg/aifirewall"
+	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/vdb"
 )
 
@@ -116,7 +116,7 @@ func runAiFirewallKeySet(cmd *cobra.Command, args []string) error {
 		return ctx.Logger.ResultJSON(resp.Data)
 	}
 	ctx.Logger.Result(renderPackageFirewallResult(ctx,
-		fmt.Sprintf("Stored %s key (%s)", slug, managedfile.MaskSecret(key)), resp.Data))
+		fmt.Sprintf("Stored %s key (%s)", slug, auth.MaskSecret(key)), resp.Data))
 	return nil
 }
 
@@ -72,6 +72,7 @@ func init() {
 	aibomCmd.Flags().Int("depth", 25, "Maximum recursion depth for file discovery")
 	aibomCmd.Flags().StringArray("ignore", nil, "Exclude paths matching glob pattern (repeatable)")
 	aibomCmd.Flags().StringP("output", "o", "pretty", "Terminal output format: pretty, json, cyclonedx-json")
+	declareOutputFormats(aibomCmd, "pretty", "json", "cyclonedx-json")
 	aibomCmd.Flags().String("output-file", "", "Path to write the CycloneDX AIBOM (default: <path>/.vulnetix/ai-bom.cdx.json)")
 	aibomCmd.Flags().String("spec-version", "1.7", "CycloneDX spec version: 1.6 or 1.7")
 	aibomCmd.Flags().String("catalog", "", "Path to a catalog file to merge over (or replace) the builtin catalog")
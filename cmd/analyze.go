@@ -76,6 +76,7 @@ func init() {
 	analyzeCmd.Flags().Int("max-commits", 20000, "Cap on commits walked; when hit, the report declares it")
 	analyzeCmd.Flags().Int("complexity-threshold", 15, "Cyclomatic complexity at which a file counts as highly complex")
 	analyzeCmd.Flags().StringP("output", "o", "pretty", "Terminal output format: pretty, json")
+	declareOutputFormats(analyzeCmd, "pretty", "json")
 	analyzeCmd.Flags().String("output-file", "", "Where to write the report (default: <path>/.vulnetix/analyze.report.json)")
 	analyzeCmd.Flags().Bool("no-git", false, "Skip the history walk (activity, contributor and ownership metrics are then absent, not zero)")
 	analyzeCmd.Flags().Bool("no-files", false, "Skip the file and complexity pass")
@@ -5,19 +5,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/vulnetix/cli/v3/internal/analytics"
 	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/internal/upload"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/vdb"
+	"golang.org/x/term"
 )
 
 var (
@@ -29,7 +31,10 @@ var (
 	authStore          string
 	authStoreDir       string
 	authNoninteractive bool
+	authWeb            bool
+	authEncrypt        bool
 	authStatusBaseURL  string
+	authStatusJSON     bool
 )
 
 // authCmd represents the auth command
@@ -48,6 +53,15 @@ Examples:
   # Non-interactive login with SigV4
   vulnetix auth login --org-id UUID --secret KEY --store home
 
+  # Browser Device Flow login, saved straight to the keyring
+  vulnetix auth login --web --store keyring
+
+  # Store a second organization's credentials under a named profile
+  vulnetix auth login --profile staging --api-key KEY --org-id UUID
+
+  # Encrypt the home credentials file with a passphrase
+  vulnetix auth login --store home --encrypt
+
   # Check auth status
   vulnetix auth status
 
@@ -68,8 +82,12 @@ Credential flags (org-scoped methods require --org-id):
   --secret KEY         SigV4 secret from your VDB account (requires --org-id)
   --token KEY          Bearer token (org resolved server-side; no --org-id)
   --noninteractive     Require ApiKey (--api-key + --org-id) from flags or environment
+  --web                Use the browser Device Flow; with --store, save there without asking
   --store home|project|keyring
-  --store-dir DIR      Override the default home credential directory`,
+  --store-dir DIR      Override the default home credential directory
+  --encrypt            Encrypt the home or project file with a passphrase, prompted
+                       for or read from $VULNETIX_CREDENTIALS_PASSPHRASE
+  --profile NAME       Save under a named profile instead of the default one`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runAuthLogin(cmd)
 	},
@@ -79,6 +97,10 @@ var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current authentication state",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if authStatusJSON {
+			initDisplayContext(cmd, display.ModeJSON)
+			return display.FromCommand(cmd).Logger.ResultJSON(collectAuthStatusInfo())
+		}
 		ctx := display.FromCommand(cmd)
 		t := ctx.Term
 
@@ -91,23 +113,13 @@ var authStatusCmd = &cobra.Command{
 
 		ctx.Logger.Result(display.Header(t, "Auth state"))
 		if creds != nil {
-			var secretLabel, secretValue string
-			switch creds.Method {
-			case auth.Token:
-				secretLabel = "Bearer token"
-				secretValue = maskSecret(creds.Token)
-			case auth.DirectAPIKey:
-				secretLabel = "ApiKey"
-				secretValue = maskSecret(creds.APIKey)
-			default:
-				secretLabel = "Secret"
-				secretValue = maskSecret(creds.Secret)
-			}
+			secretLabel, secretValue := maskedCredentialSecret(creds)
 			ctx.Logger.Result(display.CheckMark(t) + " " + display.Success(t, "Authenticated"))
 			ctx.Logger.Result(display.KeyValue(t, []display.KVPair{
 				{Key: "Organization", Value: creds.OrgID},
 				{Key: "Method", Value: string(creds.Method)},
 				{Key: "Source", Value: authSourceLabel(source)},
+				{Key: "Profile", Value: activeProfileName()},
 				{Key: "Plan", Value: plan, ValueStyle: func(_ string) string { return planBadge(t, plan) }},
 				{Key: secretLabel, Value: secretValue},
 			}))
@@ -198,12 +210,24 @@ Examples:
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Remove stored credentials",
+	Long: `Remove the active profile's credentials from the project and home
+credentials files, and any secrets it keeps in the OS keychain. Other profiles
+are kept; a file left with none is deleted. Session tokens cached for the
+profile's org are purged too.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := display.FromCommand(cmd)
+		orgs := auth.ProfileOrgIDs()
 		if err := auth.RemoveCredentials(); err != nil {
 			return fmt.Errorf("failed to remove credentials: %w", err)
 		}
-		ctx.Logger.Info(display.CheckMark(ctx.Term) + " Credentials removed successfully")
+		if vdb.DefaultTokenCacheFile != "" {
+			for _, org := range orgs {
+				if _, err := vdb.PurgeOrgTokens(vdb.DefaultTokenCacheFile, org); err != nil {
+					return fmt.Errorf("credentials removed, but purging cached tokens for org %s failed: %w", org, err)
+				}
+			}
+		}
+		ctx.Logger.Infof("%s Credentials removed successfully (profile %s)", display.CheckMark(ctx.Term), activeProfileName())
 		return nil
 	},
 }
@@ -212,16 +236,14 @@ func runAuthLogin(cmd *cobra.Command) error {
 	var store auth.CredentialStore
 	var creds *auth.Credentials
 
-	// Method flags are mutually exclusive: --api-key (org-scoped ApiKey),
-	// --secret (org-scoped SigV4), --token (org-less Bearer).
-	methodsSet := 0
-	for _, v := range []string{authAPIKey, authSecret, authToken} {
-		if v != "" {
-			methodsSet++
-		}
+	// --token (org-less Bearer) excludes the org-scoped methods. --api-key and
+	// --secret may be given together to store both for one org: uploads then
+	// use the ApiKey and VDB uses SigV4.
+	if authToken != "" && (authAPIKey != "" || authSecret != "") {
+		return fmt.Errorf("--token cannot be combined with --api-key or --secret")
 	}
-	if methodsSet > 1 {
-		return fmt.Errorf("choose only one of --api-key, --secret, or --token")
+	if authWeb && (authNoninteractive || authAPIKey != "" || authSecret != "" || authToken != "" || authMethod != "") {
+		return fmt.Errorf("--web cannot be combined with --noninteractive, --api-key, --secret or --token")
 	}
 
 	s, err := auth.ValidateStore(authStore)
@@ -229,8 +251,14 @@ func runAuthLogin(cmd *cobra.Command) error {
 		return err
 	}
 	store = s
+	if authEncrypt && store == auth.StoreKeyring {
+		return errEncryptKeyring
+	}
 
 	switch {
+	case authWeb:
+		// Handled by the Device Flow below.
+
 	case authNoninteractive:
 		// Force org-scoped ApiKey from flags/env; never browser, never prompt.
 		if authSecret != "" || authToken != "" {
@@ -241,8 +269,8 @@ func runAuthLogin(cmd *cobra.Command) error {
 		if key == "" || org == "" {
 			return fmt.Errorf("missing ApiKey or org for noninteractive login. Get your ApiKey and Org ID from your Vulnetix VDB account, then pass --api-key and --org-id (or set VULNETIX_API_KEY and VULNETIX_ORG_ID)")
 		}
-		if _, err := uuid.Parse(org); err != nil {
-			return fmt.Errorf("--org-id must be a valid UUID, got: %s", org)
+		if err := validateOrgID(org); err != nil {
+			return err
 		}
 		creds = &auth.Credentials{OrgID: org, APIKey: stripOrgPrefix(org, key), Method: auth.DirectAPIKey}
 
@@ -252,7 +280,7 @@ func runAuthLogin(cmd *cobra.Command) error {
 		if err != nil {
 			return err
 		}
-		creds = &auth.Credentials{OrgID: org, APIKey: stripOrgPrefix(org, authAPIKey), Method: auth.DirectAPIKey}
+		creds = &auth.Credentials{OrgID: org, APIKey: stripOrgPrefix(org, authAPIKey), Secret: authSecret, Method: auth.DirectAPIKey}
 
 	case authSecret != "":
 		// Org-scoped SigV4.
@@ -269,9 +297,13 @@ func runAuthLogin(cmd *cobra.Command) error {
 	case authMethod != "":
 		return fmt.Errorf("--method is deprecated; use --api-key + --org-id (ApiKey), --secret + --org-id (SigV4), or --token (Bearer)")
 
-	default:
+	}
+
+	if creds == nil {
+		// A --store given on the command line is used as is; otherwise an
+		// interactive login asks where to save.
 		reader := bufio.NewReader(os.Stdin)
-		method, orgIDVal, secret, selectedStore, err := browserLogin(reader, isInteractive())
+		method, orgIDVal, secret, selectedStore, err := browserLogin(reader, isInteractive(), cmd.Flags().Changed("store"))
 		if err != nil {
 			return err
 		}
@@ -287,6 +319,16 @@ func runAuthLogin(cmd *cobra.Command) error {
 		}
 	}
 
+	var passphrase string
+	if authEncrypt {
+		if store == auth.StoreKeyring {
+			return errEncryptKeyring
+		}
+		if passphrase, err = newCredentialsPassphrase(); err != nil {
+			return err
+		}
+	}
+
 	// Test authentication
 	ctx := display.FromCommand(cmd)
 	ctx.Logger.Info("Testing authentication...")
@@ -296,16 +338,69 @@ func runAuthLogin(cmd *cobra.Command) error {
 	ctx.Logger.Info(display.CheckMark(ctx.Term) + " Authentication verified")
 	analytics.TrackAuth(string(creds.Method), "login", true)
 
+	if authEncrypt {
+		if err := auth.SaveEncryptedCredentialsInDir(creds, store, authStoreDir, passphrase); err != nil {
+			return fmt.Errorf("failed to save credentials: %w", err)
+		}
+		ctx.Logger.Infof("%s Credentials encrypted and saved to %s store (profile %s)", display.CheckMark(ctx.Term), store, activeProfileName())
+		return nil
+	}
+
 	// Save credentials (keychain-aware, with file fallback).
 	savedStore, err := saveCredentialsWithFallback(ctx, creds, store)
 	if err != nil {
 		return fmt.Errorf("failed to save credentials: %w", err)
 	}
-	ctx.Logger.Infof("%s Credentials saved to %s store", display.CheckMark(ctx.Term), savedStore)
+	ctx.Logger.Infof("%s Credentials saved to %s store (profile %s)", display.CheckMark(ctx.Term), savedStore, activeProfileName())
 
 	return nil
 }
 
+var errEncryptKeyring = errors.New("--encrypt applies to the home and project stores; the keyring store is already protected by the OS keychain")
+
+// newCredentialsPassphrase returns the passphrase to encrypt credentials with:
+// $VULNETIX_CREDENTIALS_PASSPHRASE, else one entered twice at the terminal.
+func newCredentialsPassphrase() (string, error) {
+	if p := os.Getenv(auth.PassphraseEnv); p != "" {
+		return p, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("--encrypt needs a passphrase: set %s or run interactively", auth.PassphraseEnv)
+	}
+	p, err := readPassphrase("New credentials passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+	confirm, err := readPassphrase("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if confirm != p {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return p, nil
+}
+
+// promptCredentialsPassphrase asks for the passphrase of the encrypted
+// credentials file at path; see auth.PassphrasePrompt.
+func promptCredentialsPassphrase(path string) (string, error) {
+	return readPassphrase(fmt.Sprintf("Passphrase for %s: ", path))
+}
+
+// readPassphrase reads a line from the terminal without echoing it.
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	p, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(p), nil
+}
+
 // saveCredentialsWithFallback persists credentials, preferring the OS keychain
 // for the HMAC secret when the keyring store is chosen. If no keychain backend
 // is present it surfaces clear guidance (with a setup URL) and falls back to the
@@ -332,12 +427,9 @@ func saveCredentialsWithFallback(ctx *display.Context, creds *auth.Credentials,
 func fetchLivePlan(creds *auth.Credentials, baseURL string) string {
 	client := vdb.NewClientFromCredentials(creds)
 	if baseURL != "" {
-		base := strings.TrimRight(baseURL, "/")
-		base = strings.TrimSuffix(base, "/v1")
-		base = strings.TrimSuffix(base, "/v2")
-		client.BaseURL = base
+		client.BaseURL = vdbBaseFromAPIURL(baseURL)
 	}
-	client.HTTPClient = &http.Client{Timeout: 3 * time.Second}
+	client.HTTPClient.Timeout = 3 * time.Second
 	now := time.Now()
 	_, err := client.GetGCVEIssuances(now.Year(), int(now.Month()), 1, 0)
 	if err != nil || client.LastRateLimit == nil || strings.TrimSpace(client.LastRateLimit.Plan) == "" {
@@ -346,6 +438,60 @@ func fetchLivePlan(creds *auth.Credentials, baseURL string) string {
 	return strings.ToUpper(client.LastRateLimit.Plan)
 }
 
+// vdbBaseFromAPIURL strips a trailing "/v1" or "/v2" from an API URL such as
+// the upload --base-url, leaving the base the vdb client expects.
+func vdbBaseFromAPIURL(baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	base = strings.TrimSuffix(base, "/v1")
+	return strings.TrimSuffix(base, "/v2")
+}
+
+// authStatusInfo is the `auth status --json` report. It never carries the
+// secret itself.
+type authStatusInfo struct {
+	Authenticated bool   `json:"authenticated"`
+	Method        string `json:"method,omitempty"`
+	Org           string `json:"org,omitempty"`
+	Source        string `json:"source"`
+	// Profile is the named credentials profile in use, empty for the
+	// default one.
+	Profile string `json:"profile,omitempty"`
+	// ExpiresAt is the expiry of the cached SigV4 session JWT; other
+	// methods use long-lived credentials and leave it empty.
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// collectAuthStatusInfo describes the active credentials without contacting
+// the API.
+func collectAuthStatusInfo() authStatusInfo {
+	info := authStatusInfo{Source: auth.CredentialSource(), Profile: auth.ActiveProfile()}
+	_, creds := auth.CredentialStatus()
+	if creds == nil {
+		return info
+	}
+	info.Authenticated = true
+	info.Method = string(creds.Method)
+	info.Org = creds.OrgID
+	if creds.Method == auth.SigV4 {
+		client := vdb.NewClient(creds.OrgID, creds.Secret)
+		if authStatusBaseURL != "" {
+			client.BaseURL = vdbBaseFromAPIURL(authStatusBaseURL)
+		}
+		if exp, ok := client.CachedTokenExpiry(); ok {
+			info.ExpiresAt = exp.UTC().Format(time.RFC3339)
+		}
+	}
+	return info
+}
+
+// activeProfileName names the credentials profile in use, "default" included.
+func activeProfileName() string {
+	if name := auth.ActiveProfile(); name != "" {
+		return name
+	}
+	return auth.DefaultProfile
+}
+
 // resolveLoginOrgID returns a validated org UUID for the org-scoped methods,
 // prompting on an interactive TTY when --org-id was not supplied.
 func resolveLoginOrgID(flag string) (string, error) {
@@ -358,8 +504,8 @@ func resolveLoginOrgID(flag string) (string, error) {
 		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
 		org = strings.TrimSpace(line)
 	}
-	if _, err := uuid.Parse(org); err != nil {
-		return "", fmt.Errorf("--org-id must be a valid UUID, got: %s", org)
+	if err := validateOrgID(org); err != nil {
+		return "", err
 	}
 	return org, nil
 }
@@ -470,7 +616,7 @@ func postDevice(ctx context.Context, path string, body, out any) (int, error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := (&http.Client{Timeout: deviceRequestExpiry}).Do(req)
+	resp, err := (&http.Client{Timeout: deviceRequestExpiry, Transport: httpx.DefaultTransport}).Do(req)
 	if err != nil {
 		return 0, err
 	}
@@ -504,7 +650,10 @@ func deviceAuthorize(ctx context.Context) (*deviceAuth, error) {
 // the one failure worth offering a retry for.
 var errDeviceExpired = fmt.Errorf("device code expired")
 
-func browserLogin(reader *bufio.Reader, interactive bool) (auth.AuthMethod, string, string, auth.CredentialStore, error) {
+// browserLogin runs the Device Flow and returns the issued credentials and
+// the store to save them in: --store when storeChosen or not interactive,
+// otherwise the user's answer to a prompt.
+func browserLogin(reader *bufio.Reader, interactive, storeChosen bool) (auth.AuthMethod, string, string, auth.CredentialStore, error) {
 	for {
 		if verbose {
 			fmt.Printf("  authorize: %s/authorize\n", deviceAPIBase())
@@ -563,7 +712,7 @@ func browserLogin(reader *bufio.Reader, interactive bool) (auth.AuthMethod, stri
 		fmt.Println()
 
 		var store auth.CredentialStore
-		if interactive {
+		if interactive && !storeChosen {
 			store, err = promptStore(reader)
 			if err != nil {
 				return "", "", "", "", err
@@ -645,14 +794,21 @@ func pollForToken(da *deviceAuth) (orgID string, apiKey string, err error) {
 	}
 }
 
+// authCheckBaseURL is the VDB API the check is made against.
+var authCheckBaseURL = vdb.DefaultBaseURL
+
+// testAuth checks creds against the VDB API. The VDB client retries
+// transient network and server failures itself, so a blip does not fail
+// login; rejected credentials (401/403) fail on the first attempt.
 func testAuth(ctx *display.Context, creds *auth.Credentials) error {
 	switch creds.Method {
-	case auth.Token:
+	case auth.Token, auth.OIDC:
 		if creds.Token == "" {
 			return fmt.Errorf("token is empty")
 		}
 		now := time.Now()
 		vdbClient := vdb.NewClientFromCredentials(creds)
+		vdbClient.BaseURL = authCheckBaseURL
 		if _, err := vdbClient.GetGCVEIssuances(now.Year(), int(now.Month()), 1, 0); err != nil {
 			return err
 		}
@@ -664,19 +820,27 @@ func testAuth(ctx *display.Context, creds *auth.Credentials) error {
 		if len(creds.APIKey) == 0 {
 			return fmt.Errorf("API key is empty")
 		}
-		// Test credentials against an authenticated GCVE endpoint
+		// Test credentials against an authenticated GCVE endpoint. The VDB
+		// client would prefer a stored SigV4 secret, so test the key alone.
+		keyOnly := *creds
+		keyOnly.Secret = ""
 		now := time.Now()
-		vdbClient := vdb.NewClientFromCredentials(creds)
+		vdbClient := vdb.NewClientFromCredentials(&keyOnly)
+		vdbClient.BaseURL = authCheckBaseURL
 		_, err := vdbClient.GetGCVEIssuances(now.Year(), int(now.Month()), 1, 0)
 		if err != nil {
 			return err
 		}
 		ctx.Logger.Info(display.CheckMark(ctx.Term) + " VDB API: OK")
+		if creds.HasMethod(auth.SigV4) {
+			return testAuth(ctx, creds.Prefer(auth.SigV4))
+		}
 		return nil
 
 	case auth.SigV4:
 		// For SigV4, do a full token exchange to validate the secret
 		vdbClient := vdb.NewClient(creds.OrgID, creds.Secret)
+		vdbClient.BaseURL = authCheckBaseURL
 		_, err := vdbClient.GetToken()
 		return err
 
@@ -743,6 +907,8 @@ func init() {
 	authLoginCmd.Flags().StringVar(&authStore, "store", "home", "Credential storage: home, project, keyring")
 	authLoginCmd.Flags().StringVar(&authStoreDir, "store-dir", "", "Directory for home/keyring credential metadata instead of $HOME/.vulnetix")
 	authLoginCmd.Flags().BoolVar(&authNoninteractive, "noninteractive", false, "Require ApiKey from --api-key or environment; never launch a browser")
+	authLoginCmd.Flags().BoolVar(&authWeb, "web", false, "Log in with the browser Device Flow: show a code and URL, then wait for approval")
+	authLoginCmd.Flags().BoolVar(&authEncrypt, "encrypt", false, "Encrypt the credentials file with a passphrase (prompted, or $"+auth.PassphraseEnv+")")
 	_ = authLoginCmd.Flags().MarkHidden("token")
 	_ = authLoginCmd.RegisterFlagCompletionFunc("method", cobra.FixedCompletions([]string{"apikey", "sigv4"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = authLoginCmd.RegisterFlagCompletionFunc("store", cobra.FixedCompletions([]string{"home", "project", "keyring"}, cobra.ShellCompDirectiveNoFileComp))
@@ -756,11 +922,14 @@ func init() {
 	authCmd.Flags().StringVar(&authStore, "store", "home", "Credential storage: home, project, keyring")
 	authCmd.Flags().StringVar(&authStoreDir, "store-dir", "", "Directory for home/keyring credential metadata instead of $HOME/.vulnetix")
 	authCmd.Flags().BoolVar(&authNoninteractive, "noninteractive", false, "Require ApiKey from --api-key or environment; never launch a browser")
+	authCmd.Flags().BoolVar(&authWeb, "web", false, "Log in with the browser Device Flow: show a code and URL, then wait for approval")
+	authCmd.Flags().BoolVar(&authEncrypt, "encrypt", false, "Encrypt the credentials file with a passphrase (prompted, or $"+auth.PassphraseEnv+")")
 	_ = authCmd.Flags().MarkHidden("token")
 	_ = authCmd.RegisterFlagCompletionFunc("method", cobra.FixedCompletions([]string{"apikey", "sigv4"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = authCmd.RegisterFlagCompletionFunc("store", cobra.FixedCompletions([]string{"home", "project", "keyring"}, cobra.ShellCompDirectiveNoFileComp))
 
 	authStatusCmd.Flags().StringVar(&authStatusBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
+	authStatusCmd.Flags().BoolVar(&authStatusJSON, "json", false, "Output authentication state as JSON")
 	authVerifyCmd.Flags().StringVar(&verifyBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
 
 	authCmd.AddCommand(authLoginCmd, authStatusCmd, authLogoutCmd, authVerifyCmd)
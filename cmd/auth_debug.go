@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+var authDebugJSON bool
+
+var authDebugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Show where the active credentials come from, with secrets masked",
+	Long: `Print the resolved credential source, method, organization, masked secret,
+backing file and its permissions. Nothing is sent to the API and the full
+secret is never printed.
+
+Examples:
+  vulnetix auth debug
+  vulnetix auth debug --json`,
+	Args: cobra.NoArgs,
+	RunE: runAuthDebug,
+}
+
+// authDebugInfo is the `auth debug` report. Secret is always masked.
+type authDebugInfo struct {
+	Source            string `json:"source"`
+	Method            string `json:"method,omitempty"`
+	OrgID             string `json:"orgId,omitempty"`
+	SecretKind        string `json:"secretKind,omitempty"`
+	Secret            string `json:"secret,omitempty"`
+	SecretInKeyring   bool   `json:"secretInKeyring,omitempty"`
+	Path              string `json:"path,omitempty"`
+	Permissions       string `json:"permissions,omitempty"`
+	PermissionWarning string `json:"permissionWarning,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+func runAuthDebug(cmd *cobra.Command, args []string) error {
+	if authDebugJSON {
+		initDisplayContext(cmd, display.ModeJSON)
+	}
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	info := collectAuthDebugInfo()
+	if authDebugJSON {
+		return ctx.Logger.ResultJSON(info)
+	}
+
+	pairs := []display.KVPair{{Key: "Source", Value: info.Source}}
+	if info.Method != "" {
+		pairs = append(pairs,
+			display.KVPair{Key: "Method", Value: info.Method},
+			display.KVPair{Key: "Organization", Value: firstNonEmpty(info.OrgID, "(resolved server-side)")},
+		)
+	}
+	if info.SecretKind != "" {
+		secret := info.Secret
+		if info.SecretInKeyring {
+			secret += " (OS keyring)"
+		}
+		pairs = append(pairs, display.KVPair{Key: info.SecretKind, Value: secret})
+	}
+	if info.Path != "" {
+		pairs = append(pairs, display.KVPair{Key: "File", Value: info.Path})
+	}
+	if info.Permissions != "" {
+		pairs = append(pairs, display.KVPair{Key: "Permissions", Value: info.Permissions})
+	}
+	ctx.Logger.Result(display.Header(t, "Credential debug"))
+	ctx.Logger.Result(display.KeyValue(t, pairs))
+	if info.PermissionWarning != "" {
+		ctx.Logger.Result(display.WarningMark(t) + " " + info.PermissionWarning)
+	}
+	if info.Error != "" {
+		ctx.Logger.Result(display.WarningMark(t) + " " + info.Error)
+	}
+	return nil
+}
+
+// collectAuthDebugInfo resolves credentials the same way every command does
+// and describes the result without exposing the secret.
+func collectAuthDebugInfo() authDebugInfo {
+	info := authDebugInfo{Source: auth.CredentialSource(), Path: auth.CredentialSourcePath()}
+	if info.Path != "" {
+		if fi, err := os.Stat(info.Path); err == nil {
+			perm := fi.Mode().Perm()
+			info.Permissions = fmt.Sprintf("%#o", perm)
+			if perm&0o077 != 0 {
+				info.PermissionWarning = fmt.Sprintf("%s is readable by other users; run: chmod 600 %s", info.Path, info.Path)
+			}
+		}
+	}
+
+	creds, err := auth.LoadCredentials()
+	if err != nil {
+		if info.Source != "none" {
+			info.Error = err.Error()
+		}
+		return info
+	}
+	info.Method = string(creds.Method)
+	info.OrgID = creds.OrgID
+	info.SecretKind, info.Secret = maskedCredentialSecret(creds)
+	info.SecretInKeyring = creds.HMACInKeyring || creds.TokenInKeyring || creds.APIKeyInKeyring
+	return info
+}
+
+// maskedCredentialSecret returns the label and masked value of the secret
+// that authenticates creds.
+func maskedCredentialSecret(creds *auth.Credentials) (label, masked string) {
+	switch creds.Method {
+	case auth.Token, auth.OIDC:
+		return "Bearer token", auth.MaskSecret(creds.Token)
+	case auth.DirectAPIKey:
+		return "ApiKey", auth.MaskSecret(creds.APIKey)
+	default:
+		return "Secret", auth.MaskSecret(creds.Secret)
+	}
+}
+
+func init() {
+	authDebugCmd.Flags().BoolVar(&authDebugJSON, "json", false, "Output the report as JSON")
+	authCmd.AddCommand(authDebugCmd)
+}
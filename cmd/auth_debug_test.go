@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func runAuthDebugJSON(t *testing.T) authDebugInfo {
+	t.Helper()
+	t.Cleanup(func() { authDebugJSON = false })
+	output, err := executeCommand(t, rootCmd, "auth", "debug", "--json")
+	require.NoError(t, err)
+	var info authDebugInfo
+	require.NoError(t, json.Unmarshal([]byte(output[strings.Index(output, "{"):]), &info))
+	return info
+}
+
+func TestAuthDebug_HomeFileMasksSecret(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, dir)
+	const secret = "s3cr3t-hmac-value-0123456789abcdef"
+	path := filepath.Join(dir, "credentials.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"org_id":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","secret":"`+secret+`","method":"sigv4"}`), 0644))
+
+	info := runAuthDebugJSON(t)
+	assert.Equal(t, "home (~/.vulnetix/credentials.json)", info.Source)
+	assert.Equal(t, path, info.Path)
+	assert.Equal(t, "0644", info.Permissions)
+	assert.Contains(t, info.PermissionWarning, "chmod 600")
+	assert.Equal(t, "sigv4", info.Method)
+	assert.Equal(t, "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", info.OrgID)
+	assert.Equal(t, "s3cr...cdef", info.Secret)
+}
+
+func TestAuthDebug_EnvironmentTokenNeverPrinted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, t.TempDir())
+	const token = "vx_live_token_abcdefghijklmnop"
+	t.Setenv("VULNETIX_API_TOKEN", token)
+
+	output, err := executeCommand(t, rootCmd, "auth", "debug")
+	require.NoError(t, err)
+	assert.NotContains(t, output, token)
+	assert.Contains(t, output, "vx_l...mnop")
+
+	info := runAuthDebugJSON(t)
+	assert.Equal(t, "environment (VULNETIX_API_TOKEN)", info.Source)
+	assert.Empty(t, info.Path)
+	assert.Equal(t, "Bearer token", info.SecretKind)
+	assert.NotContains(t, info.Secret, token)
+}
@@ -5,9 +5,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/auth"
 )
 
 // deviceServer is a fake www.vulnetix.com implementing RFC 8628.
@@ -29,6 +35,9 @@ type deviceServer struct {
 	// authorizeStatus and authorizeBody override the /authorize response.
 	authorizeStatus int
 	authorizeBody   any
+
+	// requestID is the X-Request-ID header of the last /authorize request.
+	requestID string
 }
 
 type tokenReply struct {
@@ -42,6 +51,10 @@ func (s *deviceServer) start(t *testing.T) *httptest.Server {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/api/site/v1/cli/device/authorize", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requestID = r.Header.Get(httpx.RequestIDHeader)
+		s.mu.Unlock()
+
 		status := s.authorizeStatus
 		if status == 0 {
 			status = http.StatusOK
@@ -162,6 +175,9 @@ func TestDeviceFlowAuthorize(t *testing.T) {
 	if da.browseURL() != da.VerificationURIComplete {
 		t.Errorf("browseURL() = %q, want the complete URI", da.browseURL())
 	}
+	if s.requestID != httpx.RequestID {
+		t.Errorf("X-Request-ID = %q, want %q", s.requestID, httpx.RequestID)
+	}
 }
 
 func TestDeviceFlowAuthorizeDefaultsWhenServerOmitsTimings(t *testing.T) {
@@ -419,3 +435,50 @@ func TestDeviceFlowPollKeepsTryingThroughTransportErrors(t *testing.T) {
 		t.Errorf("polled %d times, want 2", polls)
 	}
 }
+
+func TestAuthLoginWebSavesToChosenStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", "")
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() {
+		authWeb = false
+		_ = authLoginCmd.Flags().Set("store", "home")
+		clearFlagsChanged(authLoginCmd)
+	})
+	s := &deviceServer{tokenReplies: []tokenReply{okReply()}}
+	s.start(t)
+	_, done := authCheckServer(t, 0, http.StatusOK)
+	defer done()
+
+	out, err := executeCommand(t, rootCmd, "auth", "login", "--web", "--store", "project")
+	if err != nil {
+		t.Fatalf("auth login --web: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "ABC-123") {
+		t.Errorf("output does not show the user code:\n%s", out)
+	}
+
+	data, err := os.ReadFile(filepath.Join(".vulnetix", "credentials.json"))
+	if err != nil {
+		t.Fatalf("project credentials not written: %v", err)
+	}
+	var saved auth.Credentials
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.OrgID != "11111111-2222-3333-4444-555555555555" || saved.APIKey != "deadbeefcafe" || saved.Method != auth.DirectAPIKey {
+		t.Errorf("saved credentials = %+v", saved)
+	}
+}
+
+func TestAuthLoginWebRejectsCredentialFlags(t *testing.T) {
+	t.Cleanup(func() {
+		authWeb = false
+		authAPIKey = ""
+		clearFlagsChanged(authLoginCmd)
+	})
+	_, err := executeCommand(t, rootCmd, "auth", "login", "--web", "--api-key", "k")
+	if err == nil || !strings.Contains(err.Error(), "--web cannot be combined") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func TestAuthLoginEncrypt(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", "")
+	t.Setenv(auth.PassphraseEnv, "correct horse")
+	t.Chdir(t.TempDir())
+	t.Cleanup(func() {
+		authEncrypt = false
+		authNoninteractive = false
+		authAPIKey = ""
+		authOrgID = ""
+		_ = authLoginCmd.Flags().Set("store", "home")
+		clearFlagsChanged(authLoginCmd)
+	})
+	_, done := authCheckServer(t, 0, http.StatusOK)
+	defer done()
+
+	const org = "11111111-2222-3333-4444-555555555555"
+	out, err := executeCommand(t, rootCmd, "auth", "login", "--noninteractive", "--api-key", "plain-key", "--org-id", org, "--store", "home", "--encrypt")
+	if err != nil {
+		t.Fatalf("auth login --encrypt: %v\n%s", err, out)
+	}
+
+	home, _ := os.UserHomeDir()
+	data, err := os.ReadFile(filepath.Join(home, ".vulnetix", "credentials.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "plain-key") || !strings.Contains(string(data), `"encrypted"`) {
+		t.Fatalf("credentials file is not encrypted:\n%s", data)
+	}
+
+	creds, err := auth.LoadCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.OrgID != org || creds.APIKey != "plain-key" {
+		t.Errorf("loaded %+v", creds)
+	}
+}
+
+func TestAuthLoginEncryptRejectsKeyring(t *testing.T) {
+	t.Cleanup(func() {
+		authEncrypt = false
+		_ = authLoginCmd.Flags().Set("store", "home")
+		clearFlagsChanged(authLoginCmd)
+	})
+	_, err := executeCommand(t, rootCmd, "auth", "login", "--store", "keyring", "--encrypt")
+	if err == nil || !strings.Contains(err.Error(), "--encrypt applies to the home and project stores") {
+		t.Fatalf("err = %v", err)
+	}
+}
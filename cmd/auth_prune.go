@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+var (
+	authPruneYes  bool
+	authPruneJSON bool
+)
+
+var authPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired cached session tokens",
+	Long: `Scan the local session token cache (~/.vulnetix/token-cache.json) and list
+the SigV4 session tokens that have expired, for every org and API they were
+issued for. Pass --yes to remove them; unexpired tokens are always kept.
+
+Stored credentials (credentials.json, the keyring and netrc) carry no expiry
+and are never touched; use 'vulnetix auth logout' to remove them.
+
+Examples:
+  vulnetix auth prune
+  vulnetix auth prune --yes`,
+	Args: cobra.NoArgs,
+	RunE: runAuthPrune,
+}
+
+// authPruneReport is the `auth prune --json` report.
+type authPruneReport struct {
+	Path    string            `json:"path"`
+	Expired []vdb.CachedToken `json:"expired"`
+	Removed bool              `json:"removed"`
+}
+
+func runAuthPrune(cmd *cobra.Command, args []string) error {
+	if authPruneJSON {
+		initDisplayContext(cmd, display.ModeJSON)
+	}
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	path, err := vdb.TokenCachePath()
+	if err != nil {
+		return err
+	}
+
+	report := authPruneReport{Path: path}
+	now := time.Now()
+	if authPruneYes {
+		report.Expired, err = vdb.PruneTokenCache(path, now)
+		if err != nil {
+			return fmt.Errorf("prune token cache: %w", err)
+		}
+		report.Removed = len(report.Expired) > 0
+	} else {
+		report.Expired = vdb.ExpiredCachedTokens(path, now)
+	}
+	if report.Expired == nil {
+		report.Expired = []vdb.CachedToken{}
+	}
+
+	if authPruneJSON {
+		return ctx.Logger.ResultJSON(report)
+	}
+	if len(report.Expired) == 0 {
+		ctx.Logger.Result(display.CheckMark(t) + " No expired session tokens in " + path)
+		return nil
+	}
+	for _, tok := range report.Expired {
+		ctx.Logger.Result(fmt.Sprintf("%s %s expired %s", display.CrossMark(t), tok.Key, tok.ExpiresAt.UTC().Format(time.RFC3339)))
+	}
+	if report.Removed {
+		ctx.Logger.Result(fmt.Sprintf("Removed %d expired session token(s) from %s", len(report.Expired), path))
+	} else {
+		ctx.Logger.Result(fmt.Sprintf("%d expired session token(s); re-run with --yes to remove them", len(report.Expired)))
+	}
+	return nil
+}
+
+func init() {
+	authPruneCmd.Flags().BoolVar(&authPruneYes, "yes", false, "Remove the expired tokens instead of only listing them")
+	authPruneCmd.Flags().BoolVar(&authPruneJSON, "json", false, "Output the report as JSON")
+	authCmd.AddCommand(authPruneCmd)
+}
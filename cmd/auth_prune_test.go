@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func writeTestTokenCache(t *testing.T, home string, tokens map[string]vdb.TokenCache) string {
+	t.Helper()
+	path := filepath.Join(home, ".vulnetix", "token-cache.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	data, err := json.Marshal(tokens)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func readTestTokenCache(t *testing.T, path string) map[string]vdb.TokenCache {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var tokens map[string]vdb.TokenCache
+	require.NoError(t, json.Unmarshal(data, &tokens))
+	return tokens
+}
+
+func TestAuthPruneRemovesOnlyExpiredTokens(t *testing.T) {
+	t.Cleanup(func() { authPruneYes, authPruneJSON = false, false })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	now := time.Now()
+	path := writeTestTokenCache(t, home, map[string]vdb.TokenCache{
+		"org-expired@https://api.vdb.vulnetix.com/v2": {Token: "a", ExpiresAt: now.Add(-2 * time.Hour)},
+		"org-stale@https://api.vdb.vulnetix.com/v1":   {Token: "b", ExpiresAt: now.Add(-time.Minute)},
+		"org-valid@https://api.vdb.vulnetix.com/v2":   {Token: "c", ExpiresAt: now.Add(time.Hour)},
+	})
+
+	out, err := executeCommand(t, rootCmd, "auth", "prune")
+	require.NoError(t, err)
+	assert.Contains(t, out, "org-expired@")
+	assert.Contains(t, out, "org-stale@")
+	assert.NotContains(t, out, "org-valid@")
+	assert.Contains(t, out, "re-run with --yes")
+	assert.Len(t, readTestTokenCache(t, path), 3, "nothing is removed without --yes")
+
+	out, err = executeCommand(t, rootCmd, "auth", "prune", "--yes")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed 2 expired session token(s)")
+	remaining := readTestTokenCache(t, path)
+	assert.Len(t, remaining, 1)
+	assert.Contains(t, remaining, "org-valid@https://api.vdb.vulnetix.com/v2")
+}
+
+func TestAuthPruneJSON(t *testing.T) {
+	t.Cleanup(func() { authPruneYes, authPruneJSON = false, false })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeTestTokenCache(t, home, map[string]vdb.TokenCache{
+		"org-expired@https://api.vdb.vulnetix.com/v2": {Token: "a", ExpiresAt: time.Now().Add(-time.Hour)},
+	})
+
+	out, err := executeCommand(t, rootCmd, "auth", "prune", "--yes", "--json")
+	require.NoError(t, err)
+	start := strings.Index(out, "{")
+	require.GreaterOrEqual(t, start, 0, out)
+	var report authPruneReport
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[start:])).Decode(&report))
+	assert.True(t, report.Removed)
+	require.Len(t, report.Expired, 1)
+	assert.Equal(t, "org-expired", report.Expired[0].OrgID)
+	assert.NotContains(t, out, `"a"`, "tokens are never printed")
+}
+
+func TestAuthPruneWithoutCache(t *testing.T) {
+	t.Cleanup(func() { authPruneYes, authPruneJSON = false, false })
+	t.Setenv("HOME", t.TempDir())
+	out, err := executeCommand(t, rootCmd, "auth", "prune", "--yes")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No expired session tokens")
+}
+
+func TestAuthLogoutPurgesOrgTokens(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", "")
+	t.Chdir(t.TempDir())
+	credsPath := filepath.Join(home, ".vulnetix", "credentials.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(credsPath), 0700))
+	require.NoError(t, os.WriteFile(credsPath, []byte(`{"org_id":"`+rotateOrg+`","secret":"s","method":"sigv4"}`), 0600))
+	future := time.Now().Add(time.Hour)
+	path := writeTestTokenCache(t, home, map[string]vdb.TokenCache{
+		rotateOrg + "@https://api.vdb.vulnetix.com/v1": {Token: "a", ExpiresAt: future},
+		rotateOrg + "@https://api.vdb.vulnetix.com/v2": {Token: "b", ExpiresAt: future},
+		"org-other@https://api.vdb.vulnetix.com/v2":    {Token: "c", ExpiresAt: future},
+	})
+
+	_, err := executeCommand(t, rootCmd, "auth", "logout")
+	require.NoError(t, err)
+	assert.NoFileExists(t, credsPath)
+	remaining := readTestTokenCache(t, path)
+	assert.Len(t, remaining, 1)
+	assert.Contains(t, remaining, "org-other@https://api.vdb.vulnetix.com/v2")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// authCheckServer stands in for the VDB token exchange: it drops the
+// connection for the first drops requests and answers the rest with status.
+func authCheckServer(t *testing.T, drops, status int) (*int, func()) {
+	t.Helper()
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits <= drops {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, `{"token":"jwt","exp":9999999999}`)
+	}))
+	oldURL, oldBackoff := authCheckBaseURL, vdb.TokenRetryBackoff
+	authCheckBaseURL, vdb.TokenRetryBackoff = srv.URL, time.Millisecond
+	return &hits, func() {
+		srv.Close()
+		authCheckBaseURL, vdb.TokenRetryBackoff = oldURL, oldBackoff
+	}
+}
+
+func TestTestAuthRetriesTransientFailure(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hits, done := authCheckServer(t, 1, http.StatusOK)
+	defer done()
+
+	creds := &auth.Credentials{OrgID: "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", Secret: "s", Method: auth.SigV4}
+	require.NoError(t, testAuth(display.New(display.ModeText, true), creds))
+	assert.Equal(t, 2, *hits, "the dropped connection should be retried once")
+}
+
+func TestTestAuthDoesNotRetryRejection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hits, done := authCheckServer(t, 0, http.StatusUnauthorized)
+	defer done()
+
+	creds := &auth.Credentials{OrgID: "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", Secret: "s", Method: auth.SigV4}
+	err := testAuth(display.New(display.ModeText, true), creds)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API error (401)")
+	var apiErr *vdb.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, 1, *hits)
+}
+
+func TestTestAuthRetriesServerError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	hits, done := authCheckServer(t, 0, http.StatusServiceUnavailable)
+	defer done()
+
+	creds := &auth.Credentials{OrgID: "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", Secret: "s", Method: auth.SigV4}
+	err := testAuth(display.New(display.ModeText, true), creds)
+	require.Error(t, err)
+	assert.Equal(t, 1+vdb.MaxRetries, *hits, "a 503 is retried by the client alone")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+var authRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace the stored API key with a newly issued one",
+	Long: `Ask the Vulnetix API for a new API key for the current org, verify it,
+save it where the current key is stored (home or project file, or the OS
+keychain they reference), then revoke the old key.
+
+The old key is revoked only once the new one has authenticated and been
+saved. If verification or saving fails, the new key is revoked instead and
+the stored credentials are left unchanged. Credentials from the environment
+or .netrc cannot be rotated; update them where they are set.
+
+Examples:
+  vulnetix auth rotate
+  vulnetix auth rotate --profile staging`,
+	Args: cobra.NoArgs,
+	RunE: runAuthRotate,
+}
+
+func runAuthRotate(cmd *cobra.Command, args []string) error {
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	creds, err := auth.LoadCredentials()
+	if err != nil {
+		return fmt.Errorf("no credentials to rotate: %w\nRun 'vulnetix auth login' to authenticate", err)
+	}
+	store := auth.CredentialSourceStore()
+	if store == "" {
+		return fmt.Errorf("credentials come from %s; only credentials saved by 'vulnetix auth login' can be rotated", auth.CredentialSource())
+	}
+	if creds.APIKey == "" {
+		return fmt.Errorf("auth rotate replaces ApiKey credentials; the stored credentials use %s", creds.Method)
+	}
+
+	// Rotation and revocation authenticate with an API key alone, never a
+	// SigV4 secret stored alongside it.
+	oldKey := apiKeyOnly(creds, creds.APIKey)
+	oldClient := vdb.NewClientFromCredentials(oldKey)
+	oldClient.BaseURL = authCheckBaseURL
+
+	ctx.Logger.Info("Issuing a new API key...")
+	rotated, err := oldClient.RotateAPIKey()
+	if err != nil {
+		return fmt.Errorf("failed to issue a new API key: %w", err)
+	}
+	newKey := apiKeyOnly(creds, stripOrgPrefix(creds.OrgID, rotated.APIKey))
+
+	// discard revokes the new key after a failure, leaving the old one in
+	// place.
+	discard := func(cause error) error {
+		if rerr := oldClient.RevokeAPIKey(rotated.KeyID); rerr != nil {
+			ctx.Logger.Warnf("Could not revoke the new API key %s: %v", rotated.KeyID, rerr)
+		}
+		return fmt.Errorf("%w; the stored credentials are unchanged", cause)
+	}
+
+	ctx.Logger.Info("Verifying the new API key...")
+	if err := testAuth(ctx, newKey); err != nil {
+		return discard(fmt.Errorf("new API key failed verification: %w", err))
+	}
+
+	updated := *creds
+	updated.APIKey = newKey.APIKey
+	if err := auth.SaveCredentials(&updated, store); err != nil {
+		// The keychain may already hold the new key if the file write failed.
+		_ = auth.SaveCredentials(creds, store)
+		return discard(fmt.Errorf("failed to save the new API key: %w", err))
+	}
+	ctx.Logger.Infof("%s New API key saved to %s store (profile %s)", display.CheckMark(t), store, activeProfileName())
+
+	revoked := "-"
+	if rotated.PreviousKeyID == "" {
+		ctx.Logger.Warn("The API did not identify the previous key; revoke it from the Vulnetix dashboard")
+	} else {
+		newClient := vdb.NewClientFromCredentials(newKey)
+		newClient.BaseURL = authCheckBaseURL
+		if err := newClient.RevokeAPIKey(rotated.PreviousKeyID); err != nil {
+			return fmt.Errorf("new API key saved, but revoking the previous key %s failed: %w", rotated.PreviousKeyID, err)
+		}
+		revoked = rotated.PreviousKeyID
+		ctx.Logger.Info(display.CheckMark(t) + " Previous API key revoked")
+	}
+
+	ctx.Logger.Result(display.KeyValue(t, []display.KVPair{
+		{Key: "Organization", Value: creds.OrgID},
+		{Key: "New key ID", Value: rotated.KeyID},
+		{Key: "Revoked key ID", Value: revoked},
+		{Key: "Store", Value: string(store)},
+	}))
+	return nil
+}
+
+// apiKeyOnly returns creds reduced to an ApiKey credential with the given
+// key.
+func apiKeyOnly(creds *auth.Credentials, key string) *auth.Credentials {
+	return &auth.Credentials{OrgID: creds.OrgID, APIKey: key, Method: auth.DirectAPIKey}
+}
+
+func init() {
+	authCmd.AddCommand(authRotateCmd)
+}
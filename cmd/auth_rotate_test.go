@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+const rotateOrg = "11111111-2222-3333-4444-555555555555"
+
+// rotateServer fakes the key rotation API. The new key authenticates only
+// when newKeyWorks is set; requests are recorded as "METHOD path key".
+type rotateServer struct {
+	newKeyWorks bool
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *rotateServer) start(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "ApiKey "+rotateOrg+":")
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path+" "+key)
+		s.mu.Unlock()
+		if key == "new-key" && !s.newKeyWorks {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid api key"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/auth/api-key/rotate":
+			_ = json.NewEncoder(w).Encode(map[string]string{"orgId": rotateOrg, "apiKey": "new-key", "keyId": "k2", "previousKeyId": "k1"})
+		default:
+			_, _ = w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	orig := authCheckBaseURL
+	authCheckBaseURL = srv.URL
+	t.Cleanup(func() { authCheckBaseURL = orig })
+}
+
+func (s *rotateServer) sent(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for _, r := range s.requests {
+		if strings.HasPrefix(r, prefix) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func writeRotateCredentials(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", "")
+	t.Chdir(t.TempDir())
+	path := filepath.Join(".vulnetix", "credentials.json")
+	if err := os.MkdirAll(".vulnetix", 0o700); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"org_id":"` + rotateOrg + `","api_key":"old-key","method":"apikey"}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func storedAPIKey(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var creds auth.Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		t.Fatal(err)
+	}
+	return creds.APIKey
+}
+
+func TestAuthRotate(t *testing.T) {
+	path := writeRotateCredentials(t)
+	s := &rotateServer{newKeyWorks: true}
+	s.start(t)
+
+	out, err := executeCommand(t, rootCmd, "auth", "rotate")
+	if err != nil {
+		t.Fatalf("auth rotate: %v\n%s", err, out)
+	}
+	if got := storedAPIKey(t, path); got != "new-key" {
+		t.Errorf("stored key = %q, want new-key", got)
+	}
+	if got := s.sent("POST"); len(got) != 1 || !strings.HasSuffix(got[0], " old-key") {
+		t.Errorf("rotate requests = %q, want one made with the old key", got)
+	}
+	if got := s.sent("DELETE"); len(got) != 1 || got[0] != "DELETE /v2/auth/api-key/k1 new-key" {
+		t.Errorf("revoke requests = %q, want the old key revoked with the new one", got)
+	}
+}
+
+func TestAuthRotateKeepsOldKeyWhenNewKeyFails(t *testing.T) {
+	path := writeRotateCredentials(t)
+	s := &rotateServer{}
+	s.start(t)
+
+	_, err := executeCommand(t, rootCmd, "auth", "rotate")
+	if err == nil || !strings.Contains(err.Error(), "stored credentials are unchanged") {
+		t.Fatalf("err = %v", err)
+	}
+	if got := storedAPIKey(t, path); got != "old-key" {
+		t.Errorf("stored key = %q, want old-key kept", got)
+	}
+	if got := s.sent("DELETE"); len(got) != 1 || got[0] != "DELETE /v2/auth/api-key/k2 old-key" {
+		t.Errorf("revoke requests = %q, want only the new key revoked", got)
+	}
+}
+
+func TestAuthRotateRejectsEnvironmentCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	t.Setenv("VULNETIX_API_KEY", "env-key")
+	t.Setenv("VULNETIX_ORG_ID", rotateOrg)
+
+	_, err := executeCommand(t, rootCmd, "auth", "rotate")
+	if err == nil || !strings.Contains(err.Error(), "environment") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func TestAuthStatusJSON(t *testing.T) {
+	t.Cleanup(func() { authStatusJSON = false })
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	const org = "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718"
+	const secret = "s3cr3t-hmac-value-0123456789abcdef"
+	t.Setenv("VVD_ORG", org)
+	t.Setenv("VVD_SECRET", secret)
+
+	exp := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	cache, err := json.Marshal(map[string]vdb.TokenCache{
+		org + "@" + vdb.DefaultBaseURL + vdb.DefaultAPIVersion: {Token: "cached-jwt", ExpiresAt: exp},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".vulnetix"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".vulnetix", "token-cache.json"), cache, 0600))
+
+	out, err := executeCommand(t, rootCmd, "auth", "status", "--json")
+	require.NoError(t, err)
+	assert.NotContains(t, out, secret)
+	assert.NotContains(t, out, "cached-jwt")
+
+	var info map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &info))
+	assert.Equal(t, map[string]any{
+		"authenticated": true,
+		"method":        "sigv4",
+		"org":           org,
+		"source":        "environment (VVD_ORG + VVD_SECRET)",
+		"expires_at":    exp.Format(time.RFC3339),
+	}, info)
+}
+
+func TestAuthStatusJSONUnauthenticated(t *testing.T) {
+	t.Cleanup(func() { authStatusJSON = false })
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	out, err := executeCommand(t, rootCmd, "auth", "status", "--json")
+	require.NoError(t, err)
+	var info authStatusInfo
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &info))
+	assert.Equal(t, authStatusInfo{Source: "none"}, info)
+}
+
+func TestAuthStatusJSONNamedProfile(t *testing.T) {
+	t.Cleanup(func() {
+		authStatusJSON = false
+		_ = rootCmd.PersistentFlags().Set("profile", "")
+		rootCmd.PersistentFlags().Lookup("profile").Changed = false
+	})
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_CREDENTIALS_DIR", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "env-token")
+	t.Chdir(t.TempDir())
+	const org = "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718"
+	require.NoError(t, os.MkdirAll(".vulnetix", 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(".vulnetix", "credentials.json"),
+		[]byte(`{"profiles": {"staging": {"org_id": "`+org+`", "api_key": "k", "method": "apikey"}}}`), 0600))
+
+	out, err := executeCommand(t, rootCmd, "auth", "status", "--json", "--profile", "staging")
+	require.NoError(t, err)
+	var info authStatusInfo
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &info))
+	assert.Equal(t, authStatusInfo{
+		Authenticated: true,
+		Method:        "apikey",
+		Org:           org,
+		Source:        "project (.vulnetix/credentials.json)",
+		Profile:       "staging",
+	}, info)
+}
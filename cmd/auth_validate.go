@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+var (
+	authValidateFile string
+	authValidateJSON bool
+)
+
+var authValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check credentials files for schema problems",
+	Long: `Load and schema-check credentials files: known fields with the right types,
+a valid method, the fields that method requires, and a UUID org_id. Nothing
+is sent to the API and the keyring is not read.
+
+Without --file, the project (.vulnetix/credentials.json) and home credentials
+files are checked when present. The command fails if any file has problems.
+
+Examples:
+  vulnetix auth validate
+  vulnetix auth validate --file ~/.vulnetix/credentials.json --json`,
+	Args: cobra.NoArgs,
+	RunE: runAuthValidate,
+}
+
+// credentialFileReport is the validation result for one credentials file.
+type credentialFileReport struct {
+	Path     string                       `json:"path"`
+	Valid    bool                         `json:"valid"`
+	Problems []auth.CredentialFileProblem `json:"problems,omitempty"`
+}
+
+func runAuthValidate(cmd *cobra.Command, args []string) error {
+	if authValidateJSON {
+		initDisplayContext(cmd, display.ModeJSON)
+	}
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	paths := []string{authValidateFile}
+	if authValidateFile == "" {
+		paths = auth.CredentialFilePaths()
+	}
+
+	var reports []credentialFileReport
+	for _, path := range paths {
+		problems, err := auth.ValidateCredentialsFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && authValidateFile == "" {
+				continue
+			}
+			return fmt.Errorf("read credentials file: %w", err)
+		}
+		reports = append(reports, credentialFileReport{Path: path, Valid: len(problems) == 0, Problems: problems})
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf("no credentials file found (looked in %v)", paths)
+	}
+
+	invalid := 0
+	for _, r := range reports {
+		if !r.Valid {
+			invalid++
+		}
+	}
+
+	if authValidateJSON {
+		if err := ctx.Logger.ResultJSON(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range reports {
+			if r.Valid {
+				ctx.Logger.Result(display.CheckMark(t) + " " + r.Path)
+				continue
+			}
+			ctx.Logger.Result(display.CrossMark(t) + " " + r.Path)
+			for _, p := range r.Problems {
+				ctx.Logger.Result("    " + p.String())
+			}
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d credentials file(s) failed validation", invalid)
+	}
+	return nil
+}
+
+func init() {
+	authValidateCmd.Flags().StringVar(&authValidateFile, "file", "", "Credentials file to check instead of the project and home files")
+	authValidateCmd.Flags().BoolVar(&authValidateJSON, "json", false, "Output the report as JSON")
+	authCmd.AddCommand(authValidateCmd)
+}
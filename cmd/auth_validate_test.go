@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func TestAuthValidate(t *testing.T) {
+	t.Cleanup(func() { authValidateFile, authValidateJSON = "", false })
+	dir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, dir)
+	path := filepath.Join(dir, "credentials.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"org_id":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","secret":"s","method":"sigv4"}`), 0600))
+	out, err := executeCommand(t, rootCmd, "auth", "validate")
+	require.NoError(t, err)
+	assert.Contains(t, out, path)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"org_id":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","method":"hmac"}`), 0600))
+	out, err = executeCommand(t, rootCmd, "auth", "validate", "--file", path, "--json")
+	require.ErrorContains(t, err, "1 credentials file(s) failed validation")
+	var reports []credentialFileReport
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "[\n"):])).Decode(&reports))
+	require.Len(t, reports, 1)
+	assert.False(t, reports[0].Valid)
+	require.Len(t, reports[0].Problems, 1)
+	assert.Equal(t, "method", reports[0].Problems[0].Field)
+}
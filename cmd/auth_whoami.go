@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+var authWhoamiJSON bool
+
+var authWhoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the organization and entitlements of the current credentials",
+	Long: `Ask the Vulnetix API who the current credentials belong to: the organization
+name, plan and entitlements, the scopes the credentials grant, and the
+plan's rate limits with today's remaining quota. Unlike 'auth status', which
+only reads local state, this needs working credentials and the network.
+
+Examples:
+  vulnetix auth whoami
+  vulnetix auth whoami --json`,
+	Args: cobra.NoArgs,
+	RunE: runAuthWhoami,
+}
+
+// authWhoamiInfo is the `auth whoami` report. Its keys are snake_case like
+// those of `auth status --json`, whatever the API calls them.
+type authWhoamiInfo struct {
+	OrgID        string   `json:"org_id"`
+	OrgName      string   `json:"org_name,omitempty"`
+	Plan         string   `json:"plan,omitempty"`
+	Entitlements []string `json:"entitlements"`
+	Scopes       []string `json:"scopes"`
+	// RateLimits is nil when the API did not describe the plan's limits.
+	RateLimits *whoamiRateLimits `json:"rate_limits,omitempty"`
+	Method     string            `json:"method"`
+	Source     string            `json:"source"`
+	// Quota is today's remaining allowance, from the response's rate-limit
+	// headers when the API sent them.
+	Quota *whoamiQuota `json:"quota,omitempty"`
+}
+
+// whoamiRateLimits are the request allowances of the org's plan. A zero
+// limit means unlimited.
+type whoamiRateLimits struct {
+	PerMinute  int  `json:"per_minute"`
+	PerDay     int  `json:"per_day"`
+	SoftLimits bool `json:"soft_limits"`
+}
+
+type whoamiQuota struct {
+	// Remaining is -1 when the daily quota is unlimited.
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at,omitzero"`
+}
+
+func runAuthWhoami(cmd *cobra.Command, args []string) error {
+	if authWhoamiJSON {
+		initDisplayContext(cmd, display.ModeJSON)
+	}
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	creds, err := auth.LoadCredentials()
+	if err != nil {
+		return fmt.Errorf("not logged in: %w\nRun 'vulnetix auth login' to authenticate", err)
+	}
+	client := vdb.NewClientFromCredentials(creds)
+	client.BaseURL = authCheckBaseURL
+	identity, err := client.WhoAmI()
+	if err != nil {
+		return fmt.Errorf("failed to look up the current credentials: %w", err)
+	}
+	info := authWhoamiInfo{
+		OrgID:        identity.OrgID,
+		OrgName:      identity.OrgName,
+		Plan:         identity.Plan,
+		Entitlements: identity.Entitlements,
+		Scopes:       identity.Scopes,
+		Method:       string(creds.Method),
+		Source:       auth.CredentialSource(),
+	}
+	if rl := identity.RateLimits; rl != nil {
+		info.RateLimits = &whoamiRateLimits{PerMinute: rl.PerMinute, PerDay: rl.PerDay, SoftLimits: rl.SoftLimits}
+	}
+	if info.OrgID == "" {
+		info.OrgID = creds.OrgID
+	}
+	if rl := client.LastRateLimit; rl != nil && rl.Present {
+		info.Quota = &whoamiQuota{Remaining: rl.Remaining}
+		if rl.Reset > 0 {
+			info.Quota.ResetsAt = time.Unix(int64(rl.Reset), 0).UTC()
+		}
+		if info.Plan == "" {
+			info.Plan = rl.Plan
+		}
+	}
+
+	if authWhoamiJSON {
+		return ctx.Logger.ResultJSON(info)
+	}
+
+	org := info.OrgID
+	if info.OrgName != "" {
+		org = info.OrgName + " (" + info.OrgID + ")"
+	}
+	pairs := []display.KVPair{
+		{Key: "Organization", Value: org},
+		{Key: "Method", Value: info.Method},
+		{Key: "Source", Value: authSourceLabel(info.Source)},
+		{Key: "Plan", Value: info.Plan, ValueStyle: func(_ string) string { return planBadge(t, info.Plan) }},
+		{Key: "Entitlements", Value: listOrDash(info.Entitlements)},
+		{Key: "Scopes", Value: listOrDash(info.Scopes)},
+		{Key: "Rate limits", Value: formatRateLimits(info.RateLimits)},
+	}
+	if q := info.Quota; q != nil {
+		remaining := "unlimited"
+		if q.Remaining >= 0 {
+			remaining = formatNumber(q.Remaining)
+			if !q.ResetsAt.IsZero() {
+				remaining += fmt.Sprintf(" (resets in %s)", formatDuration(max(0, int(time.Until(q.ResetsAt).Seconds()))))
+			}
+		}
+		pairs = append(pairs, display.KVPair{Key: "Remaining today", Value: remaining})
+	}
+	ctx.Logger.Result(display.Header(t, "Identity"))
+	ctx.Logger.Result(display.KeyValue(t, pairs))
+	return nil
+}
+
+// formatRateLimits describes a plan's allowances, e.g. "60 req/min, 10,000
+// req/day (soft limits)", or "-" when they are unknown.
+func formatRateLimits(rl *whoamiRateLimits) string {
+	if rl == nil {
+		return "-"
+	}
+	limit := func(n int, unit string) string {
+		if n <= 0 {
+			return "unlimited req/" + unit
+		}
+		return formatNumber(n) + " req/" + unit
+	}
+	s := limit(rl.PerMinute, "min") + ", " + limit(rl.PerDay, "day")
+	if rl.SoftLimits {
+		s += " (soft limits)"
+	}
+	return s
+}
+
+func listOrDash(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ", ")
+}
+
+func init() {
+	authWhoamiCmd.Flags().BoolVar(&authWhoamiJSON, "json", false, "Output the report as JSON")
+	authCmd.AddCommand(authWhoamiCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+const whoamiBody = `{"orgId":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","orgName":"Acme","plan":"teams",
+	"entitlements":["vdb","uploads"],"scopes":["vdb:read","uploads:write"],
+	"rateLimits":{"perMinute":60,"perDay":10000}}`
+
+func whoamiServer(t *testing.T, body string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/auth/whoami" || r.Header.Get("Authorization") != "Bearer vx_token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-DayLimit", "10000")
+		w.Header().Set("RateLimit-Remaining", "9876")
+		w.Header().Set("RateLimit-Reset", "4102444800")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	orig := authCheckBaseURL
+	authCheckBaseURL = srv.URL
+	t.Cleanup(func() { authCheckBaseURL = orig })
+}
+
+func TestAuthWhoami(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "vx_token")
+	whoamiServer(t, whoamiBody)
+
+	output, err := executeCommand(t, rootCmd, "auth", "whoami")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Acme (6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718)")
+	assert.Contains(t, output, "vdb:read, uploads:write")
+	assert.Contains(t, output, "60 req/min, 10,000 req/day")
+	assert.Contains(t, output, "9,876")
+	assert.NotContains(t, output, "vx_token")
+}
+
+func TestAuthWhoamiJSON(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "vx_token")
+	t.Cleanup(func() { authWhoamiJSON = false })
+	whoamiServer(t, whoamiBody)
+
+	output, err := executeCommand(t, rootCmd, "auth", "whoami", "--json")
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output[strings.Index(output, "{"):]), &got))
+	assert.Equal(t, "Acme", got["org_name"])
+	assert.Equal(t, "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", got["org_id"])
+	assert.Equal(t, "teams", got["plan"])
+	assert.Equal(t, "token", got["method"])
+	assert.Equal(t, []any{"vdb", "uploads"}, got["entitlements"])
+	assert.Equal(t, map[string]any{"per_minute": float64(60), "per_day": float64(10000), "soft_limits": false}, got["rate_limits"])
+	assert.Equal(t, map[string]any{"remaining": float64(9876), "resets_at": "2100-01-01T00:00:00Z"}, got["quota"])
+}
+
+func TestAuthWhoamiWithoutRateLimits(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "vx_token")
+	t.Cleanup(func() { authWhoamiJSON = false })
+	whoamiServer(t, `{"orgId":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","plan":"community"}`)
+
+	output, err := executeCommand(t, rootCmd, "auth", "whoami")
+	require.NoError(t, err)
+	assert.NotContains(t, output, "unlimited req/min")
+	assert.Regexp(t, `Rate limits:\s+-\n`, output)
+
+	output, err = executeCommand(t, rootCmd, "auth", "whoami", "--json")
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output[strings.Index(output, "{"):]), &got))
+	assert.NotContains(t, got, "rate_limits")
+}
+
+func TestAuthWhoamiRequiresCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(auth.CredentialsDirEnv, t.TempDir())
+	t.Chdir(t.TempDir())
+
+	_, err := executeCommand(t, rootCmd, "auth", "whoami")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not logged in")
+}
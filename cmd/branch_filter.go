@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/vulnetix/cli/v3/internal/config"
+	"github.com/vulnetix/cli/v3/internal/display"
+)
+
+// currentCIBranch returns the branch the CI platform reports for this run
+// (GITHUB_REF_NAME, CI_COMMIT_REF_NAME, BITBUCKET_BRANCH, ...), or "" when it
+// cannot be determined, e.g. outside CI. On a GitHub pull request run the
+// ref name is "<number>/merge", so the PR's source branch is used instead.
+func currentCIBranch() string {
+	ci := config.LoadCIContext(version)
+	branch := ci.RefName
+	if ci.Platform == config.PlatformGitHub && ci.HeadRef != "" {
+		branch = ci.HeadRef
+	}
+	// Jenkins and Azure DevOps may report the full ref or the remote-tracking name.
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	branch = strings.TrimPrefix(branch, "origin/")
+	return branch
+}
+
+// branchMatches reports whether branch matches any of the glob patterns
+// (path.Match syntax, so "release/*" matches "release/1.2").
+func branchMatches(branch string, patterns []string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if ok, _ := path.Match(p, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBranchPatterns rejects malformed --only-branches globs up front so
+// a typo does not silently skip every upload.
+func validateBranchPatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(strings.TrimSpace(p), ""); err != nil {
+			return fmt.Errorf("invalid --only-branches pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// skipForBranch reports whether an upload should be skipped because
+// --only-branches is set and the current branch does not match it. The reason
+// is logged so the CI output explains why nothing was uploaded.
+func skipForBranch(ctx *display.Context, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	branch := currentCIBranch()
+	if branch == "" {
+		ctx.Logger.Infof("Skipping upload: could not determine the current branch (--only-branches %s)", strings.Join(patterns, ","))
+		return true
+	}
+	if !branchMatches(branch, patterns) {
+		ctx.Logger.Infof("Skipping upload: branch %q does not match --only-branches %s", branch, strings.Join(patterns, ","))
+		return true
+	}
+	return false
+}
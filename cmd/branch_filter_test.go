@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func TestBranchMatches(t *testing.T) {
+	patterns := []string{"main", "release/*"}
+	assert.True(t, branchMatches("main", patterns))
+	assert.True(t, branchMatches("release/1.2", patterns))
+	assert.False(t, branchMatches("feature/login", patterns))
+	assert.False(t, branchMatches("release/1.2/hotfix", patterns))
+	assert.False(t, branchMatches("main", nil))
+}
+
+func TestCurrentCIBranchUsesPullRequestHeadRef(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_REF_NAME", "main")
+	t.Setenv("GITHUB_HEAD_REF", "")
+	assert.Equal(t, "main", currentCIBranch())
+
+	t.Setenv("GITHUB_REF_NAME", "123/merge")
+	t.Setenv("GITHUB_HEAD_REF", "feature/login")
+	assert.Equal(t, "feature/login", currentCIBranch())
+	assert.True(t, branchMatches(currentCIBranch(), []string{"feature/*"}))
+}
+
+// setBranchEnv makes the CLI believe it is running in GitHub Actions on branch.
+func setBranchEnv(t *testing.T, branch string) {
+	t.Helper()
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_REF_NAME", branch)
+	t.Setenv("GITHUB_HEAD_REF", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { uploadBranches, uploadFile, uploadBaseURL = nil, "", upload.DefaultBaseURL })
+}
+
+func branchTestArtifact(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	return path
+}
+
+func TestUploadOnlyBranches_SkipsNonMatchingBranch(t *testing.T) {
+	setBranchEnv(t, "feature/login")
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd,
+		"upload",
+		"--file", branchTestArtifact(t),
+		"--base-url", server.URL+"/v1",
+		"--only-branches", "main,release/*",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls, "nothing should be uploaded from a non-matching branch")
+}
+
+func TestUploadOnlyBranches_UploadsMatchingBranch(t *testing.T) {
+	setBranchEnv(t, "release/2.0")
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd,
+		"upload",
+		"--file", branchTestArtifact(t),
+		"--base-url", server.URL+"/v1",
+		"--only-branches", "main,release/*",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.NoError(t, err)
+	assert.Greater(t, calls, 0, "a matching branch should upload")
+}
+
+func TestUploadOnlyBranches_RejectsInvalidPattern(t *testing.T) {
+	setBranchEnv(t, "main")
+
+	_, err := executeCommand(t, rootCmd, "upload", "--only-branches", "release/[", "--no-progress", "--no-analytics")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --only-branches pattern")
+}
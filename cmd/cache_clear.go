@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+)
+
+var (
+	cacheClearAll    bool
+	cacheClearVDB    bool
+	cacheClearTokens bool
+	cacheClearState  bool
+	cacheClearGHA    bool
+)
+
+// localCacheTarget is a cache that `cache clear` can remove, as paths or glob
+// patterns relative to its root.
+type localCacheTarget struct {
+	name  string
+	paths []string
+	flag  *bool
+	// root returns the directory paths are relative to; nil means ~/.vulnetix.
+	root func() string
+}
+
+// localCacheTargets lists everything `cache clear` may delete. Credentials,
+// the config file and project .vulnetix/ directories are deliberately absent.
+// The gha state directory can be redirected anywhere, so only its state files
+// are removed, never the directory itself.
+var localCacheTargets = []localCacheTarget{
+	{name: "vdb", paths: []string{filepath.Join("cache", "vdb"), filepath.Join("cache", "vdb-spec.json")}, flag: &cacheClearVDB},
+	{name: "tokens", paths: []string{"token-cache.json"}, flag: &cacheClearTokens},
+	{name: "state", paths: []string{"state", "rate-limit.json"}, flag: &cacheClearState},
+	{name: "gha", paths: []string{"*.json"}, flag: &cacheClearGHA, root: ghaStatusStateDir},
+}
+
+var localCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage local caches under ~/.vulnetix",
+}
+
+var localCacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove local caches and report the space freed",
+	Long: `Remove cached data, mostly from ~/.vulnetix, and report how much space was
+freed. Stored credentials and the config file are never touched.
+
+  --vdb      VDB API responses (every CLI version) and the OpenAPI spec
+  --tokens   cached SigV4 session tokens
+  --state    update-check state and remembered exhausted rate-limit quotas
+  --gha      'gha status --resume' state (under the OS user cache directory,
+             or $VULNETIX_GHA_STATE_DIR)
+  --all      all of the above
+
+Examples:
+  vulnetix cache clear --all
+  vulnetix cache clear --vdb --tokens`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !cacheClearAll && !cacheClearVDB && !cacheClearTokens && !cacheClearState && !cacheClearGHA {
+			return fmt.Errorf("choose what to clear: --all, --vdb, --tokens, --state or --gha")
+		}
+		return nil
+	},
+	RunE: runCacheClear,
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("user home dir: %w", err)
+	}
+	root := filepath.Join(home, ".vulnetix")
+
+	var total int64
+	for _, target := range localCacheTargets {
+		if !cacheClearAll && !*target.flag {
+			continue
+		}
+		targetRoot := root
+		if target.root != nil {
+			targetRoot = target.root()
+		}
+		var freed int64
+		for _, pattern := range target.paths {
+			matches, err := filepath.Glob(filepath.Join(targetRoot, pattern))
+			if err != nil {
+				return fmt.Errorf("clear %s cache: %w", target.name, err)
+			}
+			for _, match := range matches {
+				rel, err := filepath.Rel(targetRoot, match)
+				if err != nil {
+					return fmt.Errorf("clear %s cache: %w", target.name, err)
+				}
+				n, err := removeUnderRoot(targetRoot, rel)
+				if err != nil {
+					return fmt.Errorf("clear %s cache: %w", target.name, err)
+				}
+				freed += n
+			}
+		}
+		total += freed
+		ctx.Logger.Result(fmt.Sprintf("%s %-7s %s", display.CheckMark(t), target.name, formatByteSize(int(freed))))
+	}
+	ctx.Logger.Result(fmt.Sprintf("Freed %s", formatByteSize(int(total))))
+	return nil
+}
+
+// removeUnderRoot deletes root/rel and returns the bytes it held. rel must
+// name something strictly inside root; a symlink is removed itself, never
+// followed. A missing path frees nothing.
+func removeUnderRoot(root, rel string) (int64, error) {
+	path := filepath.Join(root, rel)
+	inside, err := filepath.Rel(root, path)
+	if err != nil || inside == "." || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return 0, fmt.Errorf("refusing to remove %s: not inside %s", path, root)
+	}
+
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	if info.IsDir() {
+		_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && d.Type().IsRegular() {
+				if fi, err := d.Info(); err == nil {
+					size += fi.Size()
+				}
+			}
+			return nil
+		})
+	} else if info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	return size, os.RemoveAll(path)
+}
+
+func init() {
+	localCacheClearCmd.Flags().BoolVar(&cacheClearAll, "all", false, "Clear every cache")
+	localCacheClearCmd.Flags().BoolVar(&cacheClearVDB, "vdb", false, "Clear cached VDB responses and the OpenAPI spec")
+	localCacheClearCmd.Flags().BoolVar(&cacheClearTokens, "tokens", false, "Clear cached SigV4 session tokens")
+	localCacheClearCmd.Flags().BoolVar(&cacheClearState, "state", false, "Clear update-check state and remembered exhausted rate-limit quotas")
+	localCacheClearCmd.Flags().BoolVar(&cacheClearGHA, "gha", false, "Clear the state 'gha status --resume' compares against")
+	localCacheCmd.AddCommand(localCacheClearCmd)
+	rootCmd.AddCommand(localCacheCmd)
+}
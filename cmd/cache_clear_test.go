@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheClearIsSelective(t *testing.T) {
+	t.Cleanup(func() {
+		cacheClearAll, cacheClearVDB, cacheClearTokens, cacheClearState, cacheClearGHA = false, false, false, false, false
+	})
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ghaDir := filepath.Join(t.TempDir(), "gha-status")
+	t.Setenv("VULNETIX_GHA_STATE_DIR", ghaDir)
+	require.NoError(t, os.MkdirAll(ghaDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(ghaDir, "txn-1.json"), make([]byte, 40), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(ghaDir, "notes.txt"), []byte("x"), 0600))
+	root := filepath.Join(home, ".vulnetix")
+	files := map[string]int{
+		"cache/vdb/v1.0/abc.json": 1000,
+		"cache/vdb-spec.json":     200,
+		"token-cache.json":        30,
+		"state/last-update-check": 10,
+		"rate-limit.json":         20,
+		"credentials.json":        50,
+		"config.yaml":             5,
+	}
+	for rel, size := range files {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0600))
+	}
+
+	out, err := executeCommand(t, rootCmd, "cache", "clear", "--vdb", "--tokens")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Freed 1230 bytes")
+	assert.NoDirExists(t, filepath.Join(root, "cache", "vdb"))
+	assert.NoFileExists(t, filepath.Join(root, "cache", "vdb-spec.json"))
+	assert.NoFileExists(t, filepath.Join(root, "token-cache.json"))
+	assert.FileExists(t, filepath.Join(root, "state", "last-update-check"), "--state was not selected")
+	assert.FileExists(t, filepath.Join(root, "rate-limit.json"))
+	assert.FileExists(t, filepath.Join(root, "credentials.json"))
+	assert.FileExists(t, filepath.Join(ghaDir, "txn-1.json"), "--gha was not selected")
+
+	cacheClearVDB, cacheClearTokens = false, false
+	out, err = executeCommand(t, rootCmd, "cache", "clear", "--gha")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Freed 40 bytes")
+	assert.NoFileExists(t, filepath.Join(ghaDir, "txn-1.json"))
+	assert.FileExists(t, filepath.Join(ghaDir, "notes.txt"), "only gha state files are removed")
+	assert.FileExists(t, filepath.Join(root, "state", "last-update-check"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(ghaDir, "txn-2.json"), []byte("{}"), 0600))
+	cacheClearGHA = false
+	_, err = executeCommand(t, rootCmd, "cache", "clear", "--all")
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(root, "state"))
+	assert.NoFileExists(t, filepath.Join(root, "rate-limit.json"))
+	assert.NoFileExists(t, filepath.Join(ghaDir, "txn-2.json"))
+	assert.FileExists(t, filepath.Join(root, "credentials.json"), "credentials are never removed")
+	assert.FileExists(t, filepath.Join(root, "config.yaml"))
+}
+
+func TestCacheClearRequiresSelection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	_, err := executeCommand(t, rootCmd, "cache", "clear")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "choose what to clear")
+}
+
+func TestRemoveUnderRootStaysInside(t *testing.T) {
+	root := filepath.Join(t.TempDir(), ".vulnetix")
+	outside := filepath.Join(filepath.Dir(root), "keep")
+	require.NoError(t, os.MkdirAll(root, 0700))
+	require.NoError(t, os.WriteFile(outside, []byte("x"), 0600))
+
+	for _, rel := range []string{"..", "../keep", ".", ""} {
+		_, err := removeUnderRoot(root, rel)
+		assert.Error(t, err, "rel %q", rel)
+	}
+	assert.FileExists(t, outside)
+
+	// A symlink inside root is removed without following it.
+	require.NoError(t, os.Symlink(filepath.Dir(root), filepath.Join(root, "state")))
+	n, err := removeUnderRoot(root, "state")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.FileExists(t, outside)
+}
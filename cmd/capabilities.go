@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+var capabilitiesJSON bool
+
+// capabilities describes what this binary supports, for wrappers that gate
+// features on the installed CLI version.
+type capabilities struct {
+	Version          string                 `json:"version"`
+	Commands         []commandCapability    `json:"commands"`
+	GlobalFlags      []string               `json:"globalFlags"`
+	Formats          []string               `json:"formats"`
+	AuthMethods      []auth.AuthMethod      `json:"authMethods"`
+	CredentialStores []auth.CredentialStore `json:"credentialStores"`
+}
+
+// commandCapability is one runnable command and the flags it accepts beyond
+// the global ones, including those inherited from its parent commands.
+type commandCapability struct {
+	Path  string   `json:"path"`
+	Flags []string `json:"flags,omitempty"`
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Describe the commands, formats and auth methods this binary supports",
+	Long: `Print what this build of the CLI supports: every command and its flags,
+the artifact formats, the auth methods and the credential stores. Everything is
+read from the command tree and the CLI's own constants, so a wrapper can check
+for a feature instead of parsing --help or comparing version numbers.
+
+Hidden and deprecated commands and flags are left out.
+
+Examples:
+  vulnetix capabilities
+  vulnetix capabilities --json | jq '.commands[].path'`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		caps := collectCapabilities(cmd.Root())
+		if capabilitiesJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(caps)
+		}
+
+		t := display.FromCommand(cmd).Term
+		paths := make([]string, len(caps.Commands))
+		for i, c := range caps.Commands {
+			paths[i] = c.Path
+		}
+		methods := make([]string, len(caps.AuthMethods))
+		for i, m := range caps.AuthMethods {
+			methods[i] = string(m)
+		}
+		stores := make([]string, len(caps.CredentialStores))
+		for i, s := range caps.CredentialStores {
+			stores[i] = string(s)
+		}
+		var b strings.Builder
+		b.WriteString(display.KeyValue(t, []display.KVPair{
+			{Key: "Version", Value: caps.Version},
+			{Key: "Formats", Value: strings.Join(caps.Formats, ", ")},
+			{Key: "Auth methods", Value: strings.Join(methods, ", ")},
+			{Key: "Credential stores", Value: strings.Join(stores, ", ")},
+		}))
+		b.WriteString("\n\n" + display.Bold(t, fmt.Sprintf("Commands (%d)", len(paths))) + "\n")
+		b.WriteString(display.BulletList(t, paths))
+		fmt.Println(b.String())
+		return nil
+	},
+}
+
+// collectCapabilities walks the command tree under root.
+func collectCapabilities(root *cobra.Command) capabilities {
+	caps := capabilities{
+		Version:          version,
+		GlobalFlags:      visibleFlags(root.PersistentFlags()),
+		Formats:          upload.SupportedFormats,
+		AuthMethods:      append(slices.Clone(auth.Methods), auth.OIDC),
+		CredentialStores: auth.Stores,
+	}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if sub.Hidden || sub.Deprecated != "" || sub.Name() == "help" {
+				continue
+			}
+			if sub.Runnable() {
+				path := strings.TrimPrefix(sub.CommandPath(), root.Name()+" ")
+				var flags []string
+				for _, name := range visibleFlags(sub.LocalFlags(), sub.InheritedFlags()) {
+					if !slices.Contains(caps.GlobalFlags, name) || sub.LocalFlags().Lookup(name) != nil {
+						flags = append(flags, name)
+					}
+				}
+				caps.Commands = append(caps.Commands, commandCapability{Path: path, Flags: flags})
+			}
+			walk(sub)
+		}
+	}
+	walk(root)
+	sort.Slice(caps.Commands, func(i, j int) bool { return caps.Commands[i].Path < caps.Commands[j].Path })
+	return caps
+}
+
+// visibleFlags returns the sorted names of the flags in sets that are neither
+// hidden nor deprecated.
+func visibleFlags(sets ...*pflag.FlagSet) []string {
+	seen := map[string]bool{}
+	for _, set := range sets {
+		set.VisitAll(func(f *pflag.Flag) {
+			if !f.Hidden && f.Deprecated == "" {
+				seen[f.Name] = true
+			}
+		})
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	capabilitiesCmd.Flags().BoolVar(&capabilitiesJSON, "json", false, "Output the capabilities as JSON")
+	rootCmd.AddCommand(capabilitiesCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func TestCapabilitiesJSON(t *testing.T) {
+	t.Cleanup(func() { capabilitiesJSON = false })
+
+	out, err := executeCommand(t, rootCmd, "capabilities", "--json", "--no-analytics")
+	require.NoError(t, err)
+	var caps capabilities
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{\n"):]), &caps))
+
+	flagsByPath := map[string][]string{}
+	for _, c := range caps.Commands {
+		flagsByPath[c.Path] = c.Flags
+	}
+	for _, path := range []string{"auth login", "upload", "gha upload", "gha status", "scan", "validate", "vdb vuln", "formats", "capabilities"} {
+		assert.Contains(t, flagsByPath, path)
+	}
+	assert.NotContains(t, flagsByPath, "help")
+	assert.Contains(t, flagsByPath["upload"], "format")
+	assert.Contains(t, flagsByPath["vdb vuln"], "secret", "flags inherited from a parent command are listed")
+	assert.NotContains(t, flagsByPath["upload"], "verbose", "global flags are listed once, under globalFlags")
+	assert.Contains(t, caps.GlobalFlags, "verbose")
+
+	assert.Equal(t, upload.SupportedFormats, caps.Formats)
+	for _, f := range []string{upload.FormatCycloneDX, upload.FormatSPDX, upload.FormatSARIF, upload.FormatOpenVEX, upload.FormatCSAFVEX} {
+		assert.Contains(t, caps.Formats, f)
+	}
+	assert.ElementsMatch(t, []auth.AuthMethod{auth.Token, auth.DirectAPIKey, auth.SigV4, auth.OIDC}, caps.AuthMethods)
+	assert.ElementsMatch(t, []auth.CredentialStore{auth.StoreHome, auth.StoreProject, auth.StoreKeyring}, caps.CredentialStores)
+}
@@ -70,6 +70,7 @@ func init() {
 	cbomCmd.Flags().Int("depth", 25, "Maximum recursion depth for file discovery")
 	cbomCmd.Flags().StringArray("ignore", nil, "Exclude paths matching glob pattern (repeatable)")
 	cbomCmd.Flags().StringP("output", "o", "pretty", "Terminal output format: pretty, json, cyclonedx-json")
+	declareOutputFormats(cbomCmd, "pretty", "json", "cyclonedx-json")
 	cbomCmd.Flags().String("output-file", "", "Path to write the CycloneDX CBOM (default: <path>/.vulnetix/cbom.cdx.json)")
 	cbomCmd.Flags().String("spec-version", "1.7", "CycloneDX spec version: 1.6 or 1.7")
 	cbomCmd.Flags().String("catalog", "", "Path to a catalog file to merge over (or replace) the builtin catalog")
@@ -27,6 +27,7 @@ import (
 
 	"github.com/vulnetix/cli/v3/internal/cdx"
 	"github.com/vulnetix/cli/v3/internal/gitctx"
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/internal/license"
 	"github.com/vulnetix/cli/v3/internal/memory"
 	"github.com/vulnetix/cli/v3/internal/reachability"
@@ -1121,7 +1122,8 @@ func runSCAJobs(
 
 // scaConcurrency is how many cli.sca discovery batches the CLI sends in parallel
 // after the primary snapshot is created. Default 6; VULNETIX_SCA_CONCURRENCY
-// overrides (clamped 1–16; 1 = the legacy strictly-serial path).
+// overrides (clamped 1–16; 1 = the legacy strictly-serial path). Never more
+// than --vdb-concurrency allows.
 func scaConcurrency() int {
 	n := 6
 	if v := strings.TrimSpace(os.Getenv("VULNETIX_SCA_CONCURRENCY")); v != "" {
@@ -1129,7 +1131,17 @@ func scaConcurrency() int {
 			n = parsed
 		}
 	}
-	return min(max(n, 1), 16)
+	return vdbWorkers(min(max(n, 1), 16))
+}
+
+// vdbWorkers lowers a batch's worker count to --vdb-concurrency when that is
+// set. The client enforces the limit regardless; this only avoids starting
+// workers that would wait on it.
+func vdbWorkers(n int) int {
+	if vdb.MaxConcurrentRequests > 0 {
+		return min(n, vdb.MaxConcurrentRequests)
+	}
+	return n
 }
 
 // sendCliSCAWithRetry sends one cli.sca request, retrying transient failures
@@ -1159,7 +1171,7 @@ func sendCliSCAWithRetry(client *vdb.Client, env vdb.CliEnv, req vdb.CliSCAReque
 }
 
 // isRetryableCliErr classifies an error from CliSCAWithContext. Retryable:
-// 429, any 5xx, and transport-level failures (timeouts, resets, deadline).
+// 408, 429, any 5xx, and transport-level failures (timeouts, resets, deadline).
 // Terminal: 4xx (auth/bad-request), 404, and response-decode errors.
 func isRetryableCliErr(err error) bool {
 	if err == nil {
@@ -1167,7 +1179,7 @@ func isRetryableCliErr(err error) bool {
 	}
 	var apiErr *vdb.CliAPIError
 	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+		return httpx.Retriable(nil, apiErr.StatusCode)
 	}
 	var nf *vdb.NotFoundError
 	if errors.As(err, &nf) {
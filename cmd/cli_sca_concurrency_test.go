@@ -101,3 +101,20 @@ func TestRunSCAJobs_ConcurrencyParity(t *testing.T) {
 		t.Errorf("merged findings differ: serial=%d par=%d (want 7)", serialFindings, parFindings)
 	}
 }
+
+func TestVDBWorkersHonoursVDBConcurrency(t *testing.T) {
+	prev := vdb.MaxConcurrentRequests
+	t.Cleanup(func() { vdb.MaxConcurrentRequests = prev })
+
+	vdb.MaxConcurrentRequests = 0
+	if got := vdbWorkers(6); got != 6 {
+		t.Errorf("no limit: vdbWorkers(6) = %d, want 6", got)
+	}
+	vdb.MaxConcurrentRequests = 2
+	if got := vdbWorkers(6); got != 2 {
+		t.Errorf("limit 2: vdbWorkers(6) = %d, want 2", got)
+	}
+	if got := vdbWorkers(1); got != 1 {
+		t.Errorf("limit 2: vdbWorkers(1) = %d, want 1", got)
+	}
+}
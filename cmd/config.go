@@ -102,7 +102,7 @@ Policy form:
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
 
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -334,7 +334,7 @@ opts the org in to synthetic EOL findings.`,
 	for _, name := range []string{"next-quarter-severity", "this-quarter-severity", "within-30-days-severity", "retired-severity"} {
 		_ = cmd.RegisterFlagCompletionFunc(name, cobra.FixedCompletions(eolPolicySeverities, cobra.ShellCompDirectiveNoFileComp))
 	}
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -428,7 +428,7 @@ for the org — members then fall back to their own scan flags / builtin default
 	_ = cmd.RegisterFlagCompletionFunc("exploits", cobra.FixedCompletions(withNull(qualityGateExploits), cobra.ShellCompDirectiveNoFileComp))
 	_ = cmd.RegisterFlagCompletionFunc("severity", cobra.FixedCompletions(withNull(qualityGateSeverities), cobra.ShellCompDirectiveNoFileComp))
 	_ = cmd.RegisterFlagCompletionFunc("sca-autofix-strategy", cobra.FixedCompletions(withNull(qualityGateAutofixStrats), cobra.ShellCompDirectiveNoFileComp))
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -555,7 +555,7 @@ caller's scan flag (or builtin default) in effect.`,
 	}
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -652,7 +652,7 @@ mirror across all ecosystems.`,
 	}
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -755,7 +755,7 @@ policy is configured the org has not opted in and no EOL findings are created.`,
 	}
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -29,7 +29,7 @@ guardrails enforced inline by https://guardrails.vulnetix.com.`,
 	}
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 	return cmd
 }
 
@@ -265,7 +265,7 @@ func runConfigSetAiFirewallGuardrail(cmd *cobra.Command, args []string) error {
 func addAiFirewallCommonFlags(cmd *cobra.Command) {
 	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	cmd.Flags().StringP("output", "o", "pretty", "Output format (pretty, json)")
-	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"pretty", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(cmd, "pretty", "json")
 }
 
 func initAiFirewallOutput(cmd *cobra.Command) error {
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/config"
+)
+
+// configSections are the commands that read their section of the CLI config
+// file.
+var configSections = []string{"gha", "upload", "vdb"}
+
+// applyConfigDefaults sets the flags of cmd from the named section of the CLI
+// config file (see config.File). Flags given on the command line always win.
+// Keys are flag names with underscores in place of dashes, so `base_url`
+// configures --base-url. A key that is not a flag of cmd is skipped when some
+// other command in the section accepts it, and rejected in strict mode when
+// none does. Strict mode also rejects sections no command reads.
+func applyConfigDefaults(cmd *cobra.Command, section string) error {
+	path, err := config.DefaultFilePath()
+	if err != nil {
+		return err
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	if file.Strict {
+		names := make([]string, 0, len(file.Sections))
+		for name := range file.Sections {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !slices.Contains(configSections, name) {
+				return fmt.Errorf("config %s: unknown section %s (expected one of %s)", file.Path, name, strings.Join(configSections, ", "))
+			}
+		}
+	}
+	defaults := file.Sections[section]
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := strings.ReplaceAll(key, "_", "-")
+		fl := cmd.Flags().Lookup(name)
+		if fl == nil {
+			if file.Strict && !sectionHasFlag(section, name) {
+				return fmt.Errorf("config %s: unknown key %s.%s", file.Path, section, key)
+			}
+			continue
+		}
+		if fl.Changed {
+			continue
+		}
+		if err := fl.Value.Set(configFlagValue(defaults[key])); err != nil {
+			return fmt.Errorf("config %s: %s.%s: %w", file.Path, section, key, err)
+		}
+	}
+	return nil
+}
+
+// sectionHasFlag reports whether any command under the top-level command
+// named section, or the root, defines flag name.
+func sectionHasFlag(section, name string) bool {
+	if rootCmd.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == section {
+			return commandTreeHasFlag(c, name)
+		}
+	}
+	return false
+}
+
+func commandTreeHasFlag(cmd *cobra.Command, name string) bool {
+	if cmd.LocalFlags().Lookup(name) != nil {
+		return true
+	}
+	for _, c := range cmd.Commands() {
+		if commandTreeHasFlag(c, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// configFlagValue renders a YAML value in the syntax pflag parses: lists are
+// comma-joined and mappings become k=v pairs.
+func configFlagValue(v any) string {
+	switch v := v.(type) {
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + "=" + fmt.Sprint(v[k])
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// clearFlagsChanged marks every flag of cmd as not given, as in a fresh
+// process. Earlier tests in this binary leave flags marked, and config-file
+// defaults only apply to flags the command line did not set.
+func clearFlagsChanged(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
+}
+
+// writeCLIConfig points VULNETIX_CONFIG at a config file with content.
+func writeCLIConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	t.Setenv("VULNETIX_CONFIG", path)
+}
+
+func TestUploadReadsNamespacedConfig(t *testing.T) {
+	resetUploadFlags(t)
+	clearFlagsChanged(uploadCmd)
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+	writeCLIConfig(t, "upload:\n  base_url: "+server.URL+"/v1\n  json: true\nvdb:\n  base_url: http://127.0.0.1:0\n")
+
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+
+	out, err := executeCommand(t, rootCmd, "upload", "--file", path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits, "upload.base_url should redirect the upload")
+	assert.Contains(t, out, `"uuid": "p-1"`, "upload.json should select JSON output")
+}
+
+func TestVDBReadsNamespacedConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { vdbBaseURL, vdbOutput = vdb.DefaultBaseURL, "pretty" })
+	clearFlagsChanged(vdbStatsCmd)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ecosystems":[{"name":"npm","count":3}],"totalPackages":3,"totalCVEs":7}`)
+	}))
+	defer server.Close()
+	writeCLIConfig(t, "vdb:\n  base_url: "+server.URL+"\n  output: json\n")
+
+	out, err := executeCommand(t, rootCmd, "vdb", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"totalPackages": 3`)
+}
+
+func TestGHAReadsNamespacedConfig(t *testing.T) {
+	resetGHAStatusFlags(t)
+	clearFlagsChanged(ghaStatusCmd)
+	hits := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = io.WriteString(w, `{"status":"completed","txnid":"txn-1","artifacts":[]}`)
+	}))
+	defer api.Close()
+	writeCLIConfig(t, "gha:\n  base_url: "+api.URL+"\n  json: true\n")
+
+	_, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--txnid", "txn-1", "--no-progress", "--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits)
+	assert.True(t, ghaOutputJSON)
+}
+
+func TestConfigFlagsOverrideConfigFile(t *testing.T) {
+	resetGHAStatusFlags(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"completed","txnid":"txn-1","artifacts":[]}`)
+	}))
+	defer api.Close()
+	writeCLIConfig(t, "gha:\n  base_url: http://127.0.0.1:0\n")
+
+	_, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-1", "--json", "--no-progress", "--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, api.URL, ghaBaseURL)
+}
+
+func TestConfigStrictRejectsUnknownKeys(t *testing.T) {
+	resetGHAStatusFlags(t)
+	writeCLIConfig(t, "strict: true\ngha:\n  base_urls: https://example.com\n")
+
+	_, err := executeCommand(t, rootCmd, "gha", "status", "--txnid", "txn-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown key gha.base_urls")
+
+	// Without strict, unknown keys are ignored, and keys owned by a sibling
+	// command (gha upload's max_total_size) are accepted even in strict mode.
+	assert.NoError(t, applyConfigDefaultsFrom(t, "gha:\n  base_urls: https://example.com\n"))
+	assert.NoError(t, applyConfigDefaultsFrom(t, "strict: true\ngha:\n  max_total_size: 1024\n"))
+}
+
+func TestConfigStrictRejectsUnknownSections(t *testing.T) {
+	err := applyConfigDefaultsFrom(t, "strict: true\nuplaod:\n  base_url: https://example.com\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown section uplaod")
+
+	assert.NoError(t, applyConfigDefaultsFrom(t, "uplaod:\n  base_url: https://example.com\n"))
+	assert.NoError(t, applyConfigDefaultsFrom(t, "strict: true\nupload:\n  base_url: https://example.com\nvdb:\n  output: json\n"))
+}
+
+func applyConfigDefaultsFrom(t *testing.T, content string) error {
+	t.Helper()
+	writeCLIConfig(t, content)
+	return applyConfigDefaults(ghaStatusCmd, "gha")
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRepoConfigFile is the per-repository configuration file that
+// `config pull` seeds from the org's defaults.
+const defaultRepoConfigFile = "vulnetix.yaml"
+
+// repoConfigSetting is one top-level vulnetix.yaml key and its org value.
+type repoConfigSetting struct {
+	Key   string
+	Value any
+}
+
+func newConfigPullCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Write the org's default settings to vulnetix.yaml",
+		Long: `Fetch the org's default repository settings (production branch, required
+tools, severity thresholds) and write them to a local vulnetix.yaml so the
+repository inherits org policy.
+
+An existing file is merged rather than replaced: keys already present locally
+are kept as repository overrides, along with any comments and keys the org does
+not manage. Pass --overwrite to replace local values with the org's.
+
+Examples:
+  vulnetix config pull
+  vulnetix config pull --file .github/vulnetix.yaml --overwrite`,
+		Args: cobra.NoArgs,
+		RunE: runConfigPull,
+	}
+	cmd.Flags().String("file", defaultRepoConfigFile, "Configuration file to write")
+	cmd.Flags().Bool("overwrite", false, "Replace local values with the org's defaults")
+	cmd.Flags().String("base-url", vdb.DefaultBaseURL, "VDB API base URL")
+	_ = cmd.MarkFlagFilename("file", "yaml", "yml")
+	return cmd
+}
+
+func runConfigPull(cmd *cobra.Command, args []string) error {
+	initDisplayContext(cmd, display.ModeText)
+	path, _ := cmd.Flags().GetString("file")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	client, err := newPackageFirewallConfigClient(cmd)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CliOrgConfigGet(envForCli())
+	if err != nil {
+		return err
+	}
+
+	ctx := display.FromCommand(cmd)
+	settings := orgConfigSettings(resp.Data.Config)
+	if len(settings) == 0 {
+		ctx.Logger.Info("The org has no default configuration — nothing to pull.")
+		return nil
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	merged, kept, err := mergeRepoConfig(existing, settings, overwrite)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := os.WriteFile(path, merged, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	if len(kept) > 0 {
+		ctx.Logger.Infof("Kept local override(s) for %s (use --overwrite to replace)", strings.Join(kept, ", "))
+	}
+	ctx.Logger.Result(fmt.Sprintf("Wrote %d org setting(s) to %s", len(settings)-len(kept), path))
+	return nil
+}
+
+// orgConfigSettings flattens the org config into vulnetix.yaml keys, skipping
+// anything the org has not set.
+func orgConfigSettings(c *vdb.CliOrgConfig) []repoConfigSetting {
+	if c == nil {
+		return nil
+	}
+	var s []repoConfigSetting
+	if c.ProductionBranch != "" {
+		s = append(s, repoConfigSetting{"production_branch", c.ProductionBranch})
+	}
+	if len(c.RequiredTools) > 0 {
+		s = append(s, repoConfigSetting{"required_tools", c.RequiredTools})
+	}
+	if c.Severity != "" {
+		s = append(s, repoConfigSetting{"severity", c.Severity})
+	}
+	if c.CVSSThreshold != nil {
+		s = append(s, repoConfigSetting{"cvss_threshold", *c.CVSSThreshold})
+	}
+	if c.EPSSThreshold != nil {
+		s = append(s, repoConfigSetting{"epss_threshold", *c.EPSSThreshold})
+	}
+	return s
+}
+
+// mergeRepoConfig applies settings to an existing vulnetix.yaml document (or
+// an empty one) and returns the new content. Working on the yaml.Node tree
+// keeps local comments, key order and unmanaged keys intact. Unless overwrite
+// is set, keys already present locally win and are returned in kept.
+func mergeRepoConfig(existing []byte, settings []repoConfigSetting, overwrite bool) ([]byte, []string, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(existing)) > 0 {
+		if err := yaml.Unmarshal(existing, &doc); err != nil {
+			return nil, nil, fmt.Errorf("parse existing config: %w", err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{
+			Kind:        yaml.MappingNode,
+			HeadComment: "Org defaults from `vulnetix config pull`; local edits take precedence on the next pull.",
+		}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("existing config is not a YAML mapping")
+	}
+
+	var kept []string
+	for _, setting := range settings {
+		var value yaml.Node
+		if err := value.Encode(setting.Value); err != nil {
+			return nil, nil, fmt.Errorf("encode %s: %w", setting.Key, err)
+		}
+		idx := -1
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == setting.Key {
+				idx = i + 1
+				break
+			}
+		}
+		switch {
+		case idx < 0:
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: setting.Key}, &value)
+		case overwrite:
+			value.LineComment = root.Content[idx].LineComment
+			root.Content[idx] = &value
+		default:
+			kept = append(kept, setting.Key)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("encode config: %w", err)
+	}
+	return buf.Bytes(), kept, nil
+}
+
+func init() {
+	configCmd.AddCommand(newConfigPullCommand())
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func orgConfigServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/cli.org-config-get", r.URL.Path)
+		_, _ = io.WriteString(w, `{"meta":{"tier":"pro"},"data":{"config":{
+			"productionBranch":"main",
+			"requiredTools":["sca","sast","secrets"],
+			"severity":"high",
+			"cvssThreshold":7.5
+		}}}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConfigPull_WritesOrgDefaults(t *testing.T) {
+	server := orgConfigServer(t)
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+
+	_, err := executeCommand(t, rootCmd, "config", "pull", "--file", path, "--overwrite=false", "--base-url", server.URL)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, yaml.Unmarshal(raw, &got))
+	assert.Equal(t, map[string]any{
+		"production_branch": "main",
+		"required_tools":    []any{"sca", "sast", "secrets"},
+		"severity":          "high",
+		"cvss_threshold":    7.5,
+	}, got)
+}
+
+func TestConfigPull_KeepsLocalOverrides(t *testing.T) {
+	server := orgConfigServer(t)
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("# payments team\nseverity: critical # stricter than org\nowner: payments\n"), 0644))
+
+	_, err := executeCommand(t, rootCmd, "config", "pull", "--file", path, "--overwrite=false", "--base-url", server.URL)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "# payments team")
+	assert.Contains(t, string(raw), "severity: critical # stricter than org")
+	var got map[string]any
+	require.NoError(t, yaml.Unmarshal(raw, &got))
+	assert.Equal(t, "payments", got["owner"])
+	assert.Equal(t, "main", got["production_branch"])
+
+	_, err = executeCommand(t, rootCmd, "config", "pull", "--file", path, "--overwrite", "--base-url", server.URL)
+	require.NoError(t, err)
+	raw, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(raw, &got))
+	assert.Equal(t, "high", got["severity"])
+	assert.Equal(t, "payments", got["owner"])
+}
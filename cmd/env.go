@@ -47,6 +47,7 @@ Examples:
 func init() {
 	rootCmd.AddCommand(envCmd)
 	envCmd.Flags().StringVarP(&envOutput, "output", "o", "", "Output format (json)")
+	declareOutputFormats(envCmd, "json")
 }
 
 type envCLIInfo struct {
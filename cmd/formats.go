@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+var formatsJSON bool
+
+var formatsCmd = &cobra.Command{
+	Use:   "formats",
+	Short: "List the supported artifact formats and how they are detected",
+	Long: `List the artifact formats that upload, gha and validate understand, with
+the media type each is uploaded as and the hints used to detect it.
+
+A format is detected from the file name first. When the name gives nothing
+away, the first 2 KiB of a .json file are checked for the content hints. The
+names printed here are the values --format accepts.
+
+Examples:
+  vulnetix formats
+  vulnetix formats --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if formatsJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(upload.Formats)
+		}
+
+		t := display.FromCommand(cmd).Term
+		var b strings.Builder
+		for i, f := range upload.Formats {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(display.Bold(t, f.Name) + " — " + f.Description + "\n")
+			mediaType := f.MediaType
+			if mediaType == "" {
+				mediaType = "- (by file extension)"
+			}
+			b.WriteString(display.KeyValue(t, []display.KVPair{
+				{Key: "Media type", Value: mediaType},
+				{Key: "File names", Value: strings.Join(f.FileNames, ", ")},
+				{Key: "Content", Value: strings.Join(f.Content, "; ")},
+			}))
+			b.WriteString("\n")
+		}
+		fmt.Print(b.String())
+		return nil
+	},
+}
+
+func init() {
+	formatsCmd.Flags().BoolVar(&formatsJSON, "json", false, "Output the format list as JSON")
+	rootCmd.AddCommand(formatsCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func TestFormatsListsEveryFormat(t *testing.T) {
+	t.Cleanup(func() { formatsJSON = false })
+
+	out, err := executeCommand(t, rootCmd, "formats", "--no-analytics")
+	require.NoError(t, err)
+	for _, name := range []string{upload.FormatCycloneDX, upload.FormatSPDX, upload.FormatSARIF, upload.FormatOpenVEX, upload.FormatCSAFVEX} {
+		assert.Contains(t, out, name+" — ")
+	}
+	assert.Contains(t, out, "application/csaf+json")
+	assert.Contains(t, out, "- (by file extension)")
+
+	out, err = executeCommand(t, rootCmd, "formats", "--json", "--no-analytics")
+	require.NoError(t, err)
+	var got []upload.FormatInfo
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "[\n"):]), &got))
+	assert.Equal(t, upload.Formats, got)
+}
@@ -41,6 +41,12 @@ var (
 	triageEcosystem        string
 	triageVEXStatus        string
 	triageVEXJustification string
+
+	// Plan mode shows the recommended decisions without recording them;
+	// apply mode also records them in Vulnetix.
+	triagePlan  bool
+	triageJSON  bool
+	triageApply bool
 )
 
 // vulnetixToolKinds is the canonical set of vulnetix sub-tools that can be
@@ -89,6 +95,13 @@ Examples:
 
   # Triage all open findings from memory (default)
   vulnetix triage
+
+  # Review the recommended decisions and their reasons without recording them
+  vulnetix triage --plan
+  vulnetix triage --plan --json
+
+  # Record the decisions in Vulnetix as well as in memory
+  vulnetix triage --apply
 `,
 	Args: cobra.ArbitraryArgs,
 }
@@ -117,6 +130,9 @@ func init() {
 	triageCmd.Flags().StringVar(&triageEcosystem, "ecosystem", "", "Package ecosystem for vulnetix triage")
 	triageCmd.Flags().StringVar(&triageVEXStatus, "vex-status", "", "VEX status for non-interactive mode: not_affected, affected, fixed, under_investigation")
 	triageCmd.Flags().StringVar(&triageVEXJustification, "vex-justification", "", "VEX justification (for not_affected): component_not_present, vulnerable_code_not_present, vulnerable_code_not_in_execute_path, vulnerable_code_cannot_be_controlled_by_adversary, inline_mitigations_already_exist")
+	triageCmd.Flags().BoolVar(&triagePlan, "plan", false, "Show the recommended decisions and the signals behind them without updating memory or writing VEX (vulnetix provider)")
+	triageCmd.Flags().BoolVar(&triageJSON, "json", false, "Output the --plan as JSON")
+	triageCmd.Flags().BoolVar(&triageApply, "apply", false, "Record the SSVC and VEX decision for each finding in Vulnetix (vulnetix provider; nothing is sent without it)")
 
 	// Set the main run function
 	triageCmd.RunE = runTriageCmd
@@ -166,6 +182,9 @@ func parseToolList(raw string, valid []string, provider string) ([]string, error
 func runTriageCmd(cmd *cobra.Command, args []string) error {
 	switch triageProvider {
 	case "github":
+		if triagePlan || triageApply {
+			return fmt.Errorf("--plan and --apply are only supported with --provider vulnetix")
+		}
 		return runGitHubTriage(cmd, args)
 	case "vulnetix":
 		return runVulnetixTriage(cmd, args)
@@ -344,6 +363,10 @@ func runVulnetixTriage(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if triagePlan && triageApply {
+		return fmt.Errorf("--plan and --apply cannot be combined; review with --plan, then re-run with --apply")
+	}
+
 	// Validate VEX format
 	switch triageVEXFormat {
 	case "openvex", "cdx", "cyclonedx", "json":
@@ -487,12 +510,16 @@ func runVulnetixTriage(cmd *cobra.Command, args []string) error {
 
 		findings = append(findings, finding)
 
-		// Update memory (unless disabled)
-		if !triageDisableMemory {
+		// Update memory (unless disabled or only planning)
+		if !triageDisableMemory && !triagePlan {
 			updateMemoryFromFinding(mem, vulnID, finding)
 		}
 	}
 
+	if triagePlan {
+		return printTriagePlan(cmd, triage.BuildPlan(findings), triageJSON || triageFormat == "json")
+	}
+
 	// Save memory
 	if !triageDisableMemory {
 		if err := memory.Save(memDir, mem); err != nil {
@@ -502,8 +529,14 @@ func runVulnetixTriage(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Record the decisions server-side only when explicitly asked to.
+	var applyErr error
+	if triageApply {
+		applyErr = applyTriageDecisions(cmd, newCliClient(), triage.BuildPlan(findings))
+	}
+
 	// If running interactively and no explicit --vex-status was given, launch the TUI.
-	if isInteractive && triageVEXStatus == "" && triageFormat != "json" && triageFormat != "text" {
+	if isInteractive && !triageApply && triageVEXStatus == "" && triageFormat != "json" && triageFormat != "text" {
 		enriched := findingsToEnrichedAlerts(findings)
 		sortAlertsBySeverity(enriched)
 		return tui.RunTriage(enriched, tui.TriageOptions{
@@ -574,7 +607,7 @@ func runVulnetixTriage(cmd *cobra.Command, args []string) error {
 		fmt.Fprint(out, string(outputBytes))
 	}
 
-	return nil
+	return applyErr
 }
 
 // updateMemoryFromFinding persists a TriageFinding into the memory store.
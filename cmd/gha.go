@@ -1,27 +1,54 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/vulnetix/cli/v3/internal/display"
 	"github.com/vulnetix/cli/v3/internal/github"
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/internal/upload"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 )
 
 var (
 	// GHA command flags
-	ghaBaseURL    string
-	ghaTxnID      string
-	ghaUUID       string
-	ghaOutputJSON bool
+	ghaBaseURL        string
+	ghaTxnID          string
+	ghaUUID           string
+	ghaOutputJSON     bool
+	ghaMaxTotal       int64
+	ghaTokenFile      string
+	ghaBranches       []string
+	ghaResume         bool
+	ghaSelect         []string
+	ghaResultFile     string
+	ghaNamesFile      string
+	ghaOutput         string
+	ghaRequireAll     bool
+	ghaWatch          bool
+	ghaInterval       time.Duration
+	ghaCallback       string
+	ghaFollowSymlinks bool
 )
 
+// ghaStatusSelectors are the artifact states accepted by gha status --select.
+var ghaStatusSelectors = []string{"failed", "pending", "completed"}
+
+// ghaStatusOutputs are the formats accepted by gha status --output.
+var ghaStatusOutputs = []string{"pretty", "json", "ndjson"}
+
+// defaultGHAMaxTotalSize caps the combined declared size of all artifacts in
+// one run (10 GiB), ten times the per-artifact download limit.
+const defaultGHAMaxTotalSize int64 = 10 * 1024 * 1024 * 1024
+
 // ghaCmd represents the gha command for GitHub Actions artifact management
 var ghaCmd = &cobra.Command{
 	Use:   "gha",
@@ -46,8 +73,45 @@ This command:
 
 Example:
   vulnetix gha upload --org-id <uuid>
-  vulnetix gha upload --org-id <uuid> --base-url https://api.vdb.vulnetix.com/v1`,
-	RunE: runGHAUpload,
+  vulnetix gha upload --org-id <uuid> --base-url https://api.vdb.vulnetix.com/v1
+  vulnetix gha upload --org-id <uuid> --only-branches main,release/*
+  vulnetix gha upload --org-id <uuid> --txnid <transaction-id>
+  printf 'sbom\nsarif\n' | vulnetix gha upload --org-id <uuid> --artifact-names-file -
+  vulnetix gha upload --org-id <uuid> --require-all
+  vulnetix gha upload --oidc
+
+With --txnid, artifacts are appended to an existing, still open transaction
+(for example one started by an earlier job) instead of being uploaded as
+separate pipelines.
+
+With --artifact-names-file, only the artifacts named in the file (one per
+line, "-" for stdin) are uploaded; the rest of the run's artifacts are skipped.
+
+With --oidc, the job's GitHub Actions OIDC token is exchanged for short-lived
+Vulnetix credentials, so the workflow needs no stored API key. The job must be
+granted "permissions: id-token: write". The exchange names the org the
+repository is trusted by, so --org-id can be left out.
+
+With --callback-url, the server calls the given HTTPS URL once processing of
+the uploaded artifacts completes, so there is no need to poll gha status.
+
+Artifacts that fail to download or upload are reported but do not fail the
+command unless --require-all is set.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, "gha"); err != nil {
+			return err
+		}
+		if ghaCallback != "" {
+			if err := upload.ValidateCallbackURL(ghaCallback); err != nil {
+				return fmt.Errorf("--callback-url: %w", err)
+			}
+		}
+		if auth.OIDCRequested() {
+			auth.OIDCBaseURL = ghaBaseURL
+		}
+		return validateBranchPatterns(ghaBranches)
+	},
+	RunE: withResultFile(&ghaResultFile, runGHAUpload),
 }
 
 // ghaStatusCmd handles checking status of uploads
@@ -63,14 +127,29 @@ You can check status using either:
 Examples:
   vulnetix gha status --org-id <uuid> --txnid <transaction-id>
   vulnetix gha status --org-id <uuid> --uuid <artifact-uuid>
-  vulnetix gha status --org-id <uuid> --txnid <txn-id> --json`,
-	RunE: runGHAStatus,
+  vulnetix gha status --org-id <uuid> --txnid <txn-id> --json
+  vulnetix gha status --org-id <uuid> --txnid <txn-id> --resume
+  vulnetix gha status --org-id <uuid> --txnid <txn-id> --select failed,pending
+  vulnetix gha status --org-id <uuid> --txnid <txn-id> --watch --output ndjson
+
+With --watch, the status is polled every --interval until no artifact is
+pending and the final status is reported. --output ndjson instead writes one
+JSON snapshot per poll, each on its own line with a timestamp, for log
+aggregators.
+
+Each transaction check records the artifact statuses locally. With --resume, only
+artifacts whose status changed since the previous check are reported, so a gate
+re-run after an interruption picks up where it left off.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyConfigDefaults(cmd, "gha")
+	},
+	RunE: withResultFile(&ghaResultFile, runGHAStatus),
 }
 
 func resolveOrgID() (string, error) {
 	if orgID != "" {
-		if _, err := uuid.Parse(orgID); err != nil {
-			return "", fmt.Errorf("--org-id must be a valid UUID, got: %s", orgID)
+		if err := validateOrgID(orgID); err != nil {
+			return "", err
 		}
 		return orgID, nil
 	}
@@ -86,40 +165,36 @@ func resolveOrgID() (string, error) {
 	return creds.OrgID, nil
 }
 
-func runGHAUpload(cmd *cobra.Command, args []string) error {
+func runGHAUpload(cmd *cobra.Command, args []string, rec *resultRecorder) error {
 	dctx := display.FromCommand(cmd)
 	t := dctx.Term
 
+	if skipForBranch(dctx, ghaBranches) {
+		return nil
+	}
+
 	resolvedOrgID, err := resolveOrgID()
 	if err != nil {
 		return err
 	}
 	orgID = resolvedOrgID
 
+	var names []string
+	if ghaNamesFile != "" {
+		if names, err = readArtifactNames(ghaNamesFile, cmd.InOrStdin()); err != nil {
+			return err
+		}
+	}
+
 	// Check if we're in a GitHub Actions environment
 	if os.Getenv("GITHUB_ACTIONS") != "true" {
 		dctx.Logger.Warn("Not running in GitHub Actions environment")
 	}
 
-	// Get GitHub context
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return fmt.Errorf("GITHUB_TOKEN environment variable is required")
-	}
-
-	apiURL := os.Getenv("GITHUB_API_URL")
-	if apiURL == "" {
-		apiURL = "https://api.github.com"
-	}
-
-	repository := os.Getenv("GITHUB_REPOSITORY")
-	if repository == "" {
-		return fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
-	}
-
-	runID := os.Getenv("GITHUB_RUN_ID")
-	if runID == "" {
-		return fmt.Errorf("GITHUB_RUN_ID environment variable is required")
+	// Create artifact collector
+	collector, repository, runID, err := newGHACollector(ghaTokenFile)
+	if err != nil {
+		return err
 	}
 
 	dctx.Logger.Info(display.Bold(t, "Starting GitHub Actions artifact upload"))
@@ -130,16 +205,12 @@ func runGHAUpload(cmd *cobra.Command, args []string) error {
 	}))
 	dctx.Logger.Info("")
 
-	// Create artifact collector
-	collector := github.NewArtifactCollector(token, apiURL, repository, runID)
-
 	// List all artifacts
 	progress := dctx.Progress("GitHub Actions artifact upload", 4)
-	progress.SetStage("Fetching workflow artifacts")
+	pipeline := &github.Pipeline{Collector: collector, Progress: ghaProgressReporter(progress)}
 	ctx := cmd.Context()
-	artifacts, err := collector.ListArtifacts(ctx)
+	artifacts, err := pipeline.ListArtifacts(ctx)
 	if err != nil {
-		progress.Fail("failed to fetch workflow artifacts")
 		return fmt.Errorf("failed to list artifacts: %w", err)
 	}
 
@@ -149,13 +220,41 @@ func runGHAUpload(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	progress.Update(1, fmt.Sprintf("Found %d artifact(s)", len(artifacts)))
+	if ghaNamesFile != "" {
+		listed := len(artifacts)
+		var missing []string
+		artifacts, missing = selectArtifactsByName(artifacts, names)
+		for _, name := range missing {
+			dctx.Logger.Warn(fmt.Sprintf("Artifact %q is not in this workflow run", name))
+		}
+		source := ghaNamesFile
+		if source == "-" {
+			source = "stdin"
+		}
+		dctx.Logger.Infof("Selected %d of %d artifact(s) from %s", len(artifacts), listed, source)
+		if len(artifacts) == 0 {
+			progress.Complete("no matching artifacts")
+			dctx.Logger.Warn("None of the named artifacts are in this workflow run")
+			return nil
+		}
+	}
+
 	dctx.Logger.Infof("Found %d artifact(s)", len(artifacts))
 	for i, artifact := range artifacts {
 		dctx.Logger.Infof("   %d. %s (%d bytes)", i+1, artifact.Name, artifact.SizeInBytes)
 	}
 	dctx.Logger.Info("")
 
+	// Enforce the aggregate budget before downloading anything.
+	if err := checkArtifactBudget(artifacts, ghaMaxTotal); err != nil {
+		progress.Fail("artifact size budget exceeded")
+		return err
+	}
+
+	if ghaTxnID != "" {
+		return appendGHAArtifacts(ctx, progress, collector, artifacts, rec)
+	}
+
 	// Load credentials for upload client
 	creds, err := auth.LoadCredentials()
 	if err != nil {
@@ -171,128 +270,295 @@ func runGHAUpload(cmd *cobra.Command, args []string) error {
 
 	// Collect GitHub Actions environment metadata and attach to upload client
 	uploadClient.GitHubContext = collectGitHubActionsContext()
+	uploadClient.CallbackURL = ghaCallback
 
 	// Download and upload each artifact
 	progress.Update(2, "Prepared upload client")
-	type uploadResult struct {
+	pipeline.Uploader = uploadClient
+	summary := pipeline.UploadArtifacts(ctx, artifacts)
+	results := summary.Files
+	dedupCount, dedupBytes := summary.DeduplicatedFiles, summary.DeduplicatedBytes
+
+	successCount := 0
+	for _, r := range results {
+		if r.Status != "error" {
+			successCount++
+		}
+		rec.add(resultItem{Name: r.Name, File: r.File, UUID: r.PipelineID, Status: r.Status, Error: r.Error, DurationMs: r.DurationMs})
+	}
+	progress.Update(3, fmt.Sprintf("Uploaded %d/%d file(s)", successCount, len(results)))
+	progress.Complete("GitHub Actions upload complete")
+	if dedupCount > 0 {
+		dctx.Logger.Infof("Skipped %d duplicate file(s), saving %d bytes of upload", dedupCount, dedupBytes)
+	}
+
+	// Output JSON if requested
+	if ghaOutputJSON {
+		output := map[string]interface{}{
+			"artifacts": results,
+			"total":     len(results),
+			"success":   successCount,
+			"deduplicated": map[string]interface{}{
+				"files":      dedupCount,
+				"bytesSaved": dedupBytes,
+			},
+			"traffic": trafficJSON(),
+		}
+		jsonData, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(jsonData))
+	}
+
+	return requireAllUploaded(len(results)-successCount, len(results))
+}
+
+// trafficJSON reports the invocation's API traffic in --json output.
+func trafficJSON() map[string]int64 {
+	sent, received := httpx.Traffic()
+	return map[string]int64{"bytesSent": sent, "bytesReceived": received}
+}
+
+// requireAllUploaded fails the command under --require-all when any of total
+// artifact uploads failed; by default partial uploads still succeed.
+func requireAllUploaded(failed, total int) error {
+	if ghaRequireAll && failed > 0 {
+		return fmt.Errorf("%d of %d upload(s) failed and --require-all is set", failed, total)
+	}
+	return nil
+}
+
+// ghaProgressReporter renders pipeline events on the progress display.
+func ghaProgressReporter(progress *display.Progress) github.ProgressFunc {
+	return func(e github.ProgressEvent) {
+		switch {
+		case e.Stage == github.StageList && e.Kind == github.EventStart:
+			progress.SetStage("Fetching workflow artifacts")
+		case e.Stage == github.StageList && e.Kind == github.EventFailed:
+			progress.Fail("failed to fetch workflow artifacts")
+		case e.Stage == github.StageList && e.Kind == github.EventDone:
+			progress.Update(1, fmt.Sprintf("Found %d artifact(s)", e.Total))
+		case e.Stage == github.StageDownload && e.Kind == github.EventStart:
+			progress.Update(2, fmt.Sprintf("Processing artifact %d/%d: %s", e.Index, e.Total, e.Artifact))
+		case e.Stage == github.StageDownload && e.Kind == github.EventFailed:
+			progress.SetStage(fmt.Sprintf("Failed to download %s: %v", e.Artifact, e.Err))
+		case e.Kind == github.EventStart && e.Stage == github.StageUpload:
+			progress.SetStage(fmt.Sprintf("Uploading %s: %s", e.Artifact, e.File))
+		case e.Kind == github.EventStart, e.Kind == github.EventProgress:
+			progress.SetStage(fmt.Sprintf("%s/%s: %s %d/%d", e.Artifact, e.File, e.Message, e.Step, e.Steps))
+		case e.Kind == github.EventFailed:
+			progress.SetStage(fmt.Sprintf("Failed to upload %s: %v", e.File, e.Err))
+		}
+	}
+}
+
+// appendGHAArtifacts uploads each artifact into the existing transaction
+// ghaTxnID after confirming it is still open, skipping initiation.
+func appendGHAArtifacts(ctx context.Context, progress *display.Progress, collector *github.ArtifactCollector, artifacts []github.Artifact, rec *resultRecorder) error {
+	type appendResult struct {
 		Name       string `json:"name"`
-		File       string `json:"file"`
-		PipelineID string `json:"pipelineId,omitempty"`
+		UUID       string `json:"uuid,omitempty"`
+		QueuePath  string `json:"queuePath,omitempty"`
 		Status     string `json:"status"`
 		Error      string `json:"error,omitempty"`
+		DurationMs int64  `json:"durationMs,omitempty"`
 	}
-	var results []uploadResult
+	results := make([]appendResult, 0, len(artifacts))
+	rec.transaction(ghaTxnID, "")
+	defer func() {
+		for _, r := range results {
+			rec.add(resultItem{Name: r.Name, UUID: r.UUID, Status: r.Status, Error: r.Error, DurationMs: r.DurationMs})
+		}
+	}()
 
-	for i, artifact := range artifacts {
-		progress.Update(2, fmt.Sprintf("Processing artifact %d/%d: %s", i+1, len(artifacts), artifact.Name))
+	// Refuse a closed transaction before spending time on downloads.
+	uploader := github.NewArtifactUploader(ghaBaseURL, orgID)
+	uploader.CallbackURL = ghaCallback
+	uploader.FollowSymlinks = ghaFollowSymlinks
+	txnStatus, err := uploader.EnsureTransactionOpen(ghaTxnID)
+	if err != nil {
+		progress.Fail("cannot append to transaction")
+		return fmt.Errorf("failed to append to transaction: %w", err)
+	}
 
-		// Download and extract artifact from GitHub
+	// Artifacts are downloaded up front so the transaction can be sent a
+	// manifest (format, size and SHA-256 of every file) before the uploads.
+	progress.Update(2, fmt.Sprintf("Downloading %d artifact(s)", len(artifacts)))
+	var names []string
+	var manifest []github.ArtifactManifestEntry
+	dirs := make(map[string]string, len(artifacts))
+	defer func() {
+		for _, dir := range dirs {
+			os.RemoveAll(dir)
+		}
+	}()
+	for _, artifact := range artifacts {
 		artifactDir, err := collector.DownloadArtifact(ctx, artifact)
 		if err != nil {
-			progress.SetStage(fmt.Sprintf("Failed to download %s: %v", artifact.Name, err))
-			results = append(results, uploadResult{
-				Name:   artifact.Name,
-				Status: "error",
-				Error:  err.Error(),
-			})
+			results = append(results, appendResult{Name: artifact.Name, Status: "error", Error: err.Error()})
 			continue
 		}
-
-		// Find all files in the extracted artifact directory
-		files, err := findFiles(artifactDir)
+		dirs[artifact.Name] = artifactDir
+		entries, err := github.BuildArtifactManifest(artifact.Name, artifactDir, collector.FollowSymlinks)
 		if err != nil {
-			os.RemoveAll(artifactDir)
-			progress.SetStage(fmt.Sprintf("Failed to read %s: %v", artifact.Name, err))
-			results = append(results, uploadResult{
-				Name:   artifact.Name,
-				Status: "error",
-				Error:  err.Error(),
-			})
+			results = append(results, appendResult{Name: artifact.Name, Status: "error", Error: err.Error()})
 			continue
 		}
+		names = append(names, artifact.Name)
+		manifest = append(manifest, entries...)
+	}
 
-		// Upload each file using the standard upload API
-		for j, filePath := range files {
-			fileName := filepath.Base(filePath)
-			progress.SetStage(fmt.Sprintf("Uploading %s file %d/%d: %s", artifact.Name, j+1, len(files), fileName))
-
-			resp, err := uploadClient.UploadFileWithProgress(filePath, "", func(done, total int, stage string) {
-				progress.SetStage(fmt.Sprintf("%s/%s: %s %d/%d", artifact.Name, fileName, stage, done, total))
-			})
-			if err != nil {
-				progress.SetStage(fmt.Sprintf("Failed to upload %s: %v", fileName, err))
-				results = append(results, uploadResult{
-					Name:   artifact.Name,
-					File:   fileName,
-					Status: "error",
-					Error:  err.Error(),
-				})
-				continue
-			}
-
-			pipelineID := ""
-			if resp.PipelineRecord != nil {
-				pipelineID = resp.PipelineRecord.UUID
-			}
-
-			status := "uploaded"
-			if resp.IsDuplicate {
-				status = "duplicate"
-			}
-
-			results = append(results, uploadResult{
-				Name:       artifact.Name,
-				File:       fileName,
-				PipelineID: pipelineID,
-				Status:     status,
-			})
-		}
-
-		os.RemoveAll(artifactDir)
+	progress.SetStage(fmt.Sprintf("Appending to transaction %s", ghaTxnID))
+	if _, err := uploader.AppendToTransaction(ghaTxnID, txnStatus, github.CollectMetadata(names), names, manifest); err != nil {
+		progress.Fail("cannot append to transaction")
+		return fmt.Errorf("failed to append to transaction: %w", err)
 	}
 
 	successCount := 0
-	for _, r := range results {
-		if r.Status != "error" {
-			successCount++
+	for i, name := range names {
+		progress.SetStage(fmt.Sprintf("Uploading artifact %d/%d: %s", i+1, len(names), name))
+		started := time.Now()
+		resp, err := uploader.UploadArtifact(ghaTxnID, name, dirs[name])
+		if err != nil {
+			results = append(results, appendResult{Name: name, Status: "error", Error: err.Error(), DurationMs: time.Since(started).Milliseconds()})
+			continue
 		}
+		successCount++
+		results = append(results, appendResult{Name: name, UUID: resp.UUID, QueuePath: resp.QueuePath, Status: "uploaded", DurationMs: time.Since(started).Milliseconds()})
 	}
-	progress.Update(3, fmt.Sprintf("Uploaded %d/%d file(s)", successCount, len(results)))
+	progress.Update(3, fmt.Sprintf("Uploaded %d/%d artifact(s)", successCount, len(results)))
 	progress.Complete("GitHub Actions upload complete")
 
-	// Output JSON if requested
 	if ghaOutputJSON {
-		output := map[string]interface{}{
+		jsonData, err := json.MarshalIndent(map[string]interface{}{
+			"txnid":     ghaTxnID,
 			"artifacts": results,
 			"total":     len(results),
 			"success":   successCount,
-		}
-		jsonData, err := json.MarshalIndent(output, "", "  ")
+		}, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal JSON output: %w", err)
 		}
 		fmt.Println(string(jsonData))
 	}
+	return requireAllUploaded(len(results)-successCount, len(results))
+}
 
-	return nil
+// newGHACollector builds an artifact collector for the current workflow run
+// from the GitHub Actions environment, returning the repository and run ID
+// it targets.
+func newGHACollector(tokenFile string) (*github.ArtifactCollector, string, string, error) {
+	token, err := resolveGitHubToken(tokenFile)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.github.com"
+	}
+
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil, "", "", fmt.Errorf("GITHUB_REPOSITORY environment variable is required")
+	}
+
+	runID := os.Getenv("GITHUB_RUN_ID")
+	if runID == "" {
+		return nil, "", "", fmt.Errorf("GITHUB_RUN_ID environment variable is required")
+	}
+
+	collector := github.NewArtifactCollector(token, apiURL, repository, runID)
+	collector.FollowSymlinks = ghaFollowSymlinks
+	return collector, repository, runID, nil
 }
 
-// findFiles recursively finds all files in a directory
-func findFiles(dir string) ([]string, error) {
-	var files []string
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+// resolveGitHubToken returns the token from tokenFile when set, otherwise from
+// GITHUB_TOKEN. The file form keeps the token out of the process environment.
+func resolveGitHubToken(tokenFile string) (string, error) {
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
 		if err != nil {
-			return err
+			return "", fmt.Errorf("failed to read --github-token-file: %w", err)
 		}
-		if !info.IsDir() {
-			files = append(files, path)
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("--github-token-file %s is empty", tokenFile)
 		}
+		return token, nil
+	}
+
+	token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+	if token == "" {
+		return "", fmt.Errorf("GITHUB_TOKEN environment variable is required (or pass --github-token-file)")
+	}
+	return token, nil
+}
+
+// readArtifactNames reads artifact names, one per line, from path or from
+// stdin when path is "-". Blank lines and lines starting with # are ignored.
+func readArtifactNames(path string, stdin io.Reader) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read --artifact-names-file: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !slices.Contains(names, line) {
+			names = append(names, line)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--artifact-names-file %s lists no artifact names", path)
+	}
+	return names, nil
+}
+
+// selectArtifactsByName keeps the listed artifacts whose name is in names,
+// in listing order, and returns the names that matched nothing.
+func selectArtifactsByName(artifacts []github.Artifact, names []string) ([]github.Artifact, []string) {
+	var selected []github.Artifact
+	found := map[string]bool{}
+	for _, a := range artifacts {
+		if slices.Contains(names, a.Name) {
+			selected = append(selected, a)
+			found[a.Name] = true
+		}
+	}
+	var missing []string
+	for _, name := range names {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	return selected, missing
+}
+
+// checkArtifactBudget rejects a run whose artifacts' declared sizes sum past
+// maxTotal bytes. A maxTotal of 0 or less disables the check.
+func checkArtifactBudget(artifacts []github.Artifact, maxTotal int64) error {
+	if maxTotal <= 0 {
 		return nil
-	})
-	return files, err
+	}
+	var total int64
+	for _, a := range artifacts {
+		total += a.SizeInBytes
+	}
+	if total > maxTotal {
+		return fmt.Errorf("artifacts total %d bytes across %d artifact(s), exceeding --max-total-size of %d bytes; raise the limit or reduce the workflow's artifacts", total, len(artifacts), maxTotal)
+	}
+	return nil
 }
 
-func runGHAStatus(cmd *cobra.Command, args []string) error {
+func runGHAStatus(cmd *cobra.Command, args []string, rec *resultRecorder) error {
 	dctx := display.FromCommand(cmd)
 	resolvedOrgID, err := resolveOrgID()
 	if err != nil {
@@ -309,73 +575,202 @@ func runGHAStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("only one of --txnid or --uuid can be specified")
 	}
 
+	if ghaResume && ghaTxnID == "" {
+		return fmt.Errorf("--resume requires --txnid")
+	}
+	for _, sel := range ghaSelect {
+		if !slices.Contains(ghaStatusSelectors, sel) {
+			return fmt.Errorf("invalid --select %q: must be one of %s", sel, strings.Join(ghaStatusSelectors, ", "))
+		}
+	}
+	if !slices.Contains(ghaStatusOutputs, ghaOutput) {
+		return fmt.Errorf("invalid --output %q: must be one of %s", ghaOutput, strings.Join(ghaStatusOutputs, ", "))
+	}
+	output := ghaOutput
+	if ghaOutputJSON {
+		if ghaOutput == "ndjson" {
+			return fmt.Errorf("--json cannot be combined with --output %s", ghaOutput)
+		}
+		output = "json"
+	}
+	if ghaWatch && ghaInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
 	// Create uploader for status checks
 	uploader := github.NewArtifactUploader(ghaBaseURL, orgID)
 
+	var prev map[string]string
+	if ghaTxnID != "" {
+		prev = loadGHAStatusState(ghaTxnID)
+	}
+
 	var statusResp *github.StatusResponse
 	progress := dctx.Progress("GitHub Actions artifact status", 1)
 
-	if ghaTxnID != "" {
-		progress.SetStage(fmt.Sprintf("Checking transaction status: %s", ghaTxnID))
-		statusResp, err = uploader.GetTransactionStatus(ghaTxnID)
-	} else {
-		progress.SetStage(fmt.Sprintf("Checking artifact status: %s", ghaUUID))
-		statusResp, err = uploader.GetArtifactStatus(ghaUUID)
-	}
+	for poll := 1; ; poll++ {
+		if ghaTxnID != "" {
+			progress.SetStage(fmt.Sprintf("Checking transaction status: %s", ghaTxnID))
+			statusResp, err = uploader.GetTransactionStatus(ghaTxnID)
+		} else {
+			progress.SetStage(fmt.Sprintf("Checking artifact status: %s", ghaUUID))
+			statusResp, err = uploader.GetArtifactStatus(ghaUUID)
+		}
+		if err != nil {
+			progress.Fail("status lookup failed")
+			return fmt.Errorf("failed to get status: %w", err)
+		}
+		if ghaTxnID != "" {
+			if err := saveGHAStatusState(ghaTxnID, statusResp.Artifacts); err != nil {
+				dctx.Logger.Warnf("could not record transaction status: %v", err)
+			}
+		}
+		if output == "ndjson" {
+			view, _ := ghaStatusView(statusResp, prev)
+			line, err := json.Marshal(ghaStatusSnapshot{Timestamp: time.Now().UTC(), Poll: poll, StatusResponse: view})
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(line))
+		}
 
-	if err != nil {
-		progress.Fail("status lookup failed")
-		return fmt.Errorf("failed to get status: %w", err)
+		pending := pendingArtifacts(statusResp)
+		if !ghaWatch || pending == 0 {
+			break
+		}
+		progress.SetStage(fmt.Sprintf("Waiting on %d pending artifact(s), next check in %s", pending, ghaInterval))
+		select {
+		case <-cmd.Context().Done():
+			progress.Fail("watch interrupted")
+			return cmd.Context().Err()
+		case <-time.After(ghaInterval):
+		}
 	}
 	progress.Complete("status lookup complete")
+	rec.transaction(statusResp.TxnID, statusResp.Status)
+	for _, a := range statusResp.Artifacts {
+		rec.add(resultItem{Name: a.Name, UUID: a.UUID, Status: a.Status, Error: a.Error})
+	}
+	if output == "ndjson" {
+		return nil
+	}
+	statusResp, unchanged := ghaStatusView(statusResp, prev)
 
 	// Output JSON if requested
-	if ghaOutputJSON {
-		jsonData, err := json.MarshalIndent(statusResp, "", "  ")
-		if err != nil {
-			return fmt.Errorf("failed to marshal JSON: %w", err)
-		}
-		fmt.Println(string(jsonData))
-		return nil
+	if output == "json" {
+		return dctx.Logger.ResultJSON(statusResp)
 	}
 
 	// Pretty print status
-	fmt.Println()
-	fmt.Printf("Status: %s\n", statusResp.Status)
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nStatus: %s\n", statusResp.Status)
 	if statusResp.TxnID != "" {
-		fmt.Printf("   Transaction ID: %s\n", statusResp.TxnID)
+		fmt.Fprintf(&b, "   Transaction ID: %s\n", statusResp.TxnID)
 	}
 	if statusResp.Message != "" {
-		fmt.Printf("   Message: %s\n", statusResp.Message)
+		fmt.Fprintf(&b, "   Message: %s\n", statusResp.Message)
+	}
+
+	if unchanged > 0 {
+		fmt.Fprintf(&b, "   Unchanged since last check: %d artifact(s)\n", unchanged)
+	}
+
+	if len(ghaSelect) > 0 && len(statusResp.Artifacts) == 0 {
+		fmt.Fprintf(&b, "   No %s artifacts\n", strings.Join(ghaSelect, " or "))
 	}
 
 	if len(statusResp.Artifacts) > 0 {
-		fmt.Println()
-		fmt.Printf("Artifacts (%d):\n", len(statusResp.Artifacts))
+		fmt.Fprintf(&b, "\nArtifacts (%d):\n", len(statusResp.Artifacts))
 		for i, artifact := range statusResp.Artifacts {
-			fmt.Printf("   %d. %s\n", i+1, artifact.Name)
-			fmt.Printf("      UUID: %s\n", artifact.UUID)
-			fmt.Printf("      Status: %s\n", artifact.Status)
+			fmt.Fprintf(&b, "   %d. %s\n", i+1, artifact.Name)
+			fmt.Fprintf(&b, "      UUID: %s\n", artifact.UUID)
+			fmt.Fprintf(&b, "      Status: %s\n", artifact.Status)
 			if artifact.QueuePath != "" {
-				fmt.Printf("      Queue Path: %s\n", artifact.QueuePath)
+				fmt.Fprintf(&b, "      Queue Path: %s\n", artifact.QueuePath)
 			}
 			if artifact.Error != "" {
-				fmt.Printf("      Error: %s\n", artifact.Error)
+				fmt.Fprintf(&b, "      Error: %s\n", artifact.Error)
 			}
 		}
 	}
 
 	if len(statusResp.Details) > 0 {
-		fmt.Println()
-		fmt.Println("Details:")
+		b.WriteString("\nDetails:\n")
 		for key, value := range statusResp.Details {
-			fmt.Printf("   %s: %v\n", key, value)
+			fmt.Fprintf(&b, "   %s: %v\n", key, value)
 		}
 	}
+	dctx.Logger.Result(strings.TrimSuffix(b.String(), "\n"))
 
 	return nil
 }
 
+// artifactState buckets a server-reported artifact status into one of
+// ghaStatusSelectors. Anything not yet finished counts as pending.
+func artifactState(status string) string {
+	switch strings.ToLower(status) {
+	case "failed", "error", "rejected", "cancelled":
+		return "failed"
+	case "completed", "complete", "processed", "success", "succeeded":
+		return "completed"
+	default:
+		return "pending"
+	}
+}
+
+// selectArtifacts keeps the artifacts whose state is one of selectors.
+func selectArtifacts(artifacts []github.ArtifactStatusDetail, selectors []string) []github.ArtifactStatusDetail {
+	var selected []github.ArtifactStatusDetail
+	for _, a := range artifacts {
+		if slices.Contains(selectors, artifactState(a.Status)) {
+			selected = append(selected, a)
+		}
+	}
+	return selected
+}
+
+// ghaStatusSnapshot is one line of gha status --output ndjson: the status
+// seen by one poll and when it was taken.
+type ghaStatusSnapshot struct {
+	Timestamp time.Time `json:"timestamp"`
+	Poll      int       `json:"poll"`
+	*github.StatusResponse
+}
+
+// ghaStatusView returns a copy of resp narrowed by --resume, against prev
+// (the statuses recorded before this run), and by --select, along with the
+// number of artifacts --resume left out.
+func ghaStatusView(resp *github.StatusResponse, prev map[string]string) (*github.StatusResponse, int) {
+	view := *resp
+	unchanged := 0
+	if ghaResume {
+		view.Artifacts = changedArtifacts(prev, resp.Artifacts)
+		unchanged = len(resp.Artifacts) - len(view.Artifacts)
+	}
+	if len(ghaSelect) > 0 {
+		view.Artifacts = selectArtifacts(view.Artifacts, ghaSelect)
+	}
+	return &view, unchanged
+}
+
+// pendingArtifacts counts the artifacts in resp that have not finished. A
+// response without artifacts counts as one until its own status finishes.
+func pendingArtifacts(resp *github.StatusResponse) int {
+	if len(resp.Artifacts) == 0 {
+		if artifactState(resp.Status) == "pending" {
+			return 1
+		}
+		return 0
+	}
+	pending := 0
+	for _, a := range resp.Artifacts {
+		if artifactState(a.Status) == "pending" {
+			pending++
+		}
+	}
+	return pending
+}
+
 // collectGitHubActionsContext gathers all available GitHub Actions environment variables
 // into a GitHubActionsContext struct for sending with upload requests.
 func collectGitHubActionsContext() *upload.GitHubActionsContext {
@@ -420,12 +815,30 @@ func init() {
 	// Add upload subcommand
 	ghaUploadCmd.Flags().StringVar(&ghaBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
 	ghaUploadCmd.Flags().BoolVar(&ghaOutputJSON, "json", false, "Output results as JSON")
+	ghaUploadCmd.Flags().StringVar(&ghaTokenFile, "github-token-file", "", "Read the GitHub token from this file instead of GITHUB_TOKEN")
+	ghaUploadCmd.Flags().StringSliceVar(&ghaBranches, "only-branches", nil, "Only upload when the workflow branch matches one of these globs (e.g. main,release/*)")
+	ghaUploadCmd.Flags().StringVar(&ghaTxnID, "txnid", "", "Append artifacts to this existing open transaction instead of uploading them as new pipelines")
+	ghaUploadCmd.Flags().StringVar(&ghaNamesFile, "artifact-names-file", "", "Only upload the artifacts named in this file, one per line (\"-\" reads stdin)")
+	ghaUploadCmd.Flags().BoolVar(&ghaFollowSymlinks, "follow-symlinks", false, "Follow symbolic links inside extracted artifacts (link cycles are skipped)")
+	ghaUploadCmd.Flags().BoolVar(&ghaRequireAll, "require-all", false, "Exit non-zero if any artifact fails to download or upload")
+	ghaUploadCmd.Flags().Int64Var(&ghaMaxTotal, "max-total-size", defaultGHAMaxTotalSize, "Abort if all artifacts together exceed this many bytes (0 disables)")
+	ghaUploadCmd.Flags().BoolVar(&auth.UseOIDC, "oidc", false, "Authenticate with the GitHub Actions OIDC token of this job instead of stored credentials (needs permissions: id-token: write)")
+	ghaUploadCmd.Flags().StringVar(&ghaCallback, "callback-url", "", "HTTPS URL the server notifies when processing of the uploaded artifacts completes")
 
 	// Add status subcommand
 	ghaStatusCmd.Flags().StringVar(&ghaBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
 	ghaStatusCmd.Flags().StringVar(&ghaTxnID, "txnid", "", "Transaction ID to check status")
 	ghaStatusCmd.Flags().StringVar(&ghaUUID, "uuid", "", "Artifact UUID to check status")
 	ghaStatusCmd.Flags().BoolVar(&ghaOutputJSON, "json", false, "Output results as JSON")
+	ghaStatusCmd.Flags().StringSliceVar(&ghaSelect, "select", nil, "Only show artifacts in these states: failed, pending, completed")
+	_ = ghaStatusCmd.RegisterFlagCompletionFunc("select", cobra.FixedCompletions(ghaStatusSelectors, cobra.ShellCompDirectiveNoFileComp))
+	ghaStatusCmd.Flags().BoolVar(&ghaResume, "resume", false, "Only report artifacts whose status changed since the last check of this transaction")
+	ghaStatusCmd.Flags().StringVarP(&ghaOutput, "output", "o", "pretty", "Output format: pretty, json, ndjson (one timestamped JSON snapshot per line for each poll)")
+	declareOutputFormats(ghaStatusCmd, ghaStatusOutputs...)
+	ghaStatusCmd.Flags().BoolVar(&ghaWatch, "watch", false, "Keep polling until no artifact is pending")
+	ghaStatusCmd.Flags().DurationVar(&ghaInterval, "interval", 10*time.Second, "Time between polls with --watch")
+
+	ghaCmd.PersistentFlags().StringVar(&ghaResultFile, "result-file", "", "Write a JSON summary of the operation (artifacts, UUIDs, statuses, timings) to this path")
 
 	// Add subcommands to gha command
 	ghaCmd.AddCommand(ghaUploadCmd, ghaStatusCmd)
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/github"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// ghaRetryCmd re-pushes the artifacts of a transaction that failed processing.
+var ghaRetryCmd = &cobra.Command{
+	Use:   "retry",
+	Short: "Re-upload only the failed artifacts of a transaction",
+	Long: `Re-upload the artifacts of a transaction that failed processing, leaving the
+rest alone.
+
+The transaction status is queried, the artifacts it reports as failed are
+downloaded again from the current workflow run and uploaded into the same
+transaction, and the new status is reported. A transaction that is already
+closed is reported as an error before anything is downloaded. Run it from the
+workflow run that produced the artifacts, for example in a re-run job.
+
+Examples:
+  vulnetix gha retry --org-id <uuid> --txnid <transaction-id>
+  vulnetix gha retry --txnid <transaction-id> --json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyConfigDefaults(cmd, "gha")
+	},
+	RunE: withResultFile(&ghaResultFile, runGHARetry),
+}
+
+// ghaRetryResult is the outcome of re-uploading one failed artifact.
+type ghaRetryResult struct {
+	Name   string `json:"name"`
+	UUID   string `json:"uuid,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func runGHARetry(cmd *cobra.Command, args []string, rec *resultRecorder) error {
+	dctx := display.FromCommand(cmd)
+	t := dctx.Term
+
+	resolvedOrgID, err := resolveOrgID()
+	if err != nil {
+		return err
+	}
+	orgID = resolvedOrgID
+
+	if ghaTxnID == "" {
+		return fmt.Errorf("--txnid is required")
+	}
+
+	uploader := github.NewArtifactUploader(ghaBaseURL, orgID)
+	uploader.FollowSymlinks = ghaFollowSymlinks
+	progress := dctx.Progress("GitHub Actions artifact retry", 3)
+	progress.SetStage(fmt.Sprintf("Checking transaction status: %s", ghaTxnID))
+	before, err := uploader.GetTransactionStatus(ghaTxnID)
+	if err != nil {
+		progress.Fail("status lookup failed")
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	rec.transaction(ghaTxnID, before.Status)
+	failed := failedArtifactNames(before.Artifacts)
+	if len(failed) == 0 {
+		progress.Complete("nothing to retry")
+		if ghaOutputJSON {
+			return printGHARetryJSON(nil, before)
+		}
+		dctx.Logger.Result(display.CheckMark(t) + fmt.Sprintf(" No failed artifacts in transaction %s", ghaTxnID))
+		return nil
+	}
+	// A closed transaction rejects uploads; stop before downloading anything.
+	if err := github.CheckTransactionOpen(ghaTxnID, before); err != nil {
+		progress.Fail("cannot retry")
+		return fmt.Errorf("cannot retry %d failed artifact(s): %w", len(failed), err)
+	}
+	progress.Update(1, fmt.Sprintf("%d failed artifact(s) to retry", len(failed)))
+
+	collector, _, _, err := newGHACollector(ghaTokenFile)
+	if err != nil {
+		progress.Fail("cannot reach workflow artifacts")
+		return err
+	}
+	ctx := cmd.Context()
+	artifacts, err := collector.ListArtifacts(ctx)
+	if err != nil {
+		progress.Fail("failed to fetch workflow artifacts")
+		return fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	byName := make(map[string]github.Artifact, len(artifacts))
+	for _, a := range artifacts {
+		byName[a.Name] = a
+	}
+
+	results := make([]ghaRetryResult, 0, len(failed))
+	retryErrors := 0
+	for i, name := range failed {
+		progress.SetStage(fmt.Sprintf("Retrying artifact %d/%d: %s", i+1, len(failed), name))
+		started := time.Now()
+		resp, err := retryGHAArtifact(cmd, collector, uploader, byName, name)
+		took := time.Since(started).Milliseconds()
+		if err != nil {
+			retryErrors++
+			results = append(results, ghaRetryResult{Name: name, Status: "error", Error: err.Error()})
+			rec.add(resultItem{Name: name, Status: "error", Error: err.Error(), DurationMs: took})
+			continue
+		}
+		results = append(results, ghaRetryResult{Name: name, UUID: resp.UUID, Status: "uploaded"})
+		rec.add(resultItem{Name: name, UUID: resp.UUID, Status: "uploaded", DurationMs: took})
+	}
+	progress.Update(2, fmt.Sprintf("Re-uploaded %d/%d artifact(s)", len(failed)-retryErrors, len(failed)))
+
+	progress.SetStage(fmt.Sprintf("Checking transaction status: %s", ghaTxnID))
+	after, err := uploader.GetTransactionStatus(ghaTxnID)
+	if err != nil {
+		progress.Fail("status lookup failed")
+		return fmt.Errorf("failed to get status after retry: %w", err)
+	}
+	progress.Complete("GitHub Actions retry complete")
+	rec.transaction(ghaTxnID, after.Status)
+
+	if ghaOutputJSON {
+		if err := printGHARetryJSON(results, after); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Error != "" {
+				dctx.Logger.Result(display.CrossMark(t) + " " + r.Name + ": " + r.Error)
+				continue
+			}
+			dctx.Logger.Result(display.CheckMark(t) + " " + r.Name + " re-uploaded (" + r.UUID + ")")
+		}
+		dctx.Logger.Result(fmt.Sprintf("Transaction %s status: %s", ghaTxnID, after.Status))
+	}
+
+	if retryErrors > 0 {
+		return fmt.Errorf("%d of %d failed artifact(s) could not be re-uploaded", retryErrors, len(failed))
+	}
+	return nil
+}
+
+// retryGHAArtifact downloads the named artifact from the workflow run again
+// and uploads it into ghaTxnID.
+func retryGHAArtifact(cmd *cobra.Command, collector *github.ArtifactCollector, uploader *github.ArtifactUploader, byName map[string]github.Artifact, name string) (*github.ArtifactUploadResponse, error) {
+	artifact, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("artifact is no longer available in workflow run")
+	}
+	dir, err := collector.DownloadArtifact(cmd.Context(), artifact)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	return uploader.UploadArtifact(ghaTxnID, name, dir)
+}
+
+// failedArtifactNames returns the distinct names of the artifacts in a failed
+// state, in the order the server listed them.
+func failedArtifactNames(artifacts []github.ArtifactStatusDetail) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, a := range selectArtifacts(artifacts, []string{"failed"}) {
+		if !seen[a.Name] {
+			seen[a.Name] = true
+			names = append(names, a.Name)
+		}
+	}
+	return names
+}
+
+func printGHARetryJSON(results []ghaRetryResult, status *github.StatusResponse) error {
+	if results == nil {
+		results = []ghaRetryResult{}
+	}
+	jsonData, err := json.MarshalIndent(map[string]interface{}{
+		"txnid":   ghaTxnID,
+		"retried": results,
+		"status":  status,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON output: %w", err)
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func init() {
+	ghaRetryCmd.Flags().StringVar(&ghaBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
+	ghaRetryCmd.Flags().StringVar(&ghaTxnID, "txnid", "", "Transaction whose failed artifacts to re-upload")
+	ghaRetryCmd.Flags().BoolVar(&ghaOutputJSON, "json", false, "Output results as JSON")
+	ghaRetryCmd.Flags().StringVar(&ghaTokenFile, "github-token-file", "", "Read the GitHub token from this file instead of GITHUB_TOKEN")
+	ghaRetryCmd.Flags().BoolVar(&ghaFollowSymlinks, "follow-symlinks", false, "Follow symbolic links inside extracted artifacts (link cycles are skipped)")
+	ghaCmd.AddCommand(ghaRetryCmd)
+}
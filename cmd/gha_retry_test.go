@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/github"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func resetGHARetryFlags(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		ghaTxnID, ghaBaseURL, ghaTokenFile = "", upload.DefaultBaseURL, ""
+		ghaOutputJSON, ghaResultFile = false, ""
+		orgID = ""
+	})
+}
+
+func TestGHARetryReuploadsOnlyFailedArtifacts(t *testing.T) {
+	resetGHARetryFlags(t)
+
+	var mu sync.Mutex
+	var downloaded, uploaded []string
+	var gh *httptest.Server
+	gh = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			var list []github.Artifact
+			for i, name := range []string{"sbom", "sarif", "vex"} {
+				list = append(list, github.Artifact{ID: int64(i + 1), Name: name, SizeInBytes: 10, ArchiveDownloadURL: gh.URL + "/download/" + name})
+			}
+			_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: len(list), Artifacts: list})
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/download/")
+		mu.Lock()
+		downloaded = append(downloaded, name)
+		mu.Unlock()
+		_, _ = w.Write(zipArchive(t, name+".json", `{}`))
+	}))
+	defer gh.Close()
+	setGHAEnv(t, gh.URL)
+
+	statusCalls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/status") {
+			statusCalls++
+			resp := github.StatusResponse{Status: "in_progress", TxnID: "txn-9", Artifacts: []github.ArtifactStatusDetail{
+				{UUID: "u-1", Name: "sbom", Status: "completed"},
+				{UUID: "u-2", Name: "sarif", Status: "failed"},
+				{UUID: "u-3", Name: "vex", Status: "error"},
+			}}
+			if statusCalls > 1 {
+				resp.Status = "completed"
+				for i := range resp.Artifacts {
+					resp.Artifacts[i].Status = "completed"
+				}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+			return
+		}
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		mu.Lock()
+		uploaded = append(uploaded, r.FormValue("artifact_name"))
+		mu.Unlock()
+		_, _ = io.WriteString(w, `{"success":true,"uuid":"new-`+r.FormValue("artifact_name")+`"}`)
+	}))
+	defer api.Close()
+
+	resultFile := filepath.Join(t.TempDir(), "retry.json")
+	out, err := executeCommand(t, rootCmd,
+		"gha", "retry",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-9",
+		"--result-file", resultFile,
+		"--json",
+		"--no-progress",
+		"--no-analytics",
+	)
+	require.NoError(t, err)
+
+	slices.Sort(downloaded)
+	slices.Sort(uploaded)
+	assert.Equal(t, []string{"sarif", "vex"}, downloaded, "only failed artifacts are downloaded")
+	assert.Equal(t, []string{"sarif", "vex"}, uploaded, "only failed artifacts are re-uploaded")
+	assert.Equal(t, 2, statusCalls, "status is queried before and after the retry")
+	assert.Contains(t, out, `"uuid": "new-sarif"`)
+	assert.Contains(t, out, `"status": "completed"`)
+
+	res := readResultFile(t, resultFile)
+	assert.Equal(t, "completed", res.Status)
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, "new-sarif", res.Items[0].UUID)
+	assert.Equal(t, "uploaded", res.Items[1].Status)
+}
+
+func TestGHARetryNothingFailed(t *testing.T) {
+	resetGHARetryFlags(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"completed","txnid":"txn-9","artifacts":[{"uuid":"u-1","name":"sbom","status":"completed"}]}`)
+	}))
+	defer api.Close()
+
+	out, err := executeCommand(t, rootCmd,
+		"gha", "retry",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-9",
+		"--no-progress",
+		"--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.Contains(t, out, "No failed artifacts in transaction txn-9")
+}
+
+func TestGHARetryRejectsClosedTransaction(t *testing.T) {
+	resetGHARetryFlags(t)
+	gh, downloads := fakeGitHubArtifacts(t, []github.Artifact{{ID: 1, Name: "sarif", SizeInBytes: 10}})
+	setGHAEnv(t, gh.URL)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/status") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = io.WriteString(w, `{"status":"expired","txnid":"txn-9","artifacts":[{"uuid":"u-2","name":"sarif","status":"failed"}]}`)
+	}))
+	defer api.Close()
+
+	_, err := executeCommand(t, rootCmd,
+		"gha", "retry",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-9",
+		"--no-progress",
+		"--no-analytics",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transaction txn-9 is expired and no longer accepts artifacts")
+	assert.Zero(t, *downloads, "nothing is downloaded for a closed transaction")
+}
+
+func TestFailedArtifactNames(t *testing.T) {
+	got := failedArtifactNames([]github.ArtifactStatusDetail{
+		{Name: "a", Status: "failed"},
+		{Name: "b", Status: "pending"},
+		{Name: "a", Status: "rejected"},
+		{Name: "c", Status: "cancelled"},
+	})
+	assert.Equal(t, []string{"a", "c"}, got)
+}
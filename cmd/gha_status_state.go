@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vulnetix/cli/v3/internal/github"
+)
+
+// ghaStatusStateDir holds the last-seen artifact statuses of each transaction,
+// one file per txn ID, so `gha status --resume` can report only what changed.
+// VULNETIX_GHA_STATE_DIR overrides the OS user-cache location.
+func ghaStatusStateDir() string {
+	if d := strings.TrimSpace(os.Getenv("VULNETIX_GHA_STATE_DIR")); d != "" {
+		return d
+	}
+	base, err := os.UserCacheDir()
+	if err != nil || base == "" {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "vulnetix", "gha-status")
+}
+
+// artifactStatusKey identifies an artifact across status checks.
+func artifactStatusKey(a github.ArtifactStatusDetail) string {
+	if a.UUID != "" {
+		return a.UUID
+	}
+	return a.Name
+}
+
+// loadGHAStatusState returns the statuses recorded for txnID by the previous
+// check, keyed by artifactStatusKey. A missing or unreadable file yields nil,
+// which treats every artifact as new.
+func loadGHAStatusState(txnID string) map[string]string {
+	data, err := os.ReadFile(filepath.Join(ghaStatusStateDir(), txnID+".json"))
+	if err != nil {
+		return nil
+	}
+	var state map[string]string
+	if json.Unmarshal(data, &state) != nil {
+		return nil
+	}
+	return state
+}
+
+// saveGHAStatusState records the current artifact statuses for txnID. The
+// txn ID was validated by the status lookup, so it is safe as a file name.
+func saveGHAStatusState(txnID string, artifacts []github.ArtifactStatusDetail) error {
+	state := make(map[string]string, len(artifacts))
+	for _, a := range artifacts {
+		state[artifactStatusKey(a)] = a.Status
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := ghaStatusStateDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	return os.WriteFile(filepath.Join(dir, txnID+".json"), data, 0600)
+}
+
+// changedArtifacts returns the artifacts that are new or whose status differs
+// from the previous state.
+func changedArtifacts(prev map[string]string, artifacts []github.ArtifactStatusDetail) []github.ArtifactStatusDetail {
+	var changed []github.ArtifactStatusDetail
+	for _, a := range artifacts {
+		if old, ok := prev[artifactStatusKey(a)]; ok && old == a.Status {
+			continue
+		}
+		changed = append(changed, a)
+	}
+	return changed
+}
@@ -0,0 +1,720 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/github"
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// fakeGitHubArtifacts serves a workflow run's artifact list and records any
+// archive download attempts.
+func fakeGitHubArtifacts(t *testing.T, artifacts []github.Artifact) (*httptest.Server, *int) {
+	t.Helper()
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: len(artifacts), Artifacts: artifacts})
+			return
+		}
+		downloads++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return server, &downloads
+}
+
+func setGHAEnv(t *testing.T, apiURL string) {
+	t.Helper()
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GITHUB_API_URL", apiURL)
+	t.Setenv("GITHUB_REPOSITORY", "acme/app")
+	t.Setenv("GITHUB_RUN_ID", "42")
+}
+
+func resetGHAUploadFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = ghaUploadCmd.Flags().Set("max-total-size", strconv.FormatInt(defaultGHAMaxTotalSize, 10))
+		_ = ghaUploadCmd.Flags().Set("github-token-file", "")
+		_ = ghaUploadCmd.Flags().Set("txnid", "")
+		_ = ghaUploadCmd.Flags().Set("artifact-names-file", "")
+		_ = ghaUploadCmd.Flags().Set("require-all", "false")
+		ghaResultFile = ""
+		orgID = ""
+	})
+}
+
+func TestGHACollectorFollowsSymlinksFromFlag(t *testing.T) {
+	setGHAEnv(t, "http://127.0.0.1:0")
+	t.Cleanup(func() { ghaFollowSymlinks = false })
+
+	collector, _, _, err := newGHACollector("")
+	require.NoError(t, err)
+	assert.False(t, collector.FollowSymlinks)
+
+	ghaFollowSymlinks = true
+	collector, _, _, err = newGHACollector("")
+	require.NoError(t, err)
+	assert.True(t, collector.FollowSymlinks)
+}
+
+func TestGHAUploadRejectsArtifactsOverBudget(t *testing.T) {
+	resetGHAUploadFlags(t)
+	server, downloads := fakeGitHubArtifacts(t, []github.Artifact{
+		{ID: 1, Name: "sbom", SizeInBytes: 600, ArchiveDownloadURL: "/download/1"},
+		{ID: 2, Name: "sarif", SizeInBytes: 500, ArchiveDownloadURL: "/download/2"},
+	})
+	setGHAEnv(t, server.URL)
+
+	_, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--max-total-size", "1000",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "artifacts total 1100 bytes across 2 artifact(s)")
+	assert.Contains(t, err.Error(), "--max-total-size of 1000 bytes")
+	assert.Equal(t, 0, *downloads, "no artifact should be downloaded once the budget is exceeded")
+}
+
+func TestGHAUploadReadsTokenFromFile(t *testing.T) {
+	resetGHAUploadFlags(t)
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{})
+	}))
+	defer server.Close()
+	setGHAEnv(t, server.URL)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("  file-token\n"), 0600))
+
+	_, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--github-token-file", tokenFile,
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer file-token", gotAuth, "file token should take precedence over GITHUB_TOKEN")
+}
+
+func TestResolveGitHubToken(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(empty, []byte(" \n"), 0600))
+
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	token, err := resolveGitHubToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, "env-token", token)
+
+	_, err = resolveGitHubToken(empty)
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = resolveGitHubToken(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "failed to read --github-token-file")
+
+	t.Setenv("GITHUB_TOKEN", "")
+	_, err = resolveGitHubToken("")
+	assert.ErrorContains(t, err, "GITHUB_TOKEN environment variable is required")
+}
+
+func TestCheckArtifactBudget(t *testing.T) {
+	artifacts := []github.Artifact{{SizeInBytes: 400}, {SizeInBytes: 600}}
+
+	assert.NoError(t, checkArtifactBudget(artifacts, 1000))
+	assert.NoError(t, checkArtifactBudget(artifacts, 0), "0 disables the budget")
+	assert.NoError(t, checkArtifactBudget(nil, 1))
+	assert.Error(t, checkArtifactBudget(artifacts, 999))
+}
+
+// zipArchive builds an artifact archive holding a single file.
+func zipArchive(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	assert.NoError(t, err)
+	_, _ = io.WriteString(w, content)
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestGHAUploadDeduplicatesIdenticalFiles(t *testing.T) {
+	resetGHAUploadFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { ghaOutputJSON, ghaBaseURL = false, upload.DefaultBaseURL })
+
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`
+	archive := zipArchive(t, "bom.cdx.json", sbom)
+	var gh *httptest.Server
+	gh = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: 2, Artifacts: []github.Artifact{
+				{ID: 1, Name: "sbom-linux", SizeInBytes: int64(len(archive)), ArchiveDownloadURL: gh.URL + "/download/1"},
+				{ID: 2, Name: "sbom-darwin", SizeInBytes: int64(len(archive)), ArchiveDownloadURL: gh.URL + "/download/2"},
+			}})
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer gh.Close()
+	setGHAEnv(t, gh.URL)
+
+	uploads := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer api.Close()
+
+	out, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL+"/v1",
+		"--json",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uploads, "identical content should be uploaded once")
+	assert.Contains(t, out, `"status": "deduplicated"`)
+	assert.Contains(t, out, `"duplicateOf": "sbom-linux/bom.cdx.json"`)
+	assert.Contains(t, out, `"pipelineId": "p-1"`)
+	assert.Contains(t, out, fmt.Sprintf(`"bytesSaved": %d`, len(sbom)))
+
+	// Both archives were downloaded even though one upload was skipped.
+	m := regexp.MustCompile(`"bytesReceived": (\d+)`).FindStringSubmatch(out)
+	require.Len(t, m, 2, "traffic should be reported in the JSON output")
+	received, _ := strconv.Atoi(m[1])
+	assert.GreaterOrEqual(t, received, 2*len(archive))
+	assert.Regexp(t, `"bytesSent": [1-9]`, out)
+}
+
+func TestGHAUploadOnlyNamedArtifacts(t *testing.T) {
+	resetGHAUploadFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		ghaOutputJSON, ghaBaseURL = false, upload.DefaultBaseURL
+		rootCmd.SetIn(nil)
+	})
+
+	var gh *httptest.Server
+	var downloaded []string
+	gh = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			var artifacts []github.Artifact
+			for i, name := range []string{"sbom", "coverage", "sarif", "logs"} {
+				artifacts = append(artifacts, github.Artifact{ID: int64(i + 1), Name: name, SizeInBytes: 100, ArchiveDownloadURL: gh.URL + "/download/" + name})
+			}
+			_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: len(artifacts), Artifacts: artifacts})
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/download/")
+		downloaded = append(downloaded, name)
+		_, _ = w.Write(zipArchive(t, name+".json", `{"artifact":"`+name+`"}`))
+	}))
+	defer gh.Close()
+	setGHAEnv(t, gh.URL)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer api.Close()
+
+	rootCmd.SetIn(strings.NewReader("# artifacts to upload\nsarif\n\nsbom\nmissing\n"))
+	out, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL+"/v1",
+		"--artifact-names-file", "-",
+		"--json",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sbom", "sarif"}, downloaded, "only the named artifacts are processed, in listing order")
+	assert.Contains(t, out, `"total": 2`)
+	assert.Contains(t, out, `Artifact "missing" is not in this workflow run`)
+	assert.Contains(t, out, "Selected 2 of 4 artifact(s) from stdin")
+	assert.NotContains(t, out, `"name": "coverage"`)
+}
+
+func TestGHAUploadRequireAll(t *testing.T) {
+	for _, requireAll := range []bool{false, true} {
+		t.Run(fmt.Sprintf("require-all=%t", requireAll), func(t *testing.T) {
+			resetGHAUploadFlags(t)
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("VULNETIX_API_TOKEN", "test-token")
+			t.Cleanup(func() { ghaOutputJSON, ghaBaseURL = false, upload.DefaultBaseURL })
+
+			var gh *httptest.Server
+			gh = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/artifacts") {
+					_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: 2, Artifacts: []github.Artifact{
+						{ID: 1, Name: "sbom", SizeInBytes: 100, ArchiveDownloadURL: gh.URL + "/download/sbom"},
+						{ID: 2, Name: "sarif", SizeInBytes: 100, ArchiveDownloadURL: gh.URL + "/download/sarif"},
+					}})
+					return
+				}
+				if strings.HasSuffix(r.URL.Path, "/sarif") {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(zipArchive(t, "bom.cdx.json", `{"bomFormat":"CycloneDX"}`))
+			}))
+			defer gh.Close()
+			setGHAEnv(t, gh.URL)
+
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+			}))
+			defer api.Close()
+
+			out, err := executeCommand(t, rootCmd,
+				"gha", "upload",
+				"--org-id", "11111111-2222-3333-4444-555555555555",
+				"--base-url", api.URL+"/v1",
+				"--require-all="+strconv.FormatBool(requireAll),
+				"--json",
+				"--no-progress",
+				"--no-analytics",
+			)
+
+			assert.Contains(t, out, `"success": 1`)
+			assert.Contains(t, out, `"total": 2`)
+			if requireAll {
+				assert.ErrorContains(t, err, "1 of 2 upload(s) failed and --require-all is set")
+			} else {
+				assert.NoError(t, err, "partial uploads succeed without --require-all")
+			}
+		})
+	}
+}
+
+func TestReadArtifactNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("sbom\n  sarif  \nsbom\n"), 0o644))
+	names, err := readArtifactNames(path, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sbom", "sarif"}, names)
+
+	_, err = readArtifactNames("-", strings.NewReader("\n# nothing\n"))
+	assert.ErrorContains(t, err, "lists no artifact names")
+}
+
+func TestGHAUploadRejectsClosedTransaction(t *testing.T) {
+	resetGHAUploadFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { ghaBaseURL = upload.DefaultBaseURL })
+	server, downloads := fakeGitHubArtifacts(t, []github.Artifact{
+		{ID: 1, Name: "sbom", SizeInBytes: 10, ArchiveDownloadURL: "/download/1"},
+	})
+	setGHAEnv(t, server.URL)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"completed","txnid":"txn-42"}`)
+	}))
+	defer api.Close()
+
+	_, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-42",
+		"--no-progress",
+		"--no-analytics",
+	)
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "transaction txn-42 is completed")
+	}
+	assert.Zero(t, *downloads, "no artifacts should be downloaded for a closed transaction")
+}
+
+func TestGHAUploadAppendSendsManifest(t *testing.T) {
+	resetGHAUploadFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { ghaBaseURL = upload.DefaultBaseURL })
+
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`
+	archive := zipArchive(t, "bom.cdx.json", sbom)
+	var gh *httptest.Server
+	gh = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: 1, Artifacts: []github.Artifact{
+				{ID: 1, Name: "sbom", SizeInBytes: int64(len(archive)), ArchiveDownloadURL: gh.URL + "/download/1"},
+			}})
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer gh.Close()
+	setGHAEnv(t, gh.URL)
+
+	var appended github.TransactionRequest
+	statusChecks := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			statusChecks++
+			_, _ = io.WriteString(w, `{"status":"in_progress","txnid":"txn-42"}`)
+		case strings.HasSuffix(r.URL.Path, "/txn-42/artifacts"):
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&appended))
+			_, _ = io.WriteString(w, `{"success":true,"txnid":"txn-42"}`)
+		default:
+			_, _ = io.WriteString(w, `{"success":true,"uuid":"u-1"}`)
+		}
+	}))
+	defer api.Close()
+
+	_, err := executeCommand(t, rootCmd,
+		"gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-42",
+		"--no-progress",
+		"--no-analytics",
+	)
+	assert.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(sbom))
+	assert.Equal(t, []github.ArtifactManifestEntry{{
+		Artifact: "sbom",
+		Path:     "bom.cdx.json",
+		Format:   "cyclonedx",
+		Size:     int64(len(sbom)),
+		SHA256:   hex.EncodeToString(sum[:]),
+	}}, appended.Manifest)
+	assert.Equal(t, 1, statusChecks, "the transaction status is checked once")
+}
+
+func resetGHAStatusFlags(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Setenv("VULNETIX_GHA_STATE_DIR", t.TempDir())
+	t.Cleanup(func() {
+		ghaTxnID, ghaUUID, ghaBaseURL = "", "", upload.DefaultBaseURL
+		ghaOutputJSON, ghaResume = false, false
+		ghaOutput, ghaWatch, ghaInterval = "pretty", false, 10*time.Second
+		ghaResultFile = ""
+		orgID = ""
+	})
+}
+
+func TestGHAStatusResumeReportsOnlyChanges(t *testing.T) {
+	resetGHAStatusFlags(t)
+	statuses := map[string]string{"a-1": "pending", "a-2": "pending", "a-3": "pending"}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := github.StatusResponse{Status: "in_progress", TxnID: "txn-7"}
+		for _, id := range []string{"a-1", "a-2", "a-3"} {
+			resp.Artifacts = append(resp.Artifacts, github.ArtifactStatusDetail{UUID: id, Name: "artifact-" + id, Status: statuses[id]})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer api.Close()
+
+	check := func() github.StatusResponse {
+		t.Helper()
+		out, err := executeCommand(t, rootCmd, "gha", "status",
+			"--org-id", "11111111-2222-3333-4444-555555555555",
+			"--base-url", api.URL,
+			"--txnid", "txn-7",
+			"--resume", "--json", "--no-progress", "--no-analytics",
+		)
+		assert.NoError(t, err)
+		var resp github.StatusResponse
+		assert.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &resp))
+		return resp
+	}
+
+	// The first check has no baseline, so everything is reported.
+	assert.Len(t, check().Artifacts, 3)
+
+	// The gate is interrupted; meanwhile two artifacts progress.
+	statuses["a-1"] = "completed"
+	statuses["a-3"] = "failed"
+	resumed := check()
+	if assert.Len(t, resumed.Artifacts, 2) {
+		assert.Equal(t, "a-1", resumed.Artifacts[0].UUID)
+		assert.Equal(t, "completed", resumed.Artifacts[0].Status)
+		assert.Equal(t, "a-3", resumed.Artifacts[1].UUID)
+		assert.Equal(t, "failed", resumed.Artifacts[1].Status)
+	}
+
+	// Nothing moved since the resumed check.
+	assert.Empty(t, check().Artifacts)
+}
+
+func TestGHAStatusResumeRequiresTxnID(t *testing.T) {
+	resetGHAStatusFlags(t)
+	_, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--uuid", "a-1", "--resume",
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--resume requires --txnid")
+	}
+}
+
+func TestGHAStatusSelect(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(github.StatusResponse{Status: "in_progress", TxnID: "txn-9", Artifacts: []github.ArtifactStatusDetail{
+			{UUID: "a-1", Name: "sbom", Status: "completed"},
+			{UUID: "a-2", Name: "sarif", Status: "failed", Error: "schema invalid"},
+			{UUID: "a-3", Name: "vex", Status: "processing"},
+			{UUID: "a-4", Name: "csaf", Status: "pending"},
+		}})
+	}))
+	defer api.Close()
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"failed", []string{"a-2"}},
+		{"pending", []string{"a-3", "a-4"}},
+		{"completed", []string{"a-1"}},
+		{"failed,pending", []string{"a-2", "a-3", "a-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			resetGHAStatusFlags(t)
+			t.Cleanup(func() { ghaSelect = nil })
+			args := []string{"gha", "status",
+				"--org-id", "11111111-2222-3333-4444-555555555555",
+				"--base-url", api.URL,
+				"--txnid", "txn-9",
+				"--select", tt.selector,
+				"--no-progress", "--no-analytics",
+			}
+
+			out, err := executeCommand(t, rootCmd, append(args, "--json")...)
+			assert.NoError(t, err)
+			var resp github.StatusResponse
+			assert.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &resp))
+			var got []string
+			for _, a := range resp.Artifacts {
+				got = append(got, a.UUID)
+			}
+			assert.Equal(t, tt.want, got)
+
+			ghaOutputJSON = false
+			out, err = executeCommand(t, rootCmd, append(args, "--json=false")...)
+			assert.NoError(t, err)
+			assert.Contains(t, out, fmt.Sprintf("Artifacts (%d):", len(tt.want)))
+			for _, id := range []string{"a-1", "a-2", "a-3", "a-4"} {
+				if slices.Contains(tt.want, id) {
+					assert.Contains(t, out, "UUID: "+id)
+				} else {
+					assert.NotContains(t, out, "UUID: "+id)
+				}
+			}
+		})
+	}
+}
+
+// serveStatusPolls serves one transaction status per poll, repeating the
+// last once they run out, and counts the polls.
+func serveStatusPolls(t *testing.T, polls [][]string) (*httptest.Server, *int) {
+	t.Helper()
+	var n int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statuses := polls[min(n, len(polls)-1)]
+		n++
+		resp := github.StatusResponse{Status: "in_progress", TxnID: "txn-5"}
+		for i, s := range statuses {
+			resp.Artifacts = append(resp.Artifacts, github.ArtifactStatusDetail{UUID: fmt.Sprintf("a-%d", i+1), Name: fmt.Sprintf("artifact-%d", i+1), Status: s})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(api.Close)
+	return api, &n
+}
+
+func TestGHAStatusWatchNDJSON(t *testing.T) {
+	resetGHAStatusFlags(t)
+	api, polls := serveStatusPolls(t, [][]string{
+		{"pending", "pending"},
+		{"processing", "completed"},
+		{"completed", "failed"},
+	})
+
+	out, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-5",
+		"--watch", "--interval", "1ms", "--output", "ndjson",
+		"--no-progress", "--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *polls)
+
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "{") {
+			lines = append(lines, line)
+		}
+	}
+	require.Len(t, lines, 3)
+	want := [][]string{{"pending", "pending"}, {"processing", "completed"}, {"completed", "failed"}}
+	var last time.Time
+	for i, line := range lines {
+		var snap struct {
+			Timestamp time.Time `json:"timestamp"`
+			Poll      int       `json:"poll"`
+			github.StatusResponse
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &snap), "line %d is not valid JSON: %s", i+1, line)
+		assert.Equal(t, i+1, snap.Poll)
+		assert.False(t, snap.Timestamp.IsZero())
+		assert.False(t, snap.Timestamp.Before(last), "timestamps must not go backwards")
+		last = snap.Timestamp
+		assert.Equal(t, "txn-5", snap.TxnID)
+		var got []string
+		for _, a := range snap.Artifacts {
+			got = append(got, a.Status)
+		}
+		assert.Equal(t, want[i], got)
+	}
+}
+
+func TestGHAStatusWatchJSONReportsFinalStatus(t *testing.T) {
+	resetGHAStatusFlags(t)
+	api, polls := serveStatusPolls(t, [][]string{{"pending"}, {"completed"}})
+
+	out, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-5",
+		"--watch", "--interval", "1ms", "--json",
+		"--no-progress", "--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *polls)
+	var resp github.StatusResponse
+	require.NoError(t, json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &resp))
+	require.Len(t, resp.Artifacts, 1)
+	assert.Equal(t, "completed", resp.Artifacts[0].Status)
+}
+
+func TestGHAStatusRejectsJSONWithNDJSON(t *testing.T) {
+	resetGHAStatusFlags(t)
+	_, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--txnid", "txn-5", "--json", "--output", "ndjson",
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--json cannot be combined with --output ndjson")
+	}
+}
+
+func TestGHAStatusSelectRejectsUnknownState(t *testing.T) {
+	resetGHAStatusFlags(t)
+	t.Cleanup(func() { ghaSelect = nil })
+	_, err := executeCommand(t, rootCmd, "gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--txnid", "txn-9", "--select", "broken",
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid --select "broken"`)
+	}
+}
+
+func TestGHAStatusVerbosity(t *testing.T) {
+	resetGHAStatusFlags(t)
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("verbosity", "2")
+		rootCmd.PersistentFlags().Lookup("verbosity").Changed = false
+		silent, verbose = false, false
+	})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(github.StatusResponse{Status: "completed", TxnID: "txn-3"})
+	}))
+	defer api.Close()
+
+	status := func(args ...string) string {
+		t.Helper()
+		out, err := executeCommand(t, rootCmd, append([]string{"gha", "status",
+			"--org-id", "11111111-2222-3333-4444-555555555555",
+			"--base-url", api.URL, "--txnid", "txn-3", "--no-progress", "--no-analytics",
+		}, args...)...)
+		require.NoError(t, err)
+		return out
+	}
+
+	assert.NotContains(t, status("--verbosity", "0"), "Status: completed")
+	assert.Contains(t, status("--verbosity", "1"), "Status: completed")
+	// JSON is asked for explicitly, so even level 0 prints it.
+	assert.Contains(t, status("--verbosity", "0", "--json"), `"txnid": "txn-3"`)
+}
+
+func TestRequestIDSharedAcrossInvocationRequests(t *testing.T) {
+	resetGHAUploadFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() { ghaBaseURL = upload.DefaultBaseURL })
+
+	var ids []string
+	record := func(r *http.Request) { ids = append(ids, r.Header.Get(httpx.RequestIDHeader)) }
+	gh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_ = json.NewEncoder(w).Encode(github.ArtifactsResponse{TotalCount: 1, Artifacts: []github.Artifact{{ID: 1, Name: "sbom", SizeInBytes: 10}}})
+	}))
+	defer gh.Close()
+	setGHAEnv(t, gh.URL)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_, _ = io.WriteString(w, `{"status":"completed","txnid":"txn-42"}`)
+	}))
+	defer api.Close()
+
+	_, err := executeCommand(t, rootCmd, "gha", "upload",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-42",
+		"--no-progress", "--no-analytics",
+	)
+	assert.Error(t, err, "the closed transaction ends the run after two requests")
+
+	if assert.Len(t, ids, 2) {
+		assert.NotEmpty(t, ids[0])
+		assert.Equal(t, ids[0], ids[1], "GitHub and Vulnetix requests of one invocation share an ID")
+		assert.Equal(t, httpx.RequestID, ids[0])
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/history"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+var (
+	historySince  string
+	historyFormat string
+	historyOrg    string
+	historyLimit  int
+	historyJSON   bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the artifacts uploaded from this machine",
+	Long: `Show the local upload history kept in ~/.vulnetix/history.jsonl. Every
+successful 'vulnetix upload' appends the time, file, format, pipeline UUID and
+organization; pass --no-history to upload to leave no record. The log never
+leaves this machine.
+
+Examples:
+  vulnetix history
+  vulnetix history --since 168h --format sarif
+  vulnetix history --org 8f14e45f-ceea-467f-a8f9-2f8a4b6c1d3e --json`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := upload.ValidateFormat(historyFormat); err != nil {
+			return err
+		}
+		if historyLimit < 0 {
+			return fmt.Errorf("--limit must not be negative")
+		}
+		_, err := parseHistorySince(historySince, time.Now())
+		return err
+	},
+	RunE: runHistory,
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	since, _ := parseHistorySince(historySince, time.Now())
+	path, err := history.Path()
+	if err != nil {
+		return err
+	}
+	entries, err := history.Read(path, history.Filter{Since: since, Format: historyFormat, OrgID: historyOrg})
+	if err != nil {
+		return fmt.Errorf("read upload history: %w", err)
+	}
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	if historyJSON {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	ctx := display.FromCommand(cmd)
+	if len(entries) == 0 {
+		ctx.Logger.Result("No uploads recorded.")
+		return nil
+	}
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		uuid := e.UUID
+		if e.Duplicate {
+			uuid += " (duplicate)"
+		}
+		lines[i] = fmt.Sprintf("%s  %-9s  %s  %s", e.Time.Local().Format(time.DateTime), e.Format, e.File, uuid)
+	}
+	ctx.Logger.Result(display.Bold(ctx.Term, fmt.Sprintf("%d upload(s)", len(entries))) + "\n" + strings.Join(lines, "\n"))
+	return nil
+}
+
+// parseHistorySince reads --since as a duration before now ("72h") or as a
+// date or RFC 3339 timestamp. An empty value is no lower bound.
+func parseHistorySince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("--since %q must be a duration (e.g. 72h), a date (2006-01-02) or an RFC 3339 timestamp", s)
+}
+
+// recordUpload appends a successful upload of source to the local history
+// unless --no-history is set. A failure to write the log is only a warning;
+// the upload itself went through.
+func recordUpload(ctx *display.Context, source, format, orgID string, result *upload.FinalizeResponse) {
+	if uploadNoHistory {
+		return
+	}
+	if abs, err := filepath.Abs(source); err == nil && !strings.Contains(source, "://") {
+		source = abs
+	}
+	entry := history.Entry{
+		Time:      time.Now().UTC(),
+		File:      source,
+		Format:    format,
+		OrgID:     orgID,
+		Duplicate: result.IsDuplicate,
+	}
+	if result.PipelineRecord != nil {
+		entry.UUID = result.PipelineRecord.UUID
+	}
+	path, err := history.Path()
+	if err == nil {
+		err = history.Append(path, entry)
+	}
+	if err != nil {
+		ctx.Logger.Warn(fmt.Sprintf("could not record upload history: %v", err))
+	}
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only uploads after this duration ago (72h) or date (2006-01-02 or RFC 3339)")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "", "Only uploads of this format ("+strings.Join(upload.SupportedFormats, ", ")+")")
+	historyCmd.Flags().StringVar(&historyOrg, "org", "", "Only uploads to this organization ID")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Show only the most recent N uploads (0 shows all)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output the history as JSON")
+	_ = historyCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(upload.SupportedFormats, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(historyCmd)
+}
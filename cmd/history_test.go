@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/history"
+)
+
+func resetHistoryFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = historyCmd.Flags().Set("since", "")
+		_ = historyCmd.Flags().Set("format", "")
+		_ = historyCmd.Flags().Set("org", "")
+		_ = historyCmd.Flags().Set("limit", "0")
+		_ = historyCmd.Flags().Set("json", "false")
+	})
+}
+
+func TestUploadAppendsHistory(t *testing.T) {
+	resetUploadFlags(t)
+	resetHistoryFlags(t)
+	t.Setenv("VULNETIX_ORG_ID", "11111111-2222-3333-4444-555555555555")
+	dir := t.TempDir()
+	sbom := filepath.Join(dir, "bom.cdx.json")
+	sarif := filepath.Join(dir, "report.sarif")
+	require.NoError(t, os.WriteFile(sbom, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	require.NoError(t, os.WriteFile(sarif, []byte(`{"version":"2.1.0","runs":[]}`), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", sbom, "--base-url", server.URL+"/v1")
+	require.NoError(t, err)
+	_, err = executeCommand(t, rootCmd, "upload", "--file", sarif, "--base-url", server.URL+"/v1")
+	require.NoError(t, err)
+	_, err = executeCommand(t, rootCmd, "upload", "--file", sarif, "--base-url", server.URL+"/v1", "--no-history")
+	require.NoError(t, err)
+
+	path, err := history.Path()
+	require.NoError(t, err)
+	entries, err := history.Read(path, history.Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "--no-history must not be recorded")
+	assert.Equal(t, sbom, entries[0].File)
+	assert.Equal(t, "cyclonedx", entries[0].Format)
+	assert.Equal(t, "p-1", entries[0].UUID)
+	assert.Equal(t, "11111111-2222-3333-4444-555555555555", entries[0].OrgID)
+	assert.WithinDuration(t, time.Now(), entries[0].Time, time.Minute)
+
+	out, err := executeCommand(t, rootCmd, "history", "--format", "sarif", "--json")
+	require.NoError(t, err)
+	var filtered []history.Entry
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "[\n"):])).Decode(&filtered))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, sarif, filtered[0].File)
+}
+
+func TestUploadFailureLeavesNoHistory(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1")
+	require.Error(t, err)
+
+	historyPath, err := history.Path()
+	require.NoError(t, err)
+	_, err = os.Stat(historyPath)
+	assert.True(t, os.IsNotExist(err), "a failed upload must not be recorded")
+}
+
+func TestHistoryFilters(t *testing.T) {
+	resetHistoryFlags(t)
+	t.Setenv("HOME", t.TempDir())
+	path, err := history.Path()
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	for _, e := range []history.Entry{
+		{Time: now.Add(-48 * time.Hour), File: "old.sarif", Format: "sarif", OrgID: "org-a"},
+		{Time: now.Add(-time.Hour), File: "new.sarif", Format: "sarif", OrgID: "org-b"},
+		{Time: now, File: "bom.cdx.json", Format: "cyclonedx", OrgID: "org-a"},
+	} {
+		require.NoError(t, history.Append(path, e))
+	}
+	// A line cut short by a crash is skipped rather than failing the read.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, _ = f.WriteString(`{"time":"2026-`)
+	require.NoError(t, f.Close())
+
+	files := func(args ...string) []string {
+		t.Helper()
+		out, err := executeCommand(t, rootCmd, append([]string{"history", "--json"}, args...)...)
+		require.NoError(t, err)
+		var entries []history.Entry
+		require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "[\n"):])).Decode(&entries))
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.File
+		}
+		_ = historyCmd.Flags().Set("since", "")
+		_ = historyCmd.Flags().Set("org", "")
+		_ = historyCmd.Flags().Set("limit", "0")
+		return names
+	}
+	assert.Equal(t, []string{"old.sarif", "new.sarif", "bom.cdx.json"}, files())
+	assert.Equal(t, []string{"new.sarif", "bom.cdx.json"}, files("--since", "24h"))
+	assert.Equal(t, []string{"old.sarif", "bom.cdx.json"}, files("--org", "org-a"))
+	assert.Equal(t, []string{"bom.cdx.json"}, files("--limit", "1"))
+
+	_, err = executeCommand(t, rootCmd, "history", "--since", "last week")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--since")
+}
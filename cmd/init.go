@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/config"
+	"github.com/vulnetix/cli/v3/internal/display"
+)
+
+var (
+	initFile  string
+	initForce bool
+	initCheck bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented sample vulnetix.yaml",
+	Long: `Write a starter vulnetix.yaml to the current directory. Every field,
+including the tools list, is documented inline; edit or delete what you do
+not need.
+
+An existing file is left untouched unless --force is given. With --check
+nothing is written; the existing file is checked instead, failing on unknown
+keys and on tools without a category, an artifact name or a known format,
+and warning about tools whose format their category does not produce.
+
+Examples:
+  vulnetix init
+  vulnetix init --file .github/vulnetix.yaml
+  vulnetix init --force
+  vulnetix init --check`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	initDisplayContext(cmd, display.ModeText)
+	if initCheck {
+		return runInitCheck(cmd)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if initForce {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(initFile, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s already exists; pass --force to overwrite it", initFile)
+	}
+	if err != nil {
+		return fmt.Errorf("create %s: %w", initFile, err)
+	}
+	if _, err := f.WriteString(config.SampleConfig); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", initFile, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", initFile, err)
+	}
+
+	display.FromCommand(cmd).Logger.Result(fmt.Sprintf("Wrote sample configuration to %s", initFile))
+	return nil
+}
+
+// runInitCheck parses an existing configuration file without writing. Tools
+// whose format does not suit their category are warned about, not rejected.
+func runInitCheck(cmd *cobra.Command) error {
+	data, err := os.ReadFile(initFile)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", initFile, err)
+	}
+	cfg, err := config.ParseConfig(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", initFile, err)
+	}
+	ctx := display.FromCommand(cmd)
+	for _, w := range cfg.ToolFormatWarnings() {
+		ctx.Logger.Warn(w)
+	}
+	ctx.Logger.Result(fmt.Sprintf("%s is valid (%d tool(s) configured)", initFile, len(cfg.Tools)))
+	return nil
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initFile, "file", defaultRepoConfigFile, "Configuration file to write, or to check with --check")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing file")
+	initCmd.Flags().BoolVar(&initCheck, "check", false, "Check the existing file instead of writing one")
+	_ = initCmd.MarkFlagFilename("file", "yaml", "yml")
+	rootCmd.AddCommand(initCmd)
+}
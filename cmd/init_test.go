@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/config"
+)
+
+func TestInitWritesParseableConfig(t *testing.T) {
+	t.Cleanup(func() { initFile, initForce = defaultRepoConfigFile, false })
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+
+	_, err := executeCommand(t, rootCmd, "init", "--file", path)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	cfg, err := config.ParseConfig(raw)
+	require.NoError(t, err)
+	assert.NotEmpty(t, cfg.Tools)
+	assert.Contains(t, string(raw), "# Tools whose CI artifacts Vulnetix collects")
+}
+
+func TestInitCheck(t *testing.T) {
+	t.Cleanup(func() { initFile, initCheck = defaultRepoConfigFile, false })
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config.SampleConfig), 0644))
+
+	out, err := executeCommand(t, rootCmd, "init", "--check", "--file", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "is valid (3 tool(s) configured)")
+
+	require.NoError(t, os.WriteFile(path, []byte("projct_name: api\n"), 0644))
+	_, err = executeCommand(t, rootCmd, "init", "--check", "--file", path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "projct_name")
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "projct_name: api\n", string(raw), "--check never writes")
+}
+
+func TestInitCheckWarnsAboutToolFormats(t *testing.T) {
+	t.Cleanup(func() { initFile, initCheck = defaultRepoConfigFile, false })
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tools:\n  - category: sast\n    artifact_name: bandit\n    format: SBOM\n"), 0644))
+
+	out, err := executeCommand(t, rootCmd, "init", "--check", "--file", path)
+	require.NoError(t, err, "a doubtful format is a warning, not an error")
+	assert.Contains(t, out, `tool "bandit" declares format SBOM`)
+	assert.Contains(t, out, "is valid (1 tool(s) configured)")
+}
+
+func TestInitRefusesToOverwrite(t *testing.T) {
+	t.Cleanup(func() { initFile, initForce = defaultRepoConfigFile, false })
+	path := filepath.Join(t.TempDir(), "vulnetix.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("project_name: mine\n"), 0644))
+
+	_, err := executeCommand(t, rootCmd, "init", "--file", path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "project_name: mine\n", string(raw))
+
+	_, err = executeCommand(t, rootCmd, "init", "--file", path, "--force")
+	require.NoError(t, err)
+	raw, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.SampleConfig, string(raw))
+}
@@ -882,14 +882,13 @@ func init() {
 	licenseCmd.Flags().String("allow-file", "", "Path to YAML allow list file")
 	licenseCmd.Flags().String("severity", "", "Exit with code 1 if any finding meets or exceeds this severity (low, medium, high, critical)")
 	licenseCmd.Flags().StringP("output", "o", "", "Output format: pretty (default), json (CycloneDX), json-spdx (SPDX 2.3)")
+	declareOutputFormats(licenseCmd, "pretty", "json", "json-spdx")
 	licenseCmd.Flags().Bool("from-memory", false, "Reconstruct license output from .vulnetix/memory.yaml without re-scanning")
 	licenseCmd.Flags().Bool("dry-run", false, "Detect files and parse packages only — no license evaluation")
 	licenseCmd.Flags().Bool("results-only", false, "Only show output when there are findings or conflicts (summary + issues only, no full package table)")
 
 	_ = licenseCmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(
 		[]string{"inclusive", "individual"}, cobra.ShellCompDirectiveNoFileComp))
-	_ = licenseCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
-		[]string{"pretty", "json", "json-spdx"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = licenseCmd.RegisterFlagCompletionFunc("severity", cobra.FixedCompletions(
 		[]string{"low", "medium", "high", "critical"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = licenseCmd.MarkFlagDirname("path")
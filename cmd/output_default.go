@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/vulnetix/cli/v3/internal/config"
+	"github.com/vulnetix/cli/v3/internal/display"
+)
+
+var outputDefaultWrapped bool
+
+// installOutputDefault makes every command apply the default output format
+// (VULNETIX_OUTPUT or the config file's output key) before its own
+// persistent pre-run, so the pre-run already sees the resolved flags.
+func installOutputDefault() {
+	if outputDefaultWrapped {
+		return
+	}
+	outputDefaultWrapped = true
+	wrapOutputDefault(rootCmd)
+}
+
+func wrapOutputDefault(c *cobra.Command) {
+	switch {
+	case c.PersistentPreRunE != nil:
+		original := c.PersistentPreRunE
+		c.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if err := applyOutputDefault(cmd); err != nil {
+				return err
+			}
+			return original(cmd, args)
+		}
+	case c.PersistentPreRun != nil:
+		original := c.PersistentPreRun
+		c.PersistentPreRun = nil
+		c.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+			if err := applyOutputDefault(cmd); err != nil {
+				return err
+			}
+			original(cmd, args)
+			return nil
+		}
+	}
+	for _, child := range c.Commands() {
+		wrapOutputDefault(child)
+	}
+}
+
+// outputFormats holds the formats each --output flag accepts, as declared
+// with declareOutputFormats. A flag missing here takes a free-form value.
+var outputFormats = map[*pflag.Flag][]string{}
+
+// declareOutputFormats records the formats cmd's --output flag accepts and
+// offers them for completion. The flag must already be defined on cmd.
+func declareOutputFormats(cmd *cobra.Command, formats ...string) {
+	fl := cmd.Flags().Lookup("output")
+	if fl == nil {
+		fl = cmd.PersistentFlags().Lookup("output")
+	}
+	if fl != nil {
+		outputFormats[fl] = formats
+	}
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// applyOutputDefault sets the output flag of cmd from the default output
+// format. Only --output flags with declared formats are touched, since some
+// commands use --output for a file path; yaml falls back to json where a
+// command has no yaml output. Commands with a --json switch instead turn it
+// on for json and yaml. A flag given on the command line always wins, and the
+// command's section of the config file is applied afterwards so it does too.
+func applyOutputDefault(cmd *cobra.Command) error {
+	fl := cmd.Flags().Lookup("output")
+	var formats []string
+	if fl != nil {
+		formats = outputFormats[fl]
+		if len(formats) == 0 {
+			return nil
+		}
+	} else if fl = cmd.Flags().Lookup("json"); fl == nil || fl.Value.Type() != "bool" {
+		return nil
+	}
+	if fl.Changed {
+		return nil
+	}
+
+	format, err := defaultOutputFormat()
+	if err != nil || format == display.OutputText {
+		return err
+	}
+	if formats == nil {
+		return fl.Value.Set("true")
+	}
+	if format == display.OutputYAML && !slices.Contains(formats, display.OutputYAML) {
+		format = display.OutputJSON
+	}
+	if slices.Contains(formats, format) {
+		return fl.Value.Set(format)
+	}
+	return nil
+}
+
+// defaultOutputFormat resolves VULNETIX_OUTPUT and the config file's output
+// key.
+func defaultOutputFormat() (string, error) {
+	path, err := config.DefaultFilePath()
+	if err != nil {
+		return "", err
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return display.DefaultOutput(file.Output)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func TestOutputEnvDefaultAndFlagOverride(t *testing.T) {
+	t.Setenv("VULNETIX_CONFIG", filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Setenv(display.OutputEnvVar, "json")
+	clearFlagsChanged(formatsCmd)
+	t.Cleanup(func() {
+		formatsJSON = false
+		clearFlagsChanged(formatsCmd)
+	})
+
+	out, err := executeCommand(t, rootCmd, "formats", "--no-analytics")
+	require.NoError(t, err)
+	start := strings.Index(out, "[\n")
+	require.GreaterOrEqual(t, start, 0, "VULNETIX_OUTPUT=json should print JSON: %s", out)
+	var got []upload.FormatInfo
+	require.NoError(t, json.Unmarshal([]byte(out[start:]), &got))
+	assert.Equal(t, upload.Formats, got)
+
+	formatsJSON = false
+	out, err = executeCommand(t, rootCmd, "formats", "--json=false", "--no-analytics")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "[\n", "--json=false should override the env default")
+	assert.Contains(t, out, upload.FormatSARIF+" — ")
+
+	t.Setenv(display.OutputEnvVar, "xml")
+	clearFlagsChanged(formatsCmd)
+	_, err = executeCommand(t, rootCmd, "formats", "--no-analytics")
+	require.ErrorContains(t, err, "VULNETIX_OUTPUT")
+}
+
+func TestOutputConfigDefaultOnlySetsFormatFlags(t *testing.T) {
+	t.Setenv(display.OutputEnvVar, "")
+	writeCLIConfig(t, "output: yaml\n")
+
+	newCmd := func(formats ...string) (*cobra.Command, *string) {
+		c := &cobra.Command{Use: "test"}
+		output := c.Flags().StringP("output", "o", "pretty", "")
+		if formats != nil {
+			declareOutputFormats(c, formats...)
+		}
+		return c, output
+	}
+
+	c, output := newCmd("pretty", "json", "yaml")
+	require.NoError(t, applyOutputDefault(c))
+	assert.Equal(t, "yaml", *output)
+
+	c, output = newCmd("pretty", "json")
+	require.NoError(t, applyOutputDefault(c))
+	assert.Equal(t, "json", *output, "yaml falls back to json")
+
+	c, output = newCmd()
+	require.NoError(t, applyOutputDefault(c))
+	assert.Equal(t, "pretty", *output, "a free-form --output (a file path) is left alone")
+
+	c, output = newCmd("pretty", "json", "yaml")
+	require.NoError(t, c.Flags().Set("output", "pretty"))
+	require.NoError(t, applyOutputDefault(c))
+	assert.Equal(t, "pretty", *output, "the command-line flag wins")
+
+	// The config file is not read when the flag settles the format.
+	writeCLIConfig(t, "output: [\n")
+	c, output = newCmd("pretty", "json", "yaml")
+	require.NoError(t, c.Flags().Set("output", "json"))
+	require.NoError(t, applyOutputDefault(c))
+	assert.Equal(t, "json", *output)
+	c, _ = newCmd("pretty", "json", "yaml")
+	assert.Error(t, applyOutputDefault(c))
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// outputTemplateFuncs are the helpers available to --template in addition to
+// the text/template builtins.
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.MarshalIndent(v, "", "  ")
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// default returns def when v is empty: {{.severity | default "unknown"}}.
+	"default": func(def, v any) any {
+		if v == nil {
+			return def
+		}
+		if rv := reflect.ValueOf(v); rv.IsZero() || (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.Len() == 0 {
+			return def
+		}
+		return v
+	},
+}
+
+// parseOutputTemplate compiles a user-supplied --template so syntax errors are
+// reported before any API call is made.
+func parseOutputTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("--template must not be empty")
+	}
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderOutputTemplate executes the template against data. The data is first
+// round-tripped through JSON so fields are addressed by the same names the
+// JSON output uses (e.g. {{.pipelineRecord.uuid}}), whether the result is a
+// typed struct or a raw API map.
+func renderOutputTemplate(w io.Writer, text string, data any) error {
+	tmpl, err := parseOutputTemplate(text)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	if err := tmpl.Execute(w, generic); err != nil {
+		return fmt.Errorf("failed to render --template: %w", err)
+	}
+	if !strings.HasSuffix(text, "\n") {
+		_, _ = io.WriteString(w, "\n")
+	}
+	return nil
+}
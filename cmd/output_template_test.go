@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func TestRenderOutputTemplate_CVE(t *testing.T) {
+	cve := map[string]interface{}{
+		"id":       "CVE-2021-44228",
+		"severity": "critical",
+		"aliases":  []interface{}{"GHSA-jfh8-c2jp-5v3q"},
+		"kev":      nil,
+	}
+
+	var out bytes.Buffer
+	err := renderOutputTemplate(&out,
+		`{{.id}} {{.severity | upper}} kev={{.kev | default "no"}}{{range .aliases}} {{.}}{{end}}`, cve)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CVE-2021-44228 CRITICAL kev=no GHSA-jfh8-c2jp-5v3q\n", out.String())
+}
+
+func TestRenderOutputTemplate_UploadResult(t *testing.T) {
+	result := &upload.FinalizeResponse{
+		OK:             true,
+		PipelineRecord: &upload.PipelineRecord{UUID: "p-123", DetectedType: "sbom"},
+	}
+
+	var out bytes.Buffer
+	err := renderOutputTemplate(&out, "{{.pipelineRecord.uuid}}\t{{.pipelineRecord.detectedType | upper}}\t{{.isDuplicate | default false}}\n", result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "p-123\tSBOM\tfalse\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, renderOutputTemplate(&out, `{{json .pipelineRecord}}`, result))
+	assert.Contains(t, out.String(), `"uuid": "p-123"`)
+}
+
+func TestParseOutputTemplate_Invalid(t *testing.T) {
+	_, err := parseOutputTemplate("{{.id")
+	assert.ErrorContains(t, err, "invalid --template")
+
+	_, err = parseOutputTemplate("")
+	assert.ErrorContains(t, err, "--template must not be empty")
+}
@@ -135,7 +135,7 @@ func runPackageFirewallGo(cmd *cobra.Command, args []string) error {
 		{Key: "Organization", Value: orgID},
 		{Key: "Proxy", Value: proxyURL},
 		{Key: "GOAUTH", Value: "netrc"},
-		{Key: "API key", Value: maskSecret(apiKey)},
+		{Key: "API key", Value: auth.MaskSecret(apiKey)},
 	}) + "\n")
 	b.WriteString("\n" + display.Subheader(t, "Actions") + "\n")
 	for _, action := range actions {
@@ -186,7 +186,7 @@ func runPackageFirewallGoDev(cmd *cobra.Command, args []string) error {
 		{Key: "Credential source", Value: credentialSource},
 		{Key: "Organization", Value: orgID},
 		{Key: "API base URL", Value: apiURL},
-		{Key: "API key", Value: maskSecret(apiKey)},
+		{Key: "API key", Value: auth.MaskSecret(apiKey)},
 	}) + "\n")
 	b.WriteString("\n" + display.Subheader(t, "Actions") + "\n")
 	for _, action := range actions {
@@ -268,7 +268,7 @@ func runPackageFirewallEcosystem(cmd *cobra.Command, eco pfw.Ecosystem) error {
 		{Key: "Credential source", Value: credentialSource},
 		{Key: "Organization", Value: orgID},
 		{Key: "Proxy", Value: pfw.ProxyURL(proxyURL, eco)},
-		{Key: "API key", Value: maskSecret(apiKey)},
+		{Key: "API key", Value: auth.MaskSecret(apiKey)},
 	}) + "\n")
 	b.WriteString("\n" + display.Subheader(t, "Actions") + "\n")
 	for _, action := range actions {
@@ -562,10 +562,6 @@ func removeGoEnvValues(existing string) (string, bool) {
 	return managedfile.RemoveEnvValues(existing, pfwEnvKeys)
 }
 
-func maskSecret(s string) string {
-	return managedfile.MaskSecret(s)
-}
-
 var (
 	packageFirewallUninstallAll    bool
 	packageFirewallUninstallExcept []string
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// operationResult is the JSON summary written by --result-file. It is written
+// whatever the console output mode, and on failure too, so CI steps can read
+// what happened without parsing stdout.
+type operationResult struct {
+	Command    string       `json:"command"`
+	OK         bool         `json:"ok"`
+	Error      string       `json:"error,omitempty"`
+	TxnID      string       `json:"txnid,omitempty"`
+	Status     string       `json:"status,omitempty"`
+	StartedAt  time.Time    `json:"startedAt"`
+	FinishedAt time.Time    `json:"finishedAt"`
+	DurationMs int64        `json:"durationMs"`
+	Items      []resultItem `json:"items"`
+}
+
+// resultItem is one file or artifact the command acted on. UUID is the
+// pipeline UUID for uploads and the artifact UUID for gha transactions.
+type resultItem struct {
+	Name       string `json:"name"`
+	File       string `json:"file,omitempty"`
+	Format     string `json:"format,omitempty"`
+	UUID       string `json:"uuid,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+}
+
+// resultRecorder accumulates an operationResult for --result-file. A nil
+// recorder, used when the flag is unset, records nothing.
+type resultRecorder struct {
+	path   string
+	result operationResult
+}
+
+// withResultFile adapts fn into a RunE that records into the --result-file
+// named by *path and writes it however fn returns.
+func withResultFile(path *string, fn func(*cobra.Command, []string, *resultRecorder) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		var rec *resultRecorder
+		if *path != "" {
+			rec = &resultRecorder{path: *path, result: operationResult{
+				Command:   cmd.CommandPath(),
+				StartedAt: time.Now().UTC(),
+				Items:     []resultItem{},
+			}}
+		}
+		return rec.finish(fn(cmd, args, rec))
+	}
+}
+
+func (r *resultRecorder) add(item resultItem) {
+	if r == nil {
+		return
+	}
+	r.result.Items = append(r.result.Items, item)
+}
+
+// transaction records the gha transaction the command worked on and its
+// last known status.
+func (r *resultRecorder) transaction(txnID, status string) {
+	if r == nil {
+		return
+	}
+	r.result.TxnID = txnID
+	r.result.Status = status
+}
+
+// addUpload records the outcome of uploading one file that started at started.
+func (r *resultRecorder) addUpload(path, format string, started time.Time, result *upload.FinalizeResponse, err error) {
+	item := resultItem{
+		Name:       filepath.Base(path),
+		File:       path,
+		Format:     format,
+		Status:     "uploaded",
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		item.Status, item.Error = "error", err.Error()
+	case result.IsDuplicate:
+		item.Status = "duplicate"
+	}
+	if result != nil && result.PipelineRecord != nil {
+		item.UUID = result.PipelineRecord.UUID
+	}
+	r.add(item)
+}
+
+// finish stamps the outcome of the command and writes the result file,
+// returning err unchanged. A failure to write the file is only returned when
+// the command itself succeeded.
+func (r *resultRecorder) finish(err error) error {
+	if r == nil {
+		return err
+	}
+	r.result.FinishedAt = time.Now().UTC()
+	r.result.DurationMs = r.result.FinishedAt.Sub(r.result.StartedAt).Milliseconds()
+	r.result.OK = err == nil
+	if err != nil {
+		r.result.Error = err.Error()
+	}
+
+	data, merr := json.MarshalIndent(r.result, "", "  ")
+	if merr == nil {
+		if dir := filepath.Dir(r.path); dir != "." {
+			merr = os.MkdirAll(dir, 0o755)
+		}
+	}
+	if merr == nil {
+		merr = os.WriteFile(r.path, append(data, '\n'), 0o644)
+	}
+	if merr != nil && err == nil {
+		return fmt.Errorf("write --result-file %s: %w", r.path, merr)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/github"
+)
+
+func readResultFile(t *testing.T, path string) operationResult {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var res operationResult
+	require.NoError(t, json.Unmarshal(data, &res))
+	return res
+}
+
+func TestUploadResultFileRecordsUpload(t *testing.T) {
+	resetUploadFlags(t)
+	dir := t.TempDir()
+	sbom := filepath.Join(dir, "bom.cdx.json")
+	require.NoError(t, os.WriteFile(sbom, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","version":1}`), 0644))
+	resultFile := filepath.Join(dir, "out", "result.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", sbom, "--base-url", server.URL+"/v1", "--result-file", resultFile)
+	require.NoError(t, err)
+
+	res := readResultFile(t, resultFile)
+	assert.Equal(t, "vulnetix upload", res.Command)
+	assert.True(t, res.OK)
+	assert.Empty(t, res.Error)
+	assert.False(t, res.FinishedAt.Before(res.StartedAt))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, resultItem{Name: "bom.cdx.json", File: sbom, Format: "cyclonedx", UUID: "p-1", Status: "uploaded", DurationMs: res.Items[0].DurationMs}, res.Items[0])
+}
+
+func TestUploadResultFileWrittenOnFailure(t *testing.T) {
+	resetUploadFlags(t)
+	dir := t.TempDir()
+	sarif := filepath.Join(dir, "report.sarif")
+	require.NoError(t, os.WriteFile(sarif, []byte(`{"version":"2.1.0","runs":[]}`), 0644))
+	resultFile := filepath.Join(dir, "result.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"ok":false,"error":"forbidden"}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", sarif, "--base-url", server.URL+"/v1", "--result-file", resultFile, "--json")
+	require.Error(t, err)
+
+	res := readResultFile(t, resultFile)
+	assert.False(t, res.OK)
+	assert.Equal(t, err.Error(), res.Error)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, "error", res.Items[0].Status)
+	assert.NotEmpty(t, res.Items[0].Error)
+}
+
+func TestGHAStatusResultFile(t *testing.T) {
+	resetGHAStatusFlags(t)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(github.StatusResponse{Status: "in_progress", TxnID: "txn-5", Artifacts: []github.ArtifactStatusDetail{
+			{UUID: "a-1", Name: "sbom", Status: "completed"},
+			{UUID: "a-2", Name: "sarif", Status: "failed", Error: "bad input"},
+		}})
+	}))
+	defer api.Close()
+	resultFile := filepath.Join(t.TempDir(), "status.json")
+
+	out, err := executeCommand(t, rootCmd,
+		"gha", "status",
+		"--org-id", "11111111-2222-3333-4444-555555555555",
+		"--base-url", api.URL,
+		"--txnid", "txn-5",
+		"--result-file", resultFile,
+		"--no-progress",
+		"--no-analytics",
+	)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(out, "Status: in_progress"), "console output is unchanged")
+
+	res := readResultFile(t, resultFile)
+	assert.Equal(t, "vulnetix gha status", res.Command)
+	assert.Equal(t, "txn-5", res.TxnID)
+	assert.Equal(t, "in_progress", res.Status)
+	assert.Equal(t, []resultItem{
+		{Name: "sbom", UUID: "a-1", Status: "completed"},
+		{Name: "sarif", UUID: "a-2", Status: "failed", Error: "bad input"},
+	}, res.Items)
+}
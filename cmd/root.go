@@ -9,25 +9,30 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/vulnetix/cli/v3/internal/analytics"
 	"github.com/vulnetix/cli/v3/internal/config"
 	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/internal/update"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/cache"
 	"github.com/vulnetix/cli/v3/pkg/vdb"
+	"golang.org/x/term"
 )
 
 var (
 	// Command line flags
-	orgID         string
-	silent        bool
-	verbose       bool
-	noProgress    bool
-	disableMemory bool
-	noAnalytics   bool
+	orgID           string
+	silent          bool
+	verbose         bool
+	verbosity       = display.VerbositySteps
+	noProgress      bool
+	disableMemory   bool
+	noAnalytics     bool
+	userAgentSuffix string
 
 	// Build metadata (injected via ldflags)
 	version   = "1.0.0"   // -X github.com/vulnetix/cli/v3/cmd.version=
@@ -130,6 +135,7 @@ vulnerabilities efficiently.`,
 		})
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		reportTraffic(cmd)
 		if msg := consumeUpdateAdvisory(); msg != "" {
 			fmt.Fprint(os.Stderr, msg)
 		}
@@ -139,10 +145,40 @@ vulnerabilities efficiently.`,
 	},
 }
 
+// reportTraffic prints the request and response bytes of this invocation's
+// API calls under --verbose, for users watching CI bandwidth.
+func reportTraffic(cmd *cobra.Command) {
+	if !verbose {
+		return
+	}
+	sent, received := httpx.Traffic()
+	if sent == 0 && received == 0 {
+		return
+	}
+	display.FromCommand(cmd).Logger.Infof("Network: sent %s, received %s", formatByteSize(int(sent)), formatByteSize(int(received)))
+}
+
 // initDisplayContext creates and attaches a display.Context to the command.
 func initDisplayContext(cmd *cobra.Command, mode display.OutputMode) {
-	dc := display.NewWithProgress(mode, silent, noProgress)
+	dc := display.NewWithVerbosity(mode, verbosity, noProgress)
 	dc.Attach(cmd)
+	auth.Warn = dc.Logger.Warn
+	auth.PassphrasePrompt = nil
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		auth.PassphrasePrompt = promptCredentialsPassphrase
+	}
+}
+
+// validateOrgID checks an organization ID taken from --org-id, the
+// environment or a prompt, so every command rejects a bad value the same way.
+func validateOrgID(org string) error {
+	if org == "" {
+		return fmt.Errorf("--org-id is required")
+	}
+	if _, err := uuid.Parse(org); err != nil {
+		return fmt.Errorf("--org-id must be a valid UUID, got: %s", org)
+	}
+	return nil
 }
 
 // runInfoTask performs an authentication healthcheck across all credential sources
@@ -183,7 +219,9 @@ func runInfoTask(cmd *cobra.Command) error {
 			APIKey: apiKey,
 			Method: auth.DirectAPIKey,
 		}
-		if err := verifyDirectAPIKey(creds); err != nil {
+		if err := validateOrgID(envOrgID); err != nil {
+			formatSource("VULNETIX_API_KEY + VULNETIX_ORG_ID (env)", "···", fmt.Sprintf("(VULNETIX_ORG_ID must be a valid UUID, got: %s)", envOrgID), false)
+		} else if err := verifyDirectAPIKey(creds); err != nil {
 			formatSource("VULNETIX_API_KEY + VULNETIX_ORG_ID (env)", "···", fmt.Sprintf("(%s)", err), false)
 		} else {
 			formatSource("VULNETIX_API_KEY + VULNETIX_ORG_ID (env)", "···", display.Muted(t, fmt.Sprintf("org: %s", envOrgID)), true)
@@ -362,16 +400,51 @@ func Execute() error {
 	}
 	// For all other errors, restore normal error reporting.
 	fmt.Fprintln(os.Stderr, "Error:", err)
+	if httpx.RequestIDSent() {
+		fmt.Fprintln(os.Stderr, "Request ID:", httpx.RequestID, "(include this when contacting support)")
+	}
 	return err
 }
 
+// resolveVerbosity reconciles --verbosity with --silent and --verbose, which
+// stand for levels 1 and 3. An explicit --verbosity wins and sets the other
+// two to match, so code gated on either keeps working.
+func resolveVerbosity() {
+	switch {
+	case rootCmd.PersistentFlags().Changed("verbosity"):
+		silent = verbosity < display.VerbositySteps
+		verbose = verbosity == display.VerbosityDebug
+	case verbose:
+		verbosity = display.VerbosityDebug
+	case silent:
+		verbosity = display.VerbosityResults
+	default:
+		verbosity = display.VerbositySteps
+	}
+}
+
 // startupHooks runs before any command via cobra.OnInitialize.
 func startupHooks() {
 	installCommandProgress()
+	installOutputDefault()
+	resolveVerbosity()
 
 	// Propagate verbose flag into vdb client (gates retry/backoff stderr chatter).
 	vdb.Verbose = verbose
 
+	// Identify this build (and any operator-supplied tag) on every API request.
+	httpx.Version = version
+	httpx.UserAgentSuffix = userAgentSuffix
+	httpx.NewRequestID()
+	httpx.ResetTraffic()
+	httpx.ResetDump()
+	if path, err := vdb.RateLimitStatePath(); err == nil {
+		vdb.RateLimitStateFile = path
+	}
+	if path, err := vdb.TokenCachePath(); err == nil {
+		vdb.DefaultTokenCacheFile = path
+	}
+
 	// Initialize GA4 analytics (respects VULNETIX_NO_ANALYTICS / DO_NOT_TRACK / --no-analytics)
 	if noAnalytics {
 		os.Setenv("VULNETIX_NO_ANALYTICS", "1")
@@ -406,10 +479,23 @@ func startupHooks() {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&orgID, "org-id", "", "Organization ID (UUID) for Vulnetix operations")
 	rootCmd.PersistentFlags().BoolVar(&silent, "silent", false, "Suppress all log output, only print final result")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show verbose diagnostic output (rate limits, cache status, auth notes)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show verbose diagnostic output (rate limits, cache status, auth notes, bytes sent and received)")
+	rootCmd.PersistentFlags().Var(&verbosity, "verbosity", "Output detail: 0 errors only, 1 results, 2 steps, 3 debug (overrides --silent and --verbose)")
 	rootCmd.PersistentFlags().BoolVar(&noProgress, "no-progress", false, "Suppress progress indicators")
 	rootCmd.PersistentFlags().BoolVar(&disableMemory, "disable-memory", false, "Disable memory file reads/writes. For users who do not use the Claude Code Plugin or for debugging. VDB commands will skip memory-related side effects when set.")
 	rootCmd.PersistentFlags().BoolVar(&noAnalytics, "no-analytics", false, "Disable anonymous usage analytics")
+	rootCmd.PersistentFlags().StringVar(&auth.Profile, "profile", "", "Named credentials profile to load and save (default $"+auth.ProfileEnv+", else the default profile)")
+	rootCmd.PersistentFlags().StringVar(&userAgentSuffix, "user-agent-suffix", "", "Text appended to the User-Agent sent on API requests (e.g. a team name)")
+	rootCmd.PersistentFlags().IntVar(&vdb.MaxConcurrentRequests, "vdb-concurrency", 0, "Maximum VDB API requests in flight at once, however many lookups run in parallel (0 disables the limit)")
+	rootCmd.PersistentFlags().BoolVar(&vdb.RespectRateLimit, "respect-rate-limit", false, "Before calling the VDB API, act on a daily quota an earlier run exhausted: switch to community credentials, wait for a reset due within 5 minutes, or fail")
+	rootCmd.PersistentFlags().Var(httpx.MinTLSVersion, "min-tls-version", "Lowest TLS version accepted on API connections: 1.2 or 1.3")
+	rootCmd.PersistentFlags().Var(&httpx.DumpRequest, "dump-request", "Print the next API request (secrets redacted) to stderr, skipping credential exchanges: dry-run does not send it, send sends it too")
+	rootCmd.PersistentFlags().Lookup("dump-request").NoOptDefVal = "dry-run"
+	rootCmd.PersistentFlags().DurationVar(&httpx.AuthTimeout, "auth-timeout", httpx.AuthTimeout, "Deadline for token exchange and credential checks (0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&httpx.ListTimeout, "list-timeout", httpx.ListTimeout, "Deadline for listing CI artifacts and fetching the VDB API spec (0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&httpx.DownloadTimeout, "download-timeout", httpx.DownloadTimeout, "Deadline for downloading a CI artifact (0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&httpx.UploadChunkTimeout, "upload-chunk-timeout", httpx.UploadChunkTimeout, "Deadline for each chunk of a chunked upload (0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&httpx.FinalizeTimeout, "finalize-timeout", httpx.FinalizeTimeout, "Deadline for finalizing an upload (0 disables)")
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	cobra.OnInitialize(startupHooks)
 }
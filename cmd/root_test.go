@@ -13,6 +13,20 @@ import (
 	"github.com/vulnetix/cli/v3/internal/testutils"
 )
 
+// TestMain keeps the memory vdb commands record out of the working tree,
+// which is inside this repository.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "vulnetix-memory-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	vdbMemoryDir = dir
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // executeCommand executes a cobra command and captures its output.
 // It also mocks os.Exit to prevent the test from exiting.
 func executeCommand(t *testing.T, cmd *cobra.Command, args ...string) (output string, err error) {
@@ -123,3 +137,25 @@ func TestRootCommand(t *testing.T) {
 
 // exit is a variable that can be overridden for testing purposes
 var exit = os.Exit
+
+func TestValidateOrgID(t *testing.T) {
+	tests := []struct {
+		name string
+		org  string
+		err  string
+	}{
+		{"valid", "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", ""},
+		{"invalid", "my-org", "--org-id must be a valid UUID, got: my-org"},
+		{"empty", "", "--org-id is required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOrgID(tc.org)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// sarif enrich flags
+var (
+	sarifEnrichFile        string
+	sarifEnrichFormat      string
+	sarifEnrichOut         string
+	sarifEnrichConcurrency int
+)
+
+var (
+	sarifCVEPattern = regexp.MustCompile(`(?i)\bCVE-\d{4}-\d{4,}\b`)
+	sarifCWEPattern = regexp.MustCompile(`(?i)\bCWE-\d+\b`)
+)
+
+var sarifCmd = &cobra.Command{
+	Use:   "sarif",
+	Short: "Work with SARIF reports from any tool",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		printBanner(cmd)
+		initDisplayContext(cmd, display.ModeText)
+		// Credentials are optional — community fallback is used when absent.
+		return resolveVDBCredentials(false)
+	},
+}
+
+var sarifEnrichCmd = &cobra.Command{
+	Use:   "enrich",
+	Short: "Annotate SARIF findings with VDB severity and EPSS",
+	Long: `Extract CVE and CWE identifiers from each finding's rule ID, rule
+metadata and properties, look the CVEs up in the Vulnetix VDB, and attach the
+results to the finding under properties.vulnetix.
+
+The enriched SARIF keeps every field of the input document. With --format json
+a flat per-finding report is written instead.
+
+Examples:
+  vulnetix sarif enrich --file trivy.sarif > trivy.enriched.sarif
+  vulnetix sarif enrich --file results.sarif --format json --out enrichment.json`,
+	Args: cobra.NoArgs,
+	RunE: runSARIFEnrich,
+}
+
+// sarifVulnContext is the VDB context attached to a finding for one CVE.
+type sarifVulnContext struct {
+	ID       string  `json:"id"`
+	Severity string  `json:"severity,omitempty"`
+	CVSS     float64 `json:"cvss,omitempty"`
+	EPSS     float64 `json:"epss,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// sarifEnrichment is what enrichment adds to a single finding.
+type sarifEnrichment struct {
+	CVEs []sarifVulnContext `json:"cves,omitempty"`
+	CWEs []string           `json:"cwes,omitempty"`
+}
+
+// sarifEnrichedFinding is one row of the --format json report.
+type sarifEnrichedFinding struct {
+	Run     int    `json:"run"`
+	Index   int    `json:"index"`
+	RuleID  string `json:"ruleId"`
+	Message string `json:"message,omitempty"`
+	sarifEnrichment
+}
+
+func runSARIFEnrich(cmd *cobra.Command, args []string) error {
+	if sarifEnrichFile == "" {
+		return fmt.Errorf("--file is required")
+	}
+	format := strings.ToLower(sarifEnrichFormat)
+	if format != "sarif" && format != "json" {
+		return fmt.Errorf("unsupported --format %q: use sarif or json", sarifEnrichFormat)
+	}
+	if sarifEnrichConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	data, err := os.ReadFile(sarifEnrichFile)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", sarifEnrichFile, err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse sarif: %w", err)
+	}
+	if _, ok := doc["runs"].([]interface{}); !ok {
+		return fmt.Errorf("parse sarif: %s has no runs array", sarifEnrichFile)
+	}
+
+	ctx := display.FromCommand(cmd)
+	ids := collectSARIFCVEs(doc)
+	var vulns map[string]sarifVulnContext
+	if len(ids) > 0 {
+		ctx.Logger.Infof("Looking up %d CVE(s) referenced by %s...", len(ids), sarifEnrichFile)
+		client := newVDBClient()
+		vulns = lookupSARIFVulns(client, ids, sarifEnrichConcurrency)
+		for _, id := range ids {
+			if v := vulns[id]; v.Error != "" {
+				ctx.Logger.Warn(fmt.Sprintf("%s: %s", v.ID, v.Error))
+			}
+		}
+		printRateLimit(client)
+		recordVDBQuery("sarif-enrich", strings.Join(ids, ","))
+	} else {
+		ctx.Logger.Infof("No CVE identifiers found in %s", sarifEnrichFile)
+	}
+
+	findings := enrichSARIF(doc, vulns)
+	ctx.Logger.Infof("Enriched %d finding(s)", len(findings))
+
+	var out interface{} = doc
+	if format == "json" {
+		out = map[string]interface{}{"file": sarifEnrichFile, "findings": findings}
+	}
+	body, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal output: %w", err)
+	}
+	return writeOutput(cmd, body, sarifEnrichOut)
+}
+
+// collectSARIFCVEs returns every CVE referenced by any result or rule in the
+// document, upper-cased and sorted.
+func collectSARIFCVEs(doc map[string]interface{}) []string {
+	seen := map[string]bool{}
+	forEachSARIFResult(doc, func(_, _ int, result, rule map[string]interface{}) {
+		cves, _ := sarifFindingIDs(result, rule)
+		for _, id := range cves {
+			seen[id] = true
+		}
+	})
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// lookupSARIFVulns fetches each CVE from the VDB. Lookup failures are kept as
+// entries with Error set so the finding still records what was attempted.
+func lookupSARIFVulns(client *vdb.Client, ids []string, concurrency int) map[string]sarifVulnContext {
+	vulns := make(map[string]sarifVulnContext, len(ids))
+	for _, r := range fetchCVEsConcurrently(client, ids, concurrency) {
+		if r.Err != nil {
+			vulns[r.ID] = sarifVulnContext{ID: r.ID, Error: r.Err.Error()}
+			continue
+		}
+		s := summarizeVuln(r.Data)
+		vulns[r.ID] = sarifVulnContext{ID: r.ID, Severity: strings.ToLower(s.severity), CVSS: s.score, EPSS: s.epss}
+	}
+	return vulns
+}
+
+// enrichSARIF writes properties.vulnetix onto every result that references a
+// CVE or CWE and returns the matching report rows. Results with neither are
+// left untouched.
+func enrichSARIF(doc map[string]interface{}, vulns map[string]sarifVulnContext) []sarifEnrichedFinding {
+	var findings []sarifEnrichedFinding
+	forEachSARIFResult(doc, func(runIdx, resultIdx int, result, rule map[string]interface{}) {
+		cves, cwes := sarifFindingIDs(result, rule)
+		if len(cves) == 0 && len(cwes) == 0 {
+			return
+		}
+		e := sarifEnrichment{CWEs: cwes}
+		for _, id := range cves {
+			v, ok := vulns[id]
+			if !ok {
+				v = sarifVulnContext{ID: id}
+			}
+			e.CVEs = append(e.CVEs, v)
+		}
+
+		props, _ := result["properties"].(map[string]interface{})
+		if props == nil {
+			props = map[string]interface{}{}
+			result["properties"] = props
+		}
+		props["vulnetix"] = e
+
+		msg, _ := result["message"].(map[string]interface{})
+		findings = append(findings, sarifEnrichedFinding{
+			Run:             runIdx,
+			Index:           resultIdx,
+			RuleID:          display.ToStringVal(result["ruleId"]),
+			Message:         display.ToStringVal(msg["text"]),
+			sarifEnrichment: e,
+		})
+	})
+	return findings
+}
+
+// forEachSARIFResult calls fn for every result together with the rule it
+// references (by ruleIndex, else by ruleId), or nil when there is none.
+func forEachSARIFResult(doc map[string]interface{}, fn func(runIdx, resultIdx int, result, rule map[string]interface{})) {
+	runs, _ := doc["runs"].([]interface{})
+	for runIdx, r := range runs {
+		run, _ := r.(map[string]interface{})
+		tool, _ := run["tool"].(map[string]interface{})
+		driver, _ := tool["driver"].(map[string]interface{})
+		rules, _ := driver["rules"].([]interface{})
+		byID := map[string]map[string]interface{}{}
+		for _, item := range rules {
+			if rule, ok := item.(map[string]interface{}); ok {
+				byID[display.ToStringVal(rule["id"])] = rule
+			}
+		}
+
+		results, _ := run["results"].([]interface{})
+		for resultIdx, item := range results {
+			result, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var rule map[string]interface{}
+			if idx, ok := result["ruleIndex"].(float64); ok && int(idx) >= 0 && int(idx) < len(rules) {
+				rule, _ = rules[int(idx)].(map[string]interface{})
+			}
+			if rule == nil {
+				rule = byID[display.ToStringVal(result["ruleId"])]
+			}
+			fn(runIdx, resultIdx, result, rule)
+		}
+	}
+}
+
+// sarifFindingIDs extracts CVE and CWE identifiers from a result's ruleId and
+// properties and from its rule's id, name and properties (tags, cwe, ...).
+func sarifFindingIDs(result, rule map[string]interface{}) (cves, cwes []string) {
+	var texts []string
+	texts = append(texts, display.ToStringVal(result["ruleId"]))
+	texts = append(texts, flattenSARIFStrings(result["properties"])...)
+	if rule != nil {
+		texts = append(texts, display.ToStringVal(rule["id"]), display.ToStringVal(rule["name"]))
+		texts = append(texts, flattenSARIFStrings(rule["properties"])...)
+	}
+
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range sarifCVEPattern.FindAllString(text, -1) {
+			if id := strings.ToUpper(m); !seen[id] {
+				seen[id] = true
+				cves = append(cves, id)
+			}
+		}
+		for _, m := range sarifCWEPattern.FindAllString(text, -1) {
+			if id := strings.ToUpper(m); !seen[id] {
+				seen[id] = true
+				cwes = append(cwes, id)
+			}
+		}
+	}
+	return cves, cwes
+}
+
+// flattenSARIFStrings returns every string value nested anywhere in v.
+func flattenSARIFStrings(v interface{}) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		var out []string
+		for _, item := range t {
+			out = append(out, flattenSARIFStrings(item)...)
+		}
+		return out
+	case map[string]interface{}:
+		var out []string
+		for _, item := range t {
+			out = append(out, flattenSARIFStrings(item)...)
+		}
+		return out
+	}
+	return nil
+}
+
+func init() {
+	sarifEnrichCmd.Flags().StringVar(&sarifEnrichFile, "file", "", "SARIF file to enrich (required)")
+	sarifEnrichCmd.Flags().StringVar(&sarifEnrichFormat, "format", "sarif", "Output format: sarif (enriched document) or json (per-finding report)")
+	sarifEnrichCmd.Flags().StringVar(&sarifEnrichOut, "out", "", "Write the output to this file instead of stdout")
+	sarifEnrichCmd.Flags().IntVar(&sarifEnrichConcurrency, "concurrency", defaultExportConcurrency, "Maximum concurrent VDB lookups")
+	_ = sarifEnrichCmd.MarkFlagFilename("file", "sarif", "json")
+	_ = sarifEnrichCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"sarif", "json"}, cobra.ShellCompDirectiveNoFileComp))
+
+	sarifCmd.AddCommand(sarifEnrichCmd)
+	rootCmd.AddCommand(sarifCmd)
+}
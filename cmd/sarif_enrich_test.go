@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A Trivy-style report: one rule keyed by CVE, one whose CVE is only in its
+// tags, and a SAST rule that references a CWE but no CVE.
+const enrichInputSARIF = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "Trivy", "rules": [
+      {"id": "CVE-2021-44228", "properties": {"tags": ["vulnerability", "CRITICAL"]}},
+      {"id": "pkg-spring-rce", "properties": {"tags": ["security", "cve-2022-22965"]}},
+      {"id": "go/sql-injection", "properties": {"cwe": "CWE-89"}}
+    ]}},
+    "results": [
+      {"ruleId": "CVE-2021-44228", "ruleIndex": 0, "message": {"text": "log4j-core 2.14.1"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "pom.xml", "uriBaseId": "SRCROOT"}}}]},
+      {"ruleId": "pkg-spring-rce", "message": {"text": "spring-beans 5.3.17"}},
+      {"ruleId": "go/sql-injection", "message": {"text": "query built from input"}},
+      {"ruleId": "style/line-length", "message": {"text": "line too long"}}
+    ]
+  }]
+}`
+
+func TestSARIFEnrich_AnnotatesFindings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/vuln/CVE-2021-44228"):
+			_, _ = w.Write([]byte(`{"containers":{"cna":{"metrics":[{"cvssV3_1":{"baseScore":10.0,"baseSeverity":"CRITICAL"}}]},
+				"adp":[{"x_epss":{"score":0.97,"percentile":0.99}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(enrichInputSARIF), &doc))
+
+	ids := collectSARIFCVEs(doc)
+	assert.Equal(t, []string{"CVE-2021-44228", "CVE-2022-22965"}, ids)
+
+	vulns := lookupSARIFVulns(testSCAClient(srv.URL), ids, 2)
+	findings := enrichSARIF(doc, vulns)
+	require.Len(t, findings, 3, "the finding with no CVE or CWE is not reported")
+
+	log4j := findings[0]
+	assert.Equal(t, "CVE-2021-44228", log4j.RuleID)
+	require.Len(t, log4j.CVEs, 1)
+	assert.Equal(t, "critical", log4j.CVEs[0].Severity)
+	assert.Equal(t, 10.0, log4j.CVEs[0].CVSS)
+	assert.Equal(t, 0.97, log4j.CVEs[0].EPSS)
+
+	spring := findings[1]
+	require.Len(t, spring.CVEs, 1)
+	assert.Equal(t, "CVE-2022-22965", spring.CVEs[0].ID)
+	assert.NotEmpty(t, spring.CVEs[0].Error, "a failed lookup is recorded on the finding")
+
+	assert.Equal(t, []string{"CWE-89"}, findings[2].CWEs)
+	assert.Empty(t, findings[2].CVEs)
+
+	// The enriched document keeps input fields and carries the annotation.
+	out, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var round struct {
+		Runs []struct {
+			Results []struct {
+				Locations  []map[string]interface{} `json:"locations"`
+				Properties map[string]interface{}   `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(out, &round))
+	results := round.Runs[0].Results
+	assert.Contains(t, string(out), `"uriBaseId":"SRCROOT"`)
+	require.Contains(t, results[0].Properties, "vulnetix")
+	assert.Contains(t, results[0].Properties["vulnetix"], "cves")
+	assert.Nil(t, results[3].Properties, "unrelated findings are left untouched")
+}
@@ -829,7 +829,7 @@ func runLocalScan(
 	gitHistoryMaxFiles int,
 	respectGitignore bool,
 ) (retErr error) {
-	dctx := display.NewWithProgress(display.ModeText, silent, noProgress)
+	dctx := display.NewWithVerbosity(display.ModeText, verbosity, noProgress)
 	scanProgress := dctx.Progress("Scan", 7)
 	progressStderr := scanProgress.Writer(os.Stderr)
 	scanProgress.SetStage(fmt.Sprintf("Parsing %d detected file(s)", len(files)))
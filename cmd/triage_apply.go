@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/triage"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// applyTriageDecisions records the planned decisions in Vulnetix and reports
+// how many were applied. It fails when any decision was not recorded.
+func applyTriageDecisions(cmd *cobra.Command, client *vdb.Client, plan []triage.PlanEntry) error {
+	w := cmd.ErrOrStderr()
+	if len(plan) == 0 {
+		fmt.Fprintln(w, "No triage decisions to apply.")
+		return nil
+	}
+	if client == nil {
+		return fmt.Errorf("--apply needs credentials: run 'vulnetix auth login' or set VULNETIX_API_KEY + VULNETIX_ORG_ID")
+	}
+
+	decisions := make([]vdb.CliTriageDecision, 0, len(plan))
+	for _, e := range plan {
+		decisions = append(decisions, vdb.CliTriageDecision{
+			VulnID:           e.VulnID,
+			Package:          e.Package,
+			Ecosystem:        e.Ecosystem,
+			InstalledVersion: e.InstalledVer,
+			FixedVersion:     e.FixedVer,
+			Status:           e.Status,
+			Justification:    e.Justification,
+			Action:           e.Action,
+			Decision:         e.Decision,
+			SSVCDecision:     e.SSVC,
+			Reasons:          e.Reasons,
+		})
+	}
+	logCliOp("Recording %d triage decision(s) via /v2/cli.triage-decisions...", len(decisions))
+	resp, err := client.CliTriageDecisions(envForCli(), vdb.CliTriageDecisionsRequest{Decisions: decisions})
+	if err != nil {
+		return fmt.Errorf("apply triage decisions: %w", err)
+	}
+
+	fmt.Fprintf(w, "Applied %d of %d triage decision(s)\n", resp.Data.Applied, len(decisions))
+	for _, f := range resp.Data.Failed {
+		fmt.Fprintf(w, "  failed %s: %s\n", f.VulnID, f.Error)
+	}
+	if n := len(resp.Data.Failed); n > 0 {
+		return fmt.Errorf("%d of %d triage decision(s) could not be applied", n, len(decisions))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/triage"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// serveTriageDecisions mocks /v2/cli.triage-decisions, rejecting the decisions
+// for the vuln IDs in reject and recording the decisions it received.
+func serveTriageDecisions(t *testing.T, reject map[string]string, got *[]vdb.CliTriageDecision) *vdb.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/cli.triage-decisions" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Payload vdb.CliTriageDecisionsRequest `json:"payload"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*got = body.Payload.Decisions
+		resp := vdb.CliTriageDecisionsResponse{}
+		for _, d := range body.Payload.Decisions {
+			if msg, ok := reject[d.VulnID]; ok {
+				resp.Failed = append(resp.Failed, vdb.CliTriageDecisionFailed{VulnID: d.VulnID, Error: msg})
+				continue
+			}
+			resp.Applied++
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{}, "data": resp})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := vdb.NewClientFromCredentials(&auth.Credentials{OrgID: "org", APIKey: "key", Method: auth.DirectAPIKey})
+	client.BaseURL = srv.URL
+	client.APIVersion = "/v2"
+	return client
+}
+
+func applyTestPlan() []triage.PlanEntry {
+	return triage.BuildPlan([]*triage.TriageFinding{
+		{CVEID: "CVE-2021-44228", Package: "log4j-core", InstalledVer: "2.14.1", Status: "affected", Severity: "critical", InKEV: true},
+		{CVEID: "CVE-2022-22965", Package: "spring-beans", InstalledVer: "5.3.20", Status: "not_affected", Justification: "vulnerable_code_not_present"},
+	})
+}
+
+func TestApplyTriageDecisions(t *testing.T) {
+	var got []vdb.CliTriageDecision
+	client := serveTriageDecisions(t, nil, &got)
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+
+	require.NoError(t, applyTriageDecisions(cmd, client, applyTestPlan()))
+	assert.Contains(t, stderr.String(), "Applied 2 of 2 triage decision(s)")
+	require.Len(t, got, 2)
+	assert.Equal(t, "CVE-2021-44228", got[0].VulnID)
+	assert.Equal(t, "Act", got[0].SSVCDecision)
+	assert.Equal(t, "Track", got[1].SSVCDecision)
+	assert.Equal(t, "vulnerable_code_not_present", got[1].Justification)
+	assert.NotEmpty(t, got[0].Reasons)
+}
+
+func TestApplyTriageDecisionsPartialFailure(t *testing.T) {
+	var got []vdb.CliTriageDecision
+	client := serveTriageDecisions(t, map[string]string{"CVE-2022-22965": "finding not found"}, &got)
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+
+	err := applyTriageDecisions(cmd, client, applyTestPlan())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 triage decision(s) could not be applied")
+	assert.Contains(t, stderr.String(), "Applied 1 of 2 triage decision(s)")
+	assert.Contains(t, stderr.String(), "failed CVE-2022-22965: finding not found")
+}
+
+func TestTriagePlanAndApplyAreExclusive(t *testing.T) {
+	t.Cleanup(func() { triagePlan, triageApply = false, false })
+	_, err := executeCommand(t, rootCmd, "triage", "--plan", "--apply", "CVE-2021-44228")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined")
+}
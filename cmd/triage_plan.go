@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/triage"
+)
+
+// printTriagePlan writes the recommended decisions and the signals behind
+// each, as a table followed by the reasons, or as JSON.
+func printTriagePlan(cmd *cobra.Command, plan []triage.PlanEntry, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		body, err := json.MarshalIndent(map[string]any{"decisions": plan}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(body))
+		return nil
+	}
+
+	if len(plan) == 0 {
+		fmt.Fprintln(out, "No triage decisions to plan.")
+		return nil
+	}
+	t := display.FromCommand(cmd).Term
+	cols := []display.Column{
+		{Header: "Vulnerability"}, {Header: "Package"}, {Header: "Status"},
+		{Header: "Action"}, {Header: "Decision"}, {Header: "SSVC"},
+	}
+	rows := make([][]string, 0, len(plan))
+	for _, e := range plan {
+		pkg := e.Package
+		if pkg != "" && e.InstalledVer != "" {
+			pkg += "@" + e.InstalledVer
+		}
+		rows = append(rows, []string{e.VulnID, orDash(pkg), e.Status, orDash(e.Action), orDash(e.Decision), e.SSVC})
+	}
+
+	var b strings.Builder
+	b.WriteString(display.Table(t, cols, rows))
+	b.WriteString("\n" + display.Bold(t, "Reasons") + "\n")
+	for _, e := range plan {
+		b.WriteString(e.VulnID + "\n")
+		for _, r := range e.Reasons {
+			b.WriteString("  - " + r + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "\n%d decision(s) planned; nothing was recorded. Re-run without --plan to record them locally, or with --apply to also record them in Vulnetix.\n", len(plan))
+	fmt.Fprint(out, b.String())
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
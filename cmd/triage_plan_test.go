@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/triage"
+)
+
+func testTriagePlan() []triage.PlanEntry {
+	return triage.BuildPlan([]*triage.TriageFinding{{
+		CVEID: "CVE-2021-44228", Package: "log4j-core", InstalledVer: "2.14.1", FixedVer: "2.17.1",
+		Status: "affected", ActionResponse: "will_fix", Severity: "critical", InKEV: true,
+	}})
+}
+
+func TestPrintTriagePlanTable(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, printTriagePlan(cmd, testTriagePlan(), false))
+
+	s := out.String()
+	assert.Contains(t, s, "CVE-2021-44228")
+	assert.Contains(t, s, "log4j-core@2.14.1")
+	assert.Contains(t, s, "Act")
+	assert.Contains(t, s, "  - fix available in 2.17.1")
+	assert.Contains(t, s, "1 decision(s) planned; nothing was recorded")
+}
+
+func TestPrintTriagePlanJSON(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, printTriagePlan(cmd, testTriagePlan(), true))
+
+	var got struct {
+		Decisions []triage.PlanEntry `json:"decisions"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got.Decisions, 1)
+	assert.Equal(t, "Act", got.Decisions[0].SSVC)
+	assert.Contains(t, got.Decisions[0].Reasons, "listed in a known exploited vulnerabilities catalogue")
+}
+
+func TestTriagePlanRequiresVulnetixProvider(t *testing.T) {
+	t.Cleanup(func() { triagePlan, triageProvider = false, "vulnetix" })
+	_, err := executeCommand(t, rootCmd, "triage", "--provider", "github", "--plan")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--plan and --apply are only supported with --provider vulnetix")
+}
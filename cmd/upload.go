@@ -1,17 +1,22 @@
 package cmd
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/vulnetix/cli/v3/internal/display"
 	"github.com/vulnetix/cli/v3/internal/upload"
 	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/cache"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
 )
 
 var (
@@ -21,8 +26,45 @@ var (
 	uploadBaseURL    string
 	uploadFormat     string
 	uploadOutputJSON bool
+	uploadTemplate   string
+	uploadBranches   []string
+
+	uploadSizeLimits      map[string]int64
+	uploadRejectOversized bool
+	uploadStrict          bool
+	uploadSchemaValidate  bool
+	uploadChunkSizeMiB    int
+	uploadConcurrency     int
+	uploadMetadataFile    string
+	uploadBaseDir         string
+	uploadResultFile      string
+	uploadTags            []string
+	uploadEnvironment     string
+	uploadLabel           string
+	uploadURL             string
+	uploadURLHeaders      []string
+	uploadName            string
+	uploadNoHistory       bool
+	uploadProduct         string
+	uploadEcosystem       string
+	uploadCallbackURL     string
+
+	// uploadMetadata is the parsed --metadata-file, loaded in PreRunE so a
+	// malformed file fails before anything is uploaded.
+	uploadMetadata map[string]any
 )
 
+// defaultUploadSizeLimits is the size, in MiB, past which a file is unusual
+// for its format. SBOMs of large monorepos legitimately run to hundreds of
+// MiB, whereas a SARIF or VEX document that big is more likely the wrong file.
+var defaultUploadSizeLimits = map[string]int64{
+	"cyclonedx": 250,
+	"spdx":      250,
+	"sarif":     20,
+	"openvex":   5,
+	"csaf_vex":  10,
+}
+
 var uploadCmd = &cobra.Command{
 	Use:   "upload",
 	Short: "Upload artifact files to Vulnetix",
@@ -32,7 +74,8 @@ By default, upload discovers all artifacts in the .vulnetix/ directory (project-
 first, then ~/.vulnetix/) and uploads each one after local schema validation.
 
 The file format is auto-detected from content and extension. CycloneDX files are
-validated against the embedded JSON schema before upload.
+validated against the embedded JSON schema before upload; --schema-validate does
+the same for SPDX, SARIF and OpenVEX files.
 
 Examples:
   # Upload all artifacts from .vulnetix/ (default)
@@ -41,6 +84,13 @@ Examples:
   # Upload a specific file
   vulnetix upload --file sbom.cdx.json
 
+  # Upload a file under a different name on the dashboard
+  vulnetix upload --file build/bom.cdx.json --name payments-api.cdx.json
+
+  # Download an artifact (e.g. a pre-signed S3 link) and upload it
+  vulnetix upload --url "$SBOM_URL"
+  vulnetix upload --url https://ci.example.com/sbom.cdx.json --url-header "Authorization: Bearer $CI_TOKEN"
+
   # Upload all artifacts from a custom directory
   vulnetix upload --dir /path/to/artifacts
 
@@ -48,19 +98,118 @@ Examples:
   vulnetix upload --file sbom.cdx.json --org-id UUID
 
   # JSON output
-  vulnetix upload --json`,
+  vulnetix upload --json
+
+  # Upload an SBOM and its VEX document, linked to each other
+  vulnetix upload --file sbom.cdx.json --vex vex.openvex.json
+
+  # Attach custom provenance (JSON or YAML) to the upload
+  vulnetix upload --file sbom.cdx.json --metadata-file provenance.yaml
+
+  # Only upload from protected branches (skipped with exit 0 elsewhere)
+  vulnetix upload --only-branches main,release/*
+
+  # Keyless upload from GitHub Actions (needs permissions: id-token: write)
+  vulnetix upload --file sbom.cdx.json --oidc
+
+  # Custom output via Go template (fields use the JSON output names)
+  vulnetix upload --file sbom.cdx.json --template '{{.pipelineRecord.uuid}} {{.pipelineRecord.detectedType | upper}}'`,
 	// Reject an unknown --format before reading the file or contacting the API.
 	// Previously the value was forwarded verbatim and only the server objected.
 	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, "upload"); err != nil {
+			return err
+		}
+		if uploadTemplate != "" {
+			if uploadOutputJSON {
+				return fmt.Errorf("--template and --json cannot be used together")
+			}
+			if _, err := parseOutputTemplate(uploadTemplate); err != nil {
+				return err
+			}
+		}
+		if err := validateBranchPatterns(uploadBranches); err != nil {
+			return err
+		}
+		for format, limit := range uploadSizeLimits {
+			if err := upload.ValidateFormat(format); err != nil {
+				return fmt.Errorf("--size-limit: %w", err)
+			}
+			if limit < 0 {
+				return fmt.Errorf("--size-limit %s must not be negative", format)
+			}
+		}
+		if uploadBaseDir != "" {
+			info, err := os.Stat(uploadBaseDir)
+			if err != nil {
+				return fmt.Errorf("--base-dir: %w", err)
+			}
+			if !info.IsDir() {
+				return fmt.Errorf("--base-dir %s is not a directory", uploadBaseDir)
+			}
+		}
+		if uploadChunkSizeMiB < 1 {
+			return fmt.Errorf("--chunk-size must be at least 1 MiB")
+		}
+		if uploadConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if uploadVEXFile != "" && uploadFile == "" {
+			return fmt.Errorf("--vex requires --file naming the SBOM it describes")
+		}
+		if uploadURL != "" && (uploadFile != "" || uploadDir != "") {
+			return fmt.Errorf("--url cannot be combined with --file or --dir")
+		}
+		if cmd.Flags().Changed("name") {
+			if uploadFile == "" && uploadURL == "" {
+				return fmt.Errorf("--name requires --file or --url")
+			}
+			if uploadVEXFile != "" {
+				return fmt.Errorf("--name cannot be combined with --vex")
+			}
+			name, err := upload.SanitizeFileName(uploadName)
+			if err != nil {
+				return fmt.Errorf("--name: %w", err)
+			}
+			uploadName = name
+		}
+		if len(uploadURLHeaders) > 0 {
+			if uploadURL == "" {
+				return fmt.Errorf("--url-header requires --url")
+			}
+			if _, err := upload.ParseFetchHeaders(uploadURLHeaders); err != nil {
+				return fmt.Errorf("--url-header: %w", err)
+			}
+		}
+		if uploadCallbackURL != "" {
+			if err := upload.ValidateCallbackURL(uploadCallbackURL); err != nil {
+				return fmt.Errorf("--callback-url: %w", err)
+			}
+		}
+		if auth.OIDCRequested() {
+			auth.OIDCBaseURL = uploadBaseURL
+		}
+		uploadMetadata = nil
+		if uploadMetadataFile != "" {
+			meta, err := upload.LoadMetadataFile(uploadPath(uploadMetadataFile))
+			if err != nil {
+				return fmt.Errorf("--metadata-file: %w", err)
+			}
+			uploadMetadata = meta
+		}
 		return upload.ValidateFormat(uploadFormat)
 	},
-	RunE: runUpload,
+	RunE: withResultFile(&uploadResultFile, runUpload),
 }
 
-func runUpload(cmd *cobra.Command, args []string) error {
+func runUpload(cmd *cobra.Command, args []string, rec *resultRecorder) error {
 	ctx := display.FromCommand(cmd)
 	t := ctx.Term
 
+	if skipForBranch(ctx, uploadBranches) {
+		return nil
+	}
+
 	// Load credentials
 	creds, err := auth.LoadCredentials()
 	if err != nil {
@@ -69,8 +218,8 @@ func runUpload(cmd *cobra.Command, args []string) error {
 
 	// Override org ID if provided
 	if uploadOrgID != "" {
-		if _, err := uuid.Parse(uploadOrgID); err != nil {
-			return fmt.Errorf("--org-id must be a valid UUID, got: %s", uploadOrgID)
+		if err := validateOrgID(uploadOrgID); err != nil {
+			return err
 		}
 		creds.OrgID = uploadOrgID
 	}
@@ -79,42 +228,93 @@ func runUpload(cmd *cobra.Command, args []string) error {
 	client := upload.NewClient(uploadBaseURL, creds)
 	env := envForCli()
 	client.CliEnv = &env
+	ecosystem := strings.TrimSpace(uploadEcosystem)
+	if ecosystem != "" {
+		if ecosystem, err = resolveUploadEcosystem(ctx, creds, ecosystem); err != nil {
+			return err
+		}
+	}
+	client.Metadata = upload.WithProductContext(uploadMetadata, strings.TrimSpace(uploadProduct), ecosystem)
+	client.ChunkSize = uploadChunkSizeMiB * 1024 * 1024
+	client.Concurrency = uploadConcurrency
+	client.Warn = ctx.Logger.Warn
+	client.SchemaValidate = uploadSchemaValidate
+	client.Finalize = uploadFinalizeMetadata()
+	client.FileName = uploadName
+	client.CallbackURL = uploadCallbackURL
 
-	// Single-file mode
-	if uploadFile != "" {
-		info, err := os.Stat(uploadFile)
+	// SBOM + VEX pair
+	if uploadVEXFile != "" {
+		return runUploadWithVEX(ctx, client, rec, uploadPath(uploadFile), uploadPath(uploadVEXFile))
+	}
+
+	// Single-file mode, from --file or downloaded from --url
+	var filePath string
+	if uploadURL != "" {
+		headers, _ := upload.ParseFetchHeaders(uploadURLHeaders)
+		ctx.Logger.Info("Downloading artifact from URL")
+		path, dir, err := upload.FetchURL(cmd.Context(), uploadURL, headers, upload.MaxFetchSize)
 		if err != nil {
-			return fmt.Errorf("cannot access file %s: %w", uploadFile, err)
+			return err
+		}
+		defer os.RemoveAll(dir)
+		filePath = path
+	} else if uploadFile != "" {
+		filePath = uploadPath(uploadFile)
+	}
+	if filePath != "" {
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return fmt.Errorf("cannot access file %s: %w", filePath, err)
+		}
+		format := uploadFormat
+		if format == "" {
+			format = sniffUploadFormat(filePath)
+		}
+		if format == "auto" {
+			if err := checkBinaryUpload(ctx, filePath); err != nil {
+				return err
+			}
+		}
+		if err := checkUploadSize(ctx, filePath, format, info.Size()); err != nil {
+			return err
 		}
 		total := 3
 		if info.Size() >= upload.ChunkThreshold {
-			total = int((info.Size()+upload.DefaultChunkSize-1)/upload.DefaultChunkSize) + 2
+			total = int((info.Size()+int64(client.ChunkSize)-1)/int64(client.ChunkSize)) + 2
 		}
+		ctx.Logger.Debugf("Uploading %s as %s (%d bytes, %d step(s)) to %s", filePath, format, info.Size(), total, client.BaseURL)
 		progress := ctx.Progress("Upload artifact", total)
-		progress.SetStage(fmt.Sprintf("Preparing %s (%d bytes)", filepath.Base(uploadFile), info.Size()))
+		progress.SetStage(fmt.Sprintf("Preparing %s (%d bytes)", filepath.Base(filePath), info.Size()))
 
-		result, err := client.UploadFileWithProgress(uploadFile, uploadFormat, func(done, total int, stage string) {
-			progress.Update(done, fmt.Sprintf("%s: %s", filepath.Base(uploadFile), stage))
+		started := time.Now()
+		result, err := client.UploadFileWithProgress(filePath, uploadFormat, func(done, total int, stage string) {
+			progress.SetTotal(total)
+			progress.Update(done, fmt.Sprintf("%s: %s", filepath.Base(filePath), stage))
 		})
+		rec.addUpload(filePath, format, started, result, err)
 		if err != nil {
 			progress.Fail("upload failed")
-			if vErr, ok := err.(*upload.CycloneDXValidationError); ok {
-				printValidationFailure(t, uploadFile, vErr, uploadOutputJSON)
+			if printUploadValidationError(ctx, filePath, err) {
 				return err
 			}
 			return fmt.Errorf("upload failed: %w", err)
 		}
 		progress.Complete("upload complete")
-		printUploadResult(t, uploadFile, result, uploadOutputJSON)
-		return nil
+		source := filePath
+		if uploadURL != "" {
+			source = upload.RedactURL(uploadURL)
+		}
+		recordUpload(ctx, source, format, creds.OrgID, result)
+		return printUploadResult(ctx, filePath, result, uploadOutputJSON)
 	}
 
 	// Discover artifacts from a directory
 	var discoverDir string
 	if uploadDir != "" {
-		discoverDir = uploadDir
+		discoverDir = uploadPath(uploadDir)
 	} else {
-		found, ok := upload.FindVulnetixDir()
+		found, ok := upload.FindVulnetixDirFrom(uploadPath("."))
 		if !ok {
 			ctx.Logger.Result(display.WarningMark(t) + " No .vulnetix/ directory found.\n" +
 				"Run 'vulnetix scan' to generate artifacts, then 'vulnetix upload'.\n" +
@@ -152,21 +352,33 @@ func runUpload(cmd *cobra.Command, args []string) error {
 			continue
 		}
 		fileName := filepath.Base(f.Path)
+		if err := checkUploadSize(ctx, f.Path, f.Format, info.Size()); err != nil {
+			progress.SetStage(fmt.Sprintf("Skipping %s: %v", fileName, err))
+			rec.add(resultItem{Name: fileName, File: f.Path, Format: f.Format, Status: "skipped", Error: err.Error()})
+			anyError = true
+			continue
+		}
 		progress.Update(i, fmt.Sprintf("Uploading %s (%d bytes, format: %s)", fileName, info.Size(), f.Format))
 
-		result, err := client.UploadFileWithProgress(f.Path, f.Format, func(done, total int, stage string) {
+		started := time.Now()
+		// Discovery's format is a detection, not a --format override, so the
+		// client may re-detect it if the server rejects it.
+		result, err := client.UploadFileWithProgress(f.Path, "", func(done, total int, stage string) {
 			progress.SetStage(fmt.Sprintf("%s: %s %d/%d", fileName, stage, done, total))
 		})
+		rec.addUpload(f.Path, f.Format, started, result, err)
 		if err != nil {
 			progress.SetStage(fmt.Sprintf("%s failed: %v", fileName, err))
-			if vErr, ok := err.(*upload.CycloneDXValidationError); ok {
-				printValidationFailure(t, f.Path, vErr, uploadOutputJSON)
-			}
+			printUploadValidationError(ctx, f.Path, err)
 			anyError = true
 			continue
 		}
 		progress.Update(i+1, fmt.Sprintf("Uploaded %s", fileName))
-		printUploadResult(t, f.Path, result, uploadOutputJSON)
+		recordUpload(ctx, f.Path, f.Format, creds.OrgID, result)
+		if err := printUploadResult(ctx, f.Path, result, uploadOutputJSON); err != nil {
+			progress.Fail("cannot render result")
+			return err
+		}
 	}
 
 	if anyError {
@@ -177,11 +389,146 @@ func runUpload(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printValidationFailure(t *display.Terminal, filePath string, result *upload.CycloneDXValidationError, asJSON bool) {
+// resolveUploadEcosystem checks --ecosystem against the VDB's list of
+// ecosystems and returns the VDB's spelling of it. When the list cannot be
+// fetched the value is kept as given, with a warning.
+func resolveUploadEcosystem(ctx *display.Context, creds *auth.Credentials, ecosystem string) (string, error) {
+	client := vdb.NewClientFromCredentials(creds)
+	if u := strings.TrimSpace(os.Getenv("VULNETIX_API_URL")); u != "" {
+		client.BaseURL = strings.TrimRight(u, "/")
+	}
+	if dc, err := cache.NewDiskCache(version); err == nil {
+		client.Cache = dc
+	}
+	ecosystems, err := client.GetEcosystems()
+	if err != nil || len(ecosystems) == 0 {
+		ctx.Logger.Warn(fmt.Sprintf("could not check --ecosystem %q against the VDB; sending it as given", ecosystem))
+		return ecosystem, nil
+	}
+	names := make([]string, len(ecosystems))
+	for i, e := range ecosystems {
+		if strings.EqualFold(e.Name, ecosystem) {
+			return e.Name, nil
+		}
+		names[i] = e.Name
+	}
+	return "", fmt.Errorf("unknown --ecosystem %q: must be one of %s", ecosystem, strings.Join(names, ", "))
+}
+
+// uploadPath resolves a relative artifact path against --base-dir, which
+// defaults to the current working directory.
+func uploadPath(path string) string {
+	if uploadBaseDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(uploadBaseDir, path)
+}
+
+// sniffUploadFormat detects a file's format from its name and leading bytes,
+// which is all DetectFormat looks at, without reading the whole file.
+func sniffUploadFormat(path string) string {
+	head, err := readUploadHead(path)
+	if err != nil {
+		return "auto"
+	}
+	return upload.DetectFormat(path, head)
+}
+
+// readUploadHead returns up to the first 2 KiB of path.
+func readUploadHead(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	head := make([]byte, 2048)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// looksBinary reports whether head is predominantly binary: it holds a NUL
+// byte, or more than a tenth of it is not valid UTF-8. A multi-byte rune cut
+// off at the end of head is not counted against it.
+func looksBinary(head []byte) bool {
+	if bytes.IndexByte(head, 0) >= 0 {
+		return true
+	}
+	invalid := 0
+	for i := 0; i < len(head); {
+		r, size := utf8.DecodeRune(head[i:])
+		if r == utf8.RuneError && size == 1 && utf8.FullRune(head[i:]) {
+			invalid++
+		}
+		i += size
+	}
+	return invalid*10 > len(head)
+}
+
+// checkBinaryUpload catches a --file that is not a security artifact at all,
+// such as a compiled binary: those are text, so binary content whose format
+// could not be detected is almost certainly the wrong file. Gzip input is
+// exempt because upload decompresses it. It warns, or fails with --strict.
+func checkBinaryUpload(ctx *display.Context, path string) error {
+	head, err := readUploadHead(path)
+	if err != nil || upload.IsGzip(head) || !looksBinary(head) {
+		return nil
+	}
+	msg := fmt.Sprintf("%s looks like a binary file, not an SBOM, SARIF or VEX document; check it is the intended artifact", filepath.Base(path))
+	if uploadStrict {
+		return fmt.Errorf("%s", msg)
+	}
+	ctx.Logger.Warn(msg)
+	return nil
+}
+
+// uploadSizeLimit returns the limit in bytes for format, preferring a
+// --size-limit override. Zero means unlimited.
+func uploadSizeLimit(format string) int64 {
+	mib, ok := uploadSizeLimits[format]
+	if !ok {
+		mib = defaultUploadSizeLimits[format]
+	}
+	return mib * 1024 * 1024
+}
+
+// checkUploadSize flags a file that is far larger than is typical for its
+// format, which usually means the wrong file was picked up. It warns, or
+// fails when --reject-oversized is set.
+func checkUploadSize(ctx *display.Context, path, format string, size int64) error {
+	limit := uploadSizeLimit(format)
+	if limit <= 0 || size <= limit {
+		return nil
+	}
+	msg := fmt.Sprintf("%s is %d MiB, larger than the %d MiB expected for a %s file; check it is the intended artifact (adjust with --size-limit %s=<MiB>)",
+		filepath.Base(path), size/(1024*1024), limit/(1024*1024), format, format)
+	if uploadRejectOversized {
+		return fmt.Errorf("%s", msg)
+	}
+	ctx.Logger.Warn(msg)
+	return nil
+}
+
+// printUploadValidationError prints err when it is a local schema validation
+// failure and reports whether it was one.
+func printUploadValidationError(ctx *display.Context, filePath string, err error) bool {
+	switch vErr := err.(type) {
+	case *upload.CycloneDXValidationError:
+		printValidationFailure(ctx, filePath, vErr, uploadOutputJSON)
+	case *upload.SchemaValidationError:
+		printSchemaValidationFailure(ctx, filePath, vErr, uploadOutputJSON)
+	default:
+		return false
+	}
+	return true
+}
+
+func printValidationFailure(ctx *display.Context, filePath string, result *upload.CycloneDXValidationError, asJSON bool) {
+	t := ctx.Term
 	if asJSON {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		_ = encoder.Encode(map[string]any{
+		_ = ctx.Logger.ResultJSON(map[string]any{
 			"ok":          false,
 			"file":        filePath,
 			"specVersion": result.SpecVersion,
@@ -203,16 +550,23 @@ func printValidationFailure(t *display.Terminal, filePath string, result *upload
 		}
 		b.WriteString(fmt.Sprintf("  %s: %s\n", path, v.Message))
 	}
-	fmt.Print(b.String())
+	ctx.Logger.Result(strings.TrimSuffix(b.String(), "\n"))
 }
 
-func printUploadResult(t *display.Terminal, filePath string, result *upload.FinalizeResponse, asJSON bool) {
+// printUploadResult prints the result of uploading filePath. The upload has
+// already succeeded, so an error means only that the result could not be
+// rendered, e.g. a --template referring to a function that fails.
+func printUploadResult(ctx *display.Context, filePath string, result *upload.FinalizeResponse, asJSON bool) error {
+	if uploadTemplate != "" {
+		if err := renderOutputTemplate(os.Stdout, uploadTemplate, result); err != nil {
+			return fmt.Errorf("%s uploaded, but rendering --template failed: %w", filepath.Base(filePath), err)
+		}
+		return nil
+	}
 	if asJSON {
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		_ = encoder.Encode(result)
-		return
+		return ctx.Logger.ResultJSON(result)
 	}
+	t := ctx.Term
 
 	var b strings.Builder
 	if result.IsDuplicate {
@@ -227,18 +581,60 @@ func printUploadResult(t *display.Terminal, filePath string, result *upload.Fina
 			{Key: "Status", Value: result.PipelineRecord.ProcessingState},
 		}))
 	}
-	fmt.Print(b.String())
+	ctx.Logger.Result(strings.TrimSuffix(b.String(), "\n"))
+	return nil
+}
+
+// uploadFinalizeMetadata collects --tag, --environment and --label, or nil
+// when none were given.
+func uploadFinalizeMetadata() *upload.FinalizeMetadata {
+	meta := &upload.FinalizeMetadata{Environment: strings.TrimSpace(uploadEnvironment), Label: strings.TrimSpace(uploadLabel)}
+	for _, tag := range uploadTags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(meta.Tags, tag) {
+			meta.Tags = append(meta.Tags, tag)
+		}
+	}
+	if meta.IsZero() {
+		return nil
+	}
+	return meta
 }
 
 func init() {
 	uploadCmd.Flags().StringVar(&uploadFile, "file", "", "Path to a specific artifact file to upload")
+	uploadCmd.Flags().StringVar(&uploadURL, "url", "", "Download the artifact from this http(s) URL (e.g. a pre-signed link) and upload it")
+	uploadCmd.Flags().StringArrayVar(&uploadURLHeaders, "url-header", nil, "Header sent when downloading --url, as \"Name: value\" (repeatable)")
+	uploadCmd.Flags().StringVar(&uploadName, "name", "", "Name the artifact is uploaded under, shown on the dashboard (default: the file's base name)")
+	uploadCmd.Flags().StringVar(&uploadVEXFile, "vex", "", "VEX document to upload with the --file SBOM, linked to it")
 	uploadCmd.Flags().StringVar(&uploadDir, "dir", "", "Directory to scan for artifacts (overrides .vulnetix/ discovery)")
+	uploadCmd.Flags().StringVar(&uploadBaseDir, "base-dir", "", "Directory relative artifact paths are resolved against (default: current directory)")
 	uploadCmd.Flags().StringVar(&uploadOrgID, "org-id", "", "Organization ID (UUID, uses stored credentials if not set)")
 	uploadCmd.Flags().StringVar(&uploadBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
-	uploadCmd.Flags().StringVar(&uploadFormat, "format", "", "Override auto-detected format (cyclonedx, spdx, sarif, openvex, csaf_vex)")
+	uploadCmd.Flags().StringVar(&uploadFormat, "format", "", "Override auto-detected format ("+strings.Join(upload.SupportedFormats, ", ")+")")
 	uploadCmd.Flags().BoolVar(&uploadOutputJSON, "json", false, "Output result as JSON")
-	_ = uploadCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"cyclonedx", "spdx", "sarif", "openvex", "csaf_vex"}, cobra.ShellCompDirectiveNoFileComp))
+	uploadCmd.Flags().StringVar(&uploadResultFile, "result-file", "", "Write a JSON summary of the uploads (files, pipeline UUIDs, statuses, timings) to this path")
+	uploadCmd.Flags().StringVar(&uploadTemplate, "template", "", "Render each result through a Go text/template (helpers: json, upper, lower, default)")
+	uploadCmd.Flags().StringSliceVar(&uploadBranches, "only-branches", nil, "Only upload when the CI branch matches one of these globs (e.g. main,release/*)")
+	uploadCmd.Flags().StringToInt64Var(&uploadSizeLimits, "size-limit", nil, "Per-format size limit in MiB, overriding the defaults (e.g. sarif=50,cyclonedx=500; 0 disables)")
+	uploadCmd.Flags().BoolVar(&uploadRejectOversized, "reject-oversized", false, "Fail instead of warning when a file exceeds its format's size limit")
+	uploadCmd.Flags().IntVar(&uploadChunkSizeMiB, "chunk-size", upload.DefaultChunkSize/(1024*1024), "Chunk size in MiB for files uploaded in chunks (lowered to the server's maximum)")
+	uploadCmd.Flags().IntVar(&uploadConcurrency, "concurrency", 1, "Chunks uploaded in parallel (lowered to the server's maximum)")
+	uploadCmd.Flags().BoolVar(&uploadSchemaValidate, "schema-validate", false, "Also check SPDX, SARIF and OpenVEX files against their official schemas before upload (CycloneDX is always checked)")
+	uploadCmd.Flags().BoolVar(&uploadStrict, "strict", false, "Fail instead of warning when --file looks like a binary rather than a security artifact")
+	_ = uploadCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(upload.SupportedFormats, cobra.ShellCompDirectiveNoFileComp))
+	uploadCmd.Flags().StringSliceVar(&uploadTags, "tag", nil, "Tag the uploaded artifacts on the dashboard (repeatable or comma-separated)")
+	uploadCmd.Flags().StringVar(&uploadEnvironment, "environment", "", "Environment the artifacts belong to (e.g. prod, staging)")
+	uploadCmd.Flags().StringVar(&uploadLabel, "label", "", "Free-form label shown with the uploaded artifacts")
+	uploadCmd.Flags().BoolVar(&uploadNoHistory, "no-history", false, "Do not record the uploads in the local history (~/.vulnetix/history.jsonl)")
+	uploadCmd.Flags().StringVar(&uploadProduct, "product", "", "Product the artifact represents, for SBOMs not tied to a repository")
+	uploadCmd.Flags().StringVar(&uploadEcosystem, "ecosystem", "", "Ecosystem the artifact represents (e.g. npm, maven), checked against the VDB's list")
+	uploadCmd.Flags().StringVar(&uploadCallbackURL, "callback-url", "", "HTTPS URL the server notifies when processing of the upload completes")
+	uploadCmd.Flags().BoolVar(&auth.UseOIDC, "oidc", false, "Authenticate with the GitHub Actions OIDC token of this job instead of stored credentials (needs permissions: id-token: write)")
+	uploadCmd.Flags().StringVar(&uploadMetadataFile, "metadata-file", "", "JSON or YAML file of custom provenance (build args, commit signer, ...) to attach to each upload")
 	_ = uploadCmd.MarkFlagFilename("file")
+	_ = uploadCmd.MarkFlagFilename("vex")
+	_ = uploadCmd.MarkFlagDirname("base-dir")
+	_ = uploadCmd.MarkFlagFilename("metadata-file", "json", "yaml", "yml")
 
 	rootCmd.AddCommand(uploadCmd)
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+// upload reprocess flags
+var (
+	reprocessUUID       string
+	reprocessFormat     string
+	reprocessOrgID      string
+	reprocessBaseURL    string
+	reprocessOutputJSON bool
+)
+
+var uploadReprocessCmd = &cobra.Command{
+	Use:   "reprocess",
+	Short: "Re-process an uploaded artifact as a different format",
+	Long: `Ask Vulnetix to process an already uploaded artifact again using the given
+format. The stored file is reused, so nothing is uploaded; use this to correct an
+artifact that was detected or forced as the wrong format.
+
+Examples:
+  vulnetix upload reprocess --uuid 6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718 --format spdx
+  vulnetix upload reprocess --uuid 6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718 --format sarif --json`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if reprocessUUID == "" {
+			return fmt.Errorf("--uuid is required")
+		}
+		if _, err := uuid.Parse(reprocessUUID); err != nil {
+			return fmt.Errorf("--uuid must be a valid pipeline UUID, got: %s", reprocessUUID)
+		}
+		if reprocessFormat == "" {
+			return fmt.Errorf("--format is required")
+		}
+		return upload.ValidateFormat(reprocessFormat)
+	},
+	RunE: runUploadReprocess,
+}
+
+func runUploadReprocess(cmd *cobra.Command, args []string) error {
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	creds, err := auth.LoadCredentials()
+	if err != nil {
+		return fmt.Errorf("authentication required: %w\nRun 'vulnetix auth login' to authenticate", err)
+	}
+	if reprocessOrgID != "" {
+		if err := validateOrgID(reprocessOrgID); err != nil {
+			return err
+		}
+		creds.OrgID = reprocessOrgID
+	}
+
+	client := upload.NewClient(reprocessBaseURL, creds)
+	result, err := client.ReprocessPipeline(reprocessUUID, reprocessFormat)
+	if err != nil {
+		return err
+	}
+
+	if reprocessOutputJSON {
+		return ctx.Logger.ResultJSON(result)
+	}
+	ctx.Logger.Result(display.CheckMark(t) + " " + display.Bold(t, reprocessUUID) + " — queued for re-processing as " + reprocessFormat)
+	if result.PipelineRecord != nil {
+		ctx.Logger.Result(strings.TrimSuffix(display.KeyValue(t, []display.KVPair{
+			{Key: "Detected Type", Value: result.PipelineRecord.DetectedType},
+			{Key: "Status", Value: result.PipelineRecord.ProcessingState},
+		}), "\n"))
+	}
+	return nil
+}
+
+func init() {
+	uploadReprocessCmd.Flags().StringVar(&reprocessUUID, "uuid", "", "Pipeline record UUID to re-process (required)")
+	uploadReprocessCmd.Flags().StringVar(&reprocessFormat, "format", "", "Format to process the artifact as ("+strings.Join(upload.SupportedFormats, ", ")+")")
+	uploadReprocessCmd.Flags().StringVar(&reprocessOrgID, "org-id", "", "Organization ID (UUID, uses stored credentials if not set)")
+	uploadReprocessCmd.Flags().StringVar(&reprocessBaseURL, "base-url", upload.DefaultBaseURL, "Base URL for Vulnetix API")
+	uploadReprocessCmd.Flags().BoolVar(&reprocessOutputJSON, "json", false, "Output result as JSON")
+	_ = uploadReprocessCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(upload.SupportedFormats, cobra.ShellCompDirectiveNoFileComp))
+
+	uploadCmd.AddCommand(uploadReprocessCmd)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func resetReprocessFlags(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		reprocessUUID, reprocessFormat, reprocessOrgID, reprocessBaseURL = "", "", "", upload.DefaultBaseURL
+		reprocessOutputJSON = false
+	})
+}
+
+func TestUploadReprocess(t *testing.T) {
+	resetReprocessFlags(t)
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","processingState":"queued"}}`)
+	}))
+	defer server.Close()
+
+	output, err := executeCommand(t, rootCmd, "upload", "reprocess",
+		"--uuid", "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718",
+		"--format", "spdx",
+		"--base-url", server.URL+"/v1",
+		"--json",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/uploads/reprocess/6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", gotPath)
+	assert.Contains(t, output, `"processingState": "queued"`)
+}
+
+func TestUploadReprocessOutput(t *testing.T) {
+	resetReprocessFlags(t)
+	t.Cleanup(func() {
+		silent, verbose = false, false
+		_ = rootCmd.PersistentFlags().Set("verbosity", "2")
+		rootCmd.PersistentFlags().Lookup("verbosity").Changed = false
+	})
+	body := `{"ok":true,"pipelineRecord":{"uuid":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","processingState":"queued"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body)
+	}))
+	defer server.Close()
+	args := []string{"upload", "reprocess", "--uuid", "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", "--format", "spdx", "--base-url", server.URL + "/v1"}
+
+	out, err := executeCommand(t, rootCmd, args...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "queued for re-processing as spdx")
+
+	out, err = executeCommand(t, rootCmd, append(args, "--verbosity", "0")...)
+	require.NoError(t, err)
+	assert.NotContains(t, out, "queued for re-processing", "--verbosity 0 hides the result")
+
+	_ = rootCmd.PersistentFlags().Set("verbosity", "2")
+	body = `{"ok":false,"error":"format not supported for this artifact"}`
+	_, err = executeCommand(t, rootCmd, args...)
+	require.Error(t, err)
+	assert.Equal(t, "reprocess failed: format not supported for this artifact", err.Error())
+}
+
+func TestUploadReprocess_ValidatesInput(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"missing uuid", []string{"--format", "spdx"}, "--uuid is required"},
+		{"invalid uuid", []string{"--uuid", "not-a-uuid", "--format", "spdx"}, "valid pipeline UUID"},
+		{"missing format", []string{"--uuid", "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718"}, "--format is required"},
+		{"unknown format", []string{"--uuid", "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", "--format", "pdf"}, "unsupported format"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resetReprocessFlags(t)
+			_, err := executeCommand(t, rootCmd, append([]string{"upload", "reprocess"}, tc.args...)...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
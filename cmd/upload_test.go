@@ -0,0 +1,520 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func resetUploadFlags(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("file", "")
+		_ = uploadCmd.Flags().Set("base-url", upload.DefaultBaseURL)
+		_ = uploadCmd.Flags().Set("json", "false")
+		_ = uploadCmd.Flags().Set("reject-oversized", "false")
+		_ = uploadCmd.Flags().Set("base-dir", "")
+		_ = uploadCmd.Flags().Set("dir", "")
+		_ = uploadCmd.Flags().Set("vex", "")
+		_ = uploadCmd.Flags().Set("strict", "false")
+		_ = uploadCmd.Flags().Set("schema-validate", "false")
+		_ = uploadCmd.Flags().Set("result-file", "")
+		_ = uploadCmd.Flags().Set("environment", "")
+		_ = uploadCmd.Flags().Set("label", "")
+		_ = uploadCmd.Flags().Set("no-history", "false")
+		_ = uploadCmd.Flags().Set("callback-url", "")
+		_ = uploadCmd.Flags().Set("oidc", "false")
+		uploadTags = nil
+		_ = uploadCmd.Flags().Set("chunk-size", "5")
+		_ = uploadCmd.Flags().Set("concurrency", "1")
+		// pflag merges into a map flag once it has been set; start fresh.
+		uploadSizeLimits = map[string]int64{}
+		uploadCmd.Flags().Lookup("size-limit").Changed = false
+	})
+}
+
+func TestCheckUploadSize(t *testing.T) {
+	t.Cleanup(func() { uploadRejectOversized = false })
+	ctx := display.New(display.ModeText, true)
+	const mib = 1024 * 1024
+
+	uploadRejectOversized = true
+	err := checkUploadSize(ctx, "results.sarif", "sarif", 50*mib)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "larger than the 20 MiB expected for a sarif file")
+
+	assert.NoError(t, checkUploadSize(ctx, "bom.cdx.json", "cyclonedx", 50*mib), "a 50 MiB SBOM is normal")
+	assert.NoError(t, checkUploadSize(ctx, "unknown.bin", "auto", 900*mib), "unknown formats have no limit")
+
+	uploadRejectOversized = false
+	assert.NoError(t, checkUploadSize(ctx, "results.sarif", "sarif", 50*mib), "oversized files only warn by default")
+}
+
+func TestUploadRejectsOversizedSARIF(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "results.sarif")
+	require.NoError(t, os.WriteFile(path, make([]byte, 2*1024*1024), 0644))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload",
+		"--file", path,
+		"--base-url", server.URL+"/v1",
+		"--size-limit", "sarif=1",
+		"--reject-oversized",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "results.sarif is 2 MiB, larger than the 1 MiB expected for a sarif file")
+	assert.Zero(t, requests, "an oversized file must be rejected before contacting the API")
+}
+
+func TestUploadAcceptsNormalSizedSBOM(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "upload",
+		"--file", path,
+		"--base-url", server.URL+"/v1",
+		"--reject-oversized",
+		"--json",
+	)
+	require.NoError(t, err)
+	assert.Contains(t, out, `"uuid": "p-1"`)
+}
+
+func TestUploadResolvesPathsUnderBaseDir(t *testing.T) {
+	resetUploadFlags(t)
+	base := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(base, "bom.cdx.json"), []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(base, ".vulnetix"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, ".vulnetix", "results.sarif"), []byte(`{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[]}`), 0644))
+
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	// Single file, named relative to --base-dir rather than the CWD.
+	_, err := executeCommand(t, rootCmd, "upload", "--base-dir", base, "--file", "bom.cdx.json", "--base-url", server.URL+"/v1", "--json")
+	require.NoError(t, err)
+	assert.Equal(t, 1, uploads)
+
+	// .vulnetix/ discovery starts from --base-dir too.
+	_, err = executeCommand(t, rootCmd, "upload", "--base-dir", base, "--base-url", server.URL+"/v1", "--json")
+	require.NoError(t, err)
+	assert.Equal(t, 2, uploads)
+}
+
+func TestUploadRejectsMissingBaseDir(t *testing.T) {
+	resetUploadFlags(t)
+	_, err := executeCommand(t, rootCmd, "upload", "--base-dir", filepath.Join(t.TempDir(), "missing"), "--file", "bom.cdx.json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--base-dir")
+}
+
+func TestUploadRejectsUnknownSizeLimitFormat(t *testing.T) {
+	resetUploadFlags(t)
+	_, err := executeCommand(t, rootCmd, "upload", "--size-limit", "pdf=10")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--size-limit")
+}
+
+func TestUploadMergesMetadataFile(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() { _ = uploadCmd.Flags().Set("metadata-file", "") })
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	metaPath := filepath.Join(dir, "provenance.yaml")
+	require.NoError(t, os.WriteFile(metaPath, []byte("signer: alice@example.com\nbuildArgs:\n  GOOS: linux\n"), 0644))
+
+	var metadata string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metadata = r.FormValue("metadata")
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload",
+		"--file", path,
+		"--base-url", server.URL+"/v1",
+		"--metadata-file", metaPath,
+		"--json",
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"signer":"alice@example.com","buildArgs":{"GOOS":"linux"}}`, metadata)
+}
+
+func TestUploadRejectsInvalidMetadataFile(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() { _ = uploadCmd.Flags().Set("metadata-file", "") })
+	metaPath := filepath.Join(t.TempDir(), "provenance.json")
+	require.NoError(t, os.WriteFile(metaPath, []byte(`["not","a","mapping"]`), 0644))
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", "missing.json", "--metadata-file", metaPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--metadata-file")
+}
+
+func TestLooksBinary(t *testing.T) {
+	assert.False(t, looksBinary([]byte(`{"bomFormat":"CycloneDX","metadata":{"component":{"name":"café"}}}`)))
+	assert.False(t, looksBinary([]byte("name: caf\xc3")), "a rune cut off at the end is not binary")
+	assert.True(t, looksBinary([]byte("\x7fELF\x02\x01\x01\x00\x00\x00")), "NUL bytes")
+	assert.True(t, looksBinary([]byte{0xff, 0xfe, 0xfd, 'a', 'b', 0xc0, 0xc1, 'c'}), "mostly invalid UTF-8")
+}
+
+func TestUploadBlocksBinaryFileUnderStrict(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.WriteFile(path, append([]byte("\x7fELF\x02\x01\x01"), make([]byte, 512)...), 0755))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--strict")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app looks like a binary file")
+	assert.Zero(t, requests)
+}
+
+func TestUploadTextSBOMPassesBinaryCheckUnderStrict(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"components":[{"name":"left-pad"}]}`), 0644))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--strict")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestUploadSchemaValidateBlocksInvalidSARIF(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":"2.1.0","runs":[{"results":[]}]}`), 0644))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1")
+	require.NoError(t, err, "without --schema-validate the server decides")
+	assert.Equal(t, 1, requests)
+
+	_, err = executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--schema-validate")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sarif schema validation failed at /runs/0: missing property 'tool'")
+	assert.Equal(t, 1, requests, "an invalid file must not be sent")
+}
+
+func TestUploadSendsFinalizeMetadata(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+
+	var tags, environment, label string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tags, environment, label = r.FormValue("tags"), r.FormValue("environment"), r.FormValue("label")
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1",
+		"--tag", "release", "--tag", "v2,release", "--environment", "prod", "--label", "nightly build")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["release","v2"]`, tags)
+	assert.Equal(t, "prod", environment)
+	assert.Equal(t, "nightly build", label)
+}
+
+func TestUploadFromURL(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("url", "")
+		uploadURLHeaders = nil
+	})
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`
+
+	var gotAuth string
+	artifact := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = io.WriteString(w, sbom)
+	}))
+	defer artifact.Close()
+
+	var uploaded, format string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if file, header, err := r.FormFile("file"); err == nil {
+			data, _ := io.ReadAll(file)
+			uploaded, format = header.Filename+":"+string(data), r.FormValue("format")
+		}
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer api.Close()
+
+	out, err := executeCommand(t, rootCmd, "upload",
+		"--url", artifact.URL+"/sbom.cdx.json?sig=abc",
+		"--url-header", "Authorization: Bearer ci-token",
+		"--base-url", api.URL+"/v1",
+		"--json",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ci-token", gotAuth)
+	assert.Equal(t, "sbom.cdx.json:"+sbom, uploaded)
+	assert.Equal(t, "cyclonedx", format)
+	assert.Contains(t, out, `"uuid": "p-1"`)
+}
+
+func TestUploadURLFlagValidation(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("url", "")
+		uploadURLHeaders = nil
+	})
+	_, err := executeCommand(t, rootCmd, "upload", "--url", "https://example.com/bom.json", "--file", "bom.json")
+	assert.ErrorContains(t, err, "--url cannot be combined with --file or --dir")
+
+	_ = uploadCmd.Flags().Set("file", "")
+	_, err = executeCommand(t, rootCmd, "upload", "--url", "https://example.com/bom.json", "--url-header", "broken")
+	assert.ErrorContains(t, err, `--url-header: header "broken" must be in the form`)
+}
+
+func TestUploadNameReachesInitiateRequest(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("name", "")
+		uploadCmd.Flags().Lookup("name").Changed = false
+	})
+
+	// Pad past the chunking threshold so the upload starts a session.
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]` + strings.Repeat(" ", upload.ChunkThreshold) + `}`
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(sbom), 0644))
+
+	var initiated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/uploads/initiate"):
+			var body struct {
+				FileName string `json:"fileName"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			initiated = body.FileName
+			_, _ = io.WriteString(w, `{"ok":true,"uploadSessionId":"s1"}`)
+		case strings.Contains(r.URL.Path, "/uploads/chunk/"):
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		default:
+			_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+		}
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1",
+		"--name", " services/payments-api.cdx.json ")
+	require.NoError(t, err)
+	assert.Equal(t, "services_payments-api.cdx.json", initiated)
+}
+
+func TestUploadNameValidation(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("name", "")
+		uploadCmd.Flags().Lookup("name").Changed = false
+	})
+	_, err := executeCommand(t, rootCmd, "upload", "--name", "sbom")
+	assert.ErrorContains(t, err, "--name requires --file or --url")
+
+	_, err = executeCommand(t, rootCmd, "upload", "--file", "bom.json", "--name", "  ")
+	assert.ErrorContains(t, err, "--name: artifact name must not be empty")
+}
+
+func TestUploadTemplateErrorFailsCommand(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() { uploadTemplate = "" })
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--no-history", "--template", "{{.pipelineRecord.uuid}}")
+	require.NoError(t, err)
+	assert.Contains(t, out, "p-1")
+
+	_, err = executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--no-history", "--template", "{{index .pipelineRecord 1}}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rendering --template failed")
+}
+
+func TestUploadVerbosityLevels(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = rootCmd.PersistentFlags().Set("verbosity", "2")
+		rootCmd.PersistentFlags().Lookup("verbosity").Changed = false
+		silent, verbose = false, false
+	})
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		level                string
+		result, steps, debug bool
+	}{
+		{level: "0"},
+		{level: "1", result: true},
+		{level: "2", result: true, steps: true},
+		{level: "3", result: true, steps: true, debug: true},
+	}
+	for _, tc := range tests {
+		t.Run("verbosity "+tc.level, func(t *testing.T) {
+			out, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--no-history", "--no-progress=false", "--verbosity", tc.level)
+			require.NoError(t, err)
+			assert.Equal(t, tc.result, strings.Contains(out, "uploaded successfully"), "result line")
+			assert.Equal(t, tc.steps, strings.Contains(out, "upload complete"), "progress steps")
+			assert.Equal(t, tc.debug, strings.Contains(out, "Uploading "+path+" as cyclonedx"), "debug line")
+		})
+	}
+
+	// JSON is asked for explicitly, so even level 0 prints it.
+	out, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--no-history", "--verbosity", "0", "--json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"uuid": "p-1"`)
+
+	_, err = executeCommand(t, rootCmd, "upload", "--file", path, "--verbosity", "4")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "verbosity must be 0")
+}
+
+func TestUploadProductContextReachesInitiateRequest(t *testing.T) {
+	resetUploadFlags(t)
+	t.Cleanup(func() {
+		_ = uploadCmd.Flags().Set("product", "")
+		_ = uploadCmd.Flags().Set("ecosystem", "")
+	})
+
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]` + strings.Repeat(" ", upload.ChunkThreshold) + `}`
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(sbom), 0644))
+
+	var metadata map[string]any
+	initiated := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ecosystems"):
+			_, _ = io.WriteString(w, `{"ecosystems":[{"name":"npm","count":10},{"name":"Maven","count":5}]}`)
+		case strings.HasSuffix(r.URL.Path, "/uploads/initiate"):
+			initiated++
+			var body struct {
+				Metadata map[string]any `json:"metadata"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			metadata = body.Metadata
+			_, _ = io.WriteString(w, `{"ok":true,"uploadSessionId":"s1"}`)
+		case strings.Contains(r.URL.Path, "/uploads/chunk/"):
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		default:
+			_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("VULNETIX_API_URL", server.URL)
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1",
+		"--product", "payments-api", "--ecosystem", "maven")
+	require.NoError(t, err)
+	assert.Equal(t, "payments-api", metadata["product"])
+	assert.Equal(t, "Maven", metadata["ecosystem"], "the VDB's spelling is sent")
+
+	_, err = executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1",
+		"--ecosystem", "cobol")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown --ecosystem "cobol"`)
+	assert.Equal(t, 1, initiated, "an invalid ecosystem must stop the upload before it starts")
+}
+
+func TestUploadRejectsInsecureCallbackURL(t *testing.T) {
+	resetUploadFlags(t)
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6"}`), 0644))
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL,
+		"--callback-url", "http://listener.example.com/done")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--callback-url")
+	assert.Zero(t, requests, "nothing is uploaded with an invalid callback URL")
+}
+
+func TestUploadWithOIDC(t *testing.T) {
+	resetUploadFlags(t)
+	t.Setenv("VULNETIX_API_TOKEN", "")
+	t.Cleanup(func() { auth.OIDCBaseURL = upload.DefaultBaseURL })
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+
+	var uploadAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gh-token":
+			_, _ = io.WriteString(w, `{"value":"github-id-token"}`)
+		case "/v1/auth/oidc/github":
+			_, _ = io.WriteString(w, `{"token":"short-lived","orgId":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","expiresAt":"`+time.Now().Add(time.Hour).UTC().Format(time.RFC3339)+`"}`)
+		default:
+			uploadAuth = r.Header.Get("Authorization")
+			_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", server.URL+"/gh-token")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", path, "--base-url", server.URL+"/v1", "--oidc")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer short-lived", uploadAuth)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// uploadVEXFile is the --vex document uploaded alongside --file.
+var uploadVEXFile string
+
+var (
+	sbomFormats = []string{"cyclonedx", "spdx"}
+	vexFormats  = []string{"openvex", "csaf_vex", "cyclonedx"}
+)
+
+// linkedArtifact is one side of an `upload --file SBOM --vex VEX` pair.
+type linkedArtifact struct {
+	role   string
+	path   string
+	format string
+	size   int64
+}
+
+// runUploadWithVEX uploads an SBOM and its VEX document as a linked pair.
+// Both are checked before anything is sent; the SBOM goes first so the VEX
+// upload can reference the pipeline record it created. When the VEX upload
+// fails the SBOM's pipeline record is deleted, so the pair lands together or
+// not at all.
+func runUploadWithVEX(ctx *display.Context, client *upload.Client, rec *resultRecorder, sbomPath, vexPath string) error {
+	sbom, err := inspectLinkedArtifact("sbom", sbomPath, uploadFormat, sbomFormats)
+	if err != nil {
+		return err
+	}
+	vex, err := inspectLinkedArtifact("vex", vexPath, "", vexFormats)
+	if err != nil {
+		return err
+	}
+	for _, a := range []linkedArtifact{sbom, vex} {
+		if err := checkUploadSize(ctx, a.path, a.format, a.size); err != nil {
+			return err
+		}
+	}
+
+	link := upload.ArtifactLink{
+		ID:   uuid.NewString(),
+		SBOM: filepath.Base(sbomPath),
+		VEX:  filepath.Base(vexPath),
+	}
+	baseMetadata := client.Metadata
+	defer func() { client.Metadata = baseMetadata }()
+
+	progress := ctx.Progress("Upload SBOM and VEX", 2)
+	var sbomPipeline string
+	results := make([]*upload.FinalizeResponse, 0, 2)
+	for i, a := range []linkedArtifact{sbom, vex} {
+		link.Role = a.role
+		link.SBOMPipelineUUID = sbomPipeline
+		client.Metadata = upload.WithLink(baseMetadata, link)
+
+		fileName := filepath.Base(a.path)
+		progress.Update(i, fmt.Sprintf("Uploading %s (%d bytes, format: %s)", fileName, a.size, a.format))
+		started := time.Now()
+		result, err := client.UploadFileWithProgress(a.path, a.format, func(done, total int, stage string) {
+			progress.SetStage(fmt.Sprintf("%s: %s %d/%d", fileName, stage, done, total))
+		})
+		rec.addUpload(a.path, a.format, started, result, err)
+		if err != nil {
+			progress.Fail(fmt.Sprintf("%s upload failed", a.role))
+			validation := printUploadValidationError(ctx, a.path, err)
+			if sbomPipeline != "" {
+				if derr := client.DeletePipeline(sbomPipeline); derr != nil {
+					return fmt.Errorf("VEX upload failed: %w; removing the uploaded SBOM (pipeline %s) also failed: %v", err, sbomPipeline, derr)
+				}
+				return fmt.Errorf("VEX upload failed, so the uploaded SBOM (pipeline %s) was removed: %w", sbomPipeline, err)
+			}
+			if validation {
+				return err
+			}
+			return fmt.Errorf("upload failed: %w", err)
+		}
+		if a.role == "sbom" && result.PipelineRecord != nil {
+			sbomPipeline = result.PipelineRecord.UUID
+		}
+		progress.Update(i+1, fmt.Sprintf("Uploaded %s", fileName))
+		results = append(results, result)
+	}
+	progress.Complete(fmt.Sprintf("SBOM and VEX uploaded (link %s)", link.ID))
+	for i, a := range []linkedArtifact{sbom, vex} {
+		recordUpload(ctx, a.path, a.format, client.Creds.OrgID, results[i])
+		if err := printUploadResult(ctx, a.path, results[i], uploadOutputJSON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectLinkedArtifact stats path, detects its format unless override is
+// set, and checks the format is one of allowed.
+func inspectLinkedArtifact(role, path, override string, allowed []string) (linkedArtifact, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return linkedArtifact{}, fmt.Errorf("cannot access file %s: %w", path, err)
+	}
+	format := override
+	if format == "" {
+		format = sniffUploadFormat(path)
+	}
+	if !slices.Contains(allowed, format) {
+		return linkedArtifact{}, fmt.Errorf("%s is detected as %s, but a linked %s must be one of: %s",
+			filepath.Base(path), format, strings.ToUpper(role), strings.Join(allowed, ", "))
+	}
+	return linkedArtifact{role: role, path: path, format: format, size: info.Size()}, nil
+}
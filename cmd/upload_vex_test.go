@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+const testOpenVEX = `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"https://example.com/vex-1","statements":[]}`
+
+func writeLinkedPair(t *testing.T) (sbom, vex string) {
+	t.Helper()
+	dir := t.TempDir()
+	sbom = filepath.Join(dir, "bom.cdx.json")
+	vex = filepath.Join(dir, "statements.json")
+	require.NoError(t, os.WriteFile(sbom, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	require.NoError(t, os.WriteFile(vex, []byte(testOpenVEX), 0644))
+	return sbom, vex
+}
+
+func TestUploadLinksVEXToSBOM(t *testing.T) {
+	resetUploadFlags(t)
+	sbom, vex := writeLinkedPair(t)
+
+	type received struct {
+		file, format string
+		link         upload.ArtifactLink
+	}
+	var got []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hdr, err := r.FormFile("file")
+		require.NoError(t, err)
+		var meta struct {
+			Link upload.ArtifactLink `json:"artifactLink"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(r.FormValue("metadata")), &meta))
+		got = append(got, received{hdr.Filename, r.FormValue("format"), meta.Link})
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"ok":true,"pipelineRecord":{"uuid":"p-%d"}}`, len(got)))
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", sbom, "--vex", vex, "--base-url", server.URL+"/v1")
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "bom.cdx.json", got[0].file)
+	assert.Equal(t, "cyclonedx", got[0].format)
+	assert.Equal(t, "statements.json", got[1].file)
+	assert.Equal(t, "openvex", got[1].format)
+
+	assert.NotEmpty(t, got[0].link.ID)
+	assert.Equal(t, got[0].link.ID, got[1].link.ID, "both uploads share one link")
+	assert.Equal(t, upload.ArtifactLink{ID: got[0].link.ID, Role: "sbom", SBOM: "bom.cdx.json", VEX: "statements.json"}, got[0].link)
+	assert.Equal(t, "vex", got[1].link.Role)
+	assert.Equal(t, "p-1", got[1].link.SBOMPipelineUUID, "the VEX references the SBOM's pipeline record")
+}
+
+func TestUploadVEXFailureRemovesSBOM(t *testing.T) {
+	resetUploadFlags(t)
+	sbom, vex := writeLinkedPair(t)
+
+	var uploads int
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			_, _ = io.WriteString(w, `{"ok":true}`)
+			return
+		}
+		uploads++
+		if uploads == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"statements could not be parsed"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "upload", "--file", sbom, "--vex", vex, "--base-url", server.URL+"/v1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the uploaded SBOM (pipeline p-1) was removed")
+	assert.Contains(t, err.Error(), "statements could not be parsed")
+	assert.Equal(t, []string{"/v1/uploads/pipeline/p-1"}, deleted)
+}
+
+func TestUploadVEXRejectsWrongFormats(t *testing.T) {
+	resetUploadFlags(t)
+	sbom, vex := writeLinkedPair(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer server.Close()
+
+	// Swapped: the VEX document given as the SBOM.
+	_, err := executeCommand(t, rootCmd, "upload", "--file", vex, "--vex", sbom, "--base-url", server.URL+"/v1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statements.json is detected as openvex, but a linked SBOM must be one of: cyclonedx, spdx")
+	assert.Zero(t, requests, "nothing is uploaded when either file is the wrong kind")
+}
+
+func TestUploadVEXRequiresSBOM(t *testing.T) {
+	resetUploadFlags(t)
+	_, vex := writeLinkedPair(t)
+	_, err := executeCommand(t, rootCmd, "upload", "--vex", vex)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--vex requires --file")
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/schema"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+var (
+	validateFormat      string
+	validateJSON        bool
+	validateConcurrency int
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <file|dir|glob>...",
+	Short: "Check artifacts against their format's official JSON Schema",
+	Long: `Validate CycloneDX, SPDX, SARIF and OpenVEX documents against the official
+JSON Schema for their format, offline, and report every violation with the
+JSON pointer it was found at. Gzip-compressed files are expanded first.
+
+The format is detected from each file's name and content; --format overrides
+it for every file. A directory is searched recursively for artifacts, skipping
+files whose format is not recognized, and a quoted glob is expanded by the CLI.
+Files are validated in parallel (--concurrency) and reported in argument order,
+with each directory's files in lexical order. The command fails if any file is
+invalid.
+
+Examples:
+  vulnetix validate .vulnetix/sbom.cdx.json
+  vulnetix validate report.sarif vex.openvex.json --json
+  vulnetix validate bom.json --format spdx
+  vulnetix validate ./artifacts 'reports/*.sarif' --concurrency 8`,
+	Args: cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if validateFormat != "" && !schema.Supported(validateFormat) {
+			return fmt.Errorf("unsupported format %q: must be one of %s", validateFormat, strings.Join(schema.Formats(), ", "))
+		}
+		if validateConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		return nil
+	},
+	RunE: runValidate,
+}
+
+// artifactSchemaReport is the schema validation result for one file.
+type artifactSchemaReport struct {
+	File       string             `json:"file"`
+	Format     string             `json:"format"`
+	Valid      bool               `json:"valid"`
+	Violations []schema.Violation `json:"violations,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	if validateJSON {
+		initDisplayContext(cmd, display.ModeJSON)
+	}
+	ctx := display.FromCommand(cmd)
+	t := ctx.Term
+
+	targets, err := expandValidateArgs(args)
+	if err != nil {
+		return err
+	}
+	reports := validateArtifacts(targets, validateFormat, validateConcurrency)
+	if len(reports) == 0 {
+		return fmt.Errorf("no artifacts found in %s", strings.Join(args, ", "))
+	}
+	invalid := 0
+	for _, r := range reports {
+		if !r.Valid {
+			invalid++
+		}
+	}
+
+	if validateJSON {
+		if err := ctx.Logger.ResultJSON(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range reports {
+			label := fmt.Sprintf("%s (%s)", r.File, r.Format)
+			switch {
+			case r.Valid:
+				ctx.Logger.Result(display.CheckMark(t) + " " + label)
+			case r.Error != "":
+				ctx.Logger.Result(display.CrossMark(t) + " " + label + ": " + r.Error)
+			default:
+				ctx.Logger.Result(display.CrossMark(t) + " " + label)
+				for _, v := range r.Violations {
+					ctx.Logger.Result("    " + v.String())
+				}
+			}
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d file(s) failed schema validation", invalid)
+	}
+	return nil
+}
+
+// validateTarget is one file to validate. Files found by searching a
+// directory are dropped from the report when they are not an artifact with a
+// schema, unless --format says what they are.
+type validateTarget struct {
+	path    string
+	fromDir bool
+}
+
+// expandValidateArgs turns the command's arguments into the files to
+// validate, in order: directories are walked in lexical order, and an
+// argument that names no file but contains glob characters is expanded.
+func expandValidateArgs(args []string) ([]validateTarget, error) {
+	var targets []validateTarget
+	for _, arg := range args {
+		paths := []string{arg}
+		if _, err := os.Stat(arg); err != nil && strings.ContainsAny(arg, "*?[") {
+			matches, globErr := filepath.Glob(arg)
+			if globErr != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", arg, globErr)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", arg)
+			}
+			paths = matches
+		}
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || !info.IsDir() {
+				// A missing file is reported against its own entry.
+				targets = append(targets, validateTarget{path: path})
+				continue
+			}
+			err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.Type().IsRegular() {
+					targets = append(targets, validateTarget{path: p, fromDir: true})
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", path, err)
+			}
+		}
+	}
+	return targets, nil
+}
+
+// validateArtifacts validates targets with at most concurrency files in
+// flight and returns their reports in target order.
+func validateArtifacts(targets []validateTarget, format string, concurrency int) []artifactSchemaReport {
+	results := make([]artifactSchemaReport, len(targets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(targets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = validateArtifact(targets[i].path, format)
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	reports := make([]artifactSchemaReport, 0, len(results))
+	for i, r := range results {
+		if targets[i].fromDir && format == "" && r.Format != "" && !schema.Supported(r.Format) {
+			continue
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// validateArtifact reads path, works out its format unless format is set,
+// and checks it against that format's schema.
+func validateArtifact(path, format string) artifactSchemaReport {
+	r := artifactSchemaReport{File: path, Format: format}
+	name, data, err := upload.ReadArtifact(path)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	if r.Format == "" {
+		r.Format = upload.DetectFormat(name, data)
+	}
+	if !schema.Supported(r.Format) {
+		r.Error = fmt.Sprintf("format %s has no schema to validate against (supported: %s)", r.Format, strings.Join(schema.Formats(), ", "))
+		return r
+	}
+	r.Violations, err = schema.Validate(r.Format, data)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Valid = len(r.Violations) == 0
+	return r
+}
+
+// printSchemaValidationFailure reports an upload rejected by --schema-validate.
+func printSchemaValidationFailure(ctx *display.Context, filePath string, result *upload.SchemaValidationError, asJSON bool) {
+	t := ctx.Term
+	if asJSON {
+		_ = ctx.Logger.ResultJSON(map[string]any{
+			"ok":         false,
+			"file":       filePath,
+			"format":     result.Format,
+			"violations": result.Violations,
+		})
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(display.WarningMark(t) + " " + display.Bold(t, filepath.Base(filePath)) + " — " + result.Format + " schema validation failed\n")
+	for i, v := range result.Violations {
+		if i >= 5 {
+			b.WriteString(fmt.Sprintf("  ...%d more violation(s)\n", len(result.Violations)-i))
+			break
+		}
+		b.WriteString("  " + v.String() + "\n")
+	}
+	ctx.Logger.Result(strings.TrimSuffix(b.String(), "\n"))
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&validateFormat, "format", "", "Validate every file as this format instead of detecting it ("+strings.Join(schema.Formats(), ", ")+")")
+	validateCmd.Flags().BoolVar(&validateJSON, "json", false, "Output the report as JSON")
+	validateCmd.Flags().IntVar(&validateConcurrency, "concurrency", 4, "Files validated in parallel")
+	rootCmd.AddCommand(validateCmd)
+}
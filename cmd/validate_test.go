@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+func resetValidateFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = validateCmd.Flags().Set("format", "")
+		_ = validateCmd.Flags().Set("json", "false")
+		_ = validateCmd.Flags().Set("concurrency", "4")
+	})
+}
+
+func TestValidateReportsViolations(t *testing.T) {
+	resetValidateFlags(t)
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.sarif")
+	bad := filepath.Join(dir, "bad.sarif")
+	require.NoError(t, os.WriteFile(good, []byte(`{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"x"}}}]}`), 0644))
+	require.NoError(t, os.WriteFile(bad, []byte(`{"version":"2.1.0","runs":[{"results":[]}]}`), 0644))
+
+	out, err := executeCommand(t, rootCmd, "validate", good, bad, "--json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 file(s) failed schema validation")
+
+	var reports []artifactSchemaReport
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "[\n"):])).Decode(&reports))
+	require.Len(t, reports, 2)
+	assert.True(t, reports[0].Valid)
+	assert.Equal(t, "sarif", reports[0].Format)
+	assert.False(t, reports[1].Valid)
+	require.NotEmpty(t, reports[1].Violations)
+	assert.Equal(t, "/runs/0", reports[1].Violations[0].Path)
+}
+
+func TestValidateExpandsGzipAndHonoursFormat(t *testing.T) {
+	resetValidateFlags(t)
+	doc := `{"spdxVersion":"SPDX-2.3","dataLicense":"CC0-1.0","SPDXID":"SPDXRef-DOCUMENT","name":"app",
+		"documentNamespace":"https://example.com/app","creationInfo":{"created":"2024-01-01T00:00:00Z","creators":["Tool: test"]}}`
+	gz, err := upload.Gzip([]byte(doc))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "inventory.json.gz")
+	require.NoError(t, os.WriteFile(path, gz, 0644))
+
+	_, err = executeCommand(t, rootCmd, "validate", path, "--format", "spdx")
+	require.NoError(t, err)
+}
+
+func TestValidateRejectsFormatWithoutSchema(t *testing.T) {
+	resetValidateFlags(t)
+	_, err := executeCommand(t, rootCmd, "validate", "x.json", "--format", "csaf_vex")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported format "csaf_vex"`)
+}
+
+func TestValidateDirectoryConcurrentlyInOrder(t *testing.T) {
+	resetValidateFlags(t)
+	dir := t.TempDir()
+	valid := `{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"x"}}}]}`
+	invalid := `{"version":"2.1.0","runs":[{"results":[]}]}`
+	var want []string
+	for i := range 12 {
+		name := filepath.Join(dir, fmt.Sprintf("report-%02d.sarif", i))
+		doc := valid
+		if i%3 == 0 {
+			doc = invalid
+		}
+		require.NoError(t, os.WriteFile(name, []byte(doc), 0644))
+		want = append(want, name)
+	}
+	nested := filepath.Join(dir, "sub")
+	require.NoError(t, os.Mkdir(nested, 0755))
+	bom := filepath.Join(nested, "bom.cdx.json")
+	require.NoError(t, os.WriteFile(bom, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644))
+	want = append(want, bom)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not an artifact\n"), 0644))
+
+	out, err := executeCommand(t, rootCmd, "validate", dir, "--concurrency", "4", "--json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "4 file(s) failed schema validation")
+
+	var reports []artifactSchemaReport
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "[\n"):])).Decode(&reports))
+	got := make([]string, len(reports))
+	for i, r := range reports {
+		got[i] = r.File
+		assert.Equal(t, i < 12 && i%3 == 0, !r.Valid, r.File)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestValidateExpandsGlob(t *testing.T) {
+	resetValidateFlags(t)
+	dir := t.TempDir()
+	doc := []byte(`{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"x"}}}]}`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.sarif"), doc, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.sarif"), doc, 0644))
+
+	_, err := executeCommand(t, rootCmd, "validate", filepath.Join(dir, "*.sarif"))
+	require.NoError(t, err)
+
+	_, err = executeCommand(t, rootCmd, "validate", filepath.Join(dir, "*.cdx.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no files match")
+}
@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +38,7 @@ import (
 var (
 	vdbOrgID         string
 	vdbSecretKey     string
+	vdbSessionToken  string
 	vdbAPIKey        string
 	vdbMethod        string
 	vdbBaseURL       string
@@ -49,6 +54,7 @@ var (
 	vdbComfortable   bool
 	vdbSparse        bool
 	vdbHighlight     string
+	vdbTemplate      string
 
 	// Context flags for Claude Code Plugin memory management
 	vdbPackageManager string
@@ -68,8 +74,11 @@ var (
 	vdbContextJSON string
 
 	// Runtime state (set in PersistentPreRunE, consumed in PersistentPostRunE)
-	vdbEnvContext  *memory.EnvironmentContext
-	vdbMemory      *memory.Memory
+	vdbEnvContext *memory.EnvironmentContext
+	vdbMemory     *memory.Memory
+	// vdbMemoryDir, when set, replaces the repository or home .vulnetix
+	// directory vdb commands keep memory in. Tests point it at a temp dir.
+	vdbMemoryDir   string
 	vdbVulnetixDir string
 )
 
@@ -118,15 +127,18 @@ Examples:
   # Get vulnerabilities for a package
   vulnetix vdb vulns express`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, "vdb"); err != nil {
+			return err
+		}
 		printBanner(cmd)
 		// Initialize display context with correct output mode
 		mode := display.ModeText
-		if vdbOutput == "json" || vdbOutput == "yaml" {
+		if vdbMachineOutput() {
 			mode = display.ModeJSON
 		}
 		initDisplayContext(cmd, mode)
 
-		if err := validateOutputFlags(); err != nil {
+		if err := validateOutputFlags(cmd); err != nil {
 			return err
 		}
 		if err := validateAPIVersion(); err != nil {
@@ -169,7 +181,9 @@ Examples:
 			}
 		}
 
-		// Replicate rootCmd's PersistentPostRun (update check notification)
+		// Replicate rootCmd's PersistentPostRun (traffic report, update check
+		// notification)
+		reportTraffic(cmd)
 		if msg := consumeUpdateAdvisory(); msg != "" {
 			fmt.Fprint(os.Stderr, msg)
 		}
@@ -636,7 +650,10 @@ Examples:
   vulnetix vdb product express 4.17.1 npm
 
   # With pagination
-  vulnetix vdb product express --limit 50 --offset 100`,
+  vulnetix vdb product express --limit 50 --offset 100
+
+  # Only the newest known version
+  vulnetix vdb product express --latest`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		productName := args[0]
@@ -647,6 +664,16 @@ Examples:
 
 		client := newVDBClient()
 
+		if latest, _ := cmd.Flags().GetBool("latest"); latest {
+			if len(args) > 1 {
+				return fmt.Errorf("--latest cannot be combined with a version argument")
+			}
+			if cmd.Flags().Changed("offset") {
+				return fmt.Errorf("--latest reads every version; it cannot be combined with --offset")
+			}
+			return runProductLatest(cmd, client, productName, limit)
+		}
+
 		// If ecosystem is provided, get version+ecosystem info
 		if len(args) > 2 {
 			version := args[1]
@@ -737,7 +764,14 @@ var vulnsCmd = &cobra.Command{
 Examples:
   vulnetix vdb vulns express
   vulnetix vdb vulns express --limit 50
-  vulnetix vdb vulns express --output json`,
+  vulnetix vdb vulns express --output json
+  vulnetix vdb vulns lodash --output jsonl    # one record per line, streamed
+  vulnetix vdb vulns express --published-after 2024-01-01
+
+--published-after and --published-before keep the records published on or
+after, and before, the given date or RFC 3339 timestamp, falling back to the
+modified date. Records with neither date are left out. The filter applies to
+the page returned, so combine it with --limit and --offset to cover more.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		packageName := args[0]
@@ -746,10 +780,23 @@ Examples:
 		limit, _ := cmd.Flags().GetInt("limit")
 		offset, _ := cmd.Flags().GetInt("offset")
 
+		after, _ := cmd.Flags().GetString("published-after")
+		before, _ := cmd.Flags().GetString("published-before")
+		dates, err := parseVulnsDateRange(after, before)
+		if err != nil {
+			return err
+		}
+
 		client := newVDBClient()
 
 		vdbLog(cmd).Infof("🔒 Fetching vulnerabilities for %s...", packageName)
 
+		// JSON Lines is written as records are decoded so packages with
+		// thousands of CVEs never sit in memory as one document.
+		if vdbOutput == "jsonl" {
+			return streamPackageVulnsJSONL(cmd, client, packageName, limit, offset, dates)
+		}
+
 		resp, err := client.GetPackageVulnerabilities(packageName, limit, offset)
 		if err != nil {
 			var nfe *vdb.NotFoundError
@@ -769,10 +816,60 @@ Examples:
 			return nil
 		}
 
+		if dates.active() {
+			kept, undated := dates.filter(resp)
+			vdbLog(cmd).Infof("Kept %d record(s) in the date range", kept)
+			if undated > 0 {
+				vdbLog(cmd).Infof("  %d record(s) without a published or modified date were left out", undated)
+			}
+		}
+
 		return vdbRender(cmd, display.ToMap(resp), display.RenderPackageVulns)
 	},
 }
 
+// streamPackageVulnsJSONL writes each vulnerability record for packageName to
+// stdout as its own line while the response is still being decoded. Records
+// outside dates are skipped.
+func streamPackageVulnsJSONL(cmd *cobra.Command, client *vdb.Client, packageName string, limit, offset int, dates vulnsDateRange) error {
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var buf bytes.Buffer
+	count := 0
+	resp, err := client.StreamPackageVulnerabilities(packageName, limit, offset, func(item json.RawMessage) error {
+		if dates.active() {
+			var rec vdb.VersionRecord
+			if err := json.Unmarshal(item, &rec); err != nil || !dates.contains(rec) {
+				return nil
+			}
+		}
+		buf.Reset()
+		if err := json.Compact(&buf, item); err != nil {
+			return fmt.Errorf("failed to format output: %w", err)
+		}
+		buf.WriteByte('\n')
+		count++
+		_, err := out.Write(buf.Bytes())
+		return err
+	})
+	if err != nil {
+		var nfe *vdb.NotFoundError
+		if errors.As(err, &nfe) {
+			vdbLog(cmd).Warn(fmt.Sprintf("⚠ Package %q was not found in the database.", packageName))
+			vdbLog(cmd).Info("  This identifier has been flagged for review by Vulnetix admins.")
+			return nil
+		}
+		return fmt.Errorf("failed to get vulnerabilities: %w", err)
+	}
+	printRateLimit(client)
+	recordVDBQuery("vulns", packageName)
+	if count == 0 && resp.TotalCVEs == 0 && resp.Total == 0 {
+		vdbLog(cmd).Warn(fmt.Sprintf("⚠ Package %q was not found in the database.", packageName))
+		vdbLog(cmd).Info("  This identifier has been flagged for review by Vulnetix admins.")
+	}
+	return nil
+}
+
 // specCmd retrieves the OpenAPI specification
 var specCmd = &cobra.Command{
 	Use:   "spec",
@@ -785,58 +882,136 @@ Examples:
   vulnetix vdb spec
   vulnetix vdb spec --output json > vdb-spec.json`,
 	// Override parent's PersistentPreRunE — spec is public, no auth required
-	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		printBanner(cmd)
-		mode := display.ModeText
-		if vdbOutput == "json" || vdbOutput == "yaml" {
-			mode = display.ModeJSON
-		}
-		initDisplayContext(cmd, mode)
-		if err := validateOutputFlags(); err != nil {
-			return err
-		}
-		_ = resolveVDBCredentials(false)
-		return nil
-	},
+	PersistentPreRunE: vdbSpecPreRun,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := display.FromCommand(cmd)
 		ctx.Logger.Info("📋 Fetching OpenAPI specification...")
 
-		// If credentials are available, use the authenticated client
-		if vdbCreds != nil && vdbCreds.OrgID != "" {
-			client := newVDBClient()
-			spec, err := client.GetOpenAPISpec()
-			if err != nil {
-				return fmt.Errorf("failed to get spec: %w", err)
-			}
-			printRateLimit(client)
-			return printOutput(spec, vdbOutput)
+		spec, err := loadVDBSpec(vdbRefreshSpec)
+		if err != nil {
+			return err
 		}
+		return printOutput(spec, vdbOutput)
+	},
+}
 
-		// No credentials — use direct HTTP GET (spec is public)
-		baseURL := vdbBaseURL
-		if baseURL == "" {
-			baseURL = vdb.DefaultBaseURL
-		}
-		apiVer := vdb.DefaultAPIVersion
-		if vdbAPIVersion != "" {
-			apiVer = normalizeAPIVersion(vdbAPIVersion)
-		}
-		specURL := baseURL + apiVer + "/spec"
+// vdbSpecPreRun is the PersistentPreRunE of commands that only read the
+// public OpenAPI spec: credentials are used when present but not required.
+func vdbSpecPreRun(cmd *cobra.Command, args []string) error {
+	if err := applyConfigDefaults(cmd, "vdb"); err != nil {
+		return err
+	}
+	printBanner(cmd)
+	mode := display.ModeText
+	if vdbMachineOutput() {
+		mode = display.ModeJSON
+	}
+	initDisplayContext(cmd, mode)
+	if err := validateOutputFlags(cmd); err != nil {
+		return err
+	}
+	_ = resolveVDBCredentials(false)
+	return nil
+}
 
-		resp, err := http.Get(specURL) //nolint:noctx
+// endpointsCmd lists the operations declared by the OpenAPI specification
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints [filter]",
+	Short: "List the API endpoints declared in the OpenAPI specification",
+	Long: `List every path, method and summary in the VDB OpenAPI specification, to
+discover endpoints that have no dedicated command. The spec is read from the
+local cache when fresh.
+
+An optional filter keeps only endpoints whose method, path, summary or tags
+contain it (case-insensitive).
+
+Examples:
+  vulnetix vdb endpoints
+  vulnetix vdb endpoints exploit
+  vulnetix vdb endpoints --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	PersistentPreRunE: vdbSpecPreRun,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, err := loadVDBSpec(vdbRefreshSpec)
 		if err != nil {
-			return fmt.Errorf("failed to fetch spec: %w", err)
+			return err
 		}
-		defer resp.Body.Close()
+		filter := ""
+		if len(args) == 1 {
+			filter = args[0]
+		}
+		return vdbRender(cmd, specEndpoints(spec, filter), renderSpecEndpoints)
+	},
+}
+
+// specEndpoint is one operation (method on a path) of the OpenAPI spec.
+type specEndpoint struct {
+	Method  string   `json:"method"`
+	Path    string   `json:"path"`
+	Summary string   `json:"summary,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
 
-		var spec map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
-			return fmt.Errorf("failed to parse spec: %w", err)
+// specHTTPMethods are the OpenAPI path item keys that are operations; the
+// other keys (parameters, servers, ...) are skipped.
+var specHTTPMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// specEndpoints walks spec.paths and returns its operations sorted by path
+// then method, keeping those matching filter when it is non-empty. An
+// operation without a summary falls back to its description's first line.
+func specEndpoints(spec map[string]interface{}, filter string) []specEndpoint {
+	paths, _ := spec["paths"].(map[string]interface{})
+	filter = strings.ToLower(filter)
+	endpoints := []specEndpoint{}
+	for path, item := range paths {
+		ops, _ := item.(map[string]interface{})
+		for _, method := range specHTTPMethods {
+			op, ok := ops[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			e := specEndpoint{Method: strings.ToUpper(method), Path: path}
+			e.Summary, _ = op["summary"].(string)
+			if e.Summary == "" {
+				desc, _ := op["description"].(string)
+				e.Summary, _, _ = strings.Cut(strings.TrimSpace(desc), "\n")
+			}
+			tags, _ := op["tags"].([]interface{})
+			for _, tag := range tags {
+				if s, ok := tag.(string); ok {
+					e.Tags = append(e.Tags, s)
+				}
+			}
+			if filter != "" && !strings.Contains(strings.ToLower(strings.Join(append([]string{e.Method, e.Path, e.Summary}, e.Tags...), " ")), filter) {
+				continue
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
 		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	return endpoints
+}
 
-		return printOutput(spec, vdbOutput)
-	},
+// renderSpecEndpoints prints one table row per endpoint.
+func renderSpecEndpoints(data interface{}, ctx *display.Context) string {
+	endpoints, ok := data.([]specEndpoint)
+	if !ok {
+		return display.RenderGenericMap(data, ctx)
+	}
+	if len(endpoints) == 0 {
+		return "No matching endpoints."
+	}
+	rows := make([][]string, len(endpoints))
+	for i, e := range endpoints {
+		rows[i] = []string{e.Method, e.Path, e.Summary}
+	}
+	cols := []display.Column{{Header: "Method"}, {Header: "Path"}, {Header: "Summary"}}
+	return display.Table(ctx.Term, cols, rows)
 }
 
 // printRateLimit prints rate limit and cache status from the last API call to stderr.
@@ -977,6 +1152,7 @@ func newVDBClient() *vdb.Client {
 		}
 	} else {
 		client = vdb.NewClient(vdbOrgID, vdbSecretKey)
+		client.SessionToken = vdbSessionToken
 		if vdbBaseURL != "" {
 			client.BaseURL = vdbBaseURL
 		}
@@ -1004,7 +1180,13 @@ func newVDBClient() *vdb.Client {
 func vdbRender(cmd *cobra.Command, data interface{}, textFn func(data interface{}, ctx *display.Context) string) error {
 	if vdbOutput == "pretty" || vdbOutput == "" {
 		ctx := display.FromCommand(cmd)
-		return ctx.Render(data, textFn)
+		if ctx.IsJSON() {
+			return ctx.Render(data, textFn)
+		}
+		if result := textFn(data, ctx); result != "" {
+			return pageOutput(result)
+		}
+		return nil
 	}
 	return printOutput(data, vdbOutput)
 }
@@ -1057,18 +1239,39 @@ func printOutput(data interface{}, format string) error {
 		}
 		fmt.Print(string(yamlBytes))
 		return nil
+	case "template":
+		return renderOutputTemplate(os.Stdout, vdbTemplate, data)
+	case "jsonl":
+		return printJSONLines(os.Stdout, data)
 	case "pretty", "":
 		jsonBytes, err := json.MarshalIndent(data, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to format output: %w", err)
 		}
-		fmt.Println(string(jsonBytes))
-		return nil
+		return pageOutput(string(jsonBytes))
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
 }
 
+// printJSONLines writes one compact JSON document per line: each element when
+// data is a list, otherwise data itself.
+func printJSONLines(w io.Writer, data interface{}) error {
+	enc := json.NewEncoder(w)
+	if items, ok := data.([]interface{}); ok {
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return fmt.Errorf("failed to format output: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
+
 // resolveIndent returns the JSON indent string based on the active preset flag.
 func resolveIndent() string {
 	switch {
@@ -1116,8 +1319,14 @@ func highlightJSON(jsonStr string, theme string) (string, error) {
 	return buf.String(), nil
 }
 
-// validateOutputFlags checks that indent and highlight flags are only used with --output json.
-func validateOutputFlags() error {
+// vdbOutputFormats are the values --output accepts. Template output is chosen
+// with --template alone.
+var vdbOutputFormats = []string{"json", "jsonl", "yaml", "pretty"}
+
+// validateOutputFlags checks --output is a known format, that indent and
+// highlight flags are only used with --output json, and that --template,
+// which replaces --output, is valid.
+func validateOutputFlags(cmd *cobra.Command) error {
 	if vdbOutput != "json" {
 		if vdbCompact || vdbSparse || vdbComfortable {
 			return fmt.Errorf("--compact, --comfortable, and --sparse are only valid with --output json")
@@ -1129,9 +1338,27 @@ func validateOutputFlags() error {
 	if vdbHighlight != "" && vdbHighlight != "none" && vdbHighlight != "dark" && vdbHighlight != "light" {
 		return fmt.Errorf("--highlight must be one of: dark, light, none")
 	}
+	if vdbTemplate == "" && vdbOutput != "" && !slices.Contains(vdbOutputFormats, vdbOutput) {
+		return fmt.Errorf("--output must be one of: %s (use --template for a Go template)", strings.Join(vdbOutputFormats, ", "))
+	}
+	if vdbTemplate != "" {
+		if cmd.Flags().Changed("output") {
+			return fmt.Errorf("--template and --output cannot be used together")
+		}
+		if _, err := parseOutputTemplate(vdbTemplate); err != nil {
+			return err
+		}
+		vdbOutput = "template"
+	}
 	return nil
 }
 
+// vdbMachineOutput reports whether --output produces machine-readable output,
+// in which case progress and log chatter must stay off stdout.
+func vdbMachineOutput() bool {
+	return vdbOutput == "json" || vdbOutput == "jsonl" || vdbOutput == "yaml" || vdbTemplate != ""
+}
+
 // gitCtxToEnvContext converts a gitctx.GitContext to a memory.EnvironmentContext.
 func gitCtxToEnvContext(gc *gitctx.GitContext) *memory.EnvironmentContext {
 	env := &memory.EnvironmentContext{
@@ -1242,6 +1469,9 @@ func applyContextFlagOverrides(env *memory.EnvironmentContext) {
 // resolveVulnetixDir returns the path to the .vulnetix directory.
 // If inside a git repo, uses the repo root. Otherwise, uses ~/.vulnetix.
 func resolveVulnetixDir(gc *gitctx.GitContext) string {
+	if vdbMemoryDir != "" {
+		return vdbMemoryDir
+	}
 	if gc != nil && gc.RepoRootPath != "" {
 		return filepath.Join(gc.RepoRootPath, ".vulnetix")
 	}
@@ -1694,13 +1924,16 @@ Examples:
 	Args: cobra.NoArgs,
 	// Own PersistentPreRunE overrides parent vdbCmd's — soft-loads creds (no error if absent)
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, "vdb"); err != nil {
+			return err
+		}
 		printBanner(cmd)
 		mode := display.ModeText
-		if vdbOutput == "json" || vdbOutput == "yaml" {
+		if vdbMachineOutput() {
 			mode = display.ModeJSON
 		}
 		initDisplayContext(cmd, mode)
-		if err := validateOutputFlags(); err != nil {
+		if err := validateOutputFlags(cmd); err != nil {
 			return err
 		}
 		_ = resolveVDBCredentials(false)
@@ -1767,7 +2000,7 @@ Examples:
 				GoVersion:  runtime.Version(),
 				Platform:   runtime.GOOS + "/" + runtime.GOARCH,
 				APIVersion: client.APIVersion,
-				OASSpecURL: client.BaseURL + client.APIVersion + "/spec",
+				OASSpecURL: strings.TrimRight(client.BaseURL, "/") + client.APIVersion + "/spec",
 			},
 			API:  apiHealth,
 			Auth: authResult,
@@ -1815,9 +2048,10 @@ func resolveVDBCredentials(errorOnMissing bool) error {
 			}
 		} else {
 			vdbCreds = &auth.Credentials{
-				OrgID:  vdbOrgID,
-				Secret: vdbSecretKey,
-				Method: auth.SigV4,
+				OrgID:        vdbOrgID,
+				Secret:       vdbSecretKey,
+				SessionToken: vdbSessionToken,
+				Method:       auth.SigV4,
 			}
 		}
 		return nil
@@ -1837,6 +2071,9 @@ func resolveVDBCredentials(errorOnMissing bool) error {
 		}
 		return nil
 	}
+	if vdbSessionToken != "" && creds.Secret != "" {
+		creds.SessionToken = vdbSessionToken
+	}
 	vdbCreds = creds
 	if vdbOrgID == "" {
 		vdbOrgID = creds.OrgID
@@ -1932,9 +2169,12 @@ Examples:
 	  vulnetix vdb cache clear`,
 	// Override parent's PersistentPreRunE — cache clear needs no auth
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, "vdb"); err != nil {
+			return err
+		}
 		initDisplayContext(cmd, display.ModeText)
 		printBanner(cmd)
-		return validateOutputFlags()
+		return validateOutputFlags(cmd)
 	},
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -1989,6 +2229,7 @@ func init() {
 	vdbCmd.AddCommand(productCmd)
 	vdbCmd.AddCommand(vulnsCmd)
 	vdbCmd.AddCommand(specCmd)
+	vdbCmd.AddCommand(endpointsCmd)
 	vdbCmd.AddCommand(exploitsCmd)
 	vdbCmd.AddCommand(fixesCmd)
 	vdbCmd.AddCommand(timelineCmd)
@@ -2062,11 +2303,13 @@ func init() {
 	// Global flags
 	vdbCmd.PersistentFlags().StringVar(&vdbOrgID, "org-id", "", "Organization UUID (overrides VVD_ORG env var)")
 	vdbCmd.PersistentFlags().StringVar(&vdbSecretKey, "secret", "", "SigV4 secret key (overrides VVD_SECRET env var)")
+	vdbCmd.PersistentFlags().StringVar(&vdbSessionToken, "session-token", "", "Session token for temporary SigV4 credentials (overrides VVD_SESSION_TOKEN env var)")
 	vdbCmd.PersistentFlags().StringVar(&vdbAPIKey, "api-key", "", "Direct API key (overrides VULNETIX_API_KEY env var)")
 	vdbCmd.PersistentFlags().StringVar(&vdbMethod, "method", "", "Auth method: apikey or sigv4 (auto-detected from flags if omitted)")
 	vdbCmd.PersistentFlags().StringVar(&vdbBaseURL, "base-url", vdb.DefaultBaseURL, "VDB API base URL")
 	vdbCmd.PersistentFlags().StringVarP(&vdbAPIVersion, "api-version", "V", "v2", `VDB API version: "v2" (default) or "v1" (legacy). v1 is retained for backwards compatibility and will be removed in a future release. New commands (timeline, affected, kev, advisories, workarounds, cwe, remediation, cloud-locators, fixes, scorecard, tree-sitter reachability) require v2.`)
-	vdbCmd.PersistentFlags().StringVarP(&vdbOutput, "output", "o", "pretty", "Output format (json, yaml, pretty)")
+	vdbCmd.PersistentFlags().StringVarP(&vdbOutput, "output", "o", "pretty", "Output format (json, jsonl, yaml, pretty)")
+	vdbCmd.PersistentFlags().StringVar(&vdbTemplate, "template", "", `Render the result through a Go text/template instead of --output, e.g. '{{.id}} {{.severity | default "n/a"}}' (helpers: json, upper, lower, default)`)
 	vdbCmd.PersistentFlags().StringVar(&vdbReachability, "reachability", "both",
 		`Tree-sitter reachability analysis mode for vuln commands: "direct" (only scan the installed package folder), "transitive" (only scan the rest of the project for callers), "both" (default), or "off" (skip; no API call). Requires -V v2 (the default). Direct mode confirms the vulnerable pattern is present in the installed version; transitive mode finds first-party or other-dep code paths that reach it.`)
 	vdbCmd.PersistentFlags().BoolVar(&vdbNoCache, "no-cache", false, "Bypass local disk cache entirely")
@@ -2079,7 +2322,7 @@ func init() {
 	vdbCmd.PersistentFlags().BoolVar(&vdbSparse, "sparse", false, "8-space indent (--output json only)")
 	vdbCmd.PersistentFlags().StringVar(&vdbHighlight, "highlight", "none", "Syntax highlighting: dark, light, none (--output json only)")
 	_ = vdbCmd.RegisterFlagCompletionFunc("method", cobra.FixedCompletions([]string{"apikey", "sigv4"}, cobra.ShellCompDirectiveNoFileComp))
-	_ = vdbCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"json", "yaml", "pretty"}, cobra.ShellCompDirectiveNoFileComp))
+	declareOutputFormats(vdbCmd, vdbOutputFormats...)
 	_ = vdbCmd.RegisterFlagCompletionFunc("api-version", cobra.FixedCompletions([]string{"v1", "v2"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = vdbCmd.RegisterFlagCompletionFunc("reachability", cobra.FixedCompletions([]string{"direct", "transitive", "both", "off"}, cobra.ShellCompDirectiveNoFileComp))
 	_ = vdbCmd.RegisterFlagCompletionFunc("highlight", cobra.FixedCompletions([]string{"dark", "light", "none"}, cobra.ShellCompDirectiveNoFileComp))
@@ -2120,9 +2363,12 @@ func init() {
 	// Pagination flags for applicable commands
 	productCmd.Flags().Int("limit", 100, "Maximum number of results to return (default 100; use with --offset for pagination)")
 	productCmd.Flags().Int("offset", 0, "Number of results to skip (for pagination)")
+	productCmd.Flags().Bool("latest", false, "Show only the highest known version, preferring stable releases (semver order; pages through every version)")
 
 	vulnsCmd.Flags().Int("limit", 100, "Maximum number of results to return (default 100; use with --offset for pagination)")
 	vulnsCmd.Flags().Int("offset", 0, "Number of results to skip (for pagination)")
+	vulnsCmd.Flags().String("published-after", "", "Only records published on or after this date (YYYY-MM-DD or RFC 3339)")
+	vulnsCmd.Flags().String("published-before", "", "Only records published before this date (YYYY-MM-DD or RFC 3339)")
 
 	// purl flags
 	purlCmd.Flags().Bool("vulns", false, "Show vulnerabilities instead of versions (only when PURL has no version)")
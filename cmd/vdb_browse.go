@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/tui"
+	"github.com/vulnetix/cli/v3/pkg/tty"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// browseInteractive reports whether `vdb browse` may launch the TUI. It is a
+// variable so tests can force the non-interactive fallback.
+var browseInteractive = tty.StdoutIsTerminal
+
+// browseVuln is one row of `vdb browse`: a vulnerability and the package
+// versions it affects.
+type browseVuln struct {
+	ID       string   `json:"id"`
+	Versions []string `json:"versions"`
+}
+
+var vdbBrowseCmd = &cobra.Command{
+	Use:   "browse <package-name>",
+	Short: "Browse a package's vulnerabilities interactively",
+	Long: `Open a terminal UI listing the known vulnerabilities for a package. Move
+through the list with the arrow keys and press Enter to load the selected
+vulnerability's details.
+
+When stdout is not a terminal, or --output is not pretty, the list is printed
+instead so the command stays usable in pipes and CI logs.
+
+Examples:
+  vulnetix vdb browse express
+  vulnetix vdb browse lodash --limit 500
+  vulnetix vdb browse express | grep GHSA`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVDBBrowse,
+}
+
+func runVDBBrowse(cmd *cobra.Command, args []string) error {
+	packageName := args[0]
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+
+	client := newVDBClient()
+	vdbLog(cmd).Infof("🔒 Fetching vulnerabilities for %s...", packageName)
+
+	resp, err := client.GetPackageVulnerabilities(packageName, limit, offset)
+	if err != nil {
+		var nfe *vdb.NotFoundError
+		if errors.As(err, &nfe) {
+			vdbLog(cmd).Warn(fmt.Sprintf("⚠ Package %q was not found in the database.", packageName))
+			return nil
+		}
+		return fmt.Errorf("failed to get vulnerabilities: %w", err)
+	}
+	printRateLimit(client)
+	recordVDBQuery("browse", packageName)
+
+	vulns := collectBrowseVulns(resp)
+	if (vdbOutput != "pretty" && vdbOutput != "") || !browseInteractive() {
+		return vdbRender(cmd, vulns, renderBrowseVulns)
+	}
+
+	ctx := display.FromCommand(cmd)
+	items := make([]tui.BrowseItem, len(vulns))
+	for i, v := range vulns {
+		items[i] = tui.BrowseItem{ID: v.ID, Versions: v.Versions}
+	}
+	return tui.RunBrowse(items, tui.BrowseOptions{
+		Title: packageName,
+		Detail: func(id string) (string, error) {
+			info, err := client.GetCVE(id)
+			if err != nil {
+				return "", err
+			}
+			return display.RenderVulnDetail(info.Data, ctx), nil
+		},
+	})
+}
+
+// collectBrowseVulns turns the per-version response into one row per
+// vulnerability ID, sorted by ID, listing every affected version.
+func collectBrowseVulns(resp *vdb.VulnerabilitiesResponse) []browseVuln {
+	records := resp.Versions
+	if len(records) == 0 {
+		records = resp.Vulnerabilities
+	}
+	byID := make(map[string]*browseVuln)
+	add := func(id, version string) {
+		if id == "" {
+			return
+		}
+		v, ok := byID[id]
+		if !ok {
+			v = &browseVuln{ID: id}
+			byID[id] = v
+		}
+		if version != "" && !slices.Contains(v.Versions, version) {
+			v.Versions = append(v.Versions, version)
+		}
+	}
+	for _, r := range records {
+		for _, id := range r.CVEIDs {
+			add(id, r.Version)
+		}
+		for _, s := range r.Sources {
+			add(s.SourceID, r.Version)
+		}
+	}
+
+	vulns := make([]browseVuln, 0, len(byID))
+	for _, v := range byID {
+		vulns = append(vulns, *v)
+	}
+	slices.SortFunc(vulns, func(a, b browseVuln) int { return strings.Compare(a.ID, b.ID) })
+	return vulns
+}
+
+// renderBrowseVulns is the non-interactive `vdb browse` output: one table row
+// per vulnerability.
+func renderBrowseVulns(data interface{}, ctx *display.Context) string {
+	vulns, ok := data.([]browseVuln)
+	if !ok {
+		return display.RenderGenericMap(data, ctx)
+	}
+	if len(vulns) == 0 {
+		return "No known vulnerabilities."
+	}
+	rows := make([][]string, len(vulns))
+	for i, v := range vulns {
+		rows[i] = []string{v.ID, strings.Join(v.Versions, ", ")}
+	}
+	cols := []display.Column{{Header: "Vulnerability"}, {Header: "Affected versions"}}
+	return display.Table(ctx.Term, cols, rows)
+}
+
+func init() {
+	vdbBrowseCmd.Flags().Int("limit", 100, "Maximum number of versions to fetch")
+	vdbBrowseCmd.Flags().Int("offset", 0, "Number of versions to skip (for pagination)")
+	vdbCmd.AddCommand(vdbBrowseCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func TestCollectBrowseVulns(t *testing.T) {
+	got := collectBrowseVulns(&vdb.VulnerabilitiesResponse{Versions: []vdb.VersionRecord{
+		{Version: "4.17.0", CVEIDs: []string{"CVE-2024-0002"}, Sources: []vdb.VersionSource{{SourceTable: "ghsa", SourceID: "GHSA-aaaa-bbbb-cccc"}}},
+		{Version: "4.17.1", CVEIDs: []string{"CVE-2024-0002", "CVE-2024-0001"}},
+	}})
+
+	assert.Equal(t, []browseVuln{
+		{ID: "CVE-2024-0001", Versions: []string{"4.17.1"}},
+		{ID: "CVE-2024-0002", Versions: []string{"4.17.0", "4.17.1"}},
+		{ID: "GHSA-aaaa-bbbb-cccc", Versions: []string{"4.17.0"}},
+	}, got)
+}
+
+func TestVDBBrowseFallsBackWithoutTTY(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	prev := browseInteractive
+	browseInteractive = func() bool { return false }
+	t.Cleanup(func() { vdbBaseURL, browseInteractive = vdb.DefaultBaseURL, prev })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/express/vulns", r.URL.Path)
+		_, _ = io.WriteString(w, `{"packageName":"express","totalCVEs":2,"total":2,"versions":[
+			{"version":"4.17.1","ecosystem":"npm","cveIds":["CVE-2022-24999"]},
+			{"version":"4.18.0","ecosystem":"npm","cveIds":["CVE-2022-24999","CVE-2024-29041"]}
+		]}`)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "browse", "express", "--base-url", server.URL)
+	require.NoError(t, err)
+	assert.Contains(t, out, "CVE-2022-24999")
+	assert.Contains(t, out, "4.17.1, 4.18.0")
+	assert.Contains(t, out, "CVE-2024-29041")
+}
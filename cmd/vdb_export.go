@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/internal/sast"
+	"github.com/vulnetix/cli/v3/internal/upload"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// export flags
+var (
+	exportCVEs        []string
+	exportFormat      string
+	exportOut         string
+	exportConcurrency int
+	exportCompress    string
+)
+
+// defaultExportConcurrency keeps parallel lookups modest so a large --cves list
+// does not trip the per-minute rate limit; 429s are still retried by the client.
+const defaultExportConcurrency = 4
+
+var vdbExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export several vulnerabilities as a single SARIF document",
+	Long: `Fetch multiple vulnerabilities concurrently and write them as one portable
+SARIF 2.1.0 document: each identifier becomes a rule (description, severity,
+CVSS score) with a matching result.
+
+Identifiers that cannot be fetched are reported on stderr and recorded as
+tool execution notifications in the SARIF run; the export fails only when
+every lookup fails.
+
+--compress gzip shrinks the document for carrying to another host;
+'vulnetix upload' recognises gzip input by its header and decompresses it.
+
+Examples:
+  vulnetix vdb export --cves CVE-2021-44228,CVE-2022-22965 --format sarif --out report.sarif
+  vulnetix vdb export --cves CVE-2021-44228 --compress gzip --out report.sarif.gz
+  vulnetix vdb export --cves CVE-2021-44228 --cves GHSA-jfh8-3a1q-hjz9 > report.sarif`,
+	Args: cobra.NoArgs,
+	RunE: runVDBExport,
+}
+
+// cveExportResult is the outcome of one identifier's lookup.
+type cveExportResult struct {
+	ID   string
+	Data interface{}
+	Err  error
+}
+
+func runVDBExport(cmd *cobra.Command, args []string) error {
+	ids := normalizeExportIDs(exportCVEs)
+	if len(ids) == 0 {
+		return fmt.Errorf("--cves is required")
+	}
+	if strings.ToLower(exportFormat) != "sarif" {
+		return fmt.Errorf("unsupported --format %q: only sarif is supported", exportFormat)
+	}
+	if exportConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if exportCompress != "none" && exportCompress != "gzip" {
+		return fmt.Errorf("unsupported --compress %q: must be none or gzip", exportCompress)
+	}
+
+	ctx := display.FromCommand(cmd)
+	client := newVDBClient()
+	ctx.Logger.Infof("Fetching %d vulnerabilities...", len(ids))
+
+	results := fetchCVEsConcurrently(client, ids, vdbWorkers(exportConcurrency))
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			ctx.Logger.Warn(fmt.Sprintf("%s: %v", r.ID, r.Err))
+		}
+	}
+	printRateLimit(client)
+	recordVDBQuery("export", strings.Join(ids, ","))
+	if failed == len(results) {
+		return fmt.Errorf("failed to fetch all %d vulnerabilities", failed)
+	}
+
+	body, err := json.MarshalIndent(buildCVEExportSARIF(results, version), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal sarif: %w", err)
+	}
+	if exportCompress == "gzip" {
+		if body, err = upload.Gzip(append(body, '\n')); err != nil {
+			return fmt.Errorf("compress export: %w", err)
+		}
+		if exportOut == "" {
+			// writeOutput would append a newline, corrupting the stream.
+			_, err := cmd.OutOrStdout().Write(body)
+			return err
+		}
+	}
+	return writeOutput(cmd, body, exportOut)
+}
+
+// normalizeExportIDs upper-cases, trims and de-duplicates identifiers while
+// preserving the order they were given in.
+func normalizeExportIDs(raw []string) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, r := range raw {
+		id := strings.ToUpper(strings.TrimSpace(r))
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// fetchCVEsConcurrently looks up every identifier with at most concurrency
+// requests in flight. Results keep the input order. The workers share client,
+// which serializes its own rate-limit bookkeeping and fallback switch.
+func fetchCVEsConcurrently(client *vdb.Client, ids []string, concurrency int) []cveExportResult {
+	results := make([]cveExportResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			info, err := client.GetCVE(id)
+			results[i] = cveExportResult{ID: id, Err: err}
+			if err == nil {
+				results[i].Data = info.Data
+			}
+		}(i, id)
+	}
+	wg.Wait()
+	return results
+}
+
+// buildCVEExportSARIF aggregates fetched vulnerabilities into one SARIF run.
+// There is no source location for a database record, so results carry the
+// identifier and severity only.
+func buildCVEExportSARIF(results []cveExportResult, toolVersion string) *sast.SARIFLog {
+	log := sast.BuildSARIF(nil, nil, toolVersion)
+	run := &log.Runs[0]
+
+	var notes []string
+	for _, r := range results {
+		if r.Err != nil {
+			notes = append(notes, fmt.Sprintf("%s could not be fetched: %v", r.ID, r.Err))
+			continue
+		}
+		summary := summarizeVuln(r.Data)
+		severity := strings.ToLower(summary.severity)
+		level := sast.SeverityToLevel[severity]
+		if level == "" {
+			level = "warning"
+		}
+
+		props := sast.SARIFPropertyBag{"tags": []string{"security", "vulnerability"}}
+		if severity != "" {
+			props["severity"] = severity
+		}
+		if summary.score > 0 {
+			// GitHub code scanning ranks security results by this property.
+			props["security-severity"] = fmt.Sprintf("%.1f", summary.score)
+		}
+		description := summary.description
+		if description == "" {
+			description = r.ID
+		}
+		rule := sast.SARIFReportingDescriptor{
+			ID:               r.ID,
+			Name:             r.ID,
+			ShortDescription: &sast.SARIFMessage{Text: truncate(description, 1024)},
+			Properties:       props,
+		}
+		if strings.HasPrefix(r.ID, "CVE-") {
+			rule.HelpURI = "https://www.cve.org/CVERecord?id=" + r.ID
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+
+		message := r.ID
+		if severity != "" {
+			message += " (" + severity + ")"
+		}
+		if summary.description != "" {
+			message += ": " + truncate(summary.description, 280)
+		}
+		run.Results = append(run.Results, sast.SARIFResult{
+			RuleID:  r.ID,
+			Level:   level,
+			Message: sast.SARIFMessage{Text: message},
+		})
+	}
+	log.AddExecutionNotifications(notes)
+	return log
+}
+
+type vulnSummary struct {
+	description string
+	severity    string
+	score       float64
+	epss        float64
+}
+
+// summarizeVuln pulls description, severity, the highest CVSS base score and
+// the EPSS score out of a /vuln response, which is either a flat record, a CVE 5.0 record, or
+// an array of advisory records for the same vulnerability.
+func summarizeVuln(data interface{}) vulnSummary {
+	var s vulnSummary
+	var records []map[string]interface{}
+	switch v := data.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				records = append(records, m)
+			}
+		}
+	case map[string]interface{}:
+		records = append(records, v)
+	}
+
+	for _, m := range records {
+		if s.description == "" {
+			s.description = display.ToStringVal(m["description"])
+		}
+		if s.severity == "" {
+			s.severity = firstNonEmpty(display.ToStringVal(m["severity"]), display.ToStringVal(m["baseSeverity"]))
+		}
+		containers, _ := m["containers"].(map[string]interface{})
+		cna, _ := containers["cna"].(map[string]interface{})
+		if s.description == "" {
+			if descs, ok := cna["descriptions"].([]interface{}); ok && len(descs) > 0 {
+				if d, ok := descs[0].(map[string]interface{}); ok {
+					s.description = display.ToStringVal(d["value"])
+				}
+			}
+		}
+		metrics, _ := cna["metrics"].([]interface{})
+		for _, metric := range metrics {
+			mm, _ := metric.(map[string]interface{})
+			for _, key := range []string{"cvssV4_0", "cvssV3_1", "cvssV3_0", "cvssV2_0"} {
+				cvss, ok := mm[key].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if score, ok := cvss["baseScore"].(float64); ok && score > s.score {
+					s.score = score
+				}
+				if s.severity == "" {
+					s.severity = display.ToStringVal(cvss["baseSeverity"])
+				}
+			}
+		}
+		adps, _ := containers["adp"].([]interface{})
+		for _, item := range adps {
+			adp, _ := item.(map[string]interface{})
+			if xEpss, ok := adp["x_epss"].(map[string]interface{}); ok && s.epss == 0 {
+				s.epss = display.ToFloat64(xEpss["score"])
+			}
+		}
+		if s.epss == 0 {
+			s.epss = display.ToFloat64(m["epssScore"])
+		}
+	}
+	s.description = strings.Join(strings.Fields(s.description), " ")
+	return s
+}
+
+func init() {
+	vdbExportCmd.Flags().StringSliceVar(&exportCVEs, "cves", nil, "Vulnerability identifiers to export (comma-separated or repeatable)")
+	vdbExportCmd.Flags().StringVar(&exportFormat, "format", "sarif", "Export format (sarif)")
+	vdbExportCmd.Flags().StringVar(&exportOut, "out", "", "Write the export to this file instead of stdout")
+	vdbExportCmd.Flags().IntVar(&exportConcurrency, "concurrency", defaultExportConcurrency, "Maximum concurrent lookups")
+	vdbExportCmd.Flags().StringVar(&exportCompress, "compress", "none", "Compress the export: none or gzip")
+	_ = vdbExportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"sarif"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = vdbExportCmd.RegisterFlagCompletionFunc("compress", cobra.FixedCompletions([]string{"none", "gzip"}, cobra.ShellCompDirectiveNoFileComp))
+	vdbCmd.AddCommand(vdbExportCmd)
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/sast"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+const exportLog4Shell = `{"cveMetadata":{"cveId":"CVE-2021-44228"},"containers":{"cna":{
+	"descriptions":[{"lang":"en","value":"Apache Log4j2 JNDI features do not protect against attacker controlled LDAP."}],
+	"metrics":[{"cvssV3_1":{"baseScore":10.0,"baseSeverity":"CRITICAL"}}]}}}`
+
+const exportSpring4Shell = `[{"id":"CVE-2022-22965","description":"Spring Framework RCE via data binding.","severity":"HIGH"},
+	{"id":"CVE-2022-22965","containers":{"cna":{"metrics":[{"cvssV3_1":{"baseScore":9.8}}]}}}]`
+
+func TestVDBExport_MergesSARIF(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() { mu.Lock(); inFlight--; mu.Unlock() }()
+		time.Sleep(10 * time.Millisecond)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/vuln/CVE-2021-44228"):
+			_, _ = w.Write([]byte(exportLog4Shell))
+		case strings.HasSuffix(r.URL.Path, "/vuln/CVE-2022-22965"):
+			_, _ = w.Write([]byte(exportSpring4Shell))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ids := normalizeExportIDs([]string{"cve-2021-44228", " CVE-2022-22965", "CVE-2021-44228", "CVE-1999-0000"})
+	assert.Equal(t, []string{"CVE-2021-44228", "CVE-2022-22965", "CVE-1999-0000"}, ids)
+
+	results := fetchCVEsConcurrently(testSCAClient(srv.URL), ids, 2)
+	assert.LessOrEqual(t, maxInFlight, 2, "concurrency limit exceeded")
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+
+	log := buildCVEExportSARIF(results, "1.2.3")
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "1.2.3", run.Tool.Driver.Version)
+
+	assert.Len(t, run.Tool.Driver.Rules, 2)
+	log4j := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "CVE-2021-44228", log4j.ID)
+	assert.Contains(t, log4j.ShortDescription.Text, "Apache Log4j2")
+	assert.Equal(t, "10.0", log4j.Properties["security-severity"])
+	assert.Equal(t, "https://www.cve.org/CVERecord?id=CVE-2021-44228", log4j.HelpURI)
+	spring := run.Tool.Driver.Rules[1]
+	assert.Equal(t, "high", spring.Properties["severity"])
+	assert.Equal(t, "9.8", spring.Properties["security-severity"])
+
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, "CVE-2022-22965", run.Results[1].RuleID)
+
+	assert.Len(t, run.Invocations, 1)
+	notes := run.Invocations[0].ToolExecutionNotifications
+	assert.Len(t, notes, 1)
+	assert.Contains(t, notes[0].Message.Text, "CVE-1999-0000 could not be fetched")
+}
+
+// TestFetchCVEsConcurrently_SharedClient runs workers that share one client
+// through a quota exhaustion; run with -race to check the client's rate-limit
+// bookkeeping and switch to community credentials.
+func TestFetchCVEsConcurrently_SharedClient(t *testing.T) {
+	community := auth.CommunityCredentials()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), community.OrgID) {
+			w.Header().Set("RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"success":false,"error":"daily quota exhausted"}`))
+			return
+		}
+		w.Header().Set("RateLimit-Remaining", "99")
+		w.Header().Set("X-Cache", "MISS")
+		_, _ = w.Write([]byte(exportLog4Shell))
+	}))
+	defer srv.Close()
+
+	client := testSCAClient(srv.URL)
+	client.FallbackCreds = community
+	ids := make([]string, 32)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("CVE-2024-%04d", i+1)
+	}
+
+	results := fetchCVEsConcurrently(client, ids, 8)
+	for _, r := range results {
+		assert.NoError(t, r.Err, r.ID)
+	}
+	assert.True(t, client.UsingFallback)
+	assert.Equal(t, "MISS", client.LastCacheStatus)
+}
+
+func TestVDBExport_CompressGzip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		vdbBaseURL, exportCompress, exportOut, exportCVEs = vdb.DefaultBaseURL, "none", "", nil
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(exportLog4Shell))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(t.TempDir(), "report.sarif.gz")
+	_, err := executeCommand(t, rootCmd, "vdb", "export", "--base-url", srv.URL,
+		"--cves", "CVE-2021-44228", "--compress", "gzip", "--out", out)
+	require.NoError(t, err)
+
+	compressed, err := os.ReadFile(out)
+	require.NoError(t, err)
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err, "gzip checksum must verify")
+	var log sast.SARIFLog
+	require.NoError(t, json.Unmarshal(body, &log))
+	require.Len(t, log.Runs, 1)
+	assert.Equal(t, "CVE-2021-44228", log.Runs[0].Tool.Driver.Rules[0].ID)
+
+	_, err = executeCommand(t, rootCmd, "vdb", "export", "--base-url", srv.URL,
+		"--cves", "CVE-2021-44228", "--compress", "zstd")
+	assert.ErrorContains(t, err, `unsupported --compress "zstd"`)
+}
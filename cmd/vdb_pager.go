@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vulnetix/cli/v3/pkg/tty"
+)
+
+// vdbNoPager prints long pretty output directly instead of through a pager.
+var vdbNoPager bool
+
+// Seams for tests: whether stdout is a terminal, its height in rows, and how
+// the pager is started.
+var (
+	pagerIsTerminal = tty.StdoutIsTerminal
+	pagerHeight     = tty.StdoutHeight
+	runPager        = execPager
+)
+
+// pagerCommand returns the pager to use, like git: $PAGER when set (an empty
+// value disables paging), otherwise less when it is installed.
+func pagerCommand() string {
+	if p, ok := os.LookupEnv("PAGER"); ok {
+		return strings.TrimSpace(p)
+	}
+	if _, err := exec.LookPath("less"); err == nil {
+		return "less"
+	}
+	return ""
+}
+
+// pageOutput writes pretty output to stdout, through the pager when stdout is
+// a terminal the text does not fit on. Machine-readable formats and --silent
+// never page.
+func pageOutput(text string) error {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if vdbNoPager || silent || !pagerIsTerminal() {
+		fmt.Print(text)
+		return nil
+	}
+	rows := pagerHeight()
+	pager := pagerCommand()
+	if rows <= 0 || pager == "" || strings.Count(text, "\n") < rows {
+		fmt.Print(text)
+		return nil
+	}
+	if err := runPager(pager, text); err != nil {
+		// A pager that cannot start should not cost the user the output.
+		fmt.Print(text)
+	}
+	return nil
+}
+
+// execPager runs pager through the shell, as $PAGER may carry arguments,
+// and feeds it text. LESS defaults to FRX so colours pass through and the
+// output stays on screen after quitting.
+func execPager(pager, text string) error {
+	c := exec.Command("sh", "-c", pager)
+	c.Stdin = strings.NewReader(text)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		c.Env = append(c.Env, "LESS=FRX")
+	}
+	return c.Run()
+}
+
+func init() {
+	vdbCmd.PersistentFlags().BoolVar(&vdbNoPager, "no-pager", false, "Do not page long pretty output through $PAGER")
+}
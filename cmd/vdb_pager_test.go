@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPager fakes a terminal of the given height and records pager runs.
+func stubPager(t *testing.T, isTerminal bool, rows int) *[]string {
+	t.Helper()
+	t.Setenv("PAGER", "fake-pager")
+	var runs []string
+	oldTerminal, oldHeight, oldRun := pagerIsTerminal, pagerHeight, runPager
+	pagerIsTerminal = func() bool { return isTerminal }
+	pagerHeight = func() int { return rows }
+	runPager = func(pager, text string) error {
+		runs = append(runs, pager)
+		return nil
+	}
+	t.Cleanup(func() {
+		pagerIsTerminal, pagerHeight, runPager = oldTerminal, oldHeight, oldRun
+		vdbNoPager = false
+	})
+	return &runs
+}
+
+func TestPageOutput(t *testing.T) {
+	long := strings.Repeat("line\n", 50)
+
+	runs := stubPager(t, false, 10)
+	require.NoError(t, pageOutput(long))
+	assert.Empty(t, *runs, "no pager when stdout is not a terminal")
+
+	runs = stubPager(t, true, 10)
+	require.NoError(t, pageOutput("short\n"))
+	assert.Empty(t, *runs, "no pager when the output fits")
+	require.NoError(t, pageOutput(long))
+	assert.Equal(t, []string{"fake-pager"}, *runs)
+
+	runs = stubPager(t, true, 10)
+	vdbNoPager = true
+	require.NoError(t, pageOutput(long))
+	assert.Empty(t, *runs, "--no-pager disables the pager")
+
+	runs = stubPager(t, true, 10)
+	t.Setenv("PAGER", "")
+	require.NoError(t, pageOutput(long))
+	assert.Empty(t, *runs, "an empty $PAGER disables the pager")
+}
+
+func TestVDBNoPagerFlag(t *testing.T) {
+	resetVDBSpecFlags(t)
+	runs := stubPager(t, true, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"openapi":"3.1.0","paths":{"/a":{"get":{}},"/b":{"get":{}}}}`)
+	}))
+	defer server.Close()
+
+	_, err := executeCommand(t, rootCmd, "vdb", "spec", "--base-url", server.URL, "--no-pager")
+	require.NoError(t, err)
+	assert.Empty(t, *runs)
+
+	vdbNoPager = false
+	_, err = executeCommand(t, rootCmd, "vdb", "spec", "--base-url", server.URL, "--no-pager=false")
+	require.NoError(t, err)
+	assert.Len(t, *runs, 1, "pretty output taller than the terminal is paged")
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	vers "github.com/vulnetix/cli/v3/internal/versions"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// maxProductVersionPages bounds how many pages `vdb product --latest` reads
+// while collecting every known version.
+const maxProductVersionPages = 100
+
+// runProductLatest reports the highest known version of productName.
+func runProductLatest(cmd *cobra.Command, client *vdb.Client, productName string, pageSize int) error {
+	vdbLog(cmd).Infof("📦 Finding the latest version of %s...", productName)
+
+	records, truncated, err := fetchAllProductVersions(client, productName, pageSize)
+	if err != nil {
+		var nfe *vdb.NotFoundError
+		if errors.As(err, &nfe) {
+			vdbLog(cmd).Warn(fmt.Sprintf("⚠ Product %q was not found in the database.", productName))
+			vdbLog(cmd).Info("  This identifier has been flagged for review by Vulnetix admins.")
+			return nil
+		}
+		return fmt.Errorf("failed to get product versions: %w", err)
+	}
+	printRateLimit(client)
+	recordVDBQuery("product", productName)
+	if truncated {
+		vdbLog(cmd).Warn(fmt.Sprintf("⚠ Stopped after %d pages; only the first %d versions of %q were considered.", maxProductVersionPages, len(records), productName))
+	}
+
+	latest, isSemver, ok := latestProductVersion(records)
+	if !ok {
+		vdbLog(cmd).Warn(fmt.Sprintf("⚠ No versions of %q are known.", productName))
+		return nil
+	}
+	if !isSemver {
+		vdbLog(cmd).Warn(fmt.Sprintf("⚠ No version of %q is a semantic version; picked the highest by string comparison.", productName))
+	}
+
+	return vdbRender(cmd, map[string]any{
+		"packageName":        productName,
+		"version":            latest.Version,
+		"ecosystem":          latest.Ecosystem,
+		"cveIds":             latest.CVEIDs,
+		"semver":             isSemver,
+		"versionsConsidered": len(records),
+		"truncated":          truncated,
+	}, display.RenderGenericMap)
+}
+
+// fetchAllProductVersions pages through every version of productName, up to
+// maxProductVersionPages. truncated reports that the API had more.
+func fetchAllProductVersions(client *vdb.Client, productName string, pageSize int) (records []vdb.VersionRecord, truncated bool, err error) {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	offset := 0
+	for page := 0; page < maxProductVersionPages; page++ {
+		resp, err := client.GetProductVersions(productName, pageSize, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		records = append(records, resp.Versions...)
+		offset += len(resp.Versions)
+		if !resp.HasMore || len(resp.Versions) == 0 {
+			return records, false, nil
+		}
+	}
+	return records, true, nil
+}
+
+// latestProductVersion returns the record with the highest version. Versions
+// that parse as semver win over those that do not, and a stable release wins
+// over any prerelease; a prerelease is picked only when nothing stable is
+// known. Only when no version parses is the highest picked by plain string
+// comparison, and isSemver is false. ok is false when no record has a version
+// at all.
+func latestProductVersion(records []vdb.VersionRecord) (latest vdb.VersionRecord, isSemver, ok bool) {
+	var best *semver.Version
+	for _, r := range records {
+		if r.Version == "" {
+			continue
+		}
+		v, err := semver.NewVersion(vers.Normalize(r.Version))
+		if err != nil {
+			if best == nil && (!ok || r.Version > latest.Version) {
+				latest, ok = r, true
+			}
+			continue
+		}
+		if best == nil || semverPreferred(v, best) {
+			best, latest, ok = v, r, true
+		}
+	}
+	return latest, best != nil, ok
+}
+
+// semverPreferred reports whether v should replace best as the latest
+// version: stable releases outrank prereleases, then higher versions win.
+func semverPreferred(v, best *semver.Version) bool {
+	if stable, bestStable := v.Prerelease() == "", best.Prerelease() == ""; stable != bestStable {
+		return stable
+	}
+	return v.GreaterThan(best)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func versionRecords(versions ...string) []vdb.VersionRecord {
+	records := make([]vdb.VersionRecord, len(versions))
+	for i, v := range versions {
+		records[i] = vdb.VersionRecord{Version: v, Ecosystem: "npm"}
+	}
+	return records
+}
+
+func TestLatestProductVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		versions   []string
+		want       string
+		wantSemver bool
+		wantOK     bool
+	}{
+		{name: "semver order, not string order", versions: []string{"4.9.0", "4.17.1", "4.10.2"}, want: "4.17.1", wantSemver: true, wantOK: true},
+		{name: "stable beats newer prerelease", versions: []string{"v2.0.0-rc.1", "v1.9.9", "2.0.0-beta"}, want: "v1.9.9", wantSemver: true, wantOK: true},
+		{name: "only prereleases", versions: []string{"2.0.0-beta", "v2.0.0-rc.1", "1.0.0-alpha"}, want: "v2.0.0-rc.1", wantSemver: true, wantOK: true},
+		{name: "non-semver ignored when semver exists", versions: []string{"latest", "1.2.3", "zeta"}, want: "1.2.3", wantSemver: true, wantOK: true},
+		{name: "no semver falls back to string compare", versions: []string{"release-b", "release-c", "release-a"}, want: "release-c", wantOK: true},
+		{name: "empty versions", versions: []string{"", ""}},
+		{name: "no versions"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			latest, isSemver, ok := latestProductVersion(versionRecords(tc.versions...))
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantSemver, isSemver)
+			assert.Equal(t, tc.want, latest.Version)
+		})
+	}
+}
+
+func TestVDBProductLatestPagesThroughVersions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		vdbBaseURL, vdbOutput = vdb.DefaultBaseURL, "pretty"
+		_ = productCmd.Flags().Set("latest", "false")
+		_ = productCmd.Flags().Set("limit", "100")
+	})
+	pages := [][]string{{"4.9.0", "4.17.1"}, {"4.10.2", "5.0.0-beta.1"}, {"4.18.2"}}
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		page := len(offsets) - 1
+		_ = json.NewEncoder(w).Encode(vdb.ProductVersionsResponse{
+			PackageName: "express",
+			Total:       5,
+			HasMore:     page < len(pages)-1,
+			Versions:    versionRecords(pages[page]...),
+		})
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "product", "express", "--latest", "--limit", "2",
+		"--base-url", server.URL, "--output", "json", "--no-cache")
+	require.NoError(t, err)
+	assert.Len(t, offsets, 3, "every page is read")
+	assert.Contains(t, out, `"version": "4.18.2"`, "the newer 5.0.0-beta.1 is a prerelease")
+	assert.Contains(t, out, `"versionsConsidered": 5`)
+	assert.Contains(t, out, `"truncated": false`)
+}
+
+func TestVDBProductLatestWarnsWhenTruncated(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		vdbBaseURL, vdbOutput = vdb.DefaultBaseURL, "pretty"
+		_ = productCmd.Flags().Set("latest", "false")
+	})
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(vdb.ProductVersionsResponse{
+			PackageName: "express",
+			HasMore:     true,
+			Versions:    versionRecords(fmt.Sprintf("1.0.%d", requests)),
+		})
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "product", "express", "--latest",
+		"--base-url", server.URL, "--output", "json", "--no-cache")
+	require.NoError(t, err)
+	assert.Equal(t, maxProductVersionPages, requests)
+	assert.Contains(t, out, "Stopped after 100 pages")
+	assert.Contains(t, out, `"truncated": true`)
+}
+
+func TestVDBProductLatestRejectsVersionArg(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { _ = productCmd.Flags().Set("latest", "false") })
+	_, err := executeCommand(t, rootCmd, "vdb", "product", "express", "4.17.1", "--latest")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--latest cannot be combined")
+
+	t.Cleanup(func() { _ = productCmd.Flags().Set("offset", "0"); productCmd.Flags().Lookup("offset").Changed = false })
+	_, err = executeCommand(t, rootCmd, "vdb", "product", "express", "--latest", "--offset", "100")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --offset")
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
@@ -57,6 +58,10 @@ var rawGetCmd = &cobra.Command{
 			return fmt.Errorf("--source required (run `vulnetix vdb raw sources` to list)")
 		}
 		cveID := strings.ToUpper(strings.TrimSpace(args[0]))
+		route := fmt.Sprintf("/v2/raw/%s/%s", url.PathEscape(rawSource), url.PathEscape(cveID))
+		if err := validateVDBRoute("GET", route); err != nil {
+			return err
+		}
 		client := newVDBClient()
 		client.APIVersion = "/v2"
 		body, ct, sha, r2Path, err := client.V2RawArchive(rawSource, cveID)
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// vdbRefreshSpec forces the cached OpenAPI spec to be fetched again.
+var vdbRefreshSpec bool
+
+// vdbSpecSource returns the base URL and API version the spec is fetched
+// from; together they key the spec cache.
+func vdbSpecSource() (baseURL, apiVer string) {
+	baseURL = vdbBaseURL
+	if baseURL == "" {
+		baseURL = vdb.DefaultBaseURL
+	}
+	apiVer = vdb.DefaultAPIVersion
+	if vdbAPIVersion != "" {
+		apiVer = normalizeAPIVersion(vdbAPIVersion)
+	}
+	return baseURL, apiVer
+}
+
+// loadVDBSpec returns the VDB OpenAPI spec from the local cache
+// (~/.vulnetix/cache/vdb-spec.json) while it is fresh, otherwise fetches and
+// caches it. refresh skips the cache.
+func loadVDBSpec(refresh bool) (map[string]interface{}, error) {
+	baseURL, apiVer := vdbSpecSource()
+	return loadVDBSpecVersion(baseURL, apiVer, refresh)
+}
+
+// loadVDBSpecVersion is loadVDBSpec for the spec of API version apiVer.
+func loadVDBSpecVersion(baseURL, apiVer string, refresh bool) (map[string]interface{}, error) {
+	source := baseURL + apiVer
+	path, pathErr := vdb.SpecCachePath()
+	if pathErr == nil && !refresh {
+		if spec, ok := vdb.LoadCachedSpec(path, source, vdb.SpecCacheTTL); ok {
+			return spec, nil
+		}
+	}
+
+	spec, err := fetchVDBSpec(baseURL, apiVer, refresh)
+	if err != nil {
+		return nil, err
+	}
+	if pathErr == nil {
+		_ = vdb.SaveCachedSpec(path, source, spec)
+	}
+	return spec, nil
+}
+
+// fetchVDBSpec downloads the spec, through the authenticated client when
+// credentials are available and anonymously otherwise (the spec is public).
+// refresh also bypasses the client's response cache. The anonymous fetch is
+// bounded by --list-timeout.
+func fetchVDBSpec(baseURL, apiVer string, refresh bool) (map[string]interface{}, error) {
+	if vdbCreds != nil && vdbCreds.OrgID != "" {
+		client := newVDBClient()
+		client.APIVersion = apiVer
+		client.RefreshCache = client.RefreshCache || refresh
+		spec, err := client.GetOpenAPISpec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spec: %w", err)
+		}
+		printRateLimit(client)
+		return spec, nil
+	}
+
+	ctx, cancel := httpx.WithTimeout(context.Background(), httpx.ListTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+apiVer+"/spec", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch spec: %w", err)
+	}
+	resp, err := (&http.Client{Transport: httpx.DefaultTransport}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch spec: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("failed to fetch spec: HTTP %d", resp.StatusCode)
+	}
+
+	var spec map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	return spec, nil
+}
+
+// validateVDBRoute checks method and path against the cached OpenAPI spec
+// before a request is sent, catching a route the API does not serve early.
+// A path with a version segment, such as "/v2/...", is checked against that
+// version's spec rather than --api-version's. When the spec cannot be loaded
+// the request goes ahead unchecked.
+func validateVDBRoute(method, path string) error {
+	baseURL, apiVer := vdbSpecSource()
+	if v := vdb.RouteAPIVersion(path); v != "" {
+		apiVer = v
+	}
+	spec, err := loadVDBSpecVersion(baseURL, apiVer, vdbRefreshSpec)
+	if err != nil {
+		return nil
+	}
+	if !vdb.SpecHasOperation(spec, method, path) {
+		return fmt.Errorf("the VDB API spec has no %s %s operation; check the arguments, or pass --refresh-spec if the cached spec is stale", method, path)
+	}
+	return nil
+}
+
+func init() {
+	specCmd.Flags().BoolVar(&vdbRefreshSpec, "refresh-spec", false, "Fetch the OpenAPI spec again instead of using the local cache")
+	endpointsCmd.Flags().BoolVar(&vdbRefreshSpec, "refresh-spec", false, "Fetch the OpenAPI spec again instead of using the local cache")
+	rawCmd.PersistentFlags().BoolVar(&vdbRefreshSpec, "refresh-spec", false, "Fetch the OpenAPI spec again before validating the request")
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func resetVDBSpecFlags(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		vdbBaseURL, vdbRefreshSpec = vdb.DefaultBaseURL, false
+		_ = specCmd.Flags().Set("refresh-spec", "false")
+	})
+}
+
+func TestVDBSpecUsesCache(t *testing.T) {
+	resetVDBSpecFlags(t)
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/spec", r.URL.Path)
+		fetches++
+		_, _ = io.WriteString(w, `{"openapi":"3.1.0","paths":{"/raw/sources":{"get":{}}}}`)
+	}))
+	defer server.Close()
+
+	for i := 0; i < 2; i++ {
+		out, err := executeCommand(t, rootCmd, "vdb", "spec", "--base-url", server.URL)
+		require.NoError(t, err)
+		assert.Contains(t, out, "3.1.0")
+	}
+	assert.Equal(t, 1, fetches, "the second run should be served from the spec cache")
+
+	_, err := executeCommand(t, rootCmd, "vdb", "spec", "--base-url", server.URL, "--refresh-spec")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches, "--refresh-spec should bypass the cache")
+}
+
+func TestValidateVDBRouteRejectsUnknownPath(t *testing.T) {
+	resetVDBSpecFlags(t)
+	vdbBaseURL = "http://127.0.0.1:0"
+	path, err := vdb.SpecCachePath()
+	require.NoError(t, err)
+	require.NoError(t, vdb.SaveCachedSpec(path, vdbBaseURL+vdb.DefaultAPIVersion, map[string]any{
+		"paths": map[string]any{"/raw/{source}/{cveId}": map[string]any{"get": map[string]any{}}},
+	}))
+
+	assert.NoError(t, validateVDBRoute("GET", "/v2/raw/ghsa/CVE-2021-44228"))
+
+	err = validateVDBRoute("GET", "/v2/raws/ghsa/CVE-2021-44228")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no GET /v2/raws/ghsa/CVE-2021-44228 operation")
+}
+
+const sampleVDBSpec = `{"openapi":"3.1.0","paths":{
+	"/vuln/{identifier}":{"get":{"summary":"Get a vulnerability","tags":["Vulnerabilities"]},"parameters":[]},
+	"/exploits/search":{"get":{"description":"Search exploits.\nSupports filters.","tags":["Exploits"]}},
+	"/vex":{"post":{"summary":"Submit a VEX document"},"get":{"summary":"List VEX documents"}}}}`
+
+func TestSpecEndpoints(t *testing.T) {
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(sampleVDBSpec), &spec))
+
+	all := specEndpoints(spec, "")
+	assert.Equal(t, []specEndpoint{
+		{Method: "GET", Path: "/exploits/search", Summary: "Search exploits.", Tags: []string{"Exploits"}},
+		{Method: "GET", Path: "/vex", Summary: "List VEX documents"},
+		{Method: "POST", Path: "/vex", Summary: "Submit a VEX document"},
+		{Method: "GET", Path: "/vuln/{identifier}", Summary: "Get a vulnerability", Tags: []string{"Vulnerabilities"}},
+	}, all)
+
+	assert.Len(t, specEndpoints(spec, "VEX"), 2)
+	assert.Len(t, specEndpoints(spec, "post"), 1)
+	assert.Len(t, specEndpoints(spec, "vulnerabilities"), 1, "tags are searched")
+	assert.Empty(t, specEndpoints(spec, "kev"))
+}
+
+func TestVDBEndpointsCommand(t *testing.T) {
+	resetVDBSpecFlags(t)
+	t.Cleanup(func() { vdbOutput = "pretty" })
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, sampleVDBSpec)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "endpoints", "exploit", "--base-url", server.URL, "--output", "json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"path": "/exploits/search"`)
+	assert.NotContains(t, out, "/vuln/{identifier}")
+}
+
+func TestVDBSpecFetchTimesOut(t *testing.T) {
+	resetVDBSpecFlags(t)
+	prevTimeout, prevCreds := httpx.ListTimeout, vdbCreds
+	httpx.ListTimeout, vdbCreds = 50*time.Millisecond, nil
+	t.Cleanup(func() { httpx.ListTimeout, vdbCreds = prevTimeout, prevCreds })
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := fetchVDBSpec(server.URL, "/v2", true)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestVDBTemplateReplacesOutput(t *testing.T) {
+	resetVDBSpecFlags(t)
+	reset := func() {
+		vdbOutput, vdbTemplate = "pretty", ""
+		vdbCmd.PersistentFlags().Lookup("output").Changed = false
+		vdbCmd.PersistentFlags().Lookup("template").Changed = false
+	}
+	reset()
+	t.Cleanup(reset)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, sampleVDBSpec)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "endpoints", "exploit", "--base-url", server.URL, "--template", "{{range .}}{{.path}}{{end}}")
+	require.NoError(t, err)
+	assert.Contains(t, out, "/exploits/search")
+
+	_, err = executeCommand(t, rootCmd, "vdb", "endpoints", "exploit", "--base-url", server.URL, "--template", "{{len .}}", "--output", "json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--template and --output cannot be used together")
+
+	reset()
+	requests := 0
+	counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = io.WriteString(w, sampleVDBSpec)
+	}))
+	defer counting.Close()
+	_, err = executeCommand(t, rootCmd, "vdb", "endpoints", "exploit", "--base-url", counting.URL, "--output", "template")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--output must be one of: json, jsonl, yaml, pretty")
+	assert.Zero(t, requests, "an invalid --output is rejected before any request")
+}
+
+func TestValidateVDBRouteUsesTheRoutesAPIVersion(t *testing.T) {
+	resetVDBSpecFlags(t)
+	prevVersion := vdbAPIVersion
+	t.Cleanup(func() { vdbAPIVersion = prevVersion })
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/spec":
+			_, _ = io.WriteString(w, `{"paths":{"/raw/{source}/{cveId}":{"get":{}}}}`)
+		default:
+			_, _ = io.WriteString(w, `{"paths":{"/vuln/{identifier}":{"get":{}}}}`)
+		}
+	}))
+	defer server.Close()
+	vdbBaseURL = server.URL
+	vdbAPIVersion = "v1"
+
+	assert.NoError(t, validateVDBRoute("GET", "/v2/raw/ghsa/CVE-2021-44228"), "a /v2 route is checked against the v2 spec")
+	assert.Error(t, validateVDBRoute("GET", "/v2/raws/ghsa/CVE-2021-44228"))
+}
+
+func TestVDBStatusSpecURLIgnoresTrailingSlash(t *testing.T) {
+	resetVDBSpecFlags(t)
+	t.Cleanup(func() { vdbOutput = "pretty" })
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"ok"}`)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "status", "--base-url", server.URL+"/", "--output", "json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"oas_spec_url": "`+server.URL+`/v2/spec"`)
+}
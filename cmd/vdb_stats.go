@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+var vdbStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show VDB coverage: packages per ecosystem and total CVEs",
+	Long: `Give a quick overview of Vulnetix VDB coverage: the number of packages
+tracked per ecosystem, the total number of distinct vulnerability IDs, and when
+the ecosystem counts were last refreshed.
+
+Examples:
+  vulnetix vdb stats
+  vulnetix vdb stats --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newVDBClient()
+
+		vdbLog(cmd).Info("📊 Fetching VDB coverage statistics...")
+
+		stats, err := client.GetStats()
+		if err != nil {
+			return fmt.Errorf("failed to get stats: %w", err)
+		}
+		printRateLimit(client)
+		recordVDBQuery("stats", "")
+
+		return vdbRender(cmd, stats, renderVDBStats)
+	},
+}
+
+// renderVDBStats renders the coverage overview as a key/value header followed
+// by a per-ecosystem table with each ecosystem's share of all packages.
+func renderVDBStats(data interface{}, ctx *display.Context) string {
+	stats, ok := data.(*vdb.Stats)
+	if !ok {
+		return display.RenderGenericMap(data, ctx)
+	}
+	t := ctx.Term
+	var b strings.Builder
+
+	lastUpdated := "unknown"
+	if !stats.LastUpdated.IsZero() {
+		lastUpdated = stats.LastUpdated.Format(time.RFC3339)
+	}
+	b.WriteString("\n" + display.Header(t, "VDB coverage") + "\n")
+	b.WriteString(display.KeyValue(t, []display.KVPair{
+		{Key: "Total CVEs", Value: display.FormatNumber(stats.TotalCVEs)},
+		{Key: "Total packages", Value: display.FormatNumber(stats.TotalPackages)},
+		{Key: "Ecosystems", Value: display.FormatNumber(len(stats.Ecosystems))},
+		{Key: "Last updated", Value: lastUpdated},
+	}))
+
+	rows := make([][]string, 0, len(stats.Ecosystems))
+	for _, e := range stats.Ecosystems {
+		share := "-"
+		if stats.TotalPackages > 0 {
+			share = fmt.Sprintf("%.1f%%", float64(e.Count)*100/float64(stats.TotalPackages))
+		}
+		rows = append(rows, []string{e.Name, display.FormatNumber(e.Count), share})
+	}
+	if len(rows) > 0 {
+		cols := []display.Column{
+			{Header: "Ecosystem"},
+			{Header: "Packages", Align: display.AlignRight},
+			{Header: "Share", Align: display.AlignRight},
+		}
+		b.WriteString("\n" + display.Table(t, cols, rows))
+	}
+	return b.String()
+}
+
+func init() {
+	vdbCmd.AddCommand(vdbStatsCmd)
+}
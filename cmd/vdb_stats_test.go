@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vulnetix/cli/v3/internal/display"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func TestRenderVDBStats(t *testing.T) {
+	ctx := display.New(display.ModeText, false)
+	out := renderVDBStats(&vdb.Stats{
+		Ecosystems:    []vdb.Ecosystem{{Name: "npm", Count: 750}, {Name: "pypi", Count: 250}},
+		TotalPackages: 1000,
+		TotalCVEs:     285000,
+		LastUpdated:   time.Date(2025, 10, 15, 3, 0, 0, 0, time.UTC),
+	}, ctx)
+
+	assert.Contains(t, out, "285,000")
+	assert.Contains(t, out, "2025-10-15T03:00:00Z")
+	assert.Contains(t, out, "npm")
+	assert.Contains(t, out, "75.0%")
+	assert.Contains(t, out, "25.0%")
+	assert.Less(t, indexOf(out, "npm"), indexOf(out, "pypi"))
+}
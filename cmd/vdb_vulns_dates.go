@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+// vulnsDateRange is the --published-after/--published-before window of
+// `vdb vulns`. After is inclusive and before exclusive, so a window of
+// 2024-01-01 to 2024-02-01 is January. A zero bound is open.
+type vulnsDateRange struct {
+	after, before time.Time
+}
+
+// parseVulnsDateRange reads the two flags, each an RFC 3339 timestamp or a
+// date (2006-01-02, midnight UTC).
+func parseVulnsDateRange(after, before string) (vulnsDateRange, error) {
+	var r vulnsDateRange
+	var err error
+	if r.after, err = parseVulnsDate("--published-after", after); err != nil {
+		return r, err
+	}
+	if r.before, err = parseVulnsDate("--published-before", before); err != nil {
+		return r, err
+	}
+	if !r.after.IsZero() && !r.before.IsZero() && !r.after.Before(r.before) {
+		return r, fmt.Errorf("--published-after must be earlier than --published-before")
+	}
+	return r, nil
+}
+
+func parseVulnsDate(flag, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s %q must be a date (2006-01-02) or an RFC 3339 timestamp", flag, s)
+}
+
+// active reports whether either bound is set.
+func (r vulnsDateRange) active() bool {
+	return !r.after.IsZero() || !r.before.IsZero()
+}
+
+// contains reports whether rec falls in the window by its published date, or
+// its modified date when it has no published one. A record with neither is
+// outside any active window, since it cannot be shown to be in it.
+func (r vulnsDateRange) contains(rec vdb.VersionRecord) bool {
+	if !r.active() {
+		return true
+	}
+	date := recordDate(rec)
+	if date.IsZero() {
+		return false
+	}
+	if !r.after.IsZero() && date.Before(r.after) {
+		return false
+	}
+	if !r.before.IsZero() && !date.Before(r.before) {
+		return false
+	}
+	return true
+}
+
+// recordDate is the published date of rec, else its modified date, else zero.
+func recordDate(rec vdb.VersionRecord) time.Time {
+	if rec.Published != nil && !rec.Published.IsZero() {
+		return rec.Published.Time
+	}
+	if rec.Modified != nil {
+		return rec.Modified.Time
+	}
+	return time.Time{}
+}
+
+// filter keeps the records of resp inside r and reports how many were
+// kept and how many were dropped for having no date at all.
+func (r vulnsDateRange) filter(resp *vdb.VulnerabilitiesResponse) (kept, undated int) {
+	keep := func(records []vdb.VersionRecord) []vdb.VersionRecord {
+		if records == nil {
+			return nil
+		}
+		out := make([]vdb.VersionRecord, 0, len(records))
+		for _, rec := range records {
+			if r.contains(rec) {
+				out = append(out, rec)
+			} else if recordDate(rec).IsZero() {
+				undated++
+			}
+		}
+		kept += len(out)
+		return out
+	}
+	resp.Versions = keep(resp.Versions)
+	resp.Vulnerabilities = keep(resp.Vulnerabilities)
+	return kept, undated
+}
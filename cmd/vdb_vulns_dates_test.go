@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulnetix/cli/v3/pkg/vdb"
+)
+
+func datedRecord(t *testing.T, fields string) vdb.VersionRecord {
+	t.Helper()
+	var rec vdb.VersionRecord
+	require.NoError(t, json.Unmarshal([]byte(`{"version":"1.0.0"`+fields+`}`), &rec))
+	return rec
+}
+
+func TestVulnsDateRangeBoundaries(t *testing.T) {
+	january, err := parseVulnsDateRange("2024-01-01", "2024-02-01")
+	require.NoError(t, err)
+	since, err := parseVulnsDateRange("2024-01-01T12:00:00Z", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		fields      string
+		inJanuary   bool
+		inSinceNoon bool
+	}{
+		{name: "first instant is inclusive", fields: `,"published":"2024-01-01T00:00:00Z"`, inJanuary: true},
+		{name: "last instant before the end", fields: `,"published":"2024-01-31T23:59:59Z"`, inJanuary: true, inSinceNoon: true},
+		{name: "end is exclusive", fields: `,"published":"2024-02-01"`, inSinceNoon: true},
+		{name: "before the start", fields: `,"published":"2023-12-31T23:59:59Z"`},
+		{name: "offset timestamp", fields: `,"published":"2024-01-01T13:00:00+02:00"`, inJanuary: true},
+		{name: "epoch milliseconds", fields: `,"published":1705320000000`, inJanuary: true, inSinceNoon: true},
+		{name: "modified when not published", fields: `,"modified":"2024-01-15"`, inJanuary: true, inSinceNoon: true},
+		{name: "published wins over modified", fields: `,"published":"2023-06-01","modified":"2024-01-15"`},
+		{name: "no date"},
+		{name: "unparseable date", fields: `,"published":"last tuesday"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := datedRecord(t, tc.fields)
+			assert.Equal(t, tc.inJanuary, january.contains(rec), "2024-01-01 to 2024-02-01")
+			assert.Equal(t, tc.inSinceNoon, since.contains(rec), "from 2024-01-01T12:00:00Z")
+			assert.True(t, vulnsDateRange{}.contains(rec), "no range keeps everything")
+		})
+	}
+}
+
+func TestParseVulnsDateRangeRejectsBadInput(t *testing.T) {
+	for _, tc := range []struct{ after, before, want string }{
+		{after: "01/02/2024", want: "--published-after"},
+		{before: "2024-13-01", want: "--published-before"},
+		{after: "2024-02-01", before: "2024-02-01", want: "earlier than"},
+		{after: "2024-03-01", before: "2024-02-01", want: "earlier than"},
+	} {
+		_, err := parseVulnsDateRange(tc.after, tc.before)
+		assert.ErrorContains(t, err, tc.want, "after=%q before=%q", tc.after, tc.before)
+	}
+}
+
+func TestVDBVulnsFiltersByPublishedDate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VULNETIX_API_TOKEN", "test-token")
+	t.Cleanup(func() {
+		vdbBaseURL, vdbOutput = vdb.DefaultBaseURL, "pretty"
+		_ = vulnsCmd.Flags().Set("published-after", "")
+		_ = vulnsCmd.Flags().Set("published-before", "")
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"packageName":"express","totalCVEs":4,"total":4,"versions":[
+			{"version":"4.17.1","ecosystem":"npm","cveIds":["CVE-2022-24999"],"published":"2022-11-26T22:15:00Z"},
+			{"version":"4.18.2","ecosystem":"npm","cveIds":["CVE-2024-29041"],"published":"2024-03-25"},
+			{"version":"4.19.1","ecosystem":"npm","cveIds":["CVE-2024-43796"],"modified":1726509600000},
+			{"version":"4.0.0","ecosystem":"npm","cveIds":["CVE-2014-6393"]}
+		]}`)
+	}))
+	defer server.Close()
+
+	out, err := executeCommand(t, rootCmd, "vdb", "vulns", "express", "--published-after", "2024-01-01",
+		"--base-url", server.URL, "--output", "json", "--no-cache")
+	require.NoError(t, err)
+	var got vdb.VulnerabilitiesResponse
+	require.NoError(t, json.NewDecoder(strings.NewReader(out[strings.Index(out, "{\n"):])).Decode(&got))
+	var versions []string
+	for _, rec := range got.Versions {
+		versions = append(versions, rec.Version)
+	}
+	assert.Equal(t, []string{"4.18.2", "4.19.1"}, versions)
+
+	out, err = executeCommand(t, rootCmd, "vdb", "vulns", "express", "--published-after", "", "--published-before", "2024-01-01",
+		"--base-url", server.URL, "--output", "jsonl", "--no-cache")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"version":"4.17.1"`)
+	assert.NotContains(t, out, `"version":"4.18.2"`)
+	assert.NotContains(t, out, `"version":"4.0.0"`, "undated records are left out")
+}
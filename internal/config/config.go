@@ -81,20 +81,20 @@ type CIContext struct {
 // VulnetixConfig represents the complete configuration state
 type VulnetixConfig struct {
 	// Core Vulnetix settings
-	OrgID       string   `json:"org_id"`
-	Task        TaskType `json:"task"`
-	ProjectName string   `json:"project_name,omitempty"`
-	ProductName string   `json:"product_name,omitempty"`
-	TeamName    string   `json:"team_name,omitempty"`
-	GroupName   string   `json:"group_name,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
-	Tools       []Tool   `json:"tools,omitempty"`
+	OrgID       string   `yaml:"org_id" json:"org_id"`
+	Task        TaskType `yaml:"task" json:"task"`
+	ProjectName string   `yaml:"project_name" json:"project_name,omitempty"`
+	ProductName string   `yaml:"product_name" json:"product_name,omitempty"`
+	TeamName    string   `yaml:"team_name" json:"team_name,omitempty"`
+	GroupName   string   `yaml:"group_name" json:"group_name,omitempty"`
+	Tags        []string `yaml:"tags" json:"tags,omitempty"`
+	Tools       []Tool   `yaml:"tools" json:"tools,omitempty"`
 
 	// CI/CD context (replaces GitHub-specific context)
-	CI CIContext `json:"ci"`
+	CI CIContext `yaml:"-" json:"ci"`
 
 	// Runtime info
-	Version string `json:"version"`
+	Version string `yaml:"-" json:"version"`
 }
 
 // DetectPlatform detects the current runtime platform based on environment variables
@@ -510,6 +510,9 @@ func (c *VulnetixConfig) PrintConfiguration() {
 		for _, tool := range c.Tools {
 			fmt.Printf("     - %s (%s): %s\n", tool.Category, tool.Format, tool.ArtifactName)
 		}
+		for _, w := range c.ToolFormatWarnings() {
+			fmt.Printf("   ⚠️  %s\n", w)
+		}
 	}
 
 	fmt.Printf("   Features: %v\n", c.CI.DetectedFeatures)
@@ -528,6 +531,60 @@ func ValidateTask(task string) (TaskType, error) {
 	}
 }
 
+// toolCategoryFormats lists the artifact formats a tool of each category can
+// plausibly produce. Categories are matched case-insensitively; a category not
+// listed here is not checked, and BLOB is accepted for every category.
+var toolCategoryFormats = map[string][]ToolFormat{
+	"sca":       {FormatSBOM, FormatCycloneDX, FormatVDR, FormatOpenVEX, FormatCSAF_VEX, FormatSARIF, FormatPlainJSON},
+	"sast":      {FormatSARIF, FormatPlainJSON, FormatPlainXML},
+	"dast":      {FormatSARIF, FormatPlainJSON, FormatPlainXML},
+	"secrets":   {FormatSARIF, FormatPlainJSON},
+	"secret":    {FormatSARIF, FormatPlainJSON},
+	"iac":       {FormatSARIF, FormatPlainJSON},
+	"container": {FormatSBOM, FormatCycloneDX, FormatVDR, FormatSARIF, FormatPlainJSON},
+	"license":   {FormatSBOM, FormatPlainJSON},
+	"vex":       {FormatOpenVEX, FormatCSAF_VEX, FormatCycloneDX},
+}
+
+// ExpectedFormats returns the formats plausible for a tool category, or nil
+// when the category is not known.
+func ExpectedFormats(category string) []ToolFormat {
+	return toolCategoryFormats[strings.ToLower(strings.TrimSpace(category))]
+}
+
+// FormatWarning returns a description of why the tool's format is implausible
+// for its category, such as a SAST tool declaring an SBOM, or "" when the
+// pairing is plausible or the category is unknown.
+func (t Tool) FormatWarning() string {
+	expected := ExpectedFormats(t.Category)
+	if expected == nil || t.Format == "" || t.Format == FormatBlob {
+		return ""
+	}
+	for _, f := range expected {
+		if strings.EqualFold(string(f), string(t.Format)) {
+			return ""
+		}
+	}
+	names := make([]string, len(expected))
+	for i, f := range expected {
+		names[i] = string(f)
+	}
+	return fmt.Sprintf("tool %q declares format %s, which a %s tool does not produce (expected one of: %s)",
+		t.ArtifactName, t.Format, t.Category, strings.Join(names, ", "))
+}
+
+// ToolFormatWarnings checks every configured tool's format against its
+// category and returns one warning per implausible pairing.
+func (c *VulnetixConfig) ToolFormatWarnings() []string {
+	var warnings []string
+	for _, tool := range c.Tools {
+		if w := tool.FormatWarning(); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
 // GetWorkflowRunContext returns workflow run context information
 func (c *VulnetixConfig) GetWorkflowRunContext() map[string]string {
 	return map[string]string{
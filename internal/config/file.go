@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnvVar overrides the location of the CLI config file.
+const FileEnvVar = "VULNETIX_CONFIG"
+
+// File is the CLI config file: per-command flag defaults grouped under the
+// top-level command name, e.g.
+//
+//	strict: true
+//	output: json
+//	upload:
+//	  base_url: https://api.example.com/v1
+//	vdb:
+//	  output: json
+type File struct {
+	// Path is where the file was read from; empty when no file exists.
+	Path string
+	// Strict rejects keys that do not name a flag of their command, and
+	// sections no command reads.
+	Strict bool
+	// Output is the default output format of every command, e.g. json.
+	Output string
+	// Sections maps a command name to its key/value defaults.
+	Sections map[string]map[string]any
+}
+
+// DefaultFilePath returns $VULNETIX_CONFIG, or ~/.vulnetix/config.yaml.
+func DefaultFilePath() (string, error) {
+	if p := os.Getenv(FileEnvVar); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".vulnetix", "config.yaml"), nil
+}
+
+// LoadFile reads the config file at path. A missing file is not an error and
+// yields an empty File.
+func LoadFile(path string) (*File, error) {
+	f := &File{Sections: map[string]map[string]any{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	f.Path = path
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	for key, value := range raw {
+		if key == "strict" {
+			strict, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("config %s: strict must be true or false", path)
+			}
+			f.Strict = strict
+			continue
+		}
+		if key == "output" {
+			output, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("config %s: output must be text, json or yaml", path)
+			}
+			f.Output = output
+			continue
+		}
+		section, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("config %s: %s must be a mapping of %s flag defaults", path, key, key)
+		}
+		f.Sections[key] = section
+	}
+	return f, nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := LoadFile(filepath.Join(dir, "missing.yaml"))
+	if err != nil || f.Path != "" || len(f.Sections) != 0 {
+		t.Fatalf("missing file: got %+v, %v; want empty File", f, err)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("strict: true\noutput: json\nvdb:\n  output: json\nupload:\n  base_url: https://example.com/v1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err = LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Strict || f.Path != path || f.Output != "json" {
+		t.Errorf("Strict/Path/Output = %v/%q/%q", f.Strict, f.Path, f.Output)
+	}
+	if got := f.Sections["vdb"]["output"]; got != "json" {
+		t.Errorf("vdb.output = %v, want json", got)
+	}
+	if got := f.Sections["upload"]["base_url"]; got != "https://example.com/v1" {
+		t.Errorf("upload.base_url = %v", got)
+	}
+
+	for _, bad := range []string{"vdb: json\n", "strict: yes please\n", "output: [json]\n", "vdb: [\n"} {
+		if err := os.WriteFile(path, []byte(bad), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("LoadFile(%q) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SampleConfig is the commented vulnetix.yaml written by `vulnetix init`. It
+// must stay parseable by ParseConfig, which `vulnetix init --check` runs.
+const SampleConfig = `# vulnetix.yaml — repository configuration for the Vulnetix CLI.
+# Every key is optional; delete what you do not need.
+
+# How results are grouped in the Vulnetix dashboard. Empty values fall back
+# to names derived from the repository.
+project_name: ""
+product_name: ""
+team_name: ""
+group_name: ""
+
+# Tools whose CI artifacts Vulnetix collects. One entry per tool:
+#   category             what the tool checks: sca, sast, dast, secrets, iac,
+#                        container, license or vex
+#   artifact_name        name of the CI artifact the tool's report is uploaded as
+#   format               report format: SARIF, SBOM, CSAF_VEX, OpenVEX,
+#                        CycloneDX_VEX, VDR, PLAIN_JSON, PLAIN_XML or BLOB
+#   customer_identifier  optional ID of your own to correlate uploads with
+tools:
+  - category: sca
+    artifact_name: sbom
+    format: SBOM
+    customer_identifier: ""
+  - category: sast
+    artifact_name: semgrep-results
+    format: SARIF
+    customer_identifier: ""
+  - category: secrets
+    artifact_name: gitleaks-report
+    format: SARIF
+    customer_identifier: ""
+
+# Org policy, normally filled in by ` + "`vulnetix config pull`" + `. Values set here
+# override the org's defaults for this repository.
+# production_branch: main
+# required_tools: [sca, sast, secrets]
+# severity: high
+# cvss_threshold: 7.0
+# epss_threshold: 0.1
+`
+
+// repoConfigFile is the shape of vulnetix.yaml: the config fields plus the
+// org policy keys that `vulnetix config pull` manages.
+type repoConfigFile struct {
+	VulnetixConfig `yaml:",inline"`
+
+	ProductionBranch string   `yaml:"production_branch"`
+	RequiredTools    []string `yaml:"required_tools"`
+	Severity         string   `yaml:"severity"`
+	CVSSThreshold    *float64 `yaml:"cvss_threshold"`
+	EPSSThreshold    *float64 `yaml:"epss_threshold"`
+}
+
+// knownFormats lists every ToolFormat a tool may declare.
+var knownFormats = []ToolFormat{
+	FormatSARIF, FormatSBOM, FormatCSAF_VEX, FormatOpenVEX, FormatCycloneDX,
+	FormatVDR, FormatPlainJSON, FormatPlainXML, FormatBlob,
+}
+
+// ParseConfig decodes a vulnetix.yaml document. Unknown keys are rejected so
+// typos do not go unnoticed, and each tool must name a category, an artifact
+// and a known format. Formats are matched case-insensitively and normalized.
+func ParseConfig(data []byte) (*VulnetixConfig, error) {
+	var file repoConfigFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	cfg := &file.VulnetixConfig
+
+	task, err := ValidateTask(string(cfg.Task))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Task = task
+
+	for i := range cfg.Tools {
+		tool := &cfg.Tools[i]
+		if strings.TrimSpace(tool.Category) == "" {
+			return nil, fmt.Errorf("tools[%d]: category is required", i)
+		}
+		if strings.TrimSpace(tool.ArtifactName) == "" {
+			return nil, fmt.Errorf("tools[%d]: artifact_name is required", i)
+		}
+		format, ok := lookupFormat(tool.Format)
+		if !ok {
+			return nil, fmt.Errorf("tools[%d]: unknown format %q", i, tool.Format)
+		}
+		tool.Format = format
+	}
+	return cfg, nil
+}
+
+func lookupFormat(f ToolFormat) (ToolFormat, bool) {
+	for _, known := range knownFormats {
+		if strings.EqualFold(string(known), string(f)) {
+			return known, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleConfigParses(t *testing.T) {
+	cfg, err := ParseConfig([]byte(SampleConfig))
+	require.NoError(t, err)
+	require.NotEmpty(t, cfg.Tools)
+	for _, tool := range cfg.Tools {
+		assert.NotEmpty(t, tool.Category)
+		assert.NotEmpty(t, tool.ArtifactName)
+	}
+	assert.Empty(t, cfg.ToolFormatWarnings(), "the sample should pair every tool with a plausible format")
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte("project_name: api\ntags: [pci]\nseverity: high\ntools:\n  - category: sast\n    artifact_name: semgrep\n    format: sarif\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "api", cfg.ProjectName)
+	assert.Equal(t, []string{"pci"}, cfg.Tags)
+	assert.Equal(t, FormatSARIF, cfg.Tools[0].Format, "formats are normalized")
+
+	cfg, err = ParseConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, TaskInfo, cfg.Task)
+
+	for name, doc := range map[string]string{
+		"unknown key":      "projct_name: api\n",
+		"unknown task":     "task: scan\n",
+		"unknown format":   "tools:\n  - category: sca\n    artifact_name: sbom\n    format: SPDX\n",
+		"missing category": "tools:\n  - artifact_name: sbom\n    format: SBOM\n",
+		"missing artifact": "tools:\n  - category: sca\n    format: SBOM\n",
+		"not a mapping":    "- tools\n",
+	} {
+		_, err := ParseConfig([]byte(doc))
+		assert.Error(t, err, name)
+	}
+}
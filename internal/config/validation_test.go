@@ -29,3 +29,47 @@ func TestValidateTask(t *testing.T) {
 		})
 	}
 }
+
+func TestToolFormatWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     Tool
+		wantWarn bool
+	}{
+		{name: "SAST with SARIF", tool: Tool{Category: "SAST", Format: FormatSARIF, ArtifactName: "semgrep"}},
+		{name: "SCA with SBOM", tool: Tool{Category: "sca", Format: FormatSBOM, ArtifactName: "syft"}},
+		{name: "SCA with VDR", tool: Tool{Category: "SCA", Format: FormatVDR, ArtifactName: "grype"}},
+		{name: "secrets with SARIF", tool: Tool{Category: "secrets", Format: FormatSARIF, ArtifactName: "gitleaks"}},
+		{name: "BLOB for any category", tool: Tool{Category: "sast", Format: FormatBlob, ArtifactName: "raw"}},
+		{name: "unknown category", tool: Tool{Category: "fuzzing", Format: FormatSBOM, ArtifactName: "fuzz"}},
+		{name: "SAST with SBOM", tool: Tool{Category: "SAST", Format: FormatSBOM, ArtifactName: "semgrep"}, wantWarn: true},
+		{name: "secrets with OpenVEX", tool: Tool{Category: "secret", Format: FormatOpenVEX, ArtifactName: "gitleaks"}, wantWarn: true},
+		{name: "IaC with CSAF VEX", tool: Tool{Category: "iac", Format: FormatCSAF_VEX, ArtifactName: "checkov"}, wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := tt.tool.FormatWarning()
+			if tt.wantWarn {
+				assert.Contains(t, warning, tt.tool.ArtifactName)
+				assert.Contains(t, warning, string(tt.tool.Format))
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
+
+func TestToolFormatWarnings(t *testing.T) {
+	c := &VulnetixConfig{Tools: []Tool{
+		{Category: "sast", Format: FormatSARIF, ArtifactName: "semgrep"},
+		{Category: "sast", Format: FormatSBOM, ArtifactName: "bandit"},
+		{Category: "sca", Format: FormatSBOM, ArtifactName: "syft"},
+	}}
+	warnings := c.ToolFormatWarnings()
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "bandit")
+
+	assert.Nil(t, ExpectedFormats("unknown"))
+	assert.Contains(t, ExpectedFormats(" SCA "), FormatSBOM)
+}
@@ -15,6 +15,7 @@ type Context struct {
 	Mode       OutputMode
 	Silent     bool
 	NoProgress bool
+	Verbosity  Verbosity
 }
 
 // New creates a display context from mode and silent flag.
@@ -24,13 +25,24 @@ func New(mode OutputMode, silent bool) *Context {
 
 // NewWithProgress creates a display context from output and progress flags.
 func NewWithProgress(mode OutputMode, silent bool, noProgress bool) *Context {
+	level := VerbositySteps
+	if silent {
+		level = VerbosityResults
+	}
+	return NewWithVerbosity(mode, level, noProgress)
+}
+
+// NewWithVerbosity creates a display context that prints up to level.
+// Progress is shown from VerbositySteps up.
+func NewWithVerbosity(mode OutputMode, level Verbosity, noProgress bool) *Context {
 	term := NewTerminal()
 	return &Context{
-		Logger:     NewLogger(mode, silent, term),
+		Logger:     NewLeveledLogger(mode, level, term),
 		Term:       term,
 		Mode:       mode,
-		Silent:     silent,
+		Silent:     level < VerbositySteps,
 		NoProgress: noProgress,
+		Verbosity:  level,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -15,21 +16,74 @@ const (
 	ModeJSON
 )
 
-// Logger routes output between stdout and stderr based on mode and silent flags.
+// Verbosity is how much a command narrates, from --verbosity.
+type Verbosity int
+
+const (
+	// VerbosityErrors prints errors and explicitly requested JSON only.
+	VerbosityErrors Verbosity = iota
+	// VerbosityResults adds text results; the same as --silent.
+	VerbosityResults
+	// VerbositySteps adds info, warnings and progress; the default.
+	VerbositySteps
+	// VerbosityDebug adds debug diagnostics; the same as --verbose.
+	VerbosityDebug
+)
+
+// String implements pflag.Value.
+func (v *Verbosity) String() string { return strconv.Itoa(int(*v)) }
+
+// Set implements pflag.Value, accepting 0 to 3.
+func (v *Verbosity) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < int(VerbosityErrors) || n > int(VerbosityDebug) {
+		return fmt.Errorf("verbosity must be 0 (errors), 1 (results), 2 (steps) or 3 (debug)")
+	}
+	*v = Verbosity(n)
+	return nil
+}
+
+// Type implements pflag.Value.
+func (v *Verbosity) Type() string { return "level" }
+
+// Logger routes output between stdout and stderr based on mode and verbosity.
 type Logger struct {
-	mode   OutputMode
-	silent bool
-	term   *Terminal
+	mode  OutputMode
+	level Verbosity
+	term  *Terminal
 }
 
-// NewLogger creates a logger with the given mode and silent flag.
+// NewLogger creates a logger with the given mode and silent flag: silent is
+// VerbosityResults, otherwise VerbositySteps.
 func NewLogger(mode OutputMode, silent bool, term *Terminal) *Logger {
-	return &Logger{mode: mode, silent: silent, term: term}
+	level := VerbositySteps
+	if silent {
+		level = VerbosityResults
+	}
+	return NewLeveledLogger(mode, level, term)
+}
+
+// NewLeveledLogger creates a logger that prints messages up to level.
+func NewLeveledLogger(mode OutputMode, level Verbosity, term *Terminal) *Logger {
+	return &Logger{mode: mode, level: level, term: term}
+}
+
+// Debug prints a diagnostic message to stderr at VerbosityDebug only.
+func (l *Logger) Debug(msg string) {
+	if l.level < VerbosityDebug {
+		return
+	}
+	fmt.Fprintln(os.Stderr, l.cleanMessage(msg))
+}
+
+// Debugf prints a formatted diagnostic message to stderr.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.Debug(fmt.Sprintf(format, args...))
 }
 
 // Info prints an informational message to stderr. Suppressed when silent.
 func (l *Logger) Info(msg string) {
-	if l.silent {
+	if l.level < VerbositySteps {
 		return
 	}
 	msg = l.cleanMessage(msg)
@@ -43,7 +97,7 @@ func (l *Logger) Infof(format string, args ...interface{}) {
 
 // Status prints a status/progress message to stderr. Suppressed when silent.
 func (l *Logger) Status(msg string) {
-	if l.silent {
+	if l.level < VerbositySteps {
 		return
 	}
 	msg = l.cleanMessage(msg)
@@ -57,7 +111,7 @@ func (l *Logger) Statusf(format string, args ...interface{}) {
 
 // Warn prints a warning to stderr. Suppressed when silent.
 func (l *Logger) Warn(msg string) {
-	if l.silent {
+	if l.level < VerbositySteps {
 		return
 	}
 	msg = l.cleanMessage(msg)
@@ -86,12 +140,17 @@ func (l *Logger) Errorf(format string, args ...interface{}) {
 	l.Error(fmt.Sprintf(format, args...))
 }
 
-// Result prints the final text result to stdout.
+// Result prints the final text result to stdout. Suppressed at
+// VerbosityErrors.
 func (l *Logger) Result(s string) {
+	if l.level < VerbosityResults {
+		return
+	}
 	fmt.Println(s)
 }
 
-// ResultJSON encodes data as indented JSON to stdout.
+// ResultJSON encodes data as indented JSON to stdout. JSON is asked for
+// explicitly, so it is printed at every verbosity.
 func (l *Logger) ResultJSON(data interface{}) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -11,13 +11,25 @@ func TestNewLogger(t *testing.T) {
 	if l == nil {
 		t.Fatal("expected non-nil Logger")
 	}
-	if l.silent {
-		t.Error("expected non-silent")
+	if l.level != VerbositySteps {
+		t.Errorf("level = %d, want steps", l.level)
 	}
 
 	l2 := NewLogger(ModeText, true, term)
-	if !l2.silent {
-		t.Error("expected silent")
+	if l2.level != VerbosityResults {
+		t.Errorf("silent level = %d, want results", l2.level)
+	}
+}
+
+func TestVerbosityFlagValue(t *testing.T) {
+	var v Verbosity
+	for _, bad := range []string{"-1", "4", "debug", ""} {
+		if err := v.Set(bad); err == nil {
+			t.Errorf("Set(%q) should fail", bad)
+		}
+	}
+	if err := v.Set("3"); err != nil || v != VerbosityDebug || v.String() != "3" {
+		t.Errorf("Set(3) = %v, level %d", err, v)
 	}
 }
 
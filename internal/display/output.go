@@ -0,0 +1,48 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OutputEnvVar names the environment variable that sets the default output
+// format of every command.
+const OutputEnvVar = "VULNETIX_OUTPUT"
+
+// Output formats a default may name.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// DefaultOutput resolves the output format commands use when their own
+// output flag is not given: $VULNETIX_OUTPUT, else configured (the config
+// file's output key), else text. Values are matched case-insensitively.
+func DefaultOutput(configured string) (string, error) {
+	if env := strings.TrimSpace(os.Getenv(OutputEnvVar)); env != "" {
+		format, err := parseOutputFormat(env)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", OutputEnvVar, err)
+		}
+		return format, nil
+	}
+	if strings.TrimSpace(configured) != "" {
+		format, err := parseOutputFormat(configured)
+		if err != nil {
+			return "", fmt.Errorf("config output: %w", err)
+		}
+		return format, nil
+	}
+	return OutputText, nil
+}
+
+func parseOutputFormat(s string) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(s)); format {
+	case OutputText, OutputJSON, OutputYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("output must be text, json or yaml, got %q", s)
+	}
+}
@@ -0,0 +1,27 @@
+package display
+
+import "testing"
+
+func TestDefaultOutput(t *testing.T) {
+	t.Setenv(OutputEnvVar, "")
+	if got, err := DefaultOutput(""); err != nil || got != OutputText {
+		t.Errorf("no default: got %q, %v; want text", got, err)
+	}
+	if got, err := DefaultOutput("YAML"); err != nil || got != OutputYAML {
+		t.Errorf("configured: got %q, %v; want yaml", got, err)
+	}
+
+	t.Setenv(OutputEnvVar, "json")
+	if got, err := DefaultOutput("yaml"); err != nil || got != OutputJSON {
+		t.Errorf("env over config: got %q, %v; want json", got, err)
+	}
+
+	t.Setenv(OutputEnvVar, "xml")
+	if _, err := DefaultOutput(""); err == nil {
+		t.Error("an unknown env value should be rejected")
+	}
+	t.Setenv(OutputEnvVar, "")
+	if _, err := DefaultOutput("table"); err == nil {
+		t.Error("an unknown configured value should be rejected")
+	}
+}
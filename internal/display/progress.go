@@ -114,6 +114,17 @@ func (p *Progress) Update(done int, stage string) {
 	}
 }
 
+// SetTotal changes the numeric goal, for work whose size is only known once
+// it has started.
+func (p *Progress) SetTotal(total int) {
+	if !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+}
+
 // Advance moves progress forward by one unit and updates the stage title.
 func (p *Progress) Advance(stage string) {
 	if !p.enabled {
@@ -3,8 +3,11 @@ package github
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -12,15 +15,22 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
 )
 
 const (
 	// maxArtifactSize is the maximum size for an artifact download (1GB)
 	maxArtifactSize = 1024 * 1024 * 1024
-	// artifactDownloadTimeout is the timeout for downloading artifacts
-	artifactDownloadTimeout = 10 * time.Minute
+
+	// maxDownloadAttempts bounds DownloadArtifact retries on transient failures.
+	maxDownloadAttempts = 3
 )
 
+// Base delay between artifact download attempts, doubled on each retry. Var
+// so tests can shrink it.
+var downloadRetryBackoff = 2 * time.Second
+
 var (
 	// artifactNameRegex matches safe characters for artifact names
 	artifactNameRegex = regexp.MustCompile(`[^a-zA-Z0-9\-_\.]`)
@@ -38,6 +48,9 @@ type Artifact struct {
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
 	ExpiresAt          time.Time `json:"expires_at"`
+	// Digest is the archive's checksum as "sha256:<hex>", when GitHub
+	// reports one. Older artifacts and GHES versions omit it.
+	Digest string `json:"digest,omitempty"`
 }
 
 // ArtifactsResponse represents the GitHub API response for artifacts
@@ -63,6 +76,8 @@ type ArtifactMetadata struct {
 	APIURL          string            `json:"api_url"`
 	Artifacts       []string          `json:"artifacts"`
 	ExtraEnvVars    map[string]string `json:"extra_env_vars,omitempty"`
+	// PullRequest is set when the run was triggered by a pull request event.
+	PullRequest *PullRequestInfo `json:"pull_request,omitempty"`
 }
 
 // ArtifactCollector handles collection of GitHub Actions artifacts
@@ -72,6 +87,11 @@ type ArtifactCollector struct {
 	repository string
 	runID      string
 	client     *http.Client
+
+	// FollowSymlinks makes the files of a downloaded artifact include those
+	// reached through symbolic links. Off by default, so an extracted
+	// artifact cannot point the upload at files outside it.
+	FollowSymlinks bool
 }
 
 // NewArtifactCollector creates a new artifact collector
@@ -81,9 +101,9 @@ func NewArtifactCollector(token, apiURL, repository, runID string) *ArtifactColl
 		apiURL:     apiURL,
 		repository: repository,
 		runID:      runID,
-		client: &http.Client{
-			Timeout: artifactDownloadTimeout,
-		},
+		// No client-wide timeout: listing and downloading get their own
+		// deadlines (httpx.ListTimeout, httpx.DownloadTimeout) per request.
+		client: &http.Client{Transport: httpx.DefaultTransport},
 	}
 }
 
@@ -140,6 +160,10 @@ func CollectMetadata(artifactNames []string) *ArtifactMetadata {
 		metadata.ExtraEnvVars = extraVars
 	}
 
+	// The event payload carries what the environment does not: the pull
+	// request's number, title, labels and base/head commits.
+	metadata.PullRequest = readPullRequestEvent(getEnv("GITHUB_EVENT_PATH"))
+
 	return metadata
 }
 
@@ -151,12 +175,15 @@ func (c *ArtifactCollector) ListArtifacts(ctx context.Context) ([]Artifact, erro
 
 	url := fmt.Sprintf("%s/repos/%s/actions/runs/%s/artifacts", c.apiURL, c.repository, c.runID)
 
+	ctx, cancel := httpx.WithTimeout(ctx, httpx.ListTimeout)
+	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", httpx.UserAgent())
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
@@ -202,30 +229,11 @@ func (c *ArtifactCollector) DownloadArtifact(ctx context.Context, artifact Artif
 		return "", fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Download artifact
-	req, err := http.NewRequestWithContext(ctx, "GET", artifact.ArchiveDownloadURL, nil)
-	if err != nil {
-		os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("failed to create download request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/vnd.github+json")
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("failed to download artifact: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(body))
-	}
+	// Download artifact; the deadline also covers streaming the body to disk
+	// and every retry
+	ctx, cancel := httpx.WithTimeout(ctx, httpx.DownloadTimeout)
+	defer cancel()
 
-	// Save to temporary zip file
 	zipPath := filepath.Join(tmpDir, "artifact.zip")
 	zipFile, err := os.Create(zipPath)
 	if err != nil {
@@ -233,13 +241,33 @@ func (c *ArtifactCollector) DownloadArtifact(ctx context.Context, artifact Artif
 		return "", fmt.Errorf("failed to create zip file: %w", err)
 	}
 
-	// Limit the reader to prevent resource exhaustion
-	limitedReader := io.LimitReader(resp.Body, maxArtifactSize)
-	_, err = io.Copy(zipFile, limitedReader)
+	// A dropped connection resumes from the bytes already on disk rather
+	// than starting over
+	hash := sha256.New()
+	var written int64
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(downloadRetryBackoff * time.Duration(1<<(attempt-2))):
+			}
+		}
+		var retry bool
+		written, retry, err = c.fetchArchive(ctx, artifact.ArchiveDownloadURL, zipFile, hash, written)
+		if err == nil || !retry || ctx.Err() != nil {
+			break
+		}
+	}
 	zipFile.Close()
 	if err != nil {
 		os.RemoveAll(tmpDir)
-		return "", fmt.Errorf("failed to save artifact: %w", err)
+		return "", err
+	}
+
+	// Catch a corrupted or truncated download before extracting it
+	if err := verifyDigest(artifact.Digest, hash.Sum(nil)); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", fmt.Errorf("artifact %s: %w", artifact.Name, err)
 	}
 
 	// Extract zip
@@ -254,6 +282,87 @@ func (c *ArtifactCollector) DownloadArtifact(ctx context.Context, artifact Artif
 	return tmpDir, nil
 }
 
+// fetchArchive downloads url into f, whose first offset bytes (already fed
+// to h) are kept from an earlier attempt and resumed with a Range request.
+// A server that ignores the range gets the download started over. It returns
+// the number of bytes now in f and, on failure, whether another attempt could
+// succeed.
+func (c *ArtifactCollector) fetchArchive(ctx context.Context, url string, f *os.File, h hash.Hash, offset int64) (int64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return offset, false, fmt.Errorf("failed to create download request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", httpx.UserAgent())
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return offset, httpx.Retriable(err, 0), fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+	case resp.StatusCode == http.StatusOK:
+		if offset > 0 {
+			if err := restartArchive(f, h); err != nil {
+				return 0, false, err
+			}
+			offset = 0
+		}
+	case offset > 0 && (resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable):
+		// The range was answered from the wrong offset or refused; the
+		// next attempt downloads the whole archive
+		if err := restartArchive(f, h); err != nil {
+			return 0, false, err
+		}
+		return 0, true, fmt.Errorf("download could not be resumed (status %d)", resp.StatusCode)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return offset, httpx.Retriable(nil, resp.StatusCode), fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Limit the reader to prevent resource exhaustion
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(resp.Body, maxArtifactSize-offset))
+	offset += n
+	if err != nil {
+		return offset, httpx.Retriable(err, 0), fmt.Errorf("failed to save artifact: %w", err)
+	}
+	return offset, false, nil
+}
+
+// restartArchive empties a partial download so it can be fetched again from
+// the start.
+func restartArchive(f *os.File, h hash.Hash) error {
+	h.Reset()
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("failed to restart download: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to restart download: %w", err)
+	}
+	return nil
+}
+
+// verifyDigest checks a downloaded archive's SHA-256 against the digest
+// GitHub declared for it. An empty digest, or one using another algorithm, is
+// not checked.
+func verifyDigest(digest string, sum []byte) error {
+	algo, want, ok := strings.Cut(digest, ":")
+	if !ok || !strings.EqualFold(algo, "sha256") {
+		return nil
+	}
+	if got := hex.EncodeToString(sum); !strings.EqualFold(got, want) {
+		return fmt.Errorf("digest mismatch: downloaded archive has sha256:%s, GitHub declared %s", got, digest)
+	}
+	return nil
+}
+
 // extractZip extracts a zip file to the specified directory
 func extractZip(zipPath, destDir string) error {
 	reader, err := zip.OpenReader(zipPath)
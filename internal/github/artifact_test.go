@@ -2,7 +2,11 @@ package github
 
 import (
 	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -10,6 +14,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
 )
 
 func TestCollectMetadata(t *testing.T) {
@@ -83,6 +89,7 @@ func TestCollectMetadata_EmptyEnvironment(t *testing.T) {
 		"GITHUB_REF_NAME",
 		"GITHUB_REF_TYPE",
 		"GITHUB_EVENT_NAME",
+		"GITHUB_EVENT_PATH",
 		"GITHUB_ACTOR",
 		"GITHUB_SERVER_URL",
 		"GITHUB_API_URL",
@@ -107,6 +114,86 @@ func TestCollectMetadata_EmptyEnvironment(t *testing.T) {
 	if metadata.Artifacts[0] != "test.zip" {
 		t.Errorf("Expected artifact 'test.zip', got '%s'", metadata.Artifacts[0])
 	}
+
+	if metadata.PullRequest != nil {
+		t.Errorf("Expected no pull request without an event payload, got %+v", metadata.PullRequest)
+	}
+}
+
+func TestCollectMetadata_PullRequestEvent(t *testing.T) {
+	payload := `{
+  "action": "synchronize",
+  "number": 42,
+  "pull_request": {
+    "number": 42,
+    "title": "Bump lodash to 4.17.21",
+    "state": "open",
+    "labels": [
+      {"id": 1, "name": "dependencies"},
+      {"id": 2, "name": "security"}
+    ],
+    "base": {"ref": "main", "sha": "1111111111111111111111111111111111111111"},
+    "head": {"ref": "deps/lodash", "sha": "2222222222222222222222222222222222222222"}
+  },
+  "repository": {"full_name": "test-org/test-repo"}
+}`
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath, []byte(payload), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_EVENT_NAME", "pull_request")
+	t.Setenv("GITHUB_EVENT_PATH", eventPath)
+
+	pr := CollectMetadata(nil).PullRequest
+	if pr == nil {
+		t.Fatal("Expected pull request metadata from the event payload")
+	}
+	if pr.Number != 42 {
+		t.Errorf("Expected number 42, got %d", pr.Number)
+	}
+	if pr.Title != "Bump lodash to 4.17.21" {
+		t.Errorf("Expected title from payload, got '%s'", pr.Title)
+	}
+	if strings.Join(pr.Labels, ",") != "dependencies,security" {
+		t.Errorf("Expected labels dependencies,security, got %v", pr.Labels)
+	}
+	if pr.BaseRef != "main" || pr.HeadRef != "deps/lodash" {
+		t.Errorf("Expected refs main <- deps/lodash, got %s <- %s", pr.BaseRef, pr.HeadRef)
+	}
+	if pr.BaseSHA != strings.Repeat("1", 40) || pr.HeadSHA != strings.Repeat("2", 40) {
+		t.Errorf("Unexpected SHAs %s / %s", pr.BaseSHA, pr.HeadSHA)
+	}
+}
+
+func TestCollectMetadata_EventPayloadIgnoredWhenUnusable(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cases := map[string]string{
+		"missing":   filepath.Join(dir, "does-not-exist.json"),
+		"directory": dir,
+		"malformed": write("malformed.json", `{"pull_request": {"number": `),
+		"push":      write("push.json", `{"ref": "refs/heads/main", "commits": []}`),
+		"no number": write("nonumber.json", `{"pull_request": {"title": "x"}}`),
+	}
+	for name, path := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GITHUB_EVENT_PATH", path)
+			metadata := CollectMetadata([]string{"a.zip"})
+			if metadata.PullRequest != nil {
+				t.Errorf("Expected no pull request, got %+v", metadata.PullRequest)
+			}
+			if len(metadata.Artifacts) != 1 {
+				t.Errorf("Expected metadata to still be collected, got %+v", metadata)
+			}
+		})
+	}
 }
 
 func TestNewArtifactCollector(t *testing.T) {
@@ -429,3 +516,194 @@ func TestDownloadArtifact_Success(t *testing.T) {
 		t.Errorf("Expected 'test content', got '%s'", string(content))
 	}
 }
+
+func TestDownloadArtifact_VerifiesDigest(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("bom.json")
+	_, _ = f.Write([]byte(`{"bomFormat":"CycloneDX"}`))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zipData := buf.Bytes()
+	sum := sha256.Sum256(zipData)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(zipData)
+	}))
+	defer server.Close()
+	collector := NewArtifactCollector("token", server.URL, "org/repo", "1")
+
+	tests := []struct {
+		name    string
+		digest  string
+		wantErr bool
+	}{
+		{"matching digest", "sha256:" + hex.EncodeToString(sum[:]), false},
+		{"mismatched digest", "sha256:" + strings.Repeat("0", 64), true},
+		{"no digest", "", false},
+		{"other algorithm", "sha512:abcd", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := collector.DownloadArtifact(context.Background(), Artifact{
+				Name:               "sbom",
+				ArchiveDownloadURL: server.URL,
+				Digest:             tt.digest,
+			})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+					t.Fatalf("err = %v, want a digest mismatch", err)
+				}
+				if dir != "" {
+					t.Errorf("dir = %q, want nothing left behind", dir)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if _, err := os.Stat(filepath.Join(dir, "bom.json")); err != nil {
+				t.Errorf("artifact not extracted: %v", err)
+			}
+		})
+	}
+}
+
+func TestDownloadArtifact_ResumesInterruptedDownload(t *testing.T) {
+	origBackoff := downloadRetryBackoff
+	downloadRetryBackoff = time.Millisecond
+	defer func() { downloadRetryBackoff = origBackoff }()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("bom.json")
+	_, _ = f.Write(bytes.Repeat([]byte(`{"bomFormat":"CycloneDX"}`), 4096))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zipData := buf.Bytes()
+	sum := sha256.Sum256(zipData)
+	cut := len(zipData) / 2
+
+	tests := []struct {
+		name         string
+		honourRanges bool
+		wantRanges   []string
+	}{
+		{"resumes with a range request", true, []string{"", fmt.Sprintf("bytes=%d-", cut)}},
+		{"starts over when ranges are ignored", false, []string{"", fmt.Sprintf("bytes=%d-", cut)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranges []string
+			var servedBytes int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				if len(ranges) == 1 {
+					// Promise the whole archive, send half, drop the connection
+					w.Header().Set("Content-Length", fmt.Sprint(len(zipData)))
+					_, _ = w.Write(zipData[:cut])
+					servedBytes += cut
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+				body := zipData
+				if rng := r.Header.Get("Range"); tt.honourRanges && rng != "" {
+					var start int
+					_, _ = fmt.Sscanf(rng, "bytes=%d-", &start)
+					body = zipData[start:]
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(zipData)-1, len(zipData)))
+					w.WriteHeader(http.StatusPartialContent)
+				}
+				_, _ = w.Write(body)
+				servedBytes += len(body)
+			}))
+			defer server.Close()
+
+			collector := NewArtifactCollector("token", server.URL, "org/repo", "1")
+			dir, err := collector.DownloadArtifact(context.Background(), Artifact{
+				Name:               "sbom",
+				ArchiveDownloadURL: server.URL,
+				Digest:             "sha256:" + hex.EncodeToString(sum[:]),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			if strings.Join(ranges, ",") != strings.Join(tt.wantRanges, ",") {
+				t.Errorf("Range headers = %q, want %q", ranges, tt.wantRanges)
+			}
+			wantServed := len(zipData)
+			if !tt.honourRanges {
+				wantServed += cut
+			}
+			if servedBytes != wantServed {
+				t.Errorf("served %d bytes, want %d", servedBytes, wantServed)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "bom.json")); err != nil {
+				t.Errorf("artifact not extracted: %v", err)
+			}
+		})
+	}
+}
+
+func TestArtifactCollector_PerOperationDeadlines(t *testing.T) {
+	origList, origDownload := httpx.ListTimeout, httpx.DownloadTimeout
+	defer func() { httpx.ListTimeout, httpx.DownloadTimeout = origList, origDownload }()
+	httpx.ListTimeout, httpx.DownloadTimeout = 5*time.Second, 20*time.Minute
+
+	remaining := map[string]time.Duration{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/download" {
+			zw := zip.NewWriter(w)
+			f, _ := zw.Create("bom.json")
+			_, _ = f.Write([]byte(`{}`))
+			_ = zw.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{"total_count":0,"artifacts":[]}`))
+	}))
+	defer server.Close()
+
+	collector := NewArtifactCollector("token", server.URL, "org/repo", "1")
+	base := collector.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	collector.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if deadline, ok := r.Context().Deadline(); ok {
+			remaining[r.URL.Path] = time.Until(deadline)
+		}
+		return base.RoundTrip(r)
+	})
+
+	if _, err := collector.ListArtifacts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := collector.DownloadArtifact(context.Background(), Artifact{Name: "sbom", ArchiveDownloadURL: server.URL + "/download"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for path, d := range map[string]time.Duration{
+		"/repos/org/repo/actions/runs/1/artifacts": 5 * time.Second,
+		"/download": 20 * time.Minute,
+	} {
+		got, ok := remaining[path]
+		if !ok {
+			t.Errorf("%s: request had no deadline", path)
+			continue
+		}
+		if got > d || got < d-time.Second {
+			t.Errorf("%s: deadline in %v, want about %v", path, got, d)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
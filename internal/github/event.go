@@ -0,0 +1,79 @@
+package github
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// maxEventPayloadSize bounds how much of GITHUB_EVENT_PATH is read (25 MiB,
+// GitHub's own webhook payload cap).
+const maxEventPayloadSize = 25 * 1024 * 1024
+
+// PullRequestInfo is the pull request a workflow run was triggered by, read
+// from the event payload.
+type PullRequestInfo struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title,omitempty"`
+	Labels  []string `json:"labels,omitempty"`
+	BaseRef string   `json:"base_ref,omitempty"`
+	BaseSHA string   `json:"base_sha,omitempty"`
+	HeadRef string   `json:"head_ref,omitempty"`
+	HeadSHA string   `json:"head_sha,omitempty"`
+}
+
+// pullRequestEvent is the part of a pull_request (or pull_request_target,
+// pull_request_review, ...) event payload that PullRequestInfo is read from.
+type pullRequestEvent struct {
+	PullRequest *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		Base struct {
+			Ref string `json:"ref"`
+			SHA string `json:"sha"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+// readPullRequestEvent returns the pull request described by the event
+// payload at path, or nil when path is empty, unreadable, too large, not
+// JSON, or the event is not about a pull request. Metadata is best effort,
+// so none of these fail the upload.
+func readPullRequestEvent(path string) *PullRequestInfo {
+	if path == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxEventPayloadSize {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var event pullRequestEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.PullRequest == nil || event.PullRequest.Number == 0 {
+		return nil
+	}
+	pr := event.PullRequest
+	out := &PullRequestInfo{
+		Number:  pr.Number,
+		Title:   pr.Title,
+		BaseRef: pr.Base.Ref,
+		BaseSHA: pr.Base.SHA,
+		HeadRef: pr.Head.Ref,
+		HeadSHA: pr.Head.SHA,
+	}
+	for _, l := range pr.Labels {
+		if l.Name != "" {
+			out.Labels = append(out.Labels, l.Name)
+		}
+	}
+	return out
+}
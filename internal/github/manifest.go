@@ -0,0 +1,79 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// formatSniffSize is how much of a file DetectFormat needs to see.
+const formatSniffSize = 2048
+
+// ArtifactManifestEntry describes one file inside a workflow artifact so the
+// server can check what it receives against what the runner sent.
+type ArtifactManifestEntry struct {
+	Artifact string `json:"artifact"`
+	// Path is relative to the extracted artifact root, slash-separated.
+	Path   string `json:"path"`
+	Format string `json:"format,omitempty"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BuildArtifactManifest hashes every file of an extracted artifact and
+// detects its format. Entries are in walk (lexical) order; symbolic links
+// are followed only when followSymlinks is set.
+func BuildArtifactManifest(artifactName, artifactDir string, followSymlinks bool) ([]ArtifactManifestEntry, error) {
+	files, err := findFilesInDir(artifactDir, followSymlinks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files in artifact directory: %w", err)
+	}
+	entries := make([]ArtifactManifestEntry, 0, len(files))
+	for _, path := range files {
+		entry, err := manifestEntry(path)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(artifactDir, path)
+		if err != nil {
+			return nil, err
+		}
+		entry.Artifact = artifactName
+		entry.Path = filepath.ToSlash(rel)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func manifestEntry(path string) (ArtifactManifestEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ArtifactManifestEntry{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	head := make([]byte, formatSniffSize)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return ArtifactManifestEntry{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	head = head[:n]
+
+	h := sha256.New()
+	h.Write(head)
+	rest, err := io.Copy(h, f)
+	if err != nil {
+		return ArtifactManifestEntry{}, fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	entry := ArtifactManifestEntry{Size: int64(n) + rest, SHA256: hex.EncodeToString(h.Sum(nil))}
+	if format := upload.DetectFormat(path, head); format != "auto" {
+		entry.Format = format
+	}
+	return entry, nil
+}
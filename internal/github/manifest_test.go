@@ -0,0 +1,44 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildArtifactManifest(t *testing.T) {
+	dir := t.TempDir()
+	sbom := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`)
+	notes := []byte("release notes\n")
+	if err := os.WriteFile(filepath.Join(dir, "bom.cdx.json"), sbom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "docs", "notes.txt"), notes, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := BuildArtifactManifest("scan-results", dir, false)
+	if err != nil {
+		t.Fatalf("BuildArtifactManifest failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %+v", entries)
+	}
+
+	sum := sha256.Sum256(sbom)
+	want := ArtifactManifestEntry{Artifact: "scan-results", Path: "bom.cdx.json", Format: "cyclonedx", Size: int64(len(sbom)), SHA256: hex.EncodeToString(sum[:])}
+	if entries[0] != want {
+		t.Errorf("entry 0 = %+v, want %+v", entries[0], want)
+	}
+
+	sum = sha256.Sum256(notes)
+	want = ArtifactManifestEntry{Artifact: "scan-results", Path: "docs/notes.txt", Size: int64(len(notes)), SHA256: hex.EncodeToString(sum[:])}
+	if entries[1] != want {
+		t.Errorf("entry 1 = %+v, want %+v", entries[1], want)
+	}
+}
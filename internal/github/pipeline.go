@@ -0,0 +1,247 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// Stage is one step of the artifact upload pipeline.
+type Stage string
+
+const (
+	StageList     Stage = "list"
+	StageDownload Stage = "download"
+	StageUpload   Stage = "upload"
+	StageFinalize Stage = "finalize"
+)
+
+// EventKind says what happened within a stage.
+type EventKind string
+
+const (
+	EventStart    EventKind = "start"
+	EventProgress EventKind = "progress"
+	EventDone     EventKind = "done"
+	EventSkipped  EventKind = "skipped"
+	EventFailed   EventKind = "failed"
+)
+
+// ProgressEvent reports one step of the pipeline for one artifact or file.
+type ProgressEvent struct {
+	Stage Stage     `json:"stage"`
+	Kind  EventKind `json:"kind"`
+	// Artifact is empty for StageList events.
+	Artifact string `json:"artifact,omitempty"`
+	// File is the base name of the file, for StageUpload and StageFinalize.
+	File string `json:"file,omitempty"`
+	// Index is the 1-based position of Artifact among Total artifacts. A
+	// finished StageList event carries the number of artifacts found in Total.
+	Index int `json:"index,omitempty"`
+	Total int `json:"total,omitempty"`
+	// Step and Steps are upload progress against the per-file goal.
+	Step    int    `json:"step,omitempty"`
+	Steps   int    `json:"steps,omitempty"`
+	Message string `json:"message,omitempty"`
+	Err     error  `json:"-"`
+}
+
+// ProgressFunc receives pipeline events in the order they happen.
+type ProgressFunc func(ProgressEvent)
+
+// FileUploader uploads one extracted artifact file; *upload.Client
+// satisfies it. The last step of the per-file goal is finalization.
+type FileUploader interface {
+	UploadFileWithProgress(filePath string, formatOverride string, progress upload.ProgressFunc) (*upload.FinalizeResponse, error)
+}
+
+// FileResult is the outcome of one file of an uploaded artifact.
+type FileResult struct {
+	Name        string `json:"name"`
+	File        string `json:"file"`
+	PipelineID  string `json:"pipelineId,omitempty"`
+	Status      string `json:"status"`
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+	Error       string `json:"error,omitempty"`
+	DurationMs  int64  `json:"durationMs,omitempty"`
+}
+
+// UploadSummary is the outcome of Pipeline.UploadArtifacts. Byte-identical
+// files are uploaded once; DeduplicatedFiles and DeduplicatedBytes count the
+// copies that were skipped.
+type UploadSummary struct {
+	Files             []FileResult
+	DeduplicatedFiles int
+	DeduplicatedBytes int64
+}
+
+// Pipeline lists the artifacts of a workflow run, downloads them and uploads
+// each file, reporting every step to Progress so callers can observe it
+// without parsing output.
+type Pipeline struct {
+	Collector *ArtifactCollector
+	Uploader  FileUploader
+	Progress  ProgressFunc
+}
+
+func (p *Pipeline) emit(e ProgressEvent) {
+	if p.Progress != nil {
+		p.Progress(e)
+	}
+}
+
+// ListArtifacts lists the artifacts of the workflow run.
+func (p *Pipeline) ListArtifacts(ctx context.Context) ([]Artifact, error) {
+	p.emit(ProgressEvent{Stage: StageList, Kind: EventStart})
+	artifacts, err := p.Collector.ListArtifacts(ctx)
+	if err != nil {
+		p.emit(ProgressEvent{Stage: StageList, Kind: EventFailed, Err: err})
+		return nil, err
+	}
+	p.emit(ProgressEvent{Stage: StageList, Kind: EventDone, Total: len(artifacts)})
+	return artifacts, nil
+}
+
+// UploadArtifacts downloads each artifact and uploads every file in it. A
+// failing artifact or file is recorded in the summary and the rest carry on.
+func (p *Pipeline) UploadArtifacts(ctx context.Context, artifacts []Artifact) *UploadSummary {
+	summary := &UploadSummary{}
+	uploadedByDigest := map[string]uploadedContent{}
+
+	for i, artifact := range artifacts {
+		at := ProgressEvent{Artifact: artifact.Name, Index: i + 1, Total: len(artifacts)}
+
+		download := at
+		download.Stage = StageDownload
+		p.emit(withKind(download, EventStart))
+		artifactDir, err := p.Collector.DownloadArtifact(ctx, artifact)
+		if err == nil {
+			var files []string
+			if files, err = findFilesInDir(artifactDir, p.Collector.FollowSymlinks); err == nil {
+				p.emit(withKind(download, EventDone))
+				for _, filePath := range files {
+					p.uploadFile(at, filePath, summary, uploadedByDigest)
+				}
+			}
+			os.RemoveAll(artifactDir)
+		}
+		if err != nil {
+			failed := withKind(download, EventFailed)
+			failed.Err = err
+			p.emit(failed)
+			summary.Files = append(summary.Files, FileResult{Name: artifact.Name, Status: "error", Error: err.Error()})
+		}
+	}
+	return summary
+}
+
+// uploadedContent is the first upload of a file's content.
+type uploadedContent struct {
+	ref        string
+	pipelineID string
+}
+
+// uploadFile uploads one file of the artifact described by at, skipping it
+// when identical content is already in uploadedByDigest.
+func (p *Pipeline) uploadFile(at ProgressEvent, filePath string, summary *UploadSummary, uploadedByDigest map[string]uploadedContent) {
+	fileName := filepath.Base(filePath)
+	ev := at
+	ev.File = fileName
+
+	digest, size, hashErr := fileSHA256(filePath)
+	if hashErr == nil {
+		if prev, ok := uploadedByDigest[digest]; ok {
+			summary.DeduplicatedFiles++
+			summary.DeduplicatedBytes += size
+			skipped := withKind(ev, EventSkipped)
+			skipped.Stage = StageUpload
+			skipped.Message = "duplicate of " + prev.ref
+			p.emit(skipped)
+			summary.Files = append(summary.Files, FileResult{
+				Name:        at.Artifact,
+				File:        fileName,
+				PipelineID:  prev.pipelineID,
+				Status:      "deduplicated",
+				DuplicateOf: prev.ref,
+			})
+			return
+		}
+	}
+
+	ev.Stage = StageUpload
+	p.emit(withKind(ev, EventStart))
+	started := time.Now()
+	resp, err := p.Uploader.UploadFileWithProgress(filePath, "", func(done, total int, stage string) {
+		step := withKind(ev, EventProgress)
+		step.Step, step.Steps, step.Message = done, total, stage
+		if ev.Stage == StageUpload && total > 0 && done >= total-1 {
+			ev.Stage = StageFinalize
+			step.Stage = StageFinalize
+			step.Kind = EventStart
+		}
+		p.emit(step)
+	})
+	if err != nil {
+		failed := withKind(ev, EventFailed)
+		failed.Err = err
+		p.emit(failed)
+		summary.Files = append(summary.Files, FileResult{
+			Name:       at.Artifact,
+			File:       fileName,
+			Status:     "error",
+			Error:      err.Error(),
+			DurationMs: time.Since(started).Milliseconds(),
+		})
+		return
+	}
+
+	pipelineID := ""
+	if resp.PipelineRecord != nil {
+		pipelineID = resp.PipelineRecord.UUID
+	}
+	status := "uploaded"
+	if resp.IsDuplicate {
+		status = "duplicate"
+	}
+	if hashErr == nil {
+		uploadedByDigest[digest] = uploadedContent{ref: at.Artifact + "/" + fileName, pipelineID: pipelineID}
+	}
+
+	done := withKind(ev, EventDone)
+	done.Stage = StageFinalize
+	done.Message = status
+	p.emit(done)
+	summary.Files = append(summary.Files, FileResult{
+		Name:       at.Artifact,
+		File:       fileName,
+		PipelineID: pipelineID,
+		Status:     status,
+		DurationMs: time.Since(started).Milliseconds(),
+	})
+}
+
+func withKind(e ProgressEvent, kind EventKind) ProgressEvent {
+	e.Kind = kind
+	return e
+}
+
+// fileSHA256 returns the hex SHA-256 digest and size of a file's content.
+func fileSHA256(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
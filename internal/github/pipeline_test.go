@@ -0,0 +1,173 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vulnetix/cli/v3/internal/upload"
+)
+
+// fakeFileUploader reports the same three-step goal as the multipart upload.
+type fakeFileUploader struct {
+	fail map[string]bool
+}
+
+func (f *fakeFileUploader) UploadFileWithProgress(filePath, _ string, progress upload.ProgressFunc) (*upload.FinalizeResponse, error) {
+	progress(0, 3, "Preparing multipart upload")
+	progress(1, 3, "Uploading file")
+	if f.fail[filepath.Base(filePath)] {
+		return nil, errors.New("upload rejected")
+	}
+	progress(2, 3, "Finalizing upload")
+	progress(3, 3, "Upload finalized")
+	return &upload.FinalizeResponse{OK: true, PipelineRecord: &upload.PipelineRecord{UUID: "p-" + filepath.Base(filePath)}}, nil
+}
+
+// serveArtifacts serves a workflow run whose artifacts each hold one file.
+func serveArtifacts(t *testing.T, files map[string][2]string, order []string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/artifacts") {
+			var artifacts []Artifact
+			for i, name := range order {
+				artifacts = append(artifacts, Artifact{ID: int64(i + 1), Name: name, ArchiveDownloadURL: server.URL + "/download/" + name})
+			}
+			_ = json.NewEncoder(w).Encode(ArtifactsResponse{TotalCount: len(artifacts), Artifacts: artifacts})
+			return
+		}
+		file := files[strings.TrimPrefix(r.URL.Path, "/download/")]
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		fw, _ := zw.Create(file[0])
+		_, _ = fw.Write([]byte(file[1]))
+		_ = zw.Close()
+		_, _ = w.Write(buf.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// eventTrace renders the events that mark a stage boundary, dropping
+// intermediate upload progress.
+func eventTrace(events []ProgressEvent) []string {
+	var trace []string
+	for _, e := range events {
+		if e.Kind == EventProgress {
+			continue
+		}
+		trace = append(trace, fmt.Sprintf("%s %s %s/%s", e.Stage, e.Kind, e.Artifact, e.File))
+	}
+	return trace
+}
+
+func TestPipelineEventSequence(t *testing.T) {
+	server := serveArtifacts(t, map[string][2]string{
+		"sbom":  {"bom.cdx.json", `{"bomFormat":"CycloneDX"}`},
+		"sarif": {"scan.sarif", `{"version":"2.1.0"}`},
+	}, []string{"sbom", "sarif"})
+
+	var events []ProgressEvent
+	p := &Pipeline{
+		Collector: NewArtifactCollector("token", server.URL, "acme/app", "1"),
+		Uploader:  &fakeFileUploader{},
+		Progress:  func(e ProgressEvent) { events = append(events, e) },
+	}
+	ctx := context.Background()
+	artifacts, err := p.ListArtifacts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := p.UploadArtifacts(ctx, artifacts)
+
+	want := []string{
+		"list start /",
+		"list done /",
+		"download start sbom/",
+		"download done sbom/",
+		"upload start sbom/bom.cdx.json",
+		"finalize start sbom/bom.cdx.json",
+		"finalize done sbom/bom.cdx.json",
+		"download start sarif/",
+		"download done sarif/",
+		"upload start sarif/scan.sarif",
+		"finalize start sarif/scan.sarif",
+		"finalize done sarif/scan.sarif",
+	}
+	if got := eventTrace(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("event sequence:\n got %q\nwant %q", got, want)
+	}
+	if events[1].Total != 2 {
+		t.Errorf("list done Total = %d, want 2", events[1].Total)
+	}
+	if e := events[len(events)-1]; e.Index != 2 || e.Total != 2 {
+		t.Errorf("last event Index/Total = %d/%d, want 2/2", e.Index, e.Total)
+	}
+	if len(summary.Files) != 2 || summary.Files[1].PipelineID != "p-scan.sarif" || summary.Files[1].Status != "uploaded" {
+		t.Errorf("summary = %+v", summary.Files)
+	}
+}
+
+func TestPipelineReportsFailuresAndDuplicates(t *testing.T) {
+	server := serveArtifacts(t, map[string][2]string{
+		"linux":  {"bom.cdx.json", `{"bomFormat":"CycloneDX"}`},
+		"darwin": {"bom.cdx.json", `{"bomFormat":"CycloneDX"}`},
+		"scan":   {"scan.sarif", `{"version":"2.1.0"}`},
+	}, []string{"linux", "darwin", "scan"})
+
+	var events []ProgressEvent
+	p := &Pipeline{
+		Collector: NewArtifactCollector("token", server.URL, "acme/app", "1"),
+		Uploader:  &fakeFileUploader{fail: map[string]bool{"scan.sarif": true}},
+		Progress:  func(e ProgressEvent) { events = append(events, e) },
+	}
+	artifacts, err := p.ListArtifacts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := p.UploadArtifacts(context.Background(), artifacts)
+
+	trace := eventTrace(events)
+	for _, want := range []string{"upload skipped darwin/bom.cdx.json", "upload failed scan/scan.sarif"} {
+		found := false
+		for _, got := range trace {
+			found = found || got == want
+		}
+		if !found {
+			t.Errorf("missing event %q in %q", want, trace)
+		}
+	}
+	if summary.DeduplicatedFiles != 1 || summary.Files[1].DuplicateOf != "linux/bom.cdx.json" {
+		t.Errorf("summary = %+v", summary)
+	}
+	if summary.Files[2].Status != "error" || summary.Files[2].Error != "upload rejected" {
+		t.Errorf("failed file = %+v", summary.Files[2])
+	}
+}
+
+func TestPipelineListFailure(t *testing.T) {
+	var events []ProgressEvent
+	p := &Pipeline{
+		Collector: NewArtifactCollector("", "http://127.0.0.1:0", "acme/app", "1"),
+		Progress:  func(e ProgressEvent) { events = append(events, e) },
+	}
+	if _, err := p.ListArtifacts(context.Background()); err == nil {
+		t.Fatal("expected an error without a token")
+	}
+	if got := eventTrace(events); !reflect.DeepEqual(got, []string{"list start /", "list failed /"}) {
+		t.Errorf("events = %q", got)
+	}
+	if events[1].Err == nil {
+		t.Error("failed event should carry the error")
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -10,8 +11,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 )
 
@@ -20,10 +23,19 @@ var (
 	txnIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
 
+// maxUploadAttempts bounds UploadArtifact retries on transient failures.
+const maxUploadAttempts = 3
+
+// Base delay between artifact upload attempts, doubled on each retry. Var so
+// tests can shrink it.
+var uploadRetryBackoff = 2 * time.Second
+
 // TransactionRequest represents the initial transaction creation request
 type TransactionRequest struct {
-	Meta      *ArtifactMetadata `json:"_meta"`
-	Artifacts []string          `json:"artifacts"`
+	Meta        *ArtifactMetadata       `json:"_meta"`
+	Artifacts   []string                `json:"artifacts"`
+	Manifest    []ArtifactManifestEntry `json:"manifest,omitempty"`
+	CallbackURL string                  `json:"callbackUrl,omitempty"`
 }
 
 // TransactionResponse represents the response from transaction creation
@@ -65,9 +77,20 @@ type ArtifactUploader struct {
 	orgID   string
 	creds   *auth.Credentials
 	client  *http.Client
+
+	// CallbackURL, when set, is registered with every transaction the
+	// uploader starts or appends to, so the server notifies it once
+	// processing completes.
+	CallbackURL string
+
+	// FollowSymlinks makes UploadArtifact descend into symlinked directories
+	// and include symlinked files. Off by default, so an extracted artifact
+	// cannot point the upload at files outside it.
+	FollowSymlinks bool
 }
 
-// NewArtifactUploader creates a new artifact uploader using centralized auth
+// NewArtifactUploader creates a new artifact uploader using centralized auth.
+// A trailing slash on baseURL is dropped so request paths join cleanly.
 func NewArtifactUploader(baseURL, orgID string) *ArtifactUploader {
 	creds, _ := auth.LoadCredentials()
 
@@ -84,11 +107,12 @@ func NewArtifactUploader(baseURL, orgID string) *ArtifactUploader {
 	}
 
 	return &ArtifactUploader{
-		baseURL: baseURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
 		orgID:   orgID,
 		creds:   creds,
 		client: &http.Client{
-			Timeout: 120 * time.Second,
+			Timeout:   120 * time.Second,
+			Transport: httpx.DefaultTransport,
 		},
 	}
 }
@@ -109,7 +133,7 @@ func validateTxnID(txnID string) error {
 
 // addAuthHeaders adds authentication headers to the request
 func (u *ArtifactUploader) addAuthHeaders(req *http.Request) {
-	req.Header.Set("User-Agent", "Vulnetix-CLI/1.0")
+	req.Header.Set("User-Agent", httpx.UserAgent())
 
 	if u.creds != nil {
 		header := auth.GetAuthHeader(u.creds)
@@ -119,13 +143,97 @@ func (u *ArtifactUploader) addAuthHeaders(req *http.Request) {
 	}
 }
 
-// InitiateTransaction initiates a new artifact upload transaction
-func (u *ArtifactUploader) InitiateTransaction(metadata *ArtifactMetadata, artifactNames []string) (*TransactionResponse, error) {
+// InitiateTransaction initiates a new artifact upload transaction. manifest
+// lists the files of the artifacts about to be uploaded (see
+// BuildArtifactManifest) and may be nil.
+func (u *ArtifactUploader) InitiateTransaction(metadata *ArtifactMetadata, artifactNames []string, manifest []ArtifactManifestEntry) (*TransactionResponse, error) {
 	url := fmt.Sprintf("%s/%s/github/artifact-upload", u.baseURL, u.orgID)
 
+	txnResp, err := u.postTransaction(url, metadata, artifactNames, manifest, "transaction initiation")
+	if err != nil {
+		return nil, err
+	}
+
+	// The ID is interpolated into every later request path; reject a malformed
+	// one here rather than letting it surface as a confusing upload failure.
+	if err := validateTxnID(txnResp.TxnID); err != nil {
+		return nil, fmt.Errorf("server returned an invalid transaction ID %q: %w", txnResp.TxnID, err)
+	}
+
+	return txnResp, nil
+}
+
+// closedTxnStatuses are transaction states that no longer accept artifacts.
+var closedTxnStatuses = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"closed":    true,
+	"cancelled": true,
+	"expired":   true,
+}
+
+// CheckTransactionOpen returns an error unless status, the status of txnID,
+// shows it still accepts artifacts.
+func CheckTransactionOpen(txnID string, status *StatusResponse) error {
+	if closedTxnStatuses[strings.ToLower(status.Status)] {
+		return fmt.Errorf("transaction %s is %s and no longer accepts artifacts", txnID, status.Status)
+	}
+	return nil
+}
+
+// EnsureTransactionOpen fetches the status of txnID and returns an error
+// unless it still accepts artifacts. The status is returned so callers can
+// pass it on to AppendToTransaction instead of fetching it again.
+func (u *ArtifactUploader) EnsureTransactionOpen(txnID string) (*StatusResponse, error) {
+	status, err := u.GetTransactionStatus(txnID)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckTransactionOpen(txnID, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// AppendToTransaction registers more artifacts with an existing transaction
+// instead of starting a new one, so several jobs of a workflow can report
+// into a single transaction. The transaction must still be open: status is
+// its status from EnsureTransactionOpen, and is fetched when nil. Artifacts
+// are then sent with UploadArtifact as usual.
+func (u *ArtifactUploader) AppendToTransaction(txnID string, status *StatusResponse, metadata *ArtifactMetadata, artifactNames []string, manifest []ArtifactManifestEntry) (*TransactionResponse, error) {
+	if status == nil {
+		var err error
+		if status, err = u.GetTransactionStatus(txnID); err != nil {
+			return nil, err
+		}
+	}
+	if err := CheckTransactionOpen(txnID, status); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s/github/artifact-upload/%s/artifacts", u.baseURL, u.orgID, txnID)
+
+	txnResp, err := u.postTransaction(url, metadata, artifactNames, manifest, "transaction append")
+	if err != nil {
+		return nil, err
+	}
+	if txnResp.TxnID == "" {
+		txnResp.TxnID = txnID
+	} else if txnResp.TxnID != txnID {
+		return nil, fmt.Errorf("server appended to transaction %q, expected %q", txnResp.TxnID, txnID)
+	}
+
+	return txnResp, nil
+}
+
+// postTransaction sends a TransactionRequest to url and decodes the reply.
+// action names the operation in error messages.
+func (u *ArtifactUploader) postTransaction(url string, metadata *ArtifactMetadata, artifactNames []string, manifest []ArtifactManifestEntry, action string) (*TransactionResponse, error) {
 	request := TransactionRequest{
-		Meta:      metadata,
-		Artifacts: artifactNames,
+		Meta:        metadata,
+		Artifacts:   artifactNames,
+		Manifest:    manifest,
+		CallbackURL: u.CallbackURL,
 	}
 
 	jsonData, err := json.Marshal(request)
@@ -153,7 +261,7 @@ func (u *ArtifactUploader) InitiateTransaction(metadata *ArtifactMetadata, artif
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("transaction initiation failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("%s failed with status %d: %s", action, resp.StatusCode, string(respBody))
 	}
 
 	var txnResp TransactionResponse
@@ -162,13 +270,16 @@ func (u *ArtifactUploader) InitiateTransaction(metadata *ArtifactMetadata, artif
 	}
 
 	if !txnResp.Success {
-		return nil, fmt.Errorf("transaction initiation failed: %s", txnResp.Message)
+		return nil, fmt.Errorf("%s failed: %s", action, txnResp.Message)
 	}
 
 	return &txnResp, nil
 }
 
-// UploadArtifact uploads a single artifact file to the specified transaction
+// UploadArtifact uploads a single artifact file to the specified transaction.
+// The multipart body is streamed from disk, so memory stays bounded regardless
+// of artifact size, and transient failures (network errors, 408, 429, 5xx) are
+// retried with a freshly streamed body.
 func (u *ArtifactUploader) UploadArtifact(txnID, artifactName, artifactDir string) (*ArtifactUploadResponse, error) {
 	// Validate transaction ID
 	if err := validateTxnID(txnID); err != nil {
@@ -178,7 +289,7 @@ func (u *ArtifactUploader) UploadArtifact(txnID, artifactName, artifactDir strin
 	url := fmt.Sprintf("%s/%s/github/artifact-upload/%s", u.baseURL, u.orgID, txnID)
 
 	// Find all files in the artifact directory
-	files, err := findFilesInDir(artifactDir)
+	files, err := findFilesInDir(artifactDir, u.FollowSymlinks)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find files in artifact directory: %w", err)
 	}
@@ -187,85 +298,106 @@ func (u *ArtifactUploader) UploadArtifact(txnID, artifactName, artifactDir strin
 		return nil, fmt.Errorf("no files found in artifact directory: %s", artifactDir)
 	}
 
-	// Create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// Add artifact name as form field
-	if err := writer.WriteField("artifact_name", artifactName); err != nil {
-		return nil, fmt.Errorf("failed to write artifact name field: %w", err)
-	}
-
-	// Add each file to the multipart form
-	for _, filePath := range files {
-		file, err := os.Open(filePath)
-		if err != nil {
-			writer.Close()
-			return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
-		}
-
-		// Get relative path for the file
-		relPath, err := filepath.Rel(artifactDir, filePath)
-		if err != nil {
-			file.Close()
-			writer.Close()
-			return nil, fmt.Errorf("failed to get relative path: %w", err)
+	var lastErr error
+	for attempt := 1; attempt <= maxUploadAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(uploadRetryBackoff * time.Duration(1<<(attempt-2)))
 		}
 
-		part, err := writer.CreateFormFile("files", relPath)
-		if err != nil {
-			file.Close()
-			writer.Close()
-			return nil, fmt.Errorf("failed to create form file: %w", err)
+		uploadResp, retry, err := u.uploadArtifactOnce(url, artifactName, artifactDir, files)
+		if err == nil {
+			return uploadResp, nil
 		}
-
-		_, err = io.Copy(part, file)
-		file.Close()
-		if err != nil {
-			writer.Close()
-			return nil, fmt.Errorf("failed to copy file content: %w", err)
+		lastErr = err
+		if !retry {
+			break
 		}
 	}
+	return nil, lastErr
+}
 
-	contentType := writer.FormDataContentType()
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
-	}
+// uploadArtifactOnce performs a single streamed upload attempt. The returned
+// bool reports whether the failure is transient and worth retrying.
+func (u *ArtifactUploader) uploadArtifactOnce(url, artifactName, artifactDir string, files []string) (*ArtifactUploadResponse, bool, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeArtifactParts(writer, artifactName, artifactDir, files))
+	}()
 
-	// Create and send request
-	req, err := http.NewRequest("POST", url, body)
+	req, err := http.NewRequest("POST", url, pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		pr.Close()
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
 	u.addAuthHeaders(req)
 
 	resp, err := u.client.Do(req)
+	// Unblock the writer goroutine if the transport stopped reading early.
+	pr.Close()
 	if err != nil {
-		return nil, fmt.Errorf("upload request failed: %w", err)
+		// Local read failures (a file vanished mid-upload) won't fix themselves.
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return nil, false, fmt.Errorf("failed to stream artifact files: %w", err)
+		}
+		return nil, httpx.Retriable(err, 0), fmt.Errorf("upload request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, true, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("artifact upload failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, httpx.Retriable(nil, resp.StatusCode), fmt.Errorf("artifact upload failed with status %d: %s", resp.StatusCode, string(respBody))
 	}
 
 	var uploadResp ArtifactUploadResponse
 	if err := json.Unmarshal(respBody, &uploadResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, false, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	if !uploadResp.Success {
-		return nil, fmt.Errorf("artifact upload failed: %s", uploadResp.Message)
+		return nil, false, fmt.Errorf("artifact upload failed: %s", uploadResp.Message)
+	}
+
+	return &uploadResp, false, nil
+}
+
+// writeArtifactParts writes the artifact_name field followed by each file
+// (named by its path relative to artifactDir), copying straight from disk.
+func writeArtifactParts(writer *multipart.Writer, artifactName, artifactDir string, files []string) error {
+	if err := writer.WriteField("artifact_name", artifactName); err != nil {
+		return fmt.Errorf("failed to write artifact name field: %w", err)
+	}
+
+	for _, filePath := range files {
+		relPath, err := filepath.Rel(artifactDir, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+
+		part, err := writer.CreateFormFile("files", relPath)
+		if err != nil {
+			return fmt.Errorf("failed to create form file: %w", err)
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
 	}
 
-	return &uploadResp, nil
+	return writer.Close()
 }
 
 // GetTransactionStatus retrieves the status of a transaction
@@ -345,23 +477,56 @@ func (u *ArtifactUploader) GetArtifactStatus(artifactUUID string) (*StatusRespon
 	return &statusResp, nil
 }
 
-// findFilesInDir recursively finds all files in a directory
-func findFilesInDir(dir string) ([]string, error) {
+// findFilesInDir recursively finds all regular files in a directory, in
+// lexical order. Symbolic links are skipped unless followSymlinks is set;
+// then each directory is read once, whichever path reaches it first, so a
+// link cycle ends instead of recursing forever. Dangling links are skipped.
+func findFilesInDir(dir string, followSymlinks bool) ([]string, error) {
 	var files []string
+	visited := map[string]bool{}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		real, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+		if visited[real] {
+			return nil
+		}
+		visited[real] = true
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		entries, err := os.ReadDir(path)
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			files = append(files, path)
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+			mode := entry.Type()
+			if mode&os.ModeSymlink != 0 {
+				if !followSymlinks {
+					continue
+				}
+				info, err := os.Stat(entryPath)
+				if err != nil {
+					continue
+				}
+				mode = info.Mode().Type()
+			}
+			switch {
+			case mode.IsDir():
+				if err := walk(entryPath); err != nil {
+					return err
+				}
+			case mode.IsRegular():
+				files = append(files, entryPath)
+			}
 		}
 		return nil
-	})
+	}
 
-	if err != nil {
+	if err := walk(dir); err != nil {
 		return nil, err
 	}
-
 	return files, nil
 }
@@ -3,12 +3,15 @@ package github
 import (
 	"encoding/json"
 	"io"
+	"mime"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vulnetix/cli/v3/pkg/auth"
 )
@@ -36,6 +39,28 @@ func TestNewArtifactUploader(t *testing.T) {
 	}
 }
 
+func TestNewArtifactUploader_TrimsTrailingSlash(t *testing.T) {
+	for _, baseURL := range []string{"https://api.vulnetix.com/v1", "https://api.vulnetix.com/v1/"} {
+		uploader := NewArtifactUploader(baseURL, "org")
+		if uploader.baseURL != "https://api.vulnetix.com/v1" {
+			t.Errorf("NewArtifactUploader(%q).baseURL = %q", baseURL, uploader.baseURL)
+		}
+	}
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewEncoder(w).Encode(StatusResponse{Status: "completed"})
+	}))
+	defer server.Close()
+	if _, err := NewArtifactUploader(server.URL+"/", "org").GetTransactionStatus("txn-1"); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/org/github/artifact-upload/txn-1/status" {
+		t.Errorf("request path = %q", gotPath)
+	}
+}
+
 func TestTransactionRequest(t *testing.T) {
 	metadata := &ArtifactMetadata{
 		Repository:      "test/repo",
@@ -173,7 +198,7 @@ func TestInitiateTransaction(t *testing.T) {
 		Artifacts:  []string{"artifact1"},
 	}
 
-	txnResp, err := uploader.InitiateTransaction(metadata, []string{"artifact1"})
+	txnResp, err := uploader.InitiateTransaction(metadata, []string{"artifact1"}, nil)
 	if err != nil {
 		t.Fatalf("InitiateTransaction failed: %v", err)
 	}
@@ -225,12 +250,113 @@ func TestInitiateTransaction_WithAuth(t *testing.T) {
 		RunID:      "123",
 	}
 
-	_, err := uploader.InitiateTransaction(metadata, []string{"artifact1"})
+	_, err := uploader.InitiateTransaction(metadata, []string{"artifact1"}, nil)
 	if err != nil {
 		t.Fatalf("InitiateTransaction failed: %v", err)
 	}
 }
 
+func TestInitiateTransaction_InvalidTxnID(t *testing.T) {
+	for _, txnID := range []string{"", "../../etc/passwd", "txn 123", "txn?x=1"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(TransactionResponse{TxnID: txnID, Success: true})
+		}))
+
+		uploader := &ArtifactUploader{
+			baseURL: server.URL,
+			orgID:   "test-org",
+			client:  &http.Client{},
+		}
+
+		resp, err := uploader.InitiateTransaction(&ArtifactMetadata{}, []string{"artifact1"}, nil)
+		server.Close()
+		if err == nil {
+			t.Errorf("txn ID %q: expected error, got response %+v", txnID, resp)
+			continue
+		}
+		if !strings.Contains(err.Error(), "invalid transaction ID") {
+			t.Errorf("txn ID %q: expected invalid transaction ID error, got: %v", txnID, err)
+		}
+	}
+}
+
+func TestAppendToTransaction(t *testing.T) {
+	var appended TransactionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/test-org/github/artifact-upload/txn-open/status":
+			_ = json.NewEncoder(w).Encode(StatusResponse{Status: "in_progress", TxnID: "txn-open"})
+		case r.Method == "POST" && r.URL.Path == "/test-org/github/artifact-upload/txn-open/artifacts":
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &appended); err != nil {
+				t.Errorf("Failed to unmarshal request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(TransactionResponse{Success: true})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	uploader := &ArtifactUploader{
+		baseURL:     server.URL,
+		orgID:       "test-org",
+		client:      &http.Client{},
+		CallbackURL: "https://hooks.example.com/vulnetix",
+	}
+
+	manifest := []ArtifactManifestEntry{{Artifact: "sbom", Path: "bom.cdx.json", Format: "cyclonedx", Size: 42, SHA256: "abc"}}
+	resp, err := uploader.AppendToTransaction("txn-open", nil, &ArtifactMetadata{Repository: "test/repo"}, []string{"sbom"}, manifest)
+	if err != nil {
+		t.Fatalf("AppendToTransaction failed: %v", err)
+	}
+	if resp.TxnID != "txn-open" {
+		t.Errorf("Expected TxnID 'txn-open', got '%s'", resp.TxnID)
+	}
+	if len(appended.Artifacts) != 1 || appended.Artifacts[0] != "sbom" {
+		t.Errorf("Expected artifacts [sbom], got %v", appended.Artifacts)
+	}
+	if appended.Meta == nil || appended.Meta.Repository != "test/repo" {
+		t.Errorf("Expected metadata to be sent, got %+v", appended.Meta)
+	}
+	if len(appended.Manifest) != 1 || appended.Manifest[0] != manifest[0] {
+		t.Errorf("Expected manifest %+v, got %+v", manifest, appended.Manifest)
+	}
+	if appended.CallbackURL != "https://hooks.example.com/vulnetix" {
+		t.Errorf("Expected the callback URL to be registered, got %q", appended.CallbackURL)
+	}
+}
+
+func TestAppendToTransaction_RejectsClosed(t *testing.T) {
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posted = true
+		}
+		_ = json.NewEncoder(w).Encode(StatusResponse{Status: "completed", TxnID: "txn-done"})
+	}))
+	defer server.Close()
+
+	uploader := &ArtifactUploader{
+		baseURL: server.URL,
+		orgID:   "test-org",
+		client:  &http.Client{},
+	}
+
+	_, err := uploader.AppendToTransaction("txn-done", nil, &ArtifactMetadata{}, []string{"sbom"}, nil)
+	if err == nil {
+		t.Fatal("expected error appending to a completed transaction")
+	}
+	if !strings.Contains(err.Error(), "no longer accepts artifacts") {
+		t.Errorf("expected closed transaction error, got: %v", err)
+	}
+	if posted {
+		t.Error("artifacts must not be registered with a closed transaction")
+	}
+}
+
 func TestUploadArtifact(t *testing.T) {
 	// Create temporary artifact directory with test files
 	tmpDir := t.TempDir()
@@ -303,6 +429,126 @@ func TestUploadArtifact_InvalidTxnID(t *testing.T) {
 	}
 }
 
+func TestUploadArtifact_StreamsAllFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	large := strings.Repeat("x", 4<<20)
+	files := map[string]string{
+		"results.sarif":          `{"runs":[]}`,
+		"nested/bom.cdx.json":    `{"bomFormat":"CycloneDX"}`,
+		"nested/deep/large.json": large,
+	}
+	for rel, content := range files {
+		path := filepath.Join(tmpDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	received := map[string]int{}
+	var artifactName string
+	var contentLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Fatalf("multipart reader: %v", err)
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("next part: %v", err)
+			}
+			if part.FormName() == "artifact_name" {
+				b, _ := io.ReadAll(part)
+				artifactName = string(b)
+				continue
+			}
+			// FileName() strips directories; read the raw relative path.
+			_, params, _ := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+			n, _ := io.Copy(io.Discard, part)
+			received[params["filename"]] = int(n)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ArtifactUploadResponse{UUID: "a-1", Success: true})
+	}))
+	defer server.Close()
+
+	uploader := &ArtifactUploader{baseURL: server.URL, orgID: "test-org", client: &http.Client{}}
+	if _, err := uploader.UploadArtifact("txn-1", "scan-results", tmpDir); err != nil {
+		t.Fatalf("UploadArtifact failed: %v", err)
+	}
+
+	// A streamed body has no precomputed length; a buffered one would.
+	if contentLength != -1 {
+		t.Errorf("expected streamed (chunked) body, got Content-Length %d", contentLength)
+	}
+	if artifactName != "scan-results" {
+		t.Errorf("expected artifact_name 'scan-results', got %q", artifactName)
+	}
+	for rel, content := range files {
+		if got, ok := received[filepath.ToSlash(rel)]; !ok || got != len(content) {
+			t.Errorf("file %s: received %d bytes (present=%v), want %d", rel, got, ok, len(content))
+		}
+	}
+}
+
+func TestUploadArtifact_RetriesTransientFailures(t *testing.T) {
+	origBackoff := uploadRetryBackoff
+	uploadRetryBackoff = time.Millisecond
+	defer func() { uploadRetryBackoff = origBackoff }()
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "report.json"), []byte("payload"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds after 503", []int{http.StatusServiceUnavailable, http.StatusCreated}, 2, false},
+		{"succeeds after 429 and 502", []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}, 3, false},
+		{"gives up after max attempts", []int{500, 500, 500, 500}, maxUploadAttempts, true},
+		{"no retry on 400", []int{http.StatusBadRequest, http.StatusCreated}, 1, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[calls]
+				calls++
+				// Every attempt must carry the complete body, not a drained reader.
+				body, _ := io.ReadAll(r.Body)
+				if !strings.Contains(string(body), "payload") {
+					t.Errorf("attempt %d: body missing file content", calls)
+				}
+				w.WriteHeader(status)
+				if status < 300 {
+					_ = json.NewEncoder(w).Encode(ArtifactUploadResponse{UUID: "a-1", Success: true})
+				}
+			}))
+			defer server.Close()
+
+			uploader := &ArtifactUploader{baseURL: server.URL, orgID: "test-org", client: &http.Client{}}
+			_, err := uploader.UploadArtifact("txn-1", "artifact", tmpDir)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("expected %d attempts, got %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
 func TestGetTransactionStatus(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -409,3 +655,74 @@ func TestGetArtifactStatus_EmptyUUID(t *testing.T) {
 		t.Errorf("Expected empty UUID error, got: %v", err)
 	}
 }
+
+// symlinkTree builds root/reports/scan.sarif, root/linked.sarif -> the
+// outside report, and root/reports/loop -> root.
+func symlinkTree(t *testing.T) string {
+	t.Helper()
+	root, outside := t.TempDir(), t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "reports"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{
+		filepath.Join(root, "reports", "scan.sarif"): `{"version":"2.1.0"}`,
+		filepath.Join(outside, "external.sarif"):     `{"version":"2.1.0"}`,
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "external.sarif"), filepath.Join(root, "linked.sarif")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(root, "reports", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "missing"), filepath.Join(root, "dangling.json")); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestFindFilesInDir_SkipsSymlinksByDefault(t *testing.T) {
+	root := symlinkTree(t)
+	files, err := findFilesInDir(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(root, "reports", "scan.sarif")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %q, want %q", files, want)
+	}
+}
+
+func TestFindFilesInDir_FollowSymlinks(t *testing.T) {
+	root := symlinkTree(t)
+
+	done := make(chan struct{})
+	var files []string
+	var err error
+	go func() {
+		defer close(done)
+		files, err = findFilesInDir(root, true)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("walk did not terminate on a symlink cycle")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(root, "linked.sarif"),
+		filepath.Join(root, "reports", "scan.sarif"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %q, want %q (link target included once, cycle and dangling link skipped)", files, want)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil || string(data) != `{"version":"2.1.0"}` {
+		t.Errorf("linked file reads %q, %v", data, err)
+	}
+}
@@ -0,0 +1,100 @@
+// Package history keeps a local, append-only log of the artifacts this
+// machine has uploaded, so users have an audit trail without asking the API.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the log's name under ~/.vulnetix.
+const FileName = "history.jsonl"
+
+// Entry is one successful upload, written as a single JSON line.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	File      string    `json:"file"`
+	Format    string    `json:"format"`
+	UUID      string    `json:"uuid,omitempty"`
+	OrgID     string    `json:"orgId,omitempty"`
+	Duplicate bool      `json:"duplicate,omitempty"`
+}
+
+// Filter selects entries; zero fields match everything.
+type Filter struct {
+	Since  time.Time
+	Format string
+	OrgID  string
+}
+
+// Match reports whether e passes f.
+func (f Filter) Match(e Entry) bool {
+	return (f.Since.IsZero() || !e.Time.Before(f.Since)) &&
+		(f.Format == "" || f.Format == e.Format) &&
+		(f.OrgID == "" || f.OrgID == e.OrgID)
+}
+
+// Path returns ~/.vulnetix/history.jsonl.
+func Path() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("user home dir: %w", err)
+	}
+	return filepath.Join(home, ".vulnetix", FileName), nil
+}
+
+// Append adds e to the log at path, creating it owner-only if needed. Each
+// entry is written with a single append so concurrent CLI runs do not
+// interleave lines.
+func Append(path string, e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the entries in the log at path that match f, oldest first. A
+// missing log is empty; lines that do not parse (for instance one cut short
+// by a crash) are skipped.
+func Read(path string, f Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		if f.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ParseAPIError extracts the "error" (or "message") and "details" fields from
+// a Vulnetix API error body. details may be a string or any JSON value; the
+// latter is returned compacted. ok is false when the body is not a structured
+// error, in which case callers should fall back to the raw body.
+func ParseAPIError(body []byte) (msg, details string, ok bool) {
+	var payload struct {
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return "", "", false
+	}
+	msg = payload.Error
+	if msg == "" {
+		msg = payload.Message
+	}
+	if msg == "" {
+		return "", "", false
+	}
+	if raw := bytes.TrimSpace(payload.Details); len(raw) > 0 && string(raw) != "null" {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			details = s
+		} else {
+			var compact bytes.Buffer
+			if json.Compact(&compact, raw) == nil {
+				details = compact.String()
+			}
+		}
+	}
+	return msg, strings.TrimSpace(details), true
+}
+
+// APIErrorMessage renders an API error body for display: "error - details"
+// for a structured body, or the trimmed raw body otherwise.
+func APIErrorMessage(body []byte) string {
+	msg, details, ok := ParseAPIError(body)
+	if !ok {
+		if raw := strings.TrimSpace(string(body)); raw != "" {
+			return raw
+		}
+		return "empty response body"
+	}
+	if details != "" {
+		return msg + " - " + details
+	}
+	return msg
+}
@@ -0,0 +1,35 @@
+package httpx
+
+import "testing"
+
+func TestParseAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMsg     string
+		wantDetails string
+		wantOK      bool
+		wantDisplay string
+	}{
+		{"error and string details", `{"success":false,"error":"invalid org","details":"org 42 not found"}`, "invalid org", "org 42 not found", true, "invalid org - org 42 not found"},
+		{"error only", `{"error":"rate limited"}`, "rate limited", "", true, "rate limited"},
+		{"message fallback", `{"message":"forbidden"}`, "forbidden", "", true, "forbidden"},
+		{"object details", `{"error":"validation failed","details":{"field": "format"}}`, "validation failed", `{"field":"format"}`, true, `validation failed - {"field":"format"}`},
+		{"null details", `{"error":"boom","details":null}`, "boom", "", true, "boom"},
+		{"json without error", `{"ok":false}`, "", "", false, `{"ok":false}`},
+		{"plain text", "502 Bad Gateway\n", "", "", false, "502 Bad Gateway"},
+		{"html", "<html><body>Service Unavailable</body></html>", "", "", false, "<html><body>Service Unavailable</body></html>"},
+		{"empty", "", "", "", false, "empty response body"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, details, ok := ParseAPIError([]byte(tc.body))
+			if msg != tc.wantMsg || details != tc.wantDetails || ok != tc.wantOK {
+				t.Errorf("ParseAPIError = (%q, %q, %v), want (%q, %q, %v)", msg, details, ok, tc.wantMsg, tc.wantDetails, tc.wantOK)
+			}
+			if got := APIErrorMessage([]byte(tc.body)); got != tc.wantDisplay {
+				t.Errorf("APIErrorMessage = %q, want %q", got, tc.wantDisplay)
+			}
+		})
+	}
+}
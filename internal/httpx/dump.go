@@ -0,0 +1,206 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// DumpRequest is how Transport treats the next request of the invocation,
+// from --dump-request.
+var DumpRequest DumpMode
+
+// DumpOutput receives the dumped request. A var so tests can capture it.
+var DumpOutput io.Writer = os.Stderr
+
+// ErrRequestDumped is returned in place of a response when the request was
+// dumped with DumpOnly and never sent.
+var ErrRequestDumped = errors.New("request dumped and not sent (--dump-request); pass --dump-request=send to send it as well")
+
+// maxDumpBody caps how much of a request body is printed.
+const maxDumpBody = 64 * 1024
+
+// dumped records whether this invocation's request has been dumped already.
+var dumped atomic.Bool
+
+// DumpMode selects whether a dumped request is also sent.
+type DumpMode int
+
+const (
+	// DumpOff dumps nothing.
+	DumpOff DumpMode = iota
+	// DumpOnly prints the request and does not send it.
+	DumpOnly
+	// DumpAndSend prints the request and sends it.
+	DumpAndSend
+)
+
+var dumpModes = map[string]DumpMode{"": DumpOff, "off": DumpOff, "dry-run": DumpOnly, "send": DumpAndSend}
+
+// String implements pflag.Value.
+func (m *DumpMode) String() string {
+	for name, mode := range dumpModes {
+		if name != "" && mode == *m {
+			return name
+		}
+	}
+	return ""
+}
+
+// Set implements pflag.Value, accepting dry-run, send or off.
+func (m *DumpMode) Set(s string) error {
+	mode, ok := dumpModes[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("must be dry-run or send")
+	}
+	*m = mode
+	return nil
+}
+
+// Type implements pflag.Value.
+func (m *DumpMode) Type() string { return "mode" }
+
+type skipDumpKey struct{}
+
+// WithoutDump marks requests made with ctx as credential exchanges, which
+// DumpRequest passes over so the dump shows the command's own API call.
+func WithoutDump(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipDumpKey{}, true)
+}
+
+func dumpSkipped(req *http.Request) bool {
+	skip, _ := req.Context().Value(skipDumpKey{}).(bool)
+	return skip
+}
+
+// ResetDump arms DumpRequest for the next request. The cmd layer calls it at
+// startup so each invocation dumps its own first request.
+func ResetDump() {
+	dumped.Store(false)
+}
+
+// sensitiveNames are substrings of header, query and JSON field names whose
+// values are redacted from a dump.
+var sensitiveNames = []string{"auth", "token", "secret", "key", "password", "signature", "cookie", "credential"}
+
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpRequest writes req to w with its secrets redacted: sensitive headers
+// keep only their scheme (e.g. "Bearer"), sensitive query parameters and
+// JSON fields lose their values, and user info is dropped from the URL. The
+// body is read and put back so the request can still be sent.
+func dumpRequest(w io.Writer, req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return fmt.Errorf("read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", req.Method, redactedURL(req.URL))
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range req.Header[name] {
+			if isSensitive(name) {
+				v = redactHeader(v)
+			}
+			fmt.Fprintf(&b, "%s: %s\n", name, v)
+		}
+	}
+	if len(body) > 0 {
+		b.WriteString("\n")
+		shown := redactBody(req.Header.Get("Content-Type"), body)
+		if len(shown) > maxDumpBody {
+			fmt.Fprintf(&b, "%s\n... (%d more bytes)\n", shown[:maxDumpBody], len(shown)-maxDumpBody)
+		} else {
+			b.Write(shown)
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// redactHeader keeps the scheme of an Authorization-style value and hides
+// the rest.
+func redactHeader(v string) string {
+	if scheme, _, ok := strings.Cut(v, " "); ok {
+		return scheme + " [REDACTED]"
+	}
+	return "[REDACTED]"
+}
+
+func redactedURL(u *url.URL) string {
+	r := *u
+	r.User = nil
+	if r.RawQuery != "" {
+		q := r.Query()
+		for name := range q {
+			if isSensitive(name) {
+				q[name] = []string{"REDACTED"}
+			}
+		}
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// redactBody hides sensitive fields of a JSON body. Other bodies are shown
+// as they are.
+func redactBody(contentType string, body []byte) []byte {
+	if !strings.Contains(contentType, "json") {
+		return body
+	}
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+	out, err := json.MarshalIndent(redactJSON(doc), "", "  ")
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if isSensitive(k) {
+				v[k] = "[REDACTED]"
+			} else {
+				v[k] = redactJSON(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,113 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func withDump(t *testing.T, mode DumpMode) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	prevMode, prevOut := DumpRequest, DumpOutput
+	DumpRequest, DumpOutput = mode, &out
+	ResetDump()
+	t.Cleanup(func() { DumpRequest, DumpOutput = prevMode, prevOut })
+	return &out
+}
+
+func TestTransportDumpsRequestWithoutSending(t *testing.T) {
+	out := withDump(t, DumpOnly)
+
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	body := `{"name":"app","apiKey":"k-123","nested":{"clientSecret":"s-456"}}`
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/scan?page=2&token=t-789", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer eyJhbGciOi.secret")
+	req.Header.Set("X-Amz-Security-Token", "session-abc")
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := (&http.Client{Transport: &Transport{}}).Do(req)
+	if !errors.Is(err, ErrRequestDumped) {
+		t.Fatalf("err = %v, want ErrRequestDumped", err)
+	}
+	if called {
+		t.Error("dry-run dump sent the request")
+	}
+
+	dump := out.String()
+	for _, want := range []string{"POST " + server.URL + "/v1/scan?", "page=2", "Authorization: Bearer [REDACTED]", "X-Amz-Security-Token: [REDACTED]", `"name": "app"`} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump missing %q:\n%s", want, dump)
+		}
+	}
+	for _, secret := range []string{"eyJhbGciOi", "session-abc", "k-123", "s-456", "t-789"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump leaks %q:\n%s", secret, dump)
+		}
+	}
+}
+
+func TestTransportDumpsOnlyFirstRequestAndSends(t *testing.T) {
+	out := withDump(t, DumpAndSend)
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b bytes.Buffer
+		b.ReadFrom(r.Body)
+		bodies = append(bodies, b.String())
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	for _, path := range []string{"/first", "/second"} {
+		resp, err := client.Post(server.URL+path, "text/plain", strings.NewReader("payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(bodies) != 2 || bodies[0] != "payload" {
+		t.Errorf("server received %q, want the payload on both requests", bodies)
+	}
+	if dump := out.String(); !strings.Contains(dump, "/first") || strings.Contains(dump, "/second") {
+		t.Errorf("dump should hold only the first request:\n%s", dump)
+	}
+}
+
+func TestTransportDumpSkipsCredentialExchange(t *testing.T) {
+	out := withDump(t, DumpOnly)
+
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	exchange, _ := http.NewRequestWithContext(WithoutDump(context.Background()), http.MethodGet, server.URL+"/auth/token", nil)
+	resp, err := client.Do(exchange)
+	if err != nil {
+		t.Fatalf("credential exchange was held back: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(server.URL + "/v1/info"); !errors.Is(err, ErrRequestDumped) {
+		t.Fatalf("err = %v, want ErrRequestDumped", err)
+	}
+	if sent != 1 {
+		t.Errorf("server received %d request(s), want only the exchange", sent)
+	}
+	if dump := out.String(); strings.Contains(dump, "/auth/token") || !strings.Contains(dump, "/v1/info") {
+		t.Errorf("dump should hold the API call, not the exchange:\n%s", dump)
+	}
+}
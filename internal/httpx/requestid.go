@@ -0,0 +1,81 @@
+package httpx
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the invocation's trace ID on every API request.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID identifies the current CLI invocation so its requests can be
+// matched to server logs when filing a support ticket. Set by the cmd layer at
+// startup via NewRequestID.
+var RequestID string
+
+// requestIDSent records whether any request has carried RequestID, so errors
+// that never reached the network don't print an ID nobody can look up.
+var requestIDSent atomic.Bool
+
+// NewRequestID starts a new invocation: it assigns a fresh RequestID and
+// returns it.
+func NewRequestID() string {
+	RequestID = uuid.NewString()
+	requestIDSent.Store(false)
+	return RequestID
+}
+
+// RequestIDSent reports whether any request has carried the current RequestID.
+func RequestIDSent() bool {
+	return requestIDSent.Load()
+}
+
+// Transport stamps each outgoing request with RequestID before passing it to
+// Base (http.DefaultTransport with ClientTLSConfig when nil), and counts the body bytes it sends
+// and receives (see Traffic). A header already set by the caller is left
+// alone. With DumpRequest set, the first request of the invocation that is
+// not a credential exchange (see WithoutDump) is also printed to DumpOutput,
+// and with DumpOnly it is not sent.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// DefaultTransport is the shared transport for clients that have no pooled
+// transport of their own.
+var DefaultTransport = &Transport{}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = defaultBase
+	}
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	stamped := false
+	if RequestID != "" && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, RequestID)
+		stamped = true
+	}
+	if DumpRequest != DumpOff && !dumpSkipped(req) && dumped.CompareAndSwap(false, true) {
+		if err := dumpRequest(DumpOutput, req); err != nil {
+			return nil, err
+		}
+		if DumpRequest == DumpOnly {
+			return nil, ErrRequestDumped
+		}
+	}
+	if stamped {
+		requestIDSent.Store(true)
+	}
+	countRequestBody(req)
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingReadCloser{ReadCloser: resp.Body, n: &bytesReceived}
+	return resp, nil
+}
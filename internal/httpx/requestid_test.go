@@ -0,0 +1,71 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransportSendsOneRequestIDPerInvocation(t *testing.T) {
+	t.Cleanup(func() { RequestID = "" })
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(RequestIDHeader))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: DefaultTransport}
+	get := func() {
+		t.Helper()
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	id := NewRequestID()
+	if RequestIDSent() {
+		t.Fatal("RequestIDSent before any request")
+	}
+	get()
+	get()
+	if !RequestIDSent() {
+		t.Error("RequestIDSent = false after requests")
+	}
+	if len(seen) != 2 || seen[0] != id || seen[1] != id {
+		t.Fatalf("request IDs = %q, want %q on both", seen, id)
+	}
+
+	next := NewRequestID()
+	if next == id {
+		t.Fatal("NewRequestID reused the previous ID")
+	}
+	get()
+	if seen[2] != next {
+		t.Errorf("request ID after new invocation = %q, want %q", seen[2], next)
+	}
+}
+
+func TestTransportKeepsCallerRequestID(t *testing.T) {
+	t.Cleanup(func() { RequestID = "" })
+	NewRequestID()
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestIDHeader)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set(RequestIDHeader, "caller-id")
+	resp, err := (&http.Client{Transport: DefaultTransport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got != "caller-id" {
+		t.Errorf("X-Request-ID = %q, want caller-id", got)
+	}
+}
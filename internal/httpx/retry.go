@@ -0,0 +1,52 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Retriable reports whether a failed request is worth retrying. Pass the
+// transport error from http.Client.Do (statusCode is then ignored), or a nil
+// error with the response status code.
+//
+// Timeouts, connection resets/refusals and truncated responses are transient,
+// as are 408, 429 and every 5xx. Any other 4xx is the caller's fault and will
+// fail the same way again. A cancelled context is never retried.
+func Retriable(err error, statusCode int) bool {
+	if err != nil {
+		return retriableError(err)
+	}
+	return statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+}
+
+func retriableError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if os.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	// Some transports flatten the cause into the message.
+	msg := err.Error()
+	return strings.Contains(msg, "deadline exceeded") ||
+		strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "broken pipe")
+}
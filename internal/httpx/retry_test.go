@@ -0,0 +1,78 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestRetriable_StatusCodes(t *testing.T) {
+	tests := []struct {
+		code int
+		want bool
+	}{
+		{200, false},
+		{201, false},
+		{301, false},
+		{400, false},
+		{401, false},
+		{403, false},
+		{404, false},
+		{408, true},
+		{409, false},
+		{413, false},
+		{422, false},
+		{429, true},
+		{500, true},
+		{501, true},
+		{502, true},
+		{503, true},
+		{504, true},
+	}
+	for _, tc := range tests {
+		if got := Retriable(nil, tc.code); got != tc.want {
+			t.Errorf("Retriable(nil, %d) = %v, want %v", tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestRetriable_Errors(t *testing.T) {
+	urlErr := func(err error) error { return &url.Error{Op: "Post", URL: "https://api.example.test", Err: err} }
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"os timeout", urlErr(os.ErrDeadlineExceeded), true},
+		{"dial timeout", urlErr(&net.OpError{Op: "dial", Err: &timeoutErr{}}), true},
+		{"connection reset", urlErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"connection refused", urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"broken pipe", urlErr(syscall.EPIPE), true},
+		{"unexpected EOF", urlErr(io.ErrUnexpectedEOF), true},
+		{"flattened reset", fmt.Errorf("read tcp: connection reset by peer"), true},
+		{"cancelled", urlErr(context.Canceled), false},
+		{"unsupported scheme", urlErr(errors.New("unsupported protocol scheme \"ftp\"")), false},
+		{"dns failure", urlErr(&net.DNSError{Err: "no such host", Name: "api.example.test"}), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The status code is ignored whenever an error is present.
+			if got := Retriable(tc.err, 503); got != tc.want {
+				t.Errorf("Retriable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+type timeoutErr struct{}
+
+func (*timeoutErr) Error() string   { return "i/o timeout" }
+func (*timeoutErr) Timeout() bool   { return true }
+func (*timeoutErr) Temporary() bool { return true }
@@ -0,0 +1,30 @@
+package httpx
+
+import (
+	"context"
+	"time"
+)
+
+// Per-operation request deadlines. Each one bounds a single request through its
+// context, so a token exchange fails fast while a chunk upload on a slow link
+// still gets minutes. Set by the cmd layer from the --*-timeout flags; zero
+// disables the deadline.
+var (
+	AuthTimeout        = 15 * time.Second
+	ListTimeout        = 30 * time.Second
+	DownloadTimeout    = 10 * time.Minute
+	UploadChunkTimeout = 5 * time.Minute
+	FinalizeTimeout    = 2 * time.Minute
+)
+
+// WithTimeout derives a request context from parent that expires after d. A
+// non-positive d adds no deadline of its own.
+func WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if d <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, d)
+}
@@ -0,0 +1,22 @@
+package httpx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected a deadline within a minute, got %v (set: %v)", deadline, ok)
+	}
+
+	ctx, cancel = WithTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("a zero timeout must not add a deadline")
+	}
+}
@@ -0,0 +1,53 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// ClientTLSConfig is the TLS configuration of every API transport. Its
+// MinVersion is set by the cmd layer from --min-tls-version (see
+// MinTLSVersion) before any request is made.
+var ClientTLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+// defaultBase is http.DefaultTransport using ClientTLSConfig; Transport falls
+// back to it when no Base is set.
+var defaultBase = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = ClientTLSConfig
+	return t
+}()
+
+// tlsVersions are the values --min-tls-version accepts. Older versions are
+// left out: Go clients already refuse them by default.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// MinTLSVersion is the flag value for --min-tls-version. Setting it updates
+// ClientTLSConfig.MinVersion; any value other than "1.2" or "1.3" is rejected.
+var MinTLSVersion minTLSVersion
+
+type minTLSVersion struct{}
+
+func (minTLSVersion) String() string {
+	for name, v := range tlsVersions {
+		if v == ClientTLSConfig.MinVersion {
+			return name
+		}
+	}
+	return ""
+}
+
+func (minTLSVersion) Set(s string) error {
+	v, ok := tlsVersions[s]
+	if !ok {
+		return fmt.Errorf("unsupported TLS version %q: must be 1.2 or 1.3", s)
+	}
+	ClientTLSConfig.MinVersion = v
+	return nil
+}
+
+func (minTLSVersion) Type() string { return "version" }
@@ -0,0 +1,61 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMinTLSVersionRefusesOlderServer(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	ClientTLSConfig.RootCAs = roots
+	t.Cleanup(func() {
+		ClientTLSConfig.RootCAs = nil
+		_ = MinTLSVersion.Set("1.2")
+		defaultBase.CloseIdleConnections()
+	})
+	client := &http.Client{Transport: DefaultTransport}
+
+	if err := MinTLSVersion.Set("1.3"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Fatal("a TLS 1.2 server was accepted with --min-tls-version 1.3")
+	} else if !strings.Contains(err.Error(), "protocol version") {
+		t.Errorf("err = %v, want a protocol version failure", err)
+	}
+
+	if err := MinTLSVersion.Set("1.2"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("TLS 1.2 server refused with --min-tls-version 1.2: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestMinTLSVersionRejectsInvalidValues(t *testing.T) {
+	t.Cleanup(func() { _ = MinTLSVersion.Set("1.2") })
+	for _, v := range []string{"1.0", "1.1", "1.4", "tls1.3", ""} {
+		if err := MinTLSVersion.Set(v); err == nil {
+			t.Errorf("Set(%q) should fail", v)
+		}
+	}
+	if MinTLSVersion.String() != "1.2" {
+		t.Errorf("String() = %q after rejected values, want 1.2", MinTLSVersion.String())
+	}
+	if err := MinTLSVersion.Set("1.3"); err != nil || MinTLSVersion.String() != "1.3" {
+		t.Errorf("Set(1.3) = %v, String() = %q", err, MinTLSVersion.String())
+	}
+}
@@ -0,0 +1,47 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// bytesSent and bytesReceived total the request and response bodies that
+// went through Transport during the current invocation.
+var bytesSent, bytesReceived atomic.Int64
+
+// Traffic returns the request and response body bytes carried by Transport
+// since the last ResetTraffic. Headers and TLS overhead are not counted, and
+// a response body counts only as far as it was read.
+func Traffic() (sent, received int64) {
+	return bytesSent.Load(), bytesReceived.Load()
+}
+
+// ResetTraffic zeroes the counters reported by Traffic. The cmd layer calls
+// it at startup so each invocation reports only its own requests.
+func ResetTraffic() {
+	bytesSent.Store(0)
+	bytesReceived.Store(0)
+}
+
+// countRequestBody routes req's body through the sent counter. req must be a
+// clone the transport owns; a missing or empty body is left as is so the
+// request's framing does not change.
+func countRequestBody(req *http.Request) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	req.Body = &countingReadCloser{ReadCloser: req.Body, n: &bytesSent}
+}
+
+// countingReadCloser adds every byte read through it to n.
+type countingReadCloser struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,50 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransportCountsTraffic(t *testing.T) {
+	ResetTraffic()
+	t.Cleanup(ResetTraffic)
+
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		encodings = append(encodings, strings.Join(r.TransferEncoding, ","))
+		_, _ = io.WriteString(w, strings.Repeat("r", 300))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: DefaultTransport}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader(strings.Repeat("s", 1000)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	// A bodiless request must keep its framing rather than turn chunked.
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if sent, received := Traffic(); sent != 1000 || received != 600 {
+		t.Errorf("Traffic() = %d sent, %d received; want 1000, 600", sent, received)
+	}
+	if encodings[1] != "" {
+		t.Errorf("GET was sent with Transfer-Encoding %q", encodings[1])
+	}
+
+	ResetTraffic()
+	if sent, received := Traffic(); sent != 0 || received != 0 {
+		t.Errorf("Traffic() after reset = %d, %d", sent, received)
+	}
+}
@@ -0,0 +1,27 @@
+// Package httpx holds HTTP plumbing shared by the upload, VDB and GitHub
+// clients so every outbound request identifies and behaves the same way.
+package httpx
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Version is the CLI version reported in the User-Agent. Set by the cmd layer
+// at startup from the ldflags-injected build version.
+var Version = "dev"
+
+// UserAgentSuffix is appended to the User-Agent so operators can tag traffic
+// (e.g. a team or pipeline name). Set by the cmd layer from --user-agent-suffix.
+var UserAgentSuffix string
+
+// UserAgent returns the identification sent on every API request, e.g.
+// "Vulnetix-CLI/3.2.0 (linux/amd64) team-payments".
+func UserAgent() string {
+	ua := fmt.Sprintf("Vulnetix-CLI/%s (%s/%s)", strings.TrimPrefix(Version, "v"), runtime.GOOS, runtime.GOARCH)
+	if suffix := strings.TrimSpace(UserAgentSuffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
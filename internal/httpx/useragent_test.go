@@ -0,0 +1,27 @@
+package httpx
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	origVersion, origSuffix := Version, UserAgentSuffix
+	defer func() { Version, UserAgentSuffix = origVersion, origSuffix }()
+
+	platform := " (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	tests := []struct {
+		version, suffix, want string
+	}{
+		{"3.4.1", "", "Vulnetix-CLI/3.4.1" + platform},
+		{"v3.4.1", "", "Vulnetix-CLI/3.4.1" + platform},
+		{"3.4.1", "team-payments", "Vulnetix-CLI/3.4.1" + platform + " team-payments"},
+		{"3.4.1", "   ", "Vulnetix-CLI/3.4.1" + platform},
+	}
+	for _, tc := range tests {
+		Version, UserAgentSuffix = tc.version, tc.suffix
+		if got := UserAgent(); got != tc.want {
+			t.Errorf("UserAgent() with version %q suffix %q = %q, want %q", tc.version, tc.suffix, got, tc.want)
+		}
+	}
+}
@@ -374,16 +374,3 @@ func TestIsEnvLineForms(t *testing.T) {
 		}
 	}
 }
-
-func TestMaskSecret(t *testing.T) {
-	if got := MaskSecret("short"); got != "****" {
-		t.Errorf("short secret should be fully masked, got %q", got)
-	}
-	got := MaskSecret("abcdefghijklmnop")
-	if strings.Contains(got, "efghijkl") {
-		t.Errorf("secret body leaked: %q", got)
-	}
-	if got != "abcd...mnop" {
-		t.Errorf("unexpected mask: %q", got)
-	}
-}
@@ -0,0 +1,181 @@
+// Package schema validates documents in the formats the CLI uploads against the
+// official JSON Schema for each format, and reports every violation with the
+// JSON pointer it was found at.
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	cyclonedx "github.com/Vulnetix/vdb-cyclonedx"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// schemaFS holds the vendored upstream schemas. They are copies of the files in
+// the repo's top-level schemas/third_party/ directory, made by `just
+// sync-schemas`. Never edit the copies.
+//
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// maxViolations bounds how many leaf violations Validate reports, matching the
+// limit vdb-cyclonedx applies to CycloneDX documents.
+const maxViolations = 25
+
+// formatSchemas maps each format validated here to its embedded schema and
+// the $id it declares. CycloneDX is absent: it spans several spec versions and
+// is validated by vdb-cyclonedx, the same validator the upload path uses.
+var formatSchemas = map[string]struct{ file, id string }{
+	"sarif":   {"schemas/sarif-2.1.0.schema.json", "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"},
+	"openvex": {"schemas/openvex-0.2.0.schema.json", "https://github.com/openvex/spec/openvex_json_schema_0.2.0.json"},
+	"spdx":    {"schemas/spdx-2.3.schema.json", "http://spdx.org/rdf/terms/2.3"},
+}
+
+// Violation is one place a document breaks its format's schema. Path is a
+// JSON pointer into the document; "/" is the document itself.
+type Violation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// Formats returns the formats Validate accepts, sorted.
+func Formats() []string {
+	formats := []string{"cyclonedx"}
+	for f := range formatSchemas {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Supported reports whether Validate has a schema for format.
+func Supported(format string) bool {
+	_, ok := formatSchemas[format]
+	return ok || format == "cyclonedx"
+}
+
+var (
+	compiled    = map[string]*jsonschema.Schema{}
+	compileErr  error
+	compileOnce sync.Once
+)
+
+// compileAll compiles every embedded schema once. The schemas span three
+// drafts (SARIF draft-04, SPDX draft-07, OpenVEX 2020-12); each declares its
+// own $schema and is compiled under its own dialect.
+func compileAll() error {
+	compileOnce.Do(func() {
+		c := jsonschema.NewCompiler()
+		for _, s := range formatSchemas {
+			raw, err := schemaFS.ReadFile(s.file)
+			if err != nil {
+				compileErr = fmt.Errorf("read embedded schema %s: %w", s.file, err)
+				return
+			}
+			doc, err := jsonschema.UnmarshalJSON(strings.NewReader(string(raw)))
+			if err != nil {
+				compileErr = fmt.Errorf("parse schema %s: %w", s.file, err)
+				return
+			}
+			if err := c.AddResource(s.id, doc); err != nil {
+				compileErr = fmt.Errorf("add schema resource %s: %w", s.file, err)
+				return
+			}
+		}
+		for format, s := range formatSchemas {
+			sch, err := c.Compile(s.id)
+			if err != nil {
+				compileErr = fmt.Errorf("compile %s schema: %w", format, err)
+				return
+			}
+			compiled[format] = sch
+		}
+	})
+	return compileErr
+}
+
+// Validate checks data, a JSON document in format, against that format's
+// official schema. A nil result means the document is valid. An error is
+// returned when the format has no schema or data is not JSON; a document that
+// parses but breaks the schema is reported through the violations instead.
+func Validate(format string, data []byte) ([]Violation, error) {
+	if format == "cyclonedx" {
+		specVersion, violations, err := cyclonedx.ValidateCycloneDX(data)
+		if err != nil {
+			return nil, err
+		}
+		// vdb-cyclonedx passes JSON that does not declare itself CycloneDX,
+		// leaving the decision to the caller; asked for CycloneDX, it fails.
+		if specVersion == "" {
+			return []Violation{{Path: "/", Message: "not a CycloneDX document: bomFormat and specVersion are required"}}, nil
+		}
+		var out []Violation
+		for _, v := range violations {
+			out = append(out, Violation{Path: pointer(v.Path), Message: v.Message})
+		}
+		return out, nil
+	}
+
+	if _, ok := formatSchemas[format]; !ok {
+		return nil, fmt.Errorf("no schema for format %q (supported: %s)", format, strings.Join(Formats(), ", "))
+	}
+	if err := compileAll(); err != nil {
+		return nil, err
+	}
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	verr := compiled[format].Validate(doc)
+	if verr == nil {
+		return nil, nil
+	}
+	ve, ok := verr.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, verr
+	}
+	return leafViolations(ve), nil
+}
+
+// leafViolations flattens a validation error tree into its leaves, which are
+// the specific rules the document broke, sorted by path and bounded by
+// maxViolations.
+func leafViolations(ve *jsonschema.ValidationError) []Violation {
+	var out []Violation
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(out) >= maxViolations {
+			return
+		}
+		if len(e.Causes) == 0 {
+			// A leaf renders as "at '<pointer>': <message>"; the pointer is
+			// reported separately.
+			msg := e.Error()
+			if _, after, ok := strings.Cut(msg, "': "); ok {
+				msg = after
+			}
+			out = append(out, Violation{Path: pointer("/" + strings.Join(e.InstanceLocation, "/")), Message: msg})
+			return
+		}
+		for _, c := range e.Causes {
+			walk(c)
+		}
+	}
+	walk(ve)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
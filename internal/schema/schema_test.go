@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedSchemasMatchSource(t *testing.T) {
+	for format, s := range formatSchemas {
+		embedded, err := schemaFS.ReadFile(s.file)
+		require.NoError(t, err, format)
+
+		// schemas/foo.json in the embed FS is ../../schemas/third_party/foo.json in the repo.
+		source, err := os.ReadFile(filepath.Join("..", "..", "schemas", "third_party", filepath.Base(s.file)))
+		require.NoError(t, err, "vendored schema missing for embedded %s", s.file)
+
+		require.Equal(t, string(source), string(embedded),
+			"%s is out of step with schemas/third_party — run `just sync-schemas`", s.file)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		doc      string
+		wantPath string // empty: the document is valid
+		wantMsg  string
+	}{
+		{
+			name:   "cyclonedx valid",
+			format: "cyclonedx",
+			doc:    `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"components":[{"type":"library","name":"lodash","version":"4.17.21"}]}`,
+		},
+		{
+			name:     "cyclonedx component missing name",
+			format:   "cyclonedx",
+			doc:      `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"components":[{"type":"library"}]}`,
+			wantPath: "/components/0",
+			wantMsg:  "name",
+		},
+		{
+			name:     "cyclonedx undeclared",
+			format:   "cyclonedx",
+			doc:      `{"components":[]}`,
+			wantPath: "/",
+			wantMsg:  "not a CycloneDX document",
+		},
+		{
+			name:   "spdx valid",
+			format: "spdx",
+			doc: `{"spdxVersion":"SPDX-2.3","dataLicense":"CC0-1.0","SPDXID":"SPDXRef-DOCUMENT","name":"app",
+				"documentNamespace":"https://example.com/app","creationInfo":{"created":"2024-01-01T00:00:00Z","creators":["Tool: test"]}}`,
+		},
+		{
+			name:     "spdx missing creationInfo",
+			format:   "spdx",
+			doc:      `{"spdxVersion":"SPDX-2.3","dataLicense":"CC0-1.0","SPDXID":"SPDXRef-DOCUMENT","name":"app"}`,
+			wantPath: "/",
+			wantMsg:  "creationInfo",
+		},
+		{
+			name:   "sarif valid",
+			format: "sarif",
+			doc:    `{"version":"2.1.0","runs":[{"tool":{"driver":{"name":"scanner"}},"results":[]}]}`,
+		},
+		{
+			name:     "sarif run missing tool",
+			format:   "sarif",
+			doc:      `{"version":"2.1.0","runs":[{"results":[]}]}`,
+			wantPath: "/runs/0",
+			wantMsg:  "tool",
+		},
+		{
+			name:   "openvex valid",
+			format: "openvex",
+			doc: `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"https://example.com/vex-1","author":"Example",
+				"timestamp":"2024-01-01T00:00:00Z","version":1,
+				"statements":[{"vulnerability":{"name":"CVE-2024-0001"},"products":[{"@id":"pkg:npm/app@1.0.0"}],"status":"not_affected","justification":"component_not_present"}]}`,
+		},
+		{
+			name:   "openvex bad status",
+			format: "openvex",
+			doc: `{"@context":"https://openvex.dev/ns/v0.2.0","@id":"https://example.com/vex-1","author":"Example",
+				"timestamp":"2024-01-01T00:00:00Z","version":1,
+				"statements":[{"vulnerability":{"name":"CVE-2024-0001"},"products":[{"@id":"pkg:npm/app@1.0.0"}],"status":"maybe"}]}`,
+			wantPath: "/statements/0/status",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			violations, err := Validate(tc.format, []byte(tc.doc))
+			require.NoError(t, err)
+			if tc.wantPath == "" {
+				assert.Empty(t, violations)
+				return
+			}
+			require.NotEmpty(t, violations)
+			var found bool
+			for _, v := range violations {
+				if v.Path == tc.wantPath && strings.Contains(v.Message, tc.wantMsg) {
+					found = true
+				}
+			}
+			assert.True(t, found, "no violation at %s mentioning %q in %v", tc.wantPath, tc.wantMsg, violations)
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	_, err := Validate("csaf_vex", []byte(`{}`))
+	assert.ErrorContains(t, err, "no schema for format")
+
+	_, err = Validate("sarif", []byte(`{not json`))
+	assert.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestFormats(t *testing.T) {
+	assert.Equal(t, []string{"cyclonedx", "openvex", "sarif", "spdx"}, Formats())
+	assert.True(t, Supported("spdx"))
+	assert.False(t, Supported("csaf_vex"))
+}
@@ -0,0 +1,132 @@
+package triage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SSVC deployer decisions, most to least urgent.
+const (
+	SSVCAct       = "Act"
+	SSVCAttend    = "Attend"
+	SSVCTrackStar = "Track*"
+	SSVCTrack     = "Track"
+)
+
+// PlanEntry is the triage decision recommended for one finding and the
+// signals that drove it. Nothing in a plan has been recorded anywhere.
+type PlanEntry struct {
+	VulnID        string   `json:"vulnId"`
+	Package       string   `json:"package,omitempty"`
+	Ecosystem     string   `json:"ecosystem,omitempty"`
+	InstalledVer  string   `json:"installedVersion,omitempty"`
+	FixedVer      string   `json:"fixedVersion,omitempty"`
+	Status        string   `json:"status"`
+	Justification string   `json:"justification,omitempty"`
+	Action        string   `json:"action,omitempty"`
+	Decision      string   `json:"decision,omitempty"`
+	SSVC          string   `json:"ssvc"`
+	Reasons       []string `json:"reasons"`
+}
+
+// BuildPlan returns the recommended decision for each finding, in order.
+func BuildPlan(findings []*TriageFinding) []PlanEntry {
+	plan := make([]PlanEntry, 0, len(findings))
+	for _, f := range findings {
+		if f != nil {
+			plan = append(plan, PlanFor(f))
+		}
+	}
+	return plan
+}
+
+// PlanFor returns the recommended decision for f with the reasons behind it:
+// the affected-range check, fix availability, exploitation and impact.
+func PlanFor(f *TriageFinding) PlanEntry {
+	e := PlanEntry{
+		VulnID:        f.CVEID,
+		Package:       f.Package,
+		Ecosystem:     f.Ecosystem,
+		InstalledVer:  f.InstalledVer,
+		FixedVer:      f.FixedVer,
+		Status:        f.Status,
+		Justification: f.Justification,
+		Action:        f.ActionResponse,
+		SSVC:          SSVCDecision(f),
+	}
+	if f.Decision != nil {
+		e.Decision = f.Decision.Choice
+	}
+
+	switch f.Status {
+	case "not_affected":
+		if f.Justification == "vulnerable_code_not_present" && f.InstalledVer != "" {
+			e.Reasons = append(e.Reasons, fmt.Sprintf("installed version %s is outside the affected range", f.InstalledVer))
+		} else {
+			e.Reasons = append(e.Reasons, "not affected: "+coalesce(f.Justification, "no justification given"))
+		}
+	case "affected":
+		e.Reasons = append(e.Reasons, fmt.Sprintf("installed version %s is within the affected range", coalesce(f.InstalledVer, "(unknown)")))
+	case "fixed":
+		e.Reasons = append(e.Reasons, "fix already applied")
+	default:
+		if f.Package == "" || f.InstalledVer == "" {
+			e.Reasons = append(e.Reasons, "package or installed version unknown, affected range not checked")
+		} else {
+			e.Reasons = append(e.Reasons, "affected range is inconclusive for "+f.Package+"@"+f.InstalledVer)
+		}
+	}
+
+	switch {
+	case f.FixedVer != "":
+		e.Reasons = append(e.Reasons, "fix available in "+f.FixedVer)
+	case f.ActionResponse == "update":
+		e.Reasons = append(e.Reasons, "partial fix available")
+	case f.ActionResponse == "will_not_fix":
+		e.Reasons = append(e.Reasons, "no fix available")
+	}
+
+	if f.InKEV {
+		e.Reasons = append(e.Reasons, "listed in a known exploited vulnerabilities catalogue")
+	}
+	switch {
+	case f.ExploitCount > 0:
+		e.Reasons = append(e.Reasons, fmt.Sprintf("%d public exploit(s)", f.ExploitCount))
+	case !f.InKEV:
+		e.Reasons = append(e.Reasons, "no known exploitation")
+	}
+
+	if sev := strings.ToLower(f.Severity); sev != "" && sev != "unknown" {
+		e.Reasons = append(e.Reasons, "severity "+sev)
+	}
+	if f.CWSS != nil && f.CWSS.Score > 0 {
+		cwss := fmt.Sprintf("CWSS %.1f", f.CWSS.Score)
+		if f.CWSS.Priority != "" {
+			cwss += " (" + f.CWSS.Priority + ")"
+		}
+		e.Reasons = append(e.Reasons, cwss)
+	}
+	return e
+}
+
+// SSVCDecision derives the SSVC deployer decision for f from exploitation
+// (KEV listing, then public exploits) and technical impact (critical or high
+// severity counts as total). Findings that are not affected or already fixed
+// are tracked only.
+func SSVCDecision(f *TriageFinding) string {
+	if f.Status == "not_affected" || f.Status == "fixed" {
+		return SSVCTrack
+	}
+	sev := strings.ToLower(f.Severity)
+	totalImpact := sev == "critical" || sev == "high"
+	switch {
+	case f.InKEV && totalImpact:
+		return SSVCAct
+	case f.InKEV, f.ExploitCount > 0 && totalImpact:
+		return SSVCAttend
+	case f.ExploitCount > 0, totalImpact:
+		return SSVCTrackStar
+	default:
+		return SSVCTrack
+	}
+}
@@ -0,0 +1,97 @@
+package triage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vulnetix/cli/v3/internal/memory"
+)
+
+func TestBuildPlanEnumeratesDecisionsWithReasons(t *testing.T) {
+	findings := []*TriageFinding{
+		{
+			CVEID: "CVE-2021-44228", Package: "log4j-core", Ecosystem: "maven", InstalledVer: "2.14.1",
+			FixedVer: "2.17.1", Status: "affected", ActionResponse: "will_fix", Severity: "critical",
+			InKEV: true, ExploitCount: 12, CWSS: &CWSSData{Score: 92.5, Priority: "P1"},
+			Decision: &memory.Decision{Choice: "deferred"},
+		},
+		{
+			CVEID: "CVE-2022-22965", Package: "spring-beans", InstalledVer: "5.3.20",
+			Status: "not_affected", Justification: "vulnerable_code_not_present", Severity: "critical",
+			Decision: &memory.Decision{Choice: "not-affected"},
+		},
+		{
+			CVEID: "CVE-2023-0001", Package: "left-pad", InstalledVer: "1.0.0",
+			Status: "affected", ActionResponse: "will_not_fix", Severity: "medium", ExploitCount: 1,
+			Decision: &memory.Decision{Choice: "risk-accepted"},
+		},
+		nil,
+		{CVEID: "CVE-2024-0002", Status: "under_investigation", Severity: "unknown"},
+	}
+
+	plan := BuildPlan(findings)
+	if len(plan) != 4 {
+		t.Fatalf("expected 4 entries (nil skipped), got %d", len(plan))
+	}
+
+	want := []struct {
+		id, decision, ssvc string
+		reasons            []string
+	}{
+		{"CVE-2021-44228", "deferred", SSVCAct, []string{
+			"installed version 2.14.1 is within the affected range",
+			"fix available in 2.17.1",
+			"listed in a known exploited vulnerabilities catalogue",
+			"12 public exploit(s)",
+			"severity critical",
+			"CWSS 92.5 (P1)",
+		}},
+		{"CVE-2022-22965", "not-affected", SSVCTrack, []string{
+			"installed version 5.3.20 is outside the affected range",
+			"no known exploitation",
+			"severity critical",
+		}},
+		{"CVE-2023-0001", "risk-accepted", SSVCTrackStar, []string{
+			"installed version 1.0.0 is within the affected range",
+			"no fix available",
+			"1 public exploit(s)",
+			"severity medium",
+		}},
+		{"CVE-2024-0002", "", SSVCTrack, []string{
+			"package or installed version unknown, affected range not checked",
+			"no known exploitation",
+		}},
+	}
+	for i, w := range want {
+		e := plan[i]
+		if e.VulnID != w.id || e.Decision != w.decision || e.SSVC != w.ssvc {
+			t.Errorf("entry %d = %s/%s/%s, want %s/%s/%s", i, e.VulnID, e.Decision, e.SSVC, w.id, w.decision, w.ssvc)
+		}
+		if !reflect.DeepEqual(e.Reasons, w.reasons) {
+			t.Errorf("%s reasons:\n got %q\nwant %q", w.id, e.Reasons, w.reasons)
+		}
+	}
+}
+
+func TestSSVCDecision(t *testing.T) {
+	tests := []struct {
+		name string
+		f    TriageFinding
+		want string
+	}{
+		{"kev and total impact", TriageFinding{Status: "affected", InKEV: true, Severity: "high"}, SSVCAct},
+		{"kev and partial impact", TriageFinding{Status: "affected", InKEV: true, Severity: "low"}, SSVCAttend},
+		{"poc and total impact", TriageFinding{Status: "affected", ExploitCount: 2, Severity: "critical"}, SSVCAttend},
+		{"poc and partial impact", TriageFinding{Status: "affected", ExploitCount: 2, Severity: "medium"}, SSVCTrackStar},
+		{"no exploitation, total impact", TriageFinding{Status: "under_investigation", Severity: "critical"}, SSVCTrackStar},
+		{"no exploitation", TriageFinding{Status: "affected", Severity: "low"}, SSVCTrack},
+		{"fixed", TriageFinding{Status: "fixed", InKEV: true, Severity: "critical"}, SSVCTrack},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SSVCDecision(&tc.f); got != tc.want {
+				t.Errorf("SSVCDecision = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
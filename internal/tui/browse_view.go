@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BrowseItem is one vulnerability row in the browse TUI.
+type BrowseItem struct {
+	// ID is the vulnerability identifier (CVE, GHSA, ...).
+	ID string
+	// Versions lists the package versions the vulnerability affects.
+	Versions []string
+}
+
+// BrowseOptions configures the browse TUI.
+type BrowseOptions struct {
+	// Title is shown in the summary bar, typically the package name.
+	Title string
+	// Detail fetches and renders the detail pane for a vulnerability ID.
+	// It runs off the UI goroutine; results are cached per ID.
+	Detail func(id string) (string, error)
+}
+
+// browseDetailMsg carries the result of an async Detail call.
+type browseDetailMsg struct {
+	id   string
+	text string
+	err  error
+}
+
+// BrowseModel is the bubbletea model for the vulnerability browser.
+type BrowseModel struct {
+	items       []BrowseItem
+	selectedIdx int
+	offset      int
+	width       int
+	height      int
+	quiting     bool
+
+	details map[string]string
+	loading string
+
+	opts BrowseOptions
+}
+
+// NewBrowseModel creates a new browse TUI model.
+func NewBrowseModel(items []BrowseItem, opts BrowseOptions) *BrowseModel {
+	return &BrowseModel{
+		items:   items,
+		details: make(map[string]string),
+		opts:    opts,
+	}
+}
+
+// Init implements tea.Model.
+func (m *BrowseModel) Init() tea.Cmd {
+	return m.loadSelected()
+}
+
+// Update implements tea.Model.
+func (m *BrowseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quiting = true
+			return m, tea.Quit
+		case "up", "k":
+			m.moveTo(m.selectedIdx - 1)
+		case "down", "j":
+			m.moveTo(m.selectedIdx + 1)
+		case "pgup":
+			m.moveTo(m.selectedIdx - m.listRows())
+		case "pgdown":
+			m.moveTo(m.selectedIdx + m.listRows())
+		case "home", "g":
+			m.moveTo(0)
+		case "end", "G":
+			m.moveTo(len(m.items) - 1)
+		case "enter":
+			return m, m.loadSelected()
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.moveTo(m.selectedIdx)
+	case browseDetailMsg:
+		if m.loading == msg.id {
+			m.loading = ""
+		}
+		if msg.err != nil {
+			m.details[msg.id] = fmt.Sprintf("Failed to load %s: %v", msg.id, msg.err)
+		} else {
+			m.details[msg.id] = msg.text
+		}
+	}
+	return m, nil
+}
+
+// moveTo selects row idx (clamped) and scrolls the list so it stays visible.
+func (m *BrowseModel) moveTo(idx int) {
+	if len(m.items) == 0 {
+		m.selectedIdx, m.offset = 0, 0
+		return
+	}
+	idx = max(0, min(idx, len(m.items)-1))
+	m.selectedIdx = idx
+	rows := m.listRows()
+	if idx < m.offset {
+		m.offset = idx
+	} else if idx >= m.offset+rows {
+		m.offset = idx - rows + 1
+	}
+}
+
+// loadSelected fetches the detail pane for the selected row unless it is
+// already cached or being fetched.
+func (m *BrowseModel) loadSelected() tea.Cmd {
+	if m.opts.Detail == nil || len(m.items) == 0 {
+		return nil
+	}
+	id := m.items[m.selectedIdx].ID
+	if _, ok := m.details[id]; ok || m.loading == id {
+		return nil
+	}
+	m.loading = id
+	detail := m.opts.Detail
+	return func() tea.Msg {
+		text, err := detail(id)
+		return browseDetailMsg{id: id, text: text, err: err}
+	}
+}
+
+// listRows is the number of list rows shown; the rest of the screen is
+// left for the detail pane.
+func (m *BrowseModel) listRows() int {
+	rows := m.height/2 - 4
+	if rows < 5 {
+		rows = 5
+	}
+	return rows
+}
+
+// View implements tea.Model.
+func (m *BrowseModel) View() string {
+	if m.quiting {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styleSummaryBar.Render(fmt.Sprintf("  %s — %d vulnerabilities", m.opts.Title, len(m.items))))
+	b.WriteString("\n")
+
+	if len(m.items) == 0 {
+		b.WriteString(styleStatusBar.Render("  No known vulnerabilities."))
+		b.WriteString("\n\n")
+		b.WriteString(helpBrowse())
+		return b.String()
+	}
+
+	b.WriteString(styleDetailHeader.Render(fmt.Sprintf("  %-28s %s", "Vulnerability", "Affected versions")))
+	b.WriteString("\n")
+
+	end := min(m.offset+m.listRows(), len(m.items))
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		line := fmt.Sprintf("  %-28s %s", truncate(item.ID, 28), truncate(strings.Join(item.Versions, ", "), 60))
+		if i == m.selectedIdx {
+			line = lipglossSelectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(m.items) > end-m.offset {
+		b.WriteString(styleStatusBar.Render(fmt.Sprintf("  %d-%d of %d", m.offset+1, end, len(m.items))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderDetail())
+	b.WriteString("\n")
+	b.WriteString(helpBrowse())
+	return b.String()
+}
+
+// renderDetail renders the detail pane for the selected row, trimmed to the
+// space left below the list.
+func (m *BrowseModel) renderDetail() string {
+	id := m.items[m.selectedIdx].ID
+	text, ok := m.details[id]
+	switch {
+	case m.loading == id:
+		text = "Loading " + id + "..."
+	case !ok:
+		text = "Press Enter to load details for " + id + "."
+	}
+
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	room := m.height - m.listRows() - 8
+	if m.height > 0 && room > 0 && len(lines) > room {
+		lines = append(lines[:room], "…")
+	}
+	return styleDetailContent.Render(strings.Join(lines, "\n"))
+}
+
+func helpBrowse() string {
+	return styleHelp.Render("  ↑/↓ navigate  |  PgUp/PgDn page  |  Enter details  |  q quit")
+}
+
+// RunBrowse starts the vulnerability browser TUI.
+func RunBrowse(items []BrowseItem, opts BrowseOptions) error {
+	p := tea.NewProgram(
+		NewBrowseModel(items, opts),
+		tea.WithAltScreen(),
+		tea.WithOutput(os.Stderr),
+	)
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+	return nil
+}
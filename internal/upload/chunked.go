@@ -1,6 +1,9 @@
 package upload
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // ChunkedUpload handles large file uploads by splitting into chunks
 func (c *Client) ChunkedUpload(fileName string, data []byte, contentType, format string) (*FinalizeResponse, error) {
@@ -10,9 +13,19 @@ func (c *Client) ChunkedUpload(fileName string, data []byte, contentType, format
 // ChunkedUploadWithProgress handles large file uploads by splitting into chunks
 // and reporting progress after session initiation, each uploaded chunk, and
 // finalization.
+//
+// Chunks are ChunkSize bytes and up to Concurrency are in flight at once.
+// When the initiate response advertises lower limits those win: a smaller
+// maximum chunk size means the first session is aborted and another initiated
+// with chunks that fit, and concurrency is reduced to the server's maximum.
+// Progress totals change with the chunk count, so callers should take total
+// from each report rather than fixing it up front.
 func (c *Client) ChunkedUploadWithProgress(fileName string, data []byte, contentType, format string, progress ProgressFunc) (*FinalizeResponse, error) {
 	fileSize := len(data)
-	chunkSize := DefaultChunkSize
+	chunkSize := c.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
 	totalChunks := (fileSize + chunkSize - 1) / chunkSize
 	totalSteps := totalChunks + 2
 
@@ -24,32 +37,46 @@ func (c *Client) ChunkedUploadWithProgress(fileName string, data []byte, content
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate chunked upload: %w", err)
 	}
+	if session.MaxChunkSize > 0 && chunkSize > session.MaxChunkSize {
+		c.warnf("chunk size %d bytes exceeds the server maximum of %d bytes; using %d", chunkSize, session.MaxChunkSize, session.MaxChunkSize)
+		// The first session was declared with the old chunk layout and can
+		// never be finalized.
+		if err := c.AbortSession(session.UploadSessionID); err != nil {
+			c.warnf("could not abort upload session %s: %v", session.UploadSessionID, err)
+		}
+		chunkSize = session.MaxChunkSize
+		totalChunks = (fileSize + chunkSize - 1) / chunkSize
+		totalSteps = totalChunks + 2
+		if progress != nil {
+			progress(0, totalSteps, "Initiating chunked upload session")
+		}
+		session, err = c.InitiateSession(fileName, fileSize, contentType, totalChunks, chunkSize, format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initiate chunked upload: %w", err)
+		}
+	}
+	workers := max(c.Concurrency, 1)
+	if session.MaxConcurrency > 0 && workers > session.MaxConcurrency {
+		c.warnf("concurrency %d exceeds the server maximum of %d; using %d", workers, session.MaxConcurrency, session.MaxConcurrency)
+		workers = session.MaxConcurrency
+	}
 	if progress != nil {
 		progress(1, totalSteps, "Uploading chunks")
 	}
 
-	// Upload each chunk
-	for i := 0; i < totalChunks; i++ {
-		start := i * chunkSize
-		end := start + chunkSize
-		if end > fileSize {
-			end = fileSize
-		}
-
-		chunk := data[start:end]
-		if _, err := c.UploadChunk(session.UploadSessionID, i+1, chunk); err != nil {
-			return nil, fmt.Errorf("failed to upload chunk %d/%d: %w", i+1, totalChunks, err)
-		}
+	if err := c.uploadChunks(session.UploadSessionID, data, chunkSize, totalChunks, workers, func(uploaded int) {
 		if progress != nil {
-			progress(i+2, totalSteps, fmt.Sprintf("Uploaded chunk %d/%d", i+1, totalChunks))
+			progress(uploaded+1, totalSteps, fmt.Sprintf("Uploaded chunk %d/%d", uploaded, totalChunks))
 		}
+	}); err != nil {
+		return nil, err
 	}
 
 	// Finalize
 	if progress != nil {
 		progress(totalSteps-1, totalSteps, "Finalizing upload")
 	}
-	result, err := c.FinalizeUpload(session.UploadSessionID)
+	result, err := c.FinalizeUpload(session.UploadSessionID, c.Finalize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to finalize chunked upload: %w", err)
 	}
@@ -59,3 +86,55 @@ func (c *Client) ChunkedUploadWithProgress(fileName string, data []byte, content
 
 	return result, nil
 }
+
+// uploadChunks sends every chunk of data with up to workers in flight,
+// calling uploaded with the running count after each one. It stops handing
+// out chunks after the first failure and returns that failure.
+func (c *Client) uploadChunks(sessionID string, data []byte, chunkSize, totalChunks, workers int, uploaded func(int)) error {
+	next := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		done     int
+		firstErr error
+	)
+	for w := 0; w < min(workers, totalChunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				start := i * chunkSize
+				end := min(start+chunkSize, len(data))
+				_, err := c.UploadChunk(sessionID, i+1, data[start:end])
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("failed to upload chunk %d/%d: %w", i+1, totalChunks, err)
+				}
+				if err == nil {
+					done++
+					uploaded(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < totalChunks; i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return firstErr
+}
+
+func (c *Client) warnf(format string, args ...any) {
+	if c.Warn != nil {
+		c.Warn(fmt.Sprintf(format, args...))
+	}
+}
@@ -0,0 +1,106 @@
+package upload
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChunkedUploadRespectsServerLimits(t *testing.T) {
+	var (
+		mu                    sync.Mutex
+		initiatedChunkSizes   []int
+		aborted               []string
+		totals                []int
+		received              = map[string]string{}
+		inFlight, maxInFlight int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/uploads/initiate"):
+			var body struct {
+				ChunkSize int `json:"chunkSize"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			initiatedChunkSizes = append(initiatedChunkSizes, body.ChunkSize)
+			session := fmt.Sprintf("s%d", len(initiatedChunkSizes))
+			mu.Unlock()
+			fmt.Fprintf(w, `{"ok":true,"uploadSessionId":%q,"maxChunkSize":4,"maxConcurrency":2}`, session)
+		case strings.Contains(r.URL.Path, "/uploads/chunk/"):
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			data, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			inFlight--
+			received[r.URL.Path] = string(data)
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/uploads/session/"):
+			mu.Lock()
+			aborted = append(aborted, strings.TrimPrefix(r.URL.Path, "/v1/uploads/session/"))
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		case strings.HasSuffix(r.URL.Path, "/uploads/finalize/s2"):
+			_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var warnings []string
+	c := NewClient(server.URL+"/v1", nil)
+	c.ChunkSize, c.Concurrency = 8, 4
+	c.Warn = func(msg string) { warnings = append(warnings, msg) }
+
+	data := "abcdefghijklmnopqrst" // 20 bytes: five chunks of the server's 4
+	result, err := c.ChunkedUploadWithProgress("big.json", []byte(data), "application/json", "cyclonedx", func(done, total int, stage string) {
+		mu.Lock()
+		totals = append(totals, total)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("ChunkedUpload: %v", err)
+	}
+	if result.PipelineRecord == nil || result.PipelineRecord.UUID != "p-1" {
+		t.Errorf("result = %+v", result)
+	}
+
+	if fmt.Sprint(initiatedChunkSizes) != "[8 4]" {
+		t.Errorf("initiated with chunk sizes %v, want a retry at the server's maximum", initiatedChunkSizes)
+	}
+	if fmt.Sprint(aborted) != "[s1]" {
+		t.Errorf("aborted sessions %v, want the superseded s1", aborted)
+	}
+	if totals[0] != 5 || totals[len(totals)-1] != 7 {
+		t.Errorf("progress totals %v, want 5 before the server's limit and 7 (five chunks) after", totals)
+	}
+	var joined strings.Builder
+	for i := 1; i <= 5; i++ {
+		chunk, ok := received[fmt.Sprintf("/v1/uploads/chunk/s2/%d", i)]
+		if !ok || len(chunk) > 4 {
+			t.Errorf("chunk %d = %q, %v", i, chunk, ok)
+		}
+		joined.WriteString(chunk)
+	}
+	if joined.String() != data || len(received) != 5 {
+		t.Errorf("server reassembled %q from %d chunks", joined.String(), len(received))
+	}
+	if maxInFlight > 2 {
+		t.Errorf("%d chunks in flight, server maximum is 2", maxInFlight)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "server maximum of 4 bytes") || !strings.Contains(warnings[1], "concurrency 4 exceeds the server maximum of 2") {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
@@ -2,7 +2,11 @@ package upload
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -13,8 +17,11 @@ import (
 	"slices"
 	"strings"
 	"time"
+	"unicode"
 
 	cyclonedx "github.com/Vulnetix/vdb-cyclonedx"
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/internal/schema"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/vdb"
 )
@@ -25,6 +32,9 @@ const (
 	ChunkThreshold = 10 * 1024 * 1024 // 10 MB
 	// DefaultChunkSize is the size of each chunk for large files
 	DefaultChunkSize = 5 * 1024 * 1024 // 5 MB
+	// requestTimeout bounds requests that have no per-operation timeout of
+	// their own (session initiation, single-request uploads, reprocess)
+	requestTimeout = 300 * time.Second
 )
 
 // GitHubActionsContext contains GitHub Actions environment metadata sent with uploads
@@ -52,6 +62,30 @@ type Client struct {
 	HTTPClient    *http.Client
 	GitHubContext *GitHubActionsContext
 	CliEnv        *vdb.CliEnv
+	// Metadata is custom provenance attached to every upload, typically
+	// loaded with LoadMetadataFile.
+	Metadata map[string]any
+	// Finalize is the tags, environment and label attached to every upload
+	// when it is finalized.
+	Finalize *FinalizeMetadata
+	// CallbackURL, when set, is registered with every upload so the server
+	// notifies it once processing completes; see ValidateCallbackURL.
+	CallbackURL string
+	// ChunkSize is the chunk size in bytes for chunked uploads
+	// (DefaultChunkSize when zero) and Concurrency how many chunks are sent
+	// at once (one when zero). Both are lowered to the server's advertised
+	// maximums.
+	ChunkSize   int
+	Concurrency int
+	// Warn, when set, receives non-fatal notices such as a setting lowered
+	// to a server limit.
+	Warn func(msg string)
+	// SchemaValidate checks SPDX, SARIF and OpenVEX artifacts against their
+	// official schemas before upload. CycloneDX is always validated.
+	SchemaValidate bool
+	// FileName, when set, is the name artifacts are uploaded under in place
+	// of their on-disk base name; see SanitizeFileName.
+	FileName string
 }
 
 // ProgressFunc reports upload stage progress against a fixed per-file goal.
@@ -62,7 +96,11 @@ type InitiateResponse struct {
 	OK              bool   `json:"ok"`
 	UploadSessionID string `json:"uploadSessionId"`
 	ExpiresAt       int64  `json:"expiresAt,omitempty"`
-	Error           string `json:"error,omitempty"`
+	// MaxChunkSize (bytes) and MaxConcurrency are the server's limits for
+	// this session, when it advertises them.
+	MaxChunkSize   int    `json:"maxChunkSize,omitempty"`
+	MaxConcurrency int    `json:"maxConcurrency,omitempty"`
+	Error          string `json:"error,omitempty"`
 }
 
 // ChunkResponse is returned after uploading a chunk
@@ -103,17 +141,33 @@ func (e *CycloneDXValidationError) Error() string {
 	return fmt.Sprintf("CycloneDX schema validation failed at %s: %s", e.Violations[0].Path, e.Violations[0].Message)
 }
 
-// NewClient creates a new upload client
+// SchemaValidationError is returned when Client.SchemaValidate is set and a
+// non-CycloneDX artifact fails its format's official schema.
+type SchemaValidationError struct {
+	Format     string             `json:"format"`
+	Violations []schema.Violation `json:"violations"`
+}
+
+func (e *SchemaValidationError) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Format + " schema validation failed"
+	}
+	return fmt.Sprintf("%s schema validation failed at %s", e.Format, e.Violations[0])
+}
+
+// NewClient creates a new upload client. A trailing slash on baseURL is
+// dropped so request paths join cleanly.
 func NewClient(baseURL string, creds *auth.Credentials) *Client {
 	if baseURL == "" {
 		baseURL = DefaultBaseURL
 	}
 	return &Client{
-		BaseURL: baseURL,
+		BaseURL: strings.TrimRight(baseURL, "/"),
 		Creds:   creds,
-		HTTPClient: &http.Client{
-			Timeout: 300 * time.Second,
-		},
+		// Deadlines are applied per request (see requestTimeout and the httpx
+		// per-operation timeouts) rather than client-wide, so a long chunk
+		// upload is not cut off by a limit meant for a quick call.
+		HTTPClient: &http.Client{Transport: httpx.DefaultTransport},
 	}
 }
 
@@ -123,25 +177,25 @@ func (c *Client) UploadFile(filePath string, formatOverride string) (*FinalizeRe
 }
 
 // UploadFileWithProgress uploads a file and reports per-file upload progress
-// when progress is non-nil.
+// when progress is non-nil. When formatOverride is empty and the server
+// rejects the detected format, the upload is retried once with the format
+// re-detected from content; an explicit formatOverride is never second-guessed.
 func (c *Client) UploadFileWithProgress(filePath string, formatOverride string, progress ProgressFunc) (*FinalizeResponse, error) {
-	data, err := os.ReadFile(filePath)
+	filePath, data, err := ReadArtifact(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+		return nil, err
 	}
 
-	fileName := filepath.Base(filePath)
-	contentType := "application/octet-stream"
+	diskName := filepath.Base(filePath)
+	fileName := diskName
+	if c.FileName != "" {
+		fileName = c.FileName
+	}
 	format := formatOverride
 	if format == "" {
 		format = DetectFormat(filePath, data)
 	}
-
-	if strings.HasSuffix(fileName, ".json") {
-		contentType = "application/json"
-	} else if strings.HasSuffix(fileName, ".xml") {
-		contentType = "application/xml"
-	}
+	contentType := ContentType(diskName, format)
 
 	if format == "cyclonedx" {
 		specVersion, violations, err := cyclonedx.ValidateCycloneDX(data)
@@ -151,8 +205,46 @@ func (c *Client) UploadFileWithProgress(filePath string, formatOverride string,
 		if len(violations) > 0 {
 			return nil, &CycloneDXValidationError{SpecVersion: specVersion, Violations: violations}
 		}
+	} else if c.SchemaValidate && schema.Supported(format) {
+		violations, err := schema.Validate(format, data)
+		if err != nil {
+			return nil, fmt.Errorf("schema validation of %s: %w", fileName, err)
+		}
+		if len(violations) > 0 {
+			return nil, &SchemaValidationError{Format: format, Violations: violations}
+		}
+	}
+
+	result, err := c.uploadData(fileName, data, contentType, format, progress)
+	rejection, ok := formatRejection(err)
+	if !ok || formatOverride != "" {
+		return result, err
+	}
+
+	// The server could not process the payload as the format we sent. Detection
+	// is filename-first, so a misleading name (report.sarif.json holding an SBOM)
+	// can pin the wrong format; re-detect from content alone and retry once,
+	// falling back to server-side detection when content is no more conclusive.
+	retryFormat := DetectContentFormat(data)
+	if retryFormat == format {
+		retryFormat = "auto"
+	}
+	if retryFormat == format {
+		return nil, err
+	}
+	if progress != nil {
+		progress(0, 3, fmt.Sprintf("Server rejected format %q (%s); retrying as %q", format, rejection.Message, retryFormat))
 	}
+	result, retryErr := c.uploadData(fileName, data, ContentType(diskName, retryFormat), retryFormat, progress)
+	if retryErr != nil {
+		return nil, fmt.Errorf("%w (retry as %q also failed: %v)", err, retryFormat, retryErr)
+	}
+	return result, nil
+}
 
+// uploadData sends an already-validated payload, choosing simple or chunked
+// upload based on size.
+func (c *Client) uploadData(fileName string, data []byte, contentType, format string, progress ProgressFunc) (*FinalizeResponse, error) {
 	if len(data) < ChunkThreshold {
 		return c.MultipartUploadWithProgress(fileName, data, contentType, format, progress)
 	}
@@ -198,6 +290,31 @@ func (c *Client) MultipartUploadWithProgress(fileName string, data []byte, conte
 		}
 		_ = mw.WriteField("cliEnv", string(envBytes))
 	}
+	if len(c.Metadata) > 0 {
+		metaBytes, err := json.Marshal(c.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		_ = mw.WriteField("metadata", string(metaBytes))
+	}
+	if c.CallbackURL != "" {
+		_ = mw.WriteField("callbackUrl", c.CallbackURL)
+	}
+	if !c.Finalize.IsZero() {
+		if len(c.Finalize.Tags) > 0 {
+			tagBytes, err := json.Marshal(c.Finalize.Tags)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal tags: %w", err)
+			}
+			_ = mw.WriteField("tags", string(tagBytes))
+		}
+		if c.Finalize.Environment != "" {
+			_ = mw.WriteField("environment", c.Finalize.Environment)
+		}
+		if c.Finalize.Label != "" {
+			_ = mw.WriteField("label", c.Finalize.Label)
+		}
+	}
 	if progress != nil {
 		progress(1, 3, "Uploading file")
 	}
@@ -205,7 +322,9 @@ func (c *Client) MultipartUploadWithProgress(fileName string, data []byte, conte
 		return nil, fmt.Errorf("failed to close multipart body: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", strings.TrimSuffix(c.BaseURL, "/v1")+"/v2/cli.upload", &buf)
+	ctx, cancel := httpx.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(c.BaseURL, "/v1")+"/v2/cli.upload", &buf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create upload request: %w", err)
 	}
@@ -263,6 +382,12 @@ func (c *Client) InitiateSession(fileName string, fileSize int, contentType stri
 	if c.CliEnv != nil {
 		body["cliEnv"] = c.CliEnv
 	}
+	if len(c.Metadata) > 0 {
+		body["metadata"] = c.Metadata
+	}
+	if c.CallbackURL != "" {
+		body["callbackUrl"] = c.CallbackURL
+	}
 
 	respBody, err := c.doRequest("POST", "/uploads/initiate", body)
 	if err != nil {
@@ -281,67 +406,171 @@ func (c *Client) InitiateSession(fileName string, fileSize int, contentType stri
 	return &resp, nil
 }
 
+// APIError is returned when the upload API responds with an HTTP status >= 400.
+// Message is the server's "error" field and Details its "details" field when
+// the body is structured JSON; otherwise Message is the raw body.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Details    string
+}
+
+func (e *APIError) Error() string {
+	if e.Details != "" {
+		return fmt.Sprintf("API error (HTTP %d): %s - %s", e.StatusCode, e.Message, e.Details)
+	}
+	return fmt.Sprintf("API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
+
+// newAPIError builds an APIError from an error response body using the error
+// parsing shared with the VDB client.
+func newAPIError(status int, body []byte) *APIError {
+	if msg, details, ok := httpx.ParseAPIError(body); ok {
+		return &APIError{StatusCode: status, Message: msg, Details: details}
+	}
+	return &APIError{StatusCode: status, Message: httpx.APIErrorMessage(body)}
+}
+
 func uploadHTTPError(status int, body []byte) error {
 	var payload struct {
-		Error      string                          `json:"error"`
 		Violations []cyclonedx.ValidationViolation `json:"violations"`
 	}
-	if err := json.Unmarshal(body, &payload); err == nil {
-		if len(payload.Violations) > 0 {
-			return &CycloneDXValidationError{Violations: payload.Violations}
-		}
-		if payload.Error != "" {
-			return fmt.Errorf("API error (HTTP %d): %s", status, payload.Error)
-		}
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Violations) > 0 {
+		return &CycloneDXValidationError{Violations: payload.Violations}
+	}
+	return newAPIError(status, body)
+}
+
+// formatRejection reports whether err is a 422 the server attributes to the
+// artifact format (e.g. "unrecognized format"), as opposed to a content or
+// schema problem that a different format hint would not fix.
+func formatRejection(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		return nil, false
 	}
-	return fmt.Errorf("API error (HTTP %d): %s", status, string(body))
+	return apiErr, strings.Contains(strings.ToLower(apiErr.Message), "format")
 }
 
 func escapeQuotes(s string) string {
 	return strings.NewReplacer("\\", "\\\\", `"`, `\"`).Replace(s)
 }
 
-// UploadChunk uploads a single chunk of data
+// maxChunkAttempts bounds how often a single chunk is sent. Chunks are keyed by
+// number, so resending one after a transient failure is safe.
+const maxChunkAttempts = 3
+
+// chunkRetryBackoff is the wait before the first chunk retry; it doubles per
+// attempt. A var so tests can shorten it.
+var chunkRetryBackoff = time.Second
+
+// ChunkChecksumHeader carries the hex SHA-256 of a chunk's bytes, so the
+// server can reject a corrupted chunk on arrival rather than at finalize.
+const ChunkChecksumHeader = "X-Chunk-Checksum"
+
+// UploadChunk uploads a single chunk of data, retrying transient failures
+// (network errors, 408, 429, 5xx).
 func (c *Client) UploadChunk(sessionID string, chunkNumber int, data []byte) (*ChunkResponse, error) {
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	var lastErr error
+	for attempt := 1; attempt <= maxChunkAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(chunkRetryBackoff * time.Duration(1<<(attempt-2)))
+		}
+		chunkResp, retry, err := c.uploadChunkOnce(sessionID, chunkNumber, data, checksum)
+		if err == nil {
+			return chunkResp, nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// uploadChunkOnce sends one chunk. The returned bool reports whether the
+// failure is transient and worth retrying.
+func (c *Client) uploadChunkOnce(sessionID string, chunkNumber int, data []byte, checksum string) (*ChunkResponse, bool, error) {
 	path := fmt.Sprintf("/uploads/chunk/%s/%d", sessionID, chunkNumber)
 
-	req, err := http.NewRequest("POST", c.BaseURL+path, bytes.NewReader(data))
+	ctx, cancel := httpx.WithTimeout(context.Background(), httpx.UploadChunkTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+path, bytes.NewReader(data))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(ChunkChecksumHeader, checksum)
 	c.addAuth(req)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("chunk upload failed: %w", err)
+		return nil, httpx.Retriable(err, 0), fmt.Errorf("chunk upload failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, httpx.Retriable(err, 0), fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("chunk upload failed (HTTP %d): %s", resp.StatusCode, string(respBody))
+		return nil, httpx.Retriable(nil, resp.StatusCode), fmt.Errorf("chunk upload failed (HTTP %d): %s", resp.StatusCode, string(respBody))
 	}
 
 	var chunkResp ChunkResponse
 	if err := json.Unmarshal(respBody, &chunkResp); err != nil {
-		return nil, fmt.Errorf("failed to parse chunk response: %w", err)
+		return nil, false, fmt.Errorf("failed to parse chunk response: %w", err)
 	}
 
-	return &chunkResp, nil
+	return &chunkResp, false, nil
+}
+
+// maxFinalizeAttempts bounds how often finalize is retried after a 409.
+const maxFinalizeAttempts = 4
+
+// finalizeConflictBackoff is the wait before the first finalize retry; it
+// doubles per attempt. A var so tests can shorten it.
+var finalizeConflictBackoff = 500 * time.Millisecond
+
+// FinalizeUpload completes the upload session, attaching meta when it is set.
+// When CI jobs upload into the same collection concurrently, one finalize can
+// lose the race and get a 409 Conflict; the server re-reads the collection on
+// every finalize, so the call is simply repeated, a bounded number of times,
+// until it applies.
+func (c *Client) FinalizeUpload(sessionID string, meta *FinalizeMetadata) (*FinalizeResponse, error) {
+	var lastErr error
+	for attempt := 1; attempt <= maxFinalizeAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(finalizeConflictBackoff * time.Duration(1<<(attempt-2)))
+		}
+		resp, err := c.finalizeOnce(sessionID, meta)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+			break
+		}
+	}
+	return nil, lastErr
 }
 
-// FinalizeUpload completes the upload session
-func (c *Client) FinalizeUpload(sessionID string) (*FinalizeResponse, error) {
+// finalizeOnce sends a single finalize request.
+func (c *Client) finalizeOnce(sessionID string, meta *FinalizeMetadata) (*FinalizeResponse, error) {
 	path := fmt.Sprintf("/uploads/finalize/%s", sessionID)
 
-	// Finalize accepts an optional body with collectionUuid
-	respBody, err := c.doRequest("POST", path, map[string]interface{}{})
+	// Finalize accepts an optional body with collectionUuid and the
+	// dashboard categorization.
+	var body interface{} = map[string]interface{}{}
+	if !meta.IsZero() {
+		body = meta
+	}
+	respBody, err := c.doRequestTimeout(httpx.FinalizeTimeout, "POST", path, body)
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +587,50 @@ func (c *Client) FinalizeUpload(sessionID string) (*FinalizeResponse, error) {
 	return &resp, nil
 }
 
+// ReprocessResponse is returned after asking the server to re-process an
+// already uploaded artifact
+type ReprocessResponse struct {
+	OK             bool            `json:"ok"`
+	PipelineRecord *PipelineRecord `json:"pipelineRecord,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// ReprocessPipeline re-runs processing of an existing pipeline record as the
+// given format, reusing the stored artifact instead of uploading it again
+func (c *Client) ReprocessPipeline(pipelineUUID, format string) (*ReprocessResponse, error) {
+	path := fmt.Sprintf("/uploads/reprocess/%s", pipelineUUID)
+
+	respBody, err := c.doRequest("POST", path, map[string]interface{}{"format": format})
+	if err != nil {
+		return nil, fmt.Errorf("reprocess failed: %w", err)
+	}
+
+	var resp ReprocessResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse reprocess response: %w", err)
+	}
+
+	if !resp.OK {
+		return nil, fmt.Errorf("reprocess failed: %s", resp.Error)
+	}
+
+	return &resp, nil
+}
+
+// DeletePipeline removes an uploaded artifact's pipeline record, undoing an
+// upload that must not stand on its own
+func (c *Client) DeletePipeline(pipelineUUID string) error {
+	_, err := c.doRequest("DELETE", fmt.Sprintf("/uploads/pipeline/%s", pipelineUUID), nil)
+	return err
+}
+
+// AbortSession discards a chunked upload session that will not be finalized,
+// along with any chunks it already holds
+func (c *Client) AbortSession(sessionID string) error {
+	_, err := c.doRequest("DELETE", fmt.Sprintf("/uploads/session/%s", sessionID), nil)
+	return err
+}
+
 // VerifyResponse is returned by the /api/cli/verify endpoint
 type VerifyResponse struct {
 	OK    bool   `json:"ok"`
@@ -367,7 +640,7 @@ type VerifyResponse struct {
 
 // VerifyAuth checks that the provided credentials are valid
 func (c *Client) VerifyAuth() (*VerifyResponse, error) {
-	respBody, err := c.doRequest("GET", "/uploads/verify", nil)
+	respBody, err := c.doRequestTimeout(httpx.AuthTimeout, "GET", "/uploads/verify", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -385,6 +658,11 @@ func (c *Client) VerifyAuth() (*VerifyResponse, error) {
 }
 
 func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
+	return c.doRequestTimeout(requestTimeout, method, path, body)
+}
+
+// doRequestTimeout is doRequest with a deadline of timeout for the request.
+func (c *Client) doRequestTimeout(timeout time.Duration, method, path string, body interface{}) ([]byte, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -394,7 +672,9 @@ func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error
 		bodyReader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, bodyReader)
+	ctx, cancel := httpx.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -416,14 +696,14 @@ func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp.StatusCode, respBody)
 	}
 
 	return respBody, nil
 }
 
 func (c *Client) addAuth(req *http.Request) {
-	req.Header.Set("User-Agent", "Vulnetix-CLI/1.0")
+	req.Header.Set("User-Agent", httpx.UserAgent())
 	if c.Creds == nil {
 		return
 	}
@@ -434,10 +714,6 @@ func (c *Client) addAuth(req *http.Request) {
 	}
 }
 
-// SupportedFormats are the artifact formats the upload API accepts. A value
-// outside this set is rejected before any bytes leave the machine.
-var SupportedFormats = []string{"cyclonedx", "spdx", "sarif", "openvex", "csaf_vex"}
-
 // ValidateFormat checks an explicit --format override. An empty value means
 // "auto-detect" and is always allowed.
 func ValidateFormat(format string) error {
@@ -451,6 +727,51 @@ func ValidateFormat(format string) error {
 		format, strings.Join(SupportedFormats, ", "))
 }
 
+// maxFileNameLength is the longest name SanitizeFileName accepts, in bytes.
+const maxFileNameLength = 255
+
+// SanitizeFileName turns a user-chosen artifact name into one safe to send as
+// an upload's file name: surrounding space is trimmed, path separators become
+// "_" and control characters are dropped. The result must be non-empty, not
+// "." or "..", and at most 255 bytes.
+func SanitizeFileName(name string) (string, error) {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\':
+			return '_'
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+	switch {
+	case name == "":
+		return "", fmt.Errorf("artifact name must not be empty")
+	case name == "." || name == "..":
+		return "", fmt.Errorf("artifact name %q is not a file name", name)
+	case len(name) > maxFileNameLength:
+		return "", fmt.Errorf("artifact name is %d bytes, longer than %d", len(name), maxFileNameLength)
+	}
+	return name, nil
+}
+
+// ContentType returns the media type to upload fileName with once its format
+// is known. OpenVEX and CSAF documents get their own media types; anything
+// else is application/json or application/xml by extension, and
+// application/octet-stream otherwise.
+func ContentType(fileName, format string) string {
+	if mediaType, ok := formatMediaType(format); ok {
+		return mediaType
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return "application/json"
+	case ".xml":
+		return "application/xml"
+	}
+	return "application/octet-stream"
+}
+
 // DetectFormat inspects file extension and content to determine the artifact format
 func DetectFormat(filePath string, data []byte) string {
 	ext := strings.ToLower(filepath.Ext(filePath))
@@ -458,19 +779,19 @@ func DetectFormat(filePath string, data []byte) string {
 
 	// Check file name patterns
 	if strings.Contains(name, ".cdx.") || strings.HasSuffix(name, ".cdx") || strings.Contains(name, "cyclonedx") {
-		return "cyclonedx"
+		return FormatCycloneDX
 	}
 	if strings.Contains(name, ".spdx.") || strings.Contains(name, "spdx") {
-		return "spdx"
+		return FormatSPDX
 	}
 	if strings.Contains(name, ".sarif") || strings.HasSuffix(name, ".sarif.json") {
-		return "sarif"
+		return FormatSARIF
 	}
 	if strings.Contains(name, ".vex.") || strings.Contains(name, "openvex") {
-		return "openvex"
+		return FormatOpenVEX
 	}
 	if strings.Contains(name, ".csaf.") || strings.Contains(name, "csaf") {
-		return "csaf_vex"
+		return FormatCSAFVEX
 	}
 
 	// Check content for JSON files
@@ -478,23 +799,56 @@ func DetectFormat(filePath string, data []byte) string {
 		content := string(data[:min(len(data), 2048)])
 
 		if strings.Contains(content, "\"bomFormat\"") || strings.Contains(content, "\"specVersion\"") {
-			return "cyclonedx"
+			return FormatCycloneDX
 		}
 		if strings.Contains(content, "\"spdxVersion\"") {
-			return "spdx"
+			return FormatSPDX
 		}
 		if strings.Contains(content, "\"$schema\"") && strings.Contains(content, "sarif") {
-			return "sarif"
+			return FormatSARIF
 		}
 		if strings.Contains(content, "\"@context\"") && strings.Contains(content, "openvex") {
-			return "openvex"
+			return FormatOpenVEX
 		}
 		// CSAF was previously filename-only, so a valid advisory named
 		// advisory.json went undetected and was dropped by discovery.
 		if strings.Contains(content, "\"csaf_version\"") {
-			return "csaf_vex"
+			return FormatCSAFVEX
 		}
 	}
 
-	return "auto"
+	return FormatAuto
+}
+
+// DetectContentFormat identifies the artifact format from the document's
+// top-level structure alone, ignoring the filename and the 2 KiB prefix limit
+// DetectFormat applies. It is the deeper second opinion used when the server
+// rejects the format DetectFormat chose. Returns "auto" when the content is not
+// a JSON object or matches no known format.
+func DetectContentFormat(data []byte) string {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return FormatAuto
+	}
+	has := func(key string) bool { _, ok := doc[key]; return ok }
+
+	switch {
+	case has("bomFormat"):
+		return FormatCycloneDX
+	case has("spdxVersion"):
+		return FormatSPDX
+	case has("runs") && has("version"):
+		return FormatSARIF
+	case has("@context") && has("statements"):
+		return FormatOpenVEX
+	}
+	if raw, ok := doc["document"]; ok {
+		var document map[string]json.RawMessage
+		if json.Unmarshal(raw, &document) == nil {
+			if _, ok := document["csaf_version"]; ok {
+				return FormatCSAFVEX
+			}
+		}
+	}
+	return FormatAuto
 }
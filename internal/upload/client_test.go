@@ -0,0 +1,638 @@
+package upload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+// multipartFormat returns the "format" field of a cli.upload multipart body,
+// or "" when the client left detection to the server.
+func multipartFormat(t *testing.T, r *http.Request) string {
+	t.Helper()
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("parse content type: %v", err)
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return ""
+		}
+		if err != nil {
+			t.Fatalf("read multipart: %v", err)
+		}
+		if part.FormName() == "format" {
+			b, _ := io.ReadAll(part)
+			return string(b)
+		}
+	}
+}
+
+// A filename that pins the wrong format used to fail the whole upload on the
+// server's 422; the client now re-detects from content and retries once.
+func TestUploadFile_RetriesFormatRejection(t *testing.T) {
+	var formats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := multipartFormat(t, r)
+		formats = append(formats, format)
+		if format == "sarif" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"error":"unrecognized format: document is not SARIF"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1","detectedType":"SBOM"}}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "report.sarif.json")
+	if err := os.WriteFile(path, []byte(`{"packages":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []string
+	client := NewClient(server.URL+"/v1", nil)
+	result, err := client.UploadFileWithProgress(path, "", func(done, total int, stage string) {
+		stages = append(stages, stage)
+	})
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if result.PipelineRecord == nil || result.PipelineRecord.UUID != "p-1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(formats) != 2 || formats[0] != "sarif" || formats[1] != "" {
+		t.Errorf("expected forced sarif then auto, got %q", formats)
+	}
+	var surfaced bool
+	for _, s := range stages {
+		if strings.Contains(s, "document is not SARIF") {
+			surfaced = true
+		}
+	}
+	if !surfaced {
+		t.Errorf("server detail not surfaced in progress stages: %q", stages)
+	}
+}
+
+func TestUploadFile_RetryUsesContentFormat(t *testing.T) {
+	var formats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := multipartFormat(t, r)
+		formats = append(formats, format)
+		if format != "spdx" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"error":"unrecognized format"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "results.sarif")
+	if err := os.WriteFile(path, []byte(`{"spdxVersion":"SPDX-2.3","packages":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL+"/v1", nil)
+	if _, err := client.UploadFile(path, ""); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if len(formats) != 2 || formats[1] != "spdx" {
+		t.Errorf("expected retry with content-detected spdx, got %q", formats)
+	}
+}
+
+// A format the user forced with --format is sent as is; a rejection is
+// reported rather than retried as something else.
+func TestUploadFile_NoRetryForForcedFormat(t *testing.T) {
+	var formats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		formats = append(formats, multipartFormat(t, r))
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{"error":"unrecognized format: document is not OpenVEX"}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "sbom.json")
+	if err := os.WriteFile(path, []byte(`{"spdxVersion":"SPDX-2.3","packages":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(server.URL+"/v1", nil)
+	_, err := client.UploadFile(path, "openvex")
+	if err == nil || !strings.Contains(err.Error(), "document is not OpenVEX") {
+		t.Fatalf("expected the server's rejection, got %v", err)
+	}
+	if len(formats) != 1 || formats[0] != "openvex" {
+		t.Errorf("expected a single attempt as openvex, got %q", formats)
+	}
+}
+
+func TestUploadFile_NoRetryOnOtherErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"422 unrelated to format", http.StatusUnprocessableEntity, `{"error":"file is empty"}`},
+		{"400 mentioning format", http.StatusBadRequest, `{"error":"bad format"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(tc.status)
+				_, _ = io.WriteString(w, tc.body)
+			}))
+			defer server.Close()
+
+			path := filepath.Join(t.TempDir(), "results.sarif")
+			if err := os.WriteFile(path, []byte(`{"runs":[],"version":"2.1.0"}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+			client := NewClient(server.URL+"/v1", nil)
+			if _, err := client.UploadFile(path, ""); err == nil {
+				t.Fatal("expected error")
+			}
+			if calls != 1 {
+				t.Errorf("expected a single attempt, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestDetectContentFormat(t *testing.T) {
+	tests := []struct {
+		data   string
+		format string
+	}{
+		{`{"bomFormat":"CycloneDX"}`, "cyclonedx"},
+		{`{"spdxVersion":"SPDX-2.3"}`, "spdx"},
+		{`{"version":"2.1.0","runs":[]}`, "sarif"},
+		{`{"@context":"https://openvex.dev/ns","statements":[]}`, "openvex"},
+		{`{"document":{"csaf_version":"2.0"}}`, "csaf_vex"},
+		{`{"foo":"bar"}`, "auto"},
+		{`not json`, "auto"},
+	}
+	for _, tc := range tests {
+		if got := DetectContentFormat([]byte(tc.data)); got != tc.format {
+			t.Errorf("DetectContentFormat(%q) = %q, want %q", tc.data, got, tc.format)
+		}
+	}
+}
+
+func TestUploadFile_SendsUserAgent(t *testing.T) {
+	origVersion, origSuffix := httpx.Version, httpx.UserAgentSuffix
+	defer func() { httpx.Version, httpx.UserAgentSuffix = origVersion, origSuffix }()
+	httpx.Version, httpx.UserAgentSuffix = "3.4.1", "team-payments"
+
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	if err := os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient(server.URL+"/v1", nil).UploadFile(path, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "Vulnetix-CLI/3.4.1 (") || !strings.HasSuffix(got, " team-payments") {
+		t.Errorf("unexpected User-Agent %q", got)
+	}
+}
+
+func TestNewClient_TrimsTrailingSlash(t *testing.T) {
+	for _, suffix := range []string{"/v1", "/v1/", "/v1//"} {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Path
+			_, _ = io.WriteString(w, `{"ok":true}`)
+		}))
+		path := filepath.Join(t.TempDir(), "bom.cdx.json")
+		if err := os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		c := NewClient(server.URL+suffix, nil)
+		_, err := c.UploadFile(path, "")
+		server.Close()
+		if err != nil {
+			t.Fatalf("base %q: %v", suffix, err)
+		}
+		if c.BaseURL != server.URL+"/v1" {
+			t.Errorf("base %q: BaseURL = %q", suffix, c.BaseURL)
+		}
+		if got != "/v2/cli.upload" {
+			t.Errorf("base %q: request path = %q, want /v2/cli.upload", suffix, got)
+		}
+	}
+}
+
+func TestUploadFile_DualCredentialsSendAPIKey(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	if err := os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	creds := &auth.Credentials{OrgID: "org", APIKey: "key", Secret: "secret", Method: auth.SigV4}
+	if _, err := NewClient(server.URL+"/v1", creds).UploadFile(path, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got != "ApiKey org:key" {
+		t.Errorf("Authorization = %q, want the stored ApiKey", got)
+	}
+}
+
+func TestUploadChunk_SendsChecksum(t *testing.T) {
+	orig := chunkRetryBackoff
+	chunkRetryBackoff = time.Millisecond
+	defer func() { chunkRetryBackoff = orig }()
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if r.Header.Get(ChunkChecksumHeader) != hex.EncodeToString(sum[:]) {
+			t.Errorf("%s = %q does not match the chunk content", ChunkChecksumHeader, r.Header.Get(ChunkChecksumHeader))
+		}
+		got = append(got, r.Header.Get(ChunkChecksumHeader))
+		if len(got) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"success":true}`)
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, nil).UploadChunk("s", 1, []byte("chunk payload")); err != nil {
+		t.Fatal(err)
+	}
+	want := "5637e764afecf15ffccee9cd20bc3906842df273ed7994841b48e3c2528a01b5" // sha256("chunk payload")
+	if len(got) != 2 || got[0] != want || got[1] != want {
+		t.Errorf("checksums = %q, want %q on each attempt", got, want)
+	}
+}
+
+func TestUploadChunk_RetriesTransientStatus(t *testing.T) {
+	orig := chunkRetryBackoff
+	chunkRetryBackoff = time.Millisecond
+	defer func() { chunkRetryBackoff = orig }()
+
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"503 then success", http.StatusServiceUnavailable, 2, false},
+		{"429 then success", http.StatusTooManyRequests, 2, false},
+		{"400 is terminal", http.StatusBadRequest, 1, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls == 1 {
+					w.WriteHeader(tc.status)
+					return
+				}
+				_, _ = io.WriteString(w, `{"ok":true,"chunkNumber":3}`)
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL+"/v1", nil).UploadChunk("sess", 3, []byte("data"))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestReprocessPipeline(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1","detectedType":"SPDX","processingState":"queued"}}`)
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL+"/v1", nil).ReprocessPipeline("p-1", "spdx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "POST /v1/uploads/reprocess/p-1" {
+		t.Errorf("unexpected request %q", gotPath)
+	}
+	if gotBody != `{"format":"spdx"}` {
+		t.Errorf("unexpected body %q", gotBody)
+	}
+	if resp.PipelineRecord == nil || resp.PipelineRecord.ProcessingState != "queued" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+// deadlineTransport records how long each request had left before its context
+// deadline, keyed by path, and answers with a canned success.
+type deadlineTransport struct {
+	remaining map[string]time.Duration
+}
+
+func (d *deadlineTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if deadline, ok := r.Context().Deadline(); ok {
+		d.remaining[r.URL.Path] = time.Until(deadline)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+		Header:     http.Header{},
+		Request:    r,
+	}, nil
+}
+
+func TestOperationsUseTheirOwnDeadline(t *testing.T) {
+	orig := [3]time.Duration{httpx.AuthTimeout, httpx.UploadChunkTimeout, httpx.FinalizeTimeout}
+	defer func() { httpx.AuthTimeout, httpx.UploadChunkTimeout, httpx.FinalizeTimeout = orig[0], orig[1], orig[2] }()
+	httpx.AuthTimeout, httpx.UploadChunkTimeout, httpx.FinalizeTimeout = 7*time.Second, 11*time.Minute, 13*time.Second
+
+	rec := &deadlineTransport{remaining: map[string]time.Duration{}}
+	client := NewClient("https://vdb.test/v1", nil)
+	client.HTTPClient.Transport = rec
+
+	if _, err := client.VerifyAuth(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UploadChunk("sess", 1, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FinalizeUpload("sess", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]time.Duration{
+		"/v1/uploads/verify":        7 * time.Second,
+		"/v1/uploads/chunk/sess/1":  11 * time.Minute,
+		"/v1/uploads/finalize/sess": 13 * time.Second,
+	}
+	for path, d := range want {
+		got, ok := rec.remaining[path]
+		if !ok {
+			t.Errorf("%s: request had no deadline", path)
+			continue
+		}
+		if got > d || got < d-time.Second {
+			t.Errorf("%s: deadline in %v, want about %v", path, got, d)
+		}
+	}
+}
+
+func TestAPIErrorParsesStructuredAndRawBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantDetails string
+		wantError   string
+	}{
+		{
+			name:        "structured",
+			body:        `{"ok":false,"error":"quota exceeded","details":"org has used 100 of 100 uploads this month"}`,
+			wantMessage: "quota exceeded",
+			wantDetails: "org has used 100 of 100 uploads this month",
+			wantError:   "API error (HTTP 403): quota exceeded - org has used 100 of 100 uploads this month",
+		},
+		{
+			name:        "structured without details",
+			body:        `{"ok":false,"error":"quota exceeded"}`,
+			wantMessage: "quota exceeded",
+			wantError:   "API error (HTTP 403): quota exceeded",
+		},
+		{
+			name:        "unstructured",
+			body:        "<html>403 Forbidden</html>\n",
+			wantMessage: "<html>403 Forbidden</html>",
+			wantError:   "API error (HTTP 403): <html>403 Forbidden</html>",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, tc.body)
+			}))
+			defer server.Close()
+			client := NewClient(server.URL+"/v1", nil)
+
+			// Both the JSON endpoints and the multipart upload share the parsing.
+			_, initErr := client.InitiateSession("bom.json", 10, "application/json", 1, 10, "cyclonedx")
+			_, uploadErr := client.SimpleUpload("bom.json", []byte(`{}`), "application/json", "cyclonedx")
+			for _, err := range []error{initErr, uploadErr} {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("expected *APIError, got %T: %v", err, err)
+				}
+				if apiErr.Message != tc.wantMessage || apiErr.Details != tc.wantDetails {
+					t.Errorf("APIError = {%q, %q}, want {%q, %q}", apiErr.Message, apiErr.Details, tc.wantMessage, tc.wantDetails)
+				}
+				if apiErr.Error() != tc.wantError {
+					t.Errorf("Error() = %q, want %q", apiErr.Error(), tc.wantError)
+				}
+			}
+		})
+	}
+}
+
+func TestFinalizeUpload_RetriesConflict(t *testing.T) {
+	orig := finalizeConflictBackoff
+	finalizeConflictBackoff = time.Millisecond
+	defer func() { finalizeConflictBackoff = orig }()
+
+	tests := []struct {
+		name      string
+		failures  int
+		status    int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"409 once then success", 1, http.StatusConflict, 2, false},
+		{"409 persists", 10, http.StatusConflict, maxFinalizeAttempts, true},
+		{"400 is terminal", 1, http.StatusBadRequest, 1, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if r.URL.Path != "/v1/uploads/finalize/sess" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if calls <= tc.failures {
+					w.WriteHeader(tc.status)
+					_, _ = io.WriteString(w, `{"ok":false,"error":"collection was modified concurrently"}`)
+					return
+				}
+				_, _ = io.WriteString(w, `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`)
+			}))
+			defer server.Close()
+
+			resp, err := NewClient(server.URL+"/v1", nil).FinalizeUpload("sess", nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tc.wantCalls)
+			}
+			if !tc.wantErr && resp.PipelineRecord.UUID != "p-1" {
+				t.Errorf("pipeline = %+v", resp.PipelineRecord)
+			}
+		})
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "payments-api.cdx.json", want: "payments-api.cdx.json"},
+		{in: "  release sbom.json \n", want: "release sbom.json"},
+		{in: "../../etc/passwd", want: ".._.._etc_passwd"},
+		{in: `build\bom.json`, want: "build_bom.json"},
+		{in: "bom\x00\x1b.json", want: "bom.json"},
+		{in: "   ", wantErr: true},
+		{in: "..", wantErr: true},
+		{in: strings.Repeat("a", 256), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := SanitizeFileName(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("SanitizeFileName(%q) = %q, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("SanitizeFileName(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestUploadFile_FileNameOverride(t *testing.T) {
+	var filename, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, header, err := r.FormFile("file"); err == nil {
+			filename, contentType = header.Filename, header.Header.Get("Content-Type")
+		}
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	if err := os.WriteFile(path, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(server.URL+"/v1", nil)
+	c.FileName = "payments-api"
+	if _, err := c.UploadFile(path, ""); err != nil {
+		t.Fatal(err)
+	}
+	if filename != "payments-api" {
+		t.Errorf("uploaded as %q, want the override", filename)
+	}
+	if want := ContentType("bom.cdx.json", "cyclonedx"); contentType != want {
+		t.Errorf("content type = %q, want %q from the on-disk name", contentType, want)
+	}
+}
+
+func TestUploadFile_CountsTraffic(t *testing.T) {
+	httpx.ResetTraffic()
+	t.Cleanup(httpx.ResetTraffic)
+
+	const response = `{"ok":true,"pipelineRecord":{"uuid":"p-1"}}`
+	var bodyBytes int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := io.Copy(io.Discard, r.Body)
+		bodyBytes += n
+		_, _ = io.WriteString(w, response)
+	}))
+	defer server.Close()
+
+	components := make([]string, 500)
+	for i := range components {
+		components[i] = fmt.Sprintf(`{"type":"library","name":"pkg-%d"}`, i)
+	}
+	sbom := []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[` + strings.Join(components, ",") + `]}`)
+	path := filepath.Join(t.TempDir(), "bom.cdx.json")
+	if err := os.WriteFile(path, sbom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient(server.URL+"/v1", nil).UploadFile(path, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, received := httpx.Traffic()
+	if sent != bodyBytes {
+		t.Errorf("sent = %d bytes, want the %d the server read", sent, bodyBytes)
+	}
+	if sent < int64(len(sbom)) {
+		t.Errorf("sent = %d bytes, less than the %d byte file", sent, len(sbom))
+	}
+	if received != int64(len(response)) {
+		t.Errorf("received = %d bytes, want %d", received, len(response))
+	}
+}
+
+func TestFormatsMatchDetection(t *testing.T) {
+	if len(SupportedFormats) != len(Formats) {
+		t.Fatalf("SupportedFormats = %v, want one entry per Formats entry", SupportedFormats)
+	}
+	for i, f := range Formats {
+		if SupportedFormats[i] != f.Name {
+			t.Errorf("SupportedFormats[%d] = %q, want %q", i, SupportedFormats[i], f.Name)
+		}
+		if err := ValidateFormat(f.Name); err != nil {
+			t.Errorf("ValidateFormat(%q): %v", f.Name, err)
+		}
+		if got := ContentType("report.json", f.Name); f.MediaType != "" && got != f.MediaType {
+			t.Errorf("ContentType for %s = %q, want %q", f.Name, got, f.MediaType)
+		}
+		// Every advertised file name hint must really be detected.
+		for _, hint := range f.FileNames {
+			name := strings.ReplaceAll(hint, "*", "report")
+			if got := DetectFormat(name, nil); got != f.Name {
+				t.Errorf("DetectFormat(%q) = %q, want %q from hint %q", name, got, f.Name, hint)
+			}
+		}
+	}
+}
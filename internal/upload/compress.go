@@ -0,0 +1,70 @@
+package upload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// gzipMagic is the two-byte header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// maxDecompressedSize bounds what a compressed artifact may expand to, so a
+// gzip bomb cannot exhaust memory. It matches MaxFetchSize; tests lower it.
+var maxDecompressedSize = MaxFetchSize
+
+// IsGzip reports whether data starts with a gzip header.
+func IsGzip(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
+
+// Gzip compresses data. Artifacts such as SBOMs and SARIF shrink severalfold,
+// which matters when files are carried between air-gapped and connected hosts.
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadArtifact reads the artifact at path, expanding it if it is gzip
+// compressed. The returned name is path without any ".gz" suffix.
+func ReadArtifact(path string) (string, []byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	return decompressArtifact(path, data)
+}
+
+// decompressArtifact transparently expands a gzip-compressed artifact, going
+// by its header rather than its name. The returned name drops a ".gz" suffix
+// so format detection sees the inner file name. The gzip CRC is checked, so a
+// truncated or corrupted file is an error rather than a partial upload, as is
+// one that expands past maxDecompressedSize.
+func decompressArtifact(name string, data []byte) (string, []byte, error) {
+	if !IsGzip(data) {
+		return name, data, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decompress %s: %w", name, err)
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, maxDecompressedSize+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decompress %s: %w", name, err)
+	}
+	if int64(len(out)) > maxDecompressedSize {
+		return "", nil, fmt.Errorf("failed to decompress %s: expands past the %d byte artifact limit", name, maxDecompressedSize)
+	}
+	return strings.TrimSuffix(name, ".gz"), out, nil
+}
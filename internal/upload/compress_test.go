@@ -0,0 +1,109 @@
+package upload
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const compressTestSARIF = `{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[]}`
+
+func TestDecompressArtifactRoundTrip(t *testing.T) {
+	compressed, err := Gzip([]byte(compressTestSARIF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsGzip(compressed) {
+		t.Fatal("Gzip output lacks the gzip header")
+	}
+
+	name, data, err := decompressArtifact("report.sarif.gz", compressed)
+	if err != nil {
+		t.Fatalf("decompressArtifact: %v", err)
+	}
+	if name != "report.sarif" || string(data) != compressTestSARIF {
+		t.Errorf("got %q %q", name, data)
+	}
+
+	// Plain files pass through untouched.
+	name, data, err = decompressArtifact("report.sarif", []byte(compressTestSARIF))
+	if err != nil || name != "report.sarif" || string(data) != compressTestSARIF {
+		t.Errorf("plain file changed: %q %q %v", name, data, err)
+	}
+
+	// The gzip trailer's CRC catches corruption.
+	corrupt := bytes.Clone(compressed)
+	corrupt[len(corrupt)-8] ^= 0xff
+	if _, _, err := decompressArtifact("report.sarif.gz", corrupt); err == nil {
+		t.Error("expected a checksum error for a corrupted file")
+	}
+}
+
+func TestDecompressArtifactLimit(t *testing.T) {
+	orig := maxDecompressedSize
+	maxDecompressedSize = int64(len(compressTestSARIF))
+	t.Cleanup(func() { maxDecompressedSize = orig })
+
+	compressed, err := Gzip([]byte(compressTestSARIF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := decompressArtifact("report.sarif.gz", compressed); err != nil {
+		t.Fatalf("artifact at the limit rejected: %v", err)
+	}
+
+	compressed, err = Gzip([]byte(compressTestSARIF + " "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = decompressArtifact("report.sarif.gz", compressed)
+	if err == nil || !strings.Contains(err.Error(), "artifact limit") {
+		t.Errorf("err = %v, want the artifact limit exceeded", err)
+	}
+}
+
+func TestUploadFileDecompressesGzip(t *testing.T) {
+	compressed, err := Gzip([]byte(compressTestSARIF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "report.sarif.gz")
+	if err := os.WriteFile(path, compressed, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotName, gotFormat string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("file field: %v", err)
+		} else {
+			gotName = hdr.Filename
+			gotBody, _ = io.ReadAll(f)
+		}
+		gotFormat = r.FormValue("format")
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL+"/v1", nil).UploadFile(path, ""); err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if gotName != "report.sarif" || gotFormat != "sarif" || string(gotBody) != compressTestSARIF {
+		t.Errorf("server got name=%q format=%q body=%q", gotName, gotFormat, gotBody)
+	}
+
+	files, _, err := DiscoverVulnetixFiles(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Format != "sarif" {
+		t.Errorf("discovery found %+v, want the compressed SARIF", files)
+	}
+}
@@ -23,13 +23,14 @@ var nonArtifactNames = map[string]bool{
 }
 
 // DiscoverVulnetixFiles returns all uploadable artifact files in dir.
-// Files are matched by extension (*.json, *.xml, *.sarif, *.cdx), filtered by
+// Files are matched by extension (*.json, *.xml, *.sarif, *.cdx, and gzip
+// compressed *.gz copies of them), filtered by
 // non-artifact names, and accepted only when DetectFormat returns a
 // recognised format (not "auto"). CycloneDX files are also validated
 // against the embedded JSON schema; schema failures produce a warning
 // and skip the file rather than aborting the whole discovery.
 func DiscoverVulnetixFiles(dir string) ([]DiscoveredFile, []string, error) {
-	globs := []string{"*.json", "*.xml", "*.sarif", "*.cdx"}
+	globs := []string{"*.json", "*.xml", "*.sarif", "*.cdx", "*.gz"}
 
 	var found []DiscoveredFile
 	var warnings []string
@@ -59,7 +60,13 @@ func DiscoverVulnetixFiles(dir string) ([]DiscoveredFile, []string, error) {
 				continue
 			}
 
-			format := DetectFormat(path, data)
+			name, data, err := decompressArtifact(path, data)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("skip %s: %v", path, err))
+				continue
+			}
+
+			format := DetectFormat(name, data)
 			if format == "auto" {
 				// Not a recognised artifact format.
 				continue
@@ -84,8 +91,14 @@ func DiscoverVulnetixFiles(dir string) ([]DiscoveredFile, []string, error) {
 // at the current working directory first, then the user home directory.
 // Returns ("", false) if neither exists.
 func FindVulnetixDir() (string, bool) {
+	return FindVulnetixDirFrom(".")
+}
+
+// FindVulnetixDirFrom is FindVulnetixDir with the project directory looked
+// up under base instead of the current working directory.
+func FindVulnetixDirFrom(base string) (string, bool) {
 	// Project-relative
-	projectDir := filepath.Join(".", ".vulnetix")
+	projectDir := filepath.Join(base, ".vulnetix")
 	if info, err := os.Stat(projectDir); err == nil && info.IsDir() {
 		return projectDir, true
 	}
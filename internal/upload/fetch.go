@@ -0,0 +1,125 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+)
+
+// MaxFetchSize is the largest artifact FetchURL downloads (1 GiB), the same
+// ceiling as a GitHub Actions artifact.
+const MaxFetchSize int64 = 1024 * 1024 * 1024
+
+// ParseFetchHeaders turns "Name: value" strings into request headers.
+func ParseFetchHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header %q must be in the form \"Name: value\"", v)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// FetchURL downloads the artifact at rawURL into a new temporary directory,
+// sending headers with the request, and returns the downloaded file's path.
+// The file keeps the URL's base name so DetectFormat can use its extension.
+// The download is bounded by httpx.DownloadTimeout and by maxBytes; a larger
+// body is an error rather than a truncated file. The caller removes the
+// returned directory once done with the file.
+func FetchURL(ctx context.Context, rawURL string, headers http.Header, maxBytes int64) (filePath, dir string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", fmt.Errorf("invalid URL %q: must be an absolute http or https URL", rawURL)
+	}
+
+	ctx, cancel := httpx.WithTimeout(ctx, httpx.DownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create download request: %w", err)
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", httpx.UserAgent())
+
+	resp, err := (&http.Client{Transport: httpx.DefaultTransport}).Do(req)
+	if err != nil {
+		// *url.Error repeats the full URL, signature included.
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = ue.Err
+		}
+		return "", "", fmt.Errorf("failed to download %s: %w", redactURL(u), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", "", fmt.Errorf("download of %s failed with status %d: %s", redactURL(u), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if resp.ContentLength > maxBytes {
+		return "", "", fmt.Errorf("artifact at %s is %d bytes, over the %d byte download limit", redactURL(u), resp.ContentLength, maxBytes)
+	}
+
+	dir, err = os.MkdirTemp("", "vulnetix-fetch-*")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	filePath = filepath.Join(dir, fetchFileName(u))
+	f, err := os.Create(filePath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("failed to create download file: %w", err)
+	}
+	n, err := io.Copy(f, io.LimitReader(resp.Body, maxBytes+1))
+	f.Close()
+	if err == nil && n > maxBytes {
+		err = fmt.Errorf("artifact is over the %d byte download limit", maxBytes)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("failed to download %s: %w", redactURL(u), err)
+	}
+	return filePath, dir, nil
+}
+
+// fetchFileName is the last path segment of u, or "artifact" when it has none.
+func fetchFileName(u *url.URL) string {
+	name := path.Base(u.Path)
+	if name == "." || name == "/" || name == "" {
+		return "artifact"
+	}
+	return name
+}
+
+// RedactURL returns rawURL without its query, fragment or user info, for
+// logging where a pre-signed link was fetched from. An unparseable URL yields
+// an empty string.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return redactURL(u)
+}
+
+// redactURL drops the query and any user info, which for pre-signed links
+// hold the signature.
+func redactURL(u *url.URL) string {
+	r := *u
+	r.User, r.RawQuery, r.Fragment = nil, "", ""
+	return r.String()
+}
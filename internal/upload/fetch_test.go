@@ -0,0 +1,111 @@
+package upload
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+)
+
+func TestFetchURLDownloadsWithHeaders(t *testing.T) {
+	sbom := `{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`
+	var gotAuth, gotRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotRequestID = r.Header.Get(httpx.RequestIDHeader)
+		_, _ = io.WriteString(w, sbom)
+	}))
+	defer server.Close()
+
+	requestID := httpx.NewRequestID()
+	headers, err := ParseFetchHeaders([]string{"Authorization: Bearer ci-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, dir, err := FetchURL(context.Background(), server.URL+"/builds/7/bom.cdx.json?X-Amz-Signature=abc", headers, MaxFetchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if gotAuth != "Bearer ci-token" {
+		t.Errorf("Authorization = %q, want the --url-header value", gotAuth)
+	}
+	if gotRequestID != requestID {
+		t.Errorf("%s = %q, want the invocation's %q", httpx.RequestIDHeader, gotRequestID, requestID)
+	}
+	if filepath.Base(path) != "bom.cdx.json" || filepath.Dir(path) != dir {
+		t.Errorf("path = %s, want bom.cdx.json in %s", path, dir)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != sbom {
+		t.Errorf("downloaded %q", data)
+	}
+	if got := DetectFormat(path, data); got != "cyclonedx" {
+		t.Errorf("DetectFormat = %s, want cyclonedx", got)
+	}
+}
+
+func TestFetchURLEnforcesSizeLimit(t *testing.T) {
+	for _, declared := range []bool{true, false} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !declared {
+				// Streaming hides the length until the body is read.
+				w.Header().Set("Transfer-Encoding", "chunked")
+				w.(http.Flusher).Flush()
+			}
+			_, _ = io.WriteString(w, strings.Repeat("x", 64))
+		}))
+		_, dir, err := FetchURL(context.Background(), server.URL+"/big.json", nil, 32)
+		server.Close()
+		if err == nil || !strings.Contains(err.Error(), "32 byte download limit") {
+			t.Errorf("declared=%t: err = %v, want the download limit error", declared, err)
+		}
+		if dir != "" {
+			t.Errorf("declared=%t: no directory should be left behind, got %s", declared, dir)
+		}
+	}
+}
+
+func TestFetchURLErrorsHideQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, _, err := FetchURL(context.Background(), server.URL+"/bom.json?X-Amz-Signature=secret", nil, MaxFetchSize)
+	if err == nil || !strings.Contains(err.Error(), "status 403: AccessDenied") {
+		t.Fatalf("err = %v, want a 403 error", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error leaks the query string: %v", err)
+	}
+
+	for _, bad := range []string{"ftp://host/bom.json", "bom.json", "https://"} {
+		if _, _, err := FetchURL(context.Background(), bad, nil, MaxFetchSize); err == nil {
+			t.Errorf("FetchURL(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseFetchHeaders(t *testing.T) {
+	headers, err := ParseFetchHeaders([]string{"X-Token:  abc ", "Accept: application/json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers.Get("X-Token") != "abc" || headers.Get("Accept") != "application/json" {
+		t.Errorf("headers = %v", headers)
+	}
+	if _, err := ParseFetchHeaders([]string{"no colon"}); err == nil {
+		t.Error("a header without a colon should be rejected")
+	}
+}
@@ -1,6 +1,9 @@
 package upload
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -221,3 +224,66 @@ func TestDiscoverVulnetixFiles_FindsBareCdx(t *testing.T) {
 	// failure from being invisible to the glob. Assert on the reason.
 	t.Fatalf("sbom.cdx was not discovered at all; got %v", files)
 }
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		fileName string
+		format   string
+		want     string
+	}{
+		{"bom.cdx.json", "cyclonedx", "application/json"},
+		{"bom.cdx", "cyclonedx", "application/octet-stream"},
+		{"bom.xml", "cyclonedx", "application/xml"},
+		{"sbom.spdx.json", "spdx", "application/json"},
+		{"sbom.spdx", "spdx", "application/octet-stream"},
+		{"results.sarif", "sarif", "application/octet-stream"},
+		{"report.openvex.json", "openvex", "application/vex+json"},
+		{"advisory.csaf.json", "csaf_vex", "application/csaf+json"},
+		{"data.json", "auto", "application/json"},
+		{"data.xml", "auto", "application/xml"},
+		{"notes.txt", "auto", "application/octet-stream"},
+	}
+	for _, tc := range tests {
+		if got := ContentType(tc.fileName, tc.format); got != tc.want {
+			t.Errorf("ContentType(%q, %q) = %q, want %q", tc.fileName, tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestUploadSendsFormatMediaType(t *testing.T) {
+	tests := []struct {
+		fileName string
+		content  string
+		want     string
+	}{
+		{"advisory.csaf.json", `{"document":{"csaf_version":"2.0"}}`, "application/csaf+json"},
+		{"report.openvex.json", `{"@context":"https://openvex.dev/ns/v0.2.0","statements":[]}`, "application/vex+json"},
+		{"results.sarif.json", `{"version":"2.1.0","runs":[]}`, "application/json"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.fileName, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, header, err := r.FormFile("file")
+				if err != nil {
+					t.Errorf("file part: %v", err)
+				} else {
+					got = header.Header.Get("Content-Type")
+				}
+				_, _ = io.WriteString(w, `{"ok":true}`)
+			}))
+			defer server.Close()
+
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewClient(server.URL+"/v1", nil).UploadFile(path, ""); err != nil {
+				t.Fatalf("UploadFile: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Content-Type = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
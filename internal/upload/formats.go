@@ -0,0 +1,87 @@
+package upload
+
+// Artifact formats the upload API accepts. DetectFormat returns one of these,
+// or FormatAuto when it cannot tell.
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+	FormatSARIF     = "sarif"
+	FormatOpenVEX   = "openvex"
+	FormatCSAFVEX   = "csaf_vex"
+
+	// FormatAuto leaves detection to the server.
+	FormatAuto = "auto"
+)
+
+// FormatInfo describes one artifact format: what it is, the media type it is
+// uploaded as when it has its own, and how DetectFormat recognizes it.
+type FormatInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// MediaType is set only for the VEX formats, which some servers route
+	// by media type; the others upload as plain JSON or XML.
+	MediaType string   `json:"mediaType,omitempty"`
+	FileNames []string `json:"fileNames"`
+	Content   []string `json:"content"`
+}
+
+// Formats is the canonical list of supported artifact formats, in detection
+// order. SupportedFormats, the media types sent on upload and the --format
+// help text are all derived from it, and `vulnetix formats` prints it.
+var Formats = []FormatInfo{
+	{
+		Name:        FormatCycloneDX,
+		Description: "CycloneDX SBOM or VEX document",
+		FileNames:   []string{"*.cdx.*", "*.cdx", "*cyclonedx*"},
+		Content:     []string{`"bomFormat"`, `"specVersion"`},
+	},
+	{
+		Name:        FormatSPDX,
+		Description: "SPDX SBOM",
+		FileNames:   []string{"*spdx*"},
+		Content:     []string{`"spdxVersion"`},
+	},
+	{
+		Name:        FormatSARIF,
+		Description: "SARIF static analysis results",
+		FileNames:   []string{"*.sarif*"},
+		Content:     []string{`"$schema" naming sarif`, `"runs" with "version"`},
+	},
+	{
+		Name:        FormatOpenVEX,
+		Description: "OpenVEX vulnerability exploitability statements",
+		MediaType:   "application/vex+json",
+		FileNames:   []string{"*.vex.*", "*openvex*"},
+		Content:     []string{`"@context" naming openvex`, `"@context" with "statements"`},
+	},
+	{
+		Name:        FormatCSAFVEX,
+		Description: "CSAF security advisory (VEX profile)",
+		MediaType:   "application/csaf+json",
+		FileNames:   []string{"*.csaf.*", "*csaf*"},
+		Content:     []string{`"csaf_version"`},
+	},
+}
+
+// SupportedFormats are the artifact formats the upload API accepts. A value
+// outside this set is rejected before any bytes leave the machine.
+var SupportedFormats = formatNames()
+
+func formatNames() []string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// formatMediaType returns the media type format is uploaded as, if it has
+// one of its own.
+func formatMediaType(format string) (string, bool) {
+	for _, f := range Formats {
+		if f.Name == format {
+			return f.MediaType, f.MediaType != ""
+		}
+	}
+	return "", false
+}
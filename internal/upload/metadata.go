@@ -0,0 +1,116 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadMetadataFile reads user-supplied provenance (build arguments, commit
+// signer, ...) from a JSON or YAML file. The document must be a mapping; its
+// keys are sent verbatim as the upload's metadata.
+func LoadMetadataFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata file: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("metadata file %s is empty", path)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both.
+	var meta map[string]any
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("metadata file %s must be a JSON or YAML mapping: %w", path, err)
+	}
+	if len(meta) == 0 {
+		return nil, fmt.Errorf("metadata file %s has no keys", path)
+	}
+	return meta, nil
+}
+
+// LinkMetadataKey is the metadata key under which an ArtifactLink is sent.
+const LinkMetadataKey = "artifactLink"
+
+// ArtifactLink ties an SBOM and the VEX document describing it together so
+// the server can associate the two uploads. Both carry the same ID; the VEX
+// upload also names the pipeline record created for the SBOM.
+type ArtifactLink struct {
+	ID               string `json:"id"`
+	Role             string `json:"role"` // "sbom" or "vex"
+	SBOM             string `json:"sbom"`
+	VEX              string `json:"vex"`
+	SBOMPipelineUUID string `json:"sbomPipelineUuid,omitempty"`
+}
+
+// WithLink returns a copy of meta with link added under LinkMetadataKey.
+func WithLink(meta map[string]any, link ArtifactLink) map[string]any {
+	out := make(map[string]any, len(meta)+1)
+	for k, v := range meta {
+		out[k] = v
+	}
+	out[LinkMetadataKey] = link
+	return out
+}
+
+// Metadata keys for the product and ecosystem an artifact represents, for
+// SBOMs that are not tied to a repository.
+const (
+	ProductMetadataKey   = "product"
+	EcosystemMetadataKey = "ecosystem"
+)
+
+// WithProductContext returns a copy of meta with the non-empty product and
+// ecosystem added under ProductMetadataKey and EcosystemMetadataKey,
+// replacing any values meta already had.
+func WithProductContext(meta map[string]any, product, ecosystem string) map[string]any {
+	if product == "" && ecosystem == "" {
+		return meta
+	}
+	out := make(map[string]any, len(meta)+2)
+	for k, v := range meta {
+		out[k] = v
+	}
+	if product != "" {
+		out[ProductMetadataKey] = product
+	}
+	if ecosystem != "" {
+		out[EcosystemMetadataKey] = ecosystem
+	}
+	return out
+}
+
+// FinalizeMetadata lets the dashboard categorize an artifact. It is sent in
+// the finalize request of a chunked upload and as form fields of a
+// single-request upload.
+type FinalizeMetadata struct {
+	Tags        []string `json:"tags,omitempty"`
+	Environment string   `json:"environment,omitempty"` // e.g. prod, staging
+	Label       string   `json:"label,omitempty"`
+}
+
+// IsZero reports whether m carries nothing to send.
+func (m *FinalizeMetadata) IsZero() bool {
+	return m == nil || (len(m.Tags) == 0 && m.Environment == "" && m.Label == "")
+}
+
+// ValidateCallbackURL checks a --callback-url the server is asked to notify
+// when processing completes. It must be an absolute https URL with a host,
+// since the notification describes the artifact's findings, and it must not
+// carry user info or a fragment.
+func ValidateCallbackURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("callback URL %q must be an absolute https URL", raw)
+	}
+	if u.User != nil {
+		return fmt.Errorf("callback URL must not contain credentials; authenticate the listener another way")
+	}
+	if u.Fragment != "" {
+		return fmt.Errorf("callback URL must not contain a fragment")
+	}
+	return nil
+}
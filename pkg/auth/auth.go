@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -20,20 +21,21 @@ const (
 	// Bearer header. This is the current, self-service credential; apikey/sigv4
 	// are legacy.
 	Token AuthMethod = "token"
+	// OIDC uses a short-lived Bearer token exchanged for the GitHub Actions
+	// OIDC token of the running job (see LoadOIDCCredentials). It is never
+	// stored, so it is not one of Methods, though capabilities lists it.
+	OIDC AuthMethod = "oidc"
 )
 
+// Methods lists every AuthMethod that can be stored, current first.
+var Methods = []AuthMethod{Token, DirectAPIKey, SigV4}
+
 // ValidateMethod checks if the given string is a valid AuthMethod
 func ValidateMethod(method string) (AuthMethod, error) {
-	switch AuthMethod(method) {
-	case DirectAPIKey:
-		return DirectAPIKey, nil
-	case SigV4:
-		return SigV4, nil
-	case Token:
-		return Token, nil
-	default:
-		return "", fmt.Errorf("invalid auth method %q: must be 'token', 'apikey', or 'sigv4'", method)
+	if slices.Contains(Methods, AuthMethod(method)) {
+		return AuthMethod(method), nil
 	}
+	return "", fmt.Errorf("invalid auth method %q: must be 'token', 'apikey', or 'sigv4'", method)
 }
 
 // CredentialStore represents where credentials are persisted
@@ -45,18 +47,15 @@ const (
 	StoreKeyring CredentialStore = "keyring" // system keyring (stub)
 )
 
+// Stores lists every CredentialStore.
+var Stores = []CredentialStore{StoreHome, StoreProject, StoreKeyring}
+
 // ValidateStore checks if the given string is a valid CredentialStore
 func ValidateStore(store string) (CredentialStore, error) {
-	switch CredentialStore(store) {
-	case StoreHome:
-		return StoreHome, nil
-	case StoreProject:
-		return StoreProject, nil
-	case StoreKeyring:
-		return StoreKeyring, nil
-	default:
-		return "", fmt.Errorf("invalid store %q: must be 'home', 'project', or 'keyring'", store)
+	if slices.Contains(Stores, CredentialStore(store)) {
+		return CredentialStore(store), nil
 	}
+	return "", fmt.Errorf("invalid store %q: must be 'home', 'project', or 'keyring'", store)
 }
 
 // Credentials holds authentication credentials for the Vulnetix API
@@ -67,6 +66,10 @@ type Credentials struct {
 	Token  string     `json:"token,omitempty"`   // Authentik API token (Bearer)
 	Method AuthMethod `json:"method"`
 
+	// SessionToken accompanies a temporary SigV4 Secret, such as one issued
+	// to an assumed CI role, and is signed into every SigV4 request.
+	SessionToken string `json:"session_token,omitempty"`
+
 	// HMACInKeyring is true when the SigV4/HMAC Secret is stored in the OS
 	// keychain rather than inline in this credentials file. When set, Secret is
 	// empty on disk and hydrated from the keychain at load time.
@@ -92,10 +95,40 @@ func StripOrgPrefix(org, value string) string {
 	return strings.TrimPrefix(value, org+":")
 }
 
-// GetAuthHeader returns the Authorization header value for the given credentials
+// HasMethod reports whether c holds the secret that method needs. A
+// credentials file may hold both an ApiKey and a SigV4 secret for one org.
+func (c *Credentials) HasMethod(method AuthMethod) bool {
+	switch method {
+	case Token, OIDC:
+		return c.Token != ""
+	case DirectAPIKey:
+		return c.APIKey != "" && c.OrgID != ""
+	case SigV4:
+		return c.Secret != "" && c.OrgID != ""
+	default:
+		return false
+	}
+}
+
+// Prefer returns a copy of c that authenticates with method when c holds its
+// secret, and c's own method otherwise. Clients use it to pick the method
+// they work best with from dual credentials: uploads prefer the ApiKey, VDB
+// prefers SigV4.
+func (c *Credentials) Prefer(method AuthMethod) *Credentials {
+	out := *c
+	if c.Method != method && c.Method != Token && c.Method != OIDC && c.HasMethod(method) {
+		out.Method = method
+	}
+	return &out
+}
+
+// GetAuthHeader returns the Authorization header value for the given credentials.
+// Credentials holding both an ApiKey and a SigV4 secret send the stored
+// ApiKey, which is what the upload endpoints expect.
 func GetAuthHeader(creds *Credentials) string {
+	creds = creds.Prefer(DirectAPIKey)
 	switch creds.Method {
-	case Token:
+	case Token, OIDC:
 		return "Bearer " + creds.Token
 	case DirectAPIKey:
 		// Normalise here so every credential source benefits, whether it came
@@ -111,3 +144,17 @@ func GetAuthHeader(creds *Credentials) string {
 		return ""
 	}
 }
+
+// MaskSecret renders a credential safe to print. Short secrets are hidden
+// entirely and longer ones keep at most a third of their characters: the
+// last four from 12 characters, and the first four too from 24.
+func MaskSecret(s string) string {
+	switch {
+	case len(s) < 12:
+		return "****"
+	case len(s) < 24:
+		return "..." + s[len(s)-4:]
+	default:
+		return s[:4] + "..." + s[len(s)-4:]
+	}
+}
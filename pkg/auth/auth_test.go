@@ -4,6 +4,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"strings"
 	"testing"
 )
 
@@ -101,6 +102,46 @@ func TestGetAuthHeader_SigV4(t *testing.T) {
 	}
 }
 
+func TestGetAuthHeader_DualCredentialsSendStoredAPIKey(t *testing.T) {
+	for _, method := range []AuthMethod{DirectAPIKey, SigV4} {
+		creds := &Credentials{
+			OrgID:  "test-org",
+			APIKey: "test-key",
+			Secret: "secret",
+			Method: method,
+		}
+		if header := GetAuthHeader(creds); header != "ApiKey test-org:test-key" {
+			t.Errorf("method %s: expected the stored ApiKey, got %q", method, header)
+		}
+	}
+}
+
+func TestCredentialsPrefer(t *testing.T) {
+	dual := &Credentials{OrgID: "org", APIKey: "key", Secret: "secret", Method: DirectAPIKey}
+	tests := []struct {
+		name   string
+		creds  *Credentials
+		prefer AuthMethod
+		want   AuthMethod
+	}{
+		{"dual prefers sigv4", dual, SigV4, SigV4},
+		{"dual prefers apikey", &Credentials{OrgID: "org", APIKey: "key", Secret: "secret", Method: SigV4}, DirectAPIKey, DirectAPIKey},
+		{"apikey only", &Credentials{OrgID: "org", APIKey: "key", Method: DirectAPIKey}, SigV4, DirectAPIKey},
+		{"sigv4 only", &Credentials{OrgID: "org", Secret: "secret", Method: SigV4}, DirectAPIKey, SigV4},
+		{"token stays", &Credentials{Token: "tok", APIKey: "key", OrgID: "org", Method: Token}, DirectAPIKey, Token},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.creds.Prefer(tc.prefer).Method; got != tc.want {
+				t.Errorf("Prefer(%s) = %s, want %s", tc.prefer, got, tc.want)
+			}
+		})
+	}
+	if dual.Method != DirectAPIKey {
+		t.Errorf("Prefer modified the receiver: %s", dual.Method)
+	}
+}
+
 func TestGetAuthHeader_UnknownMethod(t *testing.T) {
 	creds := &Credentials{
 		OrgID:  "test-org",
@@ -192,3 +233,27 @@ func TestStripOrgPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		name, secret, want string
+	}{
+		{"empty", "", "****"},
+		{"six characters", "abc123", "****"},
+		{"eleven characters", "abcdefghijk", "****"},
+		{"medium", "abcdefghijklmnop", "...mnop"},
+		{"long", "abcdefghijklmnopqrstuvwxyz0123456789", "abcd...6789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaskSecret(tt.secret)
+			if got != tt.want {
+				t.Errorf("MaskSecret(%q) = %q, want %q", tt.secret, got, tt.want)
+			}
+			revealed := len(strings.ReplaceAll(strings.ReplaceAll(got, "*", ""), "...", ""))
+			if revealed > len(tt.secret)/3 {
+				t.Errorf("MaskSecret(%q) reveals %d of %d characters", tt.secret, revealed, len(tt.secret))
+			}
+		})
+	}
+}
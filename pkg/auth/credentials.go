@@ -1,10 +1,13 @@
 package auth
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 )
 
 // credentialsFile is the JSON file name for stored credentials
@@ -22,7 +25,8 @@ type SourceStatus struct {
 	Active bool
 }
 
-// SaveCredentials persists credentials to the specified store.
+// SaveCredentials persists credentials to the specified store, under the
+// active profile (see ActiveProfile). Other profiles in the file are kept.
 //
 // When creds.HMACInKeyring is set, the HMAC Secret is written to the OS keychain
 // (not the file) and stripped from the on-disk JSON; metadata (org, method,
@@ -33,8 +37,27 @@ func SaveCredentials(creds *Credentials, store CredentialStore) error {
 }
 
 // SaveCredentialsInDir persists credentials using baseDir for home/keyring
-// metadata when provided.
+// metadata when provided. A file that is already encrypted stays encrypted,
+// and its passphrase is needed to add to it.
 func SaveCredentialsInDir(creds *Credentials, store CredentialStore, baseDir string) error {
+	return saveCredentials(creds, store, baseDir, "")
+}
+
+// SaveEncryptedCredentialsInDir is SaveCredentialsInDir for a file encrypted
+// with passphrase. An existing encrypted file must have been encrypted with
+// the same passphrase.
+func SaveEncryptedCredentialsInDir(creds *Credentials, store CredentialStore, baseDir, passphrase string) error {
+	if passphrase == "" {
+		return ErrPassphraseRequired
+	}
+	return saveCredentials(creds, store, baseDir, passphrase)
+}
+
+func saveCredentials(creds *Credentials, store CredentialStore, baseDir, passphrase string) error {
+	profile, err := checkActiveProfile()
+	if err != nil {
+		return err
+	}
 	path, err := storePathInDir(store, baseDir)
 	if err != nil {
 		return err
@@ -59,44 +82,129 @@ func SaveCredentialsInDir(creds *Credentials, store CredentialStore, baseDir str
 		}
 	}
 	if toWrite.HMACInKeyring && toWrite.Secret != "" {
-		if err := saveSecretToKeyring(hmacKeyringAccount(toWrite.OrgID), toWrite.Secret); err != nil {
+		if err := saveSecretToKeyring(profileKeyringAccount(hmacKeyringAccount(toWrite.OrgID), profile), toWrite.Secret); err != nil {
 			return err
 		}
 		toWrite.Secret = "" // keep the secret out of the file
 	}
 	if toWrite.TokenInKeyring && toWrite.Token != "" {
-		if err := saveSecretToKeyring(tokenKeyringAccount(toWrite.OrgID), toWrite.Token); err != nil {
+		if err := saveSecretToKeyring(profileKeyringAccount(tokenKeyringAccount(toWrite.OrgID), profile), toWrite.Token); err != nil {
 			return err
 		}
 		toWrite.Token = ""
 	}
 	if toWrite.APIKeyInKeyring && toWrite.APIKey != "" {
-		if err := saveSecretToKeyring(apiKeyKeyringAccount(toWrite.OrgID), toWrite.APIKey); err != nil {
+		if err := saveSecretToKeyring(profileKeyringAccount(apiKeyKeyringAccount(toWrite.OrgID), profile), toWrite.APIKey); err != nil {
 			return err
 		}
 		toWrite.APIKey = ""
 	}
 
-	data, err := json.MarshalIndent(&toWrite, "", "  ")
+	if passphrase != "" {
+		passphrases.Store(path, passphrase)
+	}
+	// A missing file has no other profiles to keep. Any other read error,
+	// an encrypted file that cannot be opened included, is returned rather
+	// than overwriting profiles that could not be read.
+	doc, err := readDocumentAt(path)
+	if errors.Is(err, os.ErrNotExist) {
+		doc = &credentialsDocument{}
+	} else if err != nil {
+		return err
+	}
+	if passphrase != "" {
+		doc.passphrase = passphrase
+	}
+	doc.setProfile(profile, &toWrite)
+	return writeDocumentAt(path, doc)
+}
+
+// renameFile is os.Rename; tests replace it to simulate a crash between
+// writing the temp file and moving it into place.
+var renameFile = os.Rename
+
+// renameAttempts bounds how often writeFileAtomic retries the final rename,
+// which on Windows fails transiently while a scanner or indexer holds the
+// target open.
+const renameAttempts = 3
+
+// writeFileAtomic replaces path with data, mode 0600. The data is written and
+// synced to a temp file in the same directory, then renamed over path, so an
+// interrupted write leaves the previous file intact rather than truncated.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-*.tmp")
 	if err != nil {
-		return fmt.Errorf("failed to marshal credentials: %w", err)
+		return err
 	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
-		return fmt.Errorf("failed to write credentials to %s: %w", path, err)
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
 	}
 
-	return nil
+	for attempt := 1; ; attempt++ {
+		err = renameFile(tmpName, path)
+		if err == nil || attempt == renameAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * 50 * time.Millisecond)
+	}
 }
 
 // LoadCredentials loads credentials using the following precedence:
 //  0. Authentik API token (VULNETIX_API_TOKEN env; org resolved server-side)
-//  1. Direct API Key env vars (VULNETIX_API_KEY + VULNETIX_ORG_ID)
-//  2. SigV4 env vars (VVD_ORG + VVD_SECRET)
+//  1. Direct API Key env vars (VULNETIX_API_KEY + VULNETIX_ORG_ID), keeping
+//     VVD_SECRET alongside when VVD_ORG names the same org
+//  2. SigV4 env vars (VVD_ORG + VVD_SECRET, plus VVD_SESSION_TOKEN for
+//     temporary credentials)
 //  3. Project dotfile (.vulnetix/credentials.json)
 //  4. Home directory (~/.vulnetix/credentials.json)
 //  5. Package Firewall netrc entry (packages.vulnetix.com)
+//
+// When OIDC is requested (--oidc or VULNETIX_OIDC), the GitHub Actions OIDC
+// exchange replaces this chain entirely; see LoadOIDCCredentials.
+//
+// A named profile (--profile or VULNETIX_PROFILE) is read from the project
+// file, then the home file, and nowhere else: steps 0-2 and 5 are skipped,
+// so credential env vars and the netrc entry are ignored, since they hold
+// only default-profile credentials.
+//
+// Either file may be encrypted (--encrypt); it is decrypted with the
+// passphrase from $VULNETIX_CREDENTIALS_PASSPHRASE or PassphrasePrompt, and
+// skipped with a warning when neither yields the right one.
+//
+// Either file may keep its secrets in the OS keychain (--store keyring). When
+// the keychain is locked or unavailable, as on most headless CI hosts, the
+// secrets stored inline in the file are used if they suffice; otherwise the
+// file is skipped with a warning and the next source is tried.
 func LoadCredentials() (*Credentials, error) {
+	if OIDCRequested() {
+		return LoadOIDCCredentials()
+	}
+	profile, err := checkActiveProfile()
+	if err != nil {
+		return nil, err
+	}
+	if profile != "" {
+		return loadProfile(profile)
+	}
+	if msg := envCredentialConflict(); msg != "" {
+		warnOnce(msg)
+	}
+
 	// 0. Authentik API token (current credential; org resolved server-side).
 	if tok := os.Getenv("VULNETIX_API_TOKEN"); tok != "" {
 		return &Credentials{
@@ -109,33 +217,48 @@ func LoadCredentials() (*Credentials, error) {
 	// 1. Try Direct API Key env vars
 	apiKey := os.Getenv("VULNETIX_API_KEY")
 	orgID := os.Getenv("VULNETIX_ORG_ID")
+	vvdOrg := os.Getenv("VVD_ORG")
+	vvdSecret := os.Getenv("VVD_SECRET")
 	if apiKey != "" && orgID != "" {
-		return &Credentials{
+		creds := &Credentials{
 			OrgID:  orgID,
 			APIKey: apiKey,
 			Method: DirectAPIKey,
-		}, nil
+		}
+		// A SigV4 secret for the same org is kept alongside, so VDB
+		// clients can still prefer it.
+		if vvdSecret != "" && vvdOrg == orgID {
+			creds.Secret = vvdSecret
+			creds.SessionToken = os.Getenv("VVD_SESSION_TOKEN")
+		}
+		return creds, nil
 	}
 
 	// 2. Try SigV4 env vars
-	vvdOrg := os.Getenv("VVD_ORG")
-	vvdSecret := os.Getenv("VVD_SECRET")
 	if vvdOrg != "" && vvdSecret != "" {
 		return &Credentials{
-			OrgID:  vvdOrg,
-			Secret: vvdSecret,
-			Method: SigV4,
+			OrgID:        vvdOrg,
+			Secret:       vvdSecret,
+			SessionToken: os.Getenv("VVD_SESSION_TOKEN"),
+			Method:       SigV4,
 		}, nil
 	}
 
-	// 3. Try project dotfile
-	if creds, err := loadFromFile(StoreProject); err == nil {
-		return creds, nil
-	}
-
-	// 4. Try home directory
-	if creds, err := loadFromFile(StoreHome); err == nil {
-		return creds, nil
+	// 3-4. Try the project dotfile, then the home directory. A file whose
+	// secrets are in an unavailable keychain, or that is encrypted and cannot
+	// be decrypted, is skipped with a warning.
+	var skippedErr error
+	for _, store := range []CredentialStore{StoreProject, StoreHome} {
+		creds, err := loadFromFile(store)
+		if err == nil {
+			return creds, nil
+		}
+		if skipped := skippedFileError(err); skipped != nil {
+			warnOnce(skipped.Error() + "; falling back to the next credential source")
+			if skippedErr == nil {
+				skippedErr = skipped
+			}
+		}
 	}
 
 	// 5. Try Package Firewall netrc credentials
@@ -145,32 +268,99 @@ func LoadCredentials() (*Credentials, error) {
 		return nil, fmt.Errorf("netrc credentials are not usable: %w", status.Err)
 	}
 
+	if skippedErr != nil {
+		return nil, fmt.Errorf("no usable credentials found: %w", skippedErr)
+	}
 	return nil, fmt.Errorf("no credentials found. Run 'vulnetix auth login' or set VULNETIX_API_KEY + VULNETIX_ORG_ID environment variables")
 }
 
-// RemoveCredentials removes stored credentials from all file-based stores and
-// clears any HMAC secret held in the OS keychain.
+// loadProfile loads the named profile from the project file, then the home
+// file.
+func loadProfile(profile string) (*Credentials, error) {
+	var skippedErr error
+	for _, store := range []CredentialStore{StoreProject, StoreHome} {
+		creds, err := loadFromFile(store)
+		if err == nil {
+			return creds, nil
+		}
+		if skipped := skippedFileError(err); skipped != nil {
+			warnOnce(skipped.Error() + "; falling back to the next credential source")
+			if skippedErr == nil {
+				skippedErr = skipped
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if skippedErr != nil {
+		return nil, fmt.Errorf("no usable credentials for profile %q: %w", profile, skippedErr)
+	}
+	return nil, &ProfileNotFoundError{Profile: profile}
+}
+
+// skippedFileError returns err when it leaves a credentials file unusable for
+// this run only, so LoadCredentials warns and tries the next source: the
+// file's keychain is unavailable, or it is encrypted and cannot be decrypted.
+// It returns nil for any other error.
+func skippedFileError(err error) error {
+	var unavailable *KeyringUnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable
+	}
+	var encrypted *EncryptedCredentialsError
+	if errors.As(err, &encrypted) {
+		return encrypted
+	}
+	return nil
+}
+
+// RemoveCredentials removes the active profile from all file-based stores and
+// clears any secrets it held in the OS keychain. A file left without any
+// profile is deleted.
 func RemoveCredentials() error {
+	profile, err := checkActiveProfile()
+	if err != nil {
+		return err
+	}
 	var lastErr error
 	for _, store := range []CredentialStore{StoreHome, StoreProject} {
-		path, err := storePath(store)
+		doc, path, err := readCredentialsDocument(store)
+		var encErr *EncryptedCredentialsError
+		if errors.As(err, &encErr) {
+			// Other profiles may be inside; never delete what cannot be read.
+			lastErr = err
+			continue
+		}
 		if err != nil {
+			if path != "" && !os.IsNotExist(err) {
+				// Unparseable: nothing to keep, so remove it as before.
+				if rerr := os.Remove(path); rerr != nil && !os.IsNotExist(rerr) {
+					lastErr = fmt.Errorf("failed to remove %s: %w", path, rerr)
+				}
+			}
 			continue
 		}
-		// Clear the keychain secret referenced by this store's metadata first.
-		if creds, lerr := loadCredentialMetadata(store); lerr == nil {
+		// Clear the keychain secrets referenced by the profile's metadata first.
+		if creds := doc.profile(profile); creds != nil {
 			if creds.HMACInKeyring {
-				_ = removeSecretFromKeyring(hmacKeyringAccount(creds.OrgID))
+				_ = removeSecretFromKeyring(profileKeyringAccount(hmacKeyringAccount(creds.OrgID), profile))
 			}
 			if creds.TokenInKeyring {
-				_ = removeSecretFromKeyring(tokenKeyringAccount(creds.OrgID))
+				_ = removeSecretFromKeyring(profileKeyringAccount(tokenKeyringAccount(creds.OrgID), profile))
 			}
 			if creds.APIKeyInKeyring {
-				_ = removeSecretFromKeyring(apiKeyKeyringAccount(creds.OrgID))
+				_ = removeSecretFromKeyring(profileKeyringAccount(apiKeyKeyringAccount(creds.OrgID), profile))
 			}
 		}
-		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-			lastErr = fmt.Errorf("failed to remove %s: %w", path, err)
+		doc.setProfile(profile, nil)
+		if doc.empty() {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				lastErr = fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			continue
+		}
+		if err := writeDocumentAt(path, doc); err != nil {
+			lastErr = fmt.Errorf("failed to update %s: %w", path, err)
 		}
 	}
 	return lastErr
@@ -179,31 +369,100 @@ func RemoveCredentials() error {
 // CredentialSource returns the name of the credential source that would win
 // in the LoadCredentials precedence chain, or "none" if nothing is configured.
 func CredentialSource() string {
-	if os.Getenv("VULNETIX_API_TOKEN") != "" {
-		return "environment (VULNETIX_API_TOKEN)"
-	}
-	if os.Getenv("VULNETIX_API_KEY") != "" && os.Getenv("VULNETIX_ORG_ID") != "" {
-		return "environment (VULNETIX_API_KEY + VULNETIX_ORG_ID)"
+	source, _ := resolveCredentialSource()
+	return source
+}
+
+// resolveCredentialSource walks the LoadCredentials precedence chain and
+// returns the name of the winning source and, when it is a credentials file,
+// the store holding it.
+func resolveCredentialSource() (string, CredentialStore) {
+	if OIDCRequested() {
+		return "github-oidc (" + githubIDTokenURLEnv + ")", ""
 	}
-	if os.Getenv("VVD_ORG") != "" && os.Getenv("VVD_SECRET") != "" {
-		return "environment (VVD_ORG + VVD_SECRET)"
+	// Environment and netrc credentials belong to the default profile.
+	named := ActiveProfile() != ""
+	if source := envCredentialSource(); source != "" && !named {
+		return source, ""
 	}
-	if _, err := loadFromFile(StoreProject); err == nil {
-		if creds, _ := loadFromFile(StoreProject); creds != nil && creds.usesKeyring() {
-			return "keyring (project .vulnetix/credentials.json)"
+	if creds, err := loadFromFile(StoreProject); err == nil {
+		if creds != nil && creds.usesKeyring() {
+			return "keyring (project .vulnetix/credentials.json)", StoreProject
 		}
-		return "project (.vulnetix/credentials.json)"
+		return "project (.vulnetix/credentials.json)", StoreProject
 	}
-	if _, err := loadFromFile(StoreHome); err == nil {
-		if creds, _ := loadFromFile(StoreHome); creds != nil && creds.usesKeyring() {
-			return "keyring (home ~/.vulnetix/credentials.json)"
+	if creds, err := loadFromFile(StoreHome); err == nil {
+		if creds != nil && creds.usesKeyring() {
+			return "keyring (home ~/.vulnetix/credentials.json)", StoreHome
 		}
-		return "home (~/.vulnetix/credentials.json)"
+		return "home (~/.vulnetix/credentials.json)", StoreHome
+	}
+	if _, err := LoadNetrcCredentials(); err == nil && !named {
+		return "netrc (" + PackageFirewallHost + ")", ""
+	}
+	return "none", ""
+}
+
+// envCredentialSource names the environment credentials LoadCredentials
+// would use, or returns "" when none are set.
+func envCredentialSource() string {
+	switch {
+	case os.Getenv("VULNETIX_API_TOKEN") != "":
+		return "environment (VULNETIX_API_TOKEN)"
+	case os.Getenv("VULNETIX_API_KEY") != "" && os.Getenv("VULNETIX_ORG_ID") != "":
+		return "environment (VULNETIX_API_KEY + VULNETIX_ORG_ID)"
+	case os.Getenv("VVD_ORG") != "" && os.Getenv("VVD_SECRET") != "":
+		return "environment (VVD_ORG + VVD_SECRET)"
+	}
+	return ""
+}
+
+// envCredentialConflict describes environment credentials that are set
+// together but cannot all be used, naming the one that wins by precedence,
+// or returns "" when there is no conflict. An ApiKey and a SigV4 secret for
+// the same org are used together and do not conflict.
+func envCredentialConflict() string {
+	orgID, vvdOrg := os.Getenv("VULNETIX_ORG_ID"), os.Getenv("VVD_ORG")
+	apiKeySet := os.Getenv("VULNETIX_API_KEY") != "" && orgID != ""
+	sigV4Set := vvdOrg != "" && os.Getenv("VVD_SECRET") != ""
+
+	var set []string
+	if os.Getenv("VULNETIX_API_TOKEN") != "" {
+		set = append(set, "VULNETIX_API_TOKEN")
+	}
+	if apiKeySet {
+		set = append(set, "VULNETIX_API_KEY + VULNETIX_ORG_ID")
+	}
+	if sigV4Set && !(apiKeySet && vvdOrg == orgID) {
+		set = append(set, "VVD_ORG + VVD_SECRET")
 	}
-	if _, err := LoadNetrcCredentials(); err == nil {
-		return "netrc (" + PackageFirewallHost + ")"
+	if len(set) < 2 {
+		return ""
 	}
-	return "none"
+	return fmt.Sprintf("several credentials are set in the environment (%s); using %s, which takes precedence", strings.Join(set, ", "), set[0])
+}
+
+// CredentialSourcePath returns the file backing the winning credential source
+// (the project or home credentials file, also for keyring metadata, or the
+// netrc file), or "" when credentials come from the environment or nowhere.
+func CredentialSourcePath() string {
+	source, store := resolveCredentialSource()
+	var path string
+	switch {
+	case store != "":
+		path, _ = storePath(store)
+	case strings.HasPrefix(source, "netrc"):
+		path, _ = NetrcPath()
+	}
+	return path
+}
+
+// CredentialSourceStore returns the file store backing the winning credential
+// source, StoreProject or StoreHome (also when its secrets are in the OS
+// keychain), or "" when credentials come from elsewhere.
+func CredentialSourceStore() CredentialStore {
+	_, store := resolveCredentialSource()
+	return store
 }
 
 // CredentialStatus returns a human-readable description of the current auth state
@@ -328,7 +587,7 @@ func fileSourceStatus(store CredentialStore, label, activeSource string, active
 		status.State = "set"
 		return status, nil
 	}
-	if os.IsNotExist(err) {
+	if errors.Is(err, os.ErrNotExist) {
 		return status, err
 	}
 	status.State = "unusable"
@@ -343,26 +602,37 @@ func loadFromFile(store CredentialStore) (*Credentials, error) {
 	}
 
 	// Hydrate secrets from the OS keychain when metadata says they live there.
-	if creds.HMACInKeyring && creds.Secret == "" {
-		secret, kerr := loadRequiredSecretFromKeyring(hmacKeyringAccount(creds.OrgID))
-		if kerr != nil {
-			return nil, fmt.Errorf("credentials file %s references an unusable keyring secret: %w", path, kerr)
+	// A missing entry is an error; a locked or absent keychain is remembered
+	// so the secrets held inline in the file can still be used.
+	var backendErr *keyringBackendError
+	hydrate := func(inKeyring bool, value *string, account, what string) error {
+		if !inKeyring || *value != "" || backendErr != nil {
+			return nil
+		}
+		secret, kerr := loadRequiredSecretFromKeyring(profileKeyringAccount(account, ActiveProfile()))
+		if errors.As(kerr, &backendErr) {
+			return nil
 		}
-		creds.Secret = secret
-	}
-	if creds.TokenInKeyring && creds.Token == "" {
-		token, kerr := loadRequiredSecretFromKeyring(tokenKeyringAccount(creds.OrgID))
 		if kerr != nil {
-			return nil, fmt.Errorf("credentials file %s references an unusable keyring token: %w", path, kerr)
+			return fmt.Errorf("credentials file %s references an unusable keyring %s: %w", path, what, kerr)
 		}
-		creds.Token = token
+		*value = secret
+		return nil
 	}
-	if creds.APIKeyInKeyring && creds.APIKey == "" {
-		apiKey, kerr := loadRequiredSecretFromKeyring(apiKeyKeyringAccount(creds.OrgID))
-		if kerr != nil {
-			return nil, fmt.Errorf("credentials file %s references an unusable keyring API key: %w", path, kerr)
+	if err := hydrate(creds.HMACInKeyring, &creds.Secret, hmacKeyringAccount(creds.OrgID), "secret"); err != nil {
+		return nil, err
+	}
+	if err := hydrate(creds.TokenInKeyring, &creds.Token, tokenKeyringAccount(creds.OrgID), "token"); err != nil {
+		return nil, err
+	}
+	if err := hydrate(creds.APIKeyInKeyring, &creds.APIKey, apiKeyKeyringAccount(creds.OrgID), "API key"); err != nil {
+		return nil, err
+	}
+	if backendErr != nil {
+		if !creds.HasMethod(creds.Method) {
+			return nil, &KeyringUnavailableError{Path: path, Err: backendErr.err}
 		}
-		creds.APIKey = apiKey
+		warnOnce(fmt.Sprintf("OS keychain unavailable (%v); using the credentials stored in %s", backendErr.err, path))
 	}
 
 	if creds.OrgID == "" && creds.Token == "" {
@@ -372,6 +642,49 @@ func loadFromFile(store CredentialStore) (*Credentials, error) {
 	return creds, nil
 }
 
+// KeyringUnavailableError reports a credentials file whose secrets are held
+// in an OS keychain that is locked or unavailable, with no usable secret
+// stored inline.
+type KeyringUnavailableError struct {
+	Path string
+	Err  error
+}
+
+func (e *KeyringUnavailableError) Error() string {
+	return fmt.Sprintf("credentials file %s keeps its secret in the OS keychain, which is unavailable: %v", e.Path, e.Err)
+}
+
+func (e *KeyringUnavailableError) Unwrap() error { return e.Err }
+
+// Warn, when set, receives non-fatal notices from LoadCredentials, such as a
+// keychain-backed source skipped because the keychain is unavailable.
+var Warn func(msg string)
+
+var warned sync.Map
+
+// warnOnce passes msg to Warn the first time it is seen, so credentials
+// loaded several times in one run do not repeat it.
+func warnOnce(msg string) {
+	if Warn == nil {
+		return
+	}
+	if _, seen := warned.LoadOrStore(msg, true); !seen {
+		Warn(msg)
+	}
+}
+
+// CredentialFilePaths returns the project and home credentials file paths,
+// in LoadCredentials precedence order.
+func CredentialFilePaths() []string {
+	var paths []string
+	for _, store := range []CredentialStore{StoreProject, StoreHome} {
+		if path, err := storePath(store); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 func storePath(store CredentialStore) (string, error) {
 	return storePathInDir(store, "")
 }
@@ -410,22 +723,25 @@ func loadCredentialMetadata(store CredentialStore) (*Credentials, error) {
 	return creds, err
 }
 
+// readCredentialsFile reads the active profile from store's file. A file
+// without that profile yields a *ProfileNotFoundError.
 func readCredentialsFile(store CredentialStore) (*Credentials, string, error) {
-	path, err := storePath(store)
+	profile, err := checkActiveProfile()
 	if err != nil {
 		return nil, "", err
 	}
-
-	data, err := os.ReadFile(path)
+	doc, path, err := readCredentialsDocument(store)
 	if err != nil {
 		return nil, path, err
 	}
-
-	var creds Credentials
-	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, path, fmt.Errorf("failed to parse credentials from %s: %w", path, err)
+	creds := doc.profile(profile)
+	if creds == nil {
+		if profile == "" {
+			profile = DefaultProfile
+		}
+		return nil, path, &ProfileNotFoundError{Profile: profile, Path: path}
 	}
-	return &creds, path, nil
+	return creds, path, nil
 }
 
 func (c *Credentials) usesKeyring() bool {
@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -91,6 +94,63 @@ func TestLoadCredentials_FromSigV4Env(t *testing.T) {
 	}
 }
 
+func TestLoadCredentials_SigV4SessionTokenEnv(t *testing.T) {
+	isolateCredentialSources(t)
+	t.Setenv("VVD_ORG", "sigv4-org")
+	t.Setenv("VVD_SECRET", "temporary-secret")
+	t.Setenv("VVD_SESSION_TOKEN", "session-token")
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.Method != SigV4 || creds.SessionToken != "session-token" {
+		t.Errorf("expected SigV4 with the session token, got %+v", creds)
+	}
+}
+
+func TestLoadCredentials_DualEnv(t *testing.T) {
+	t.Setenv("VULNETIX_API_TOKEN", "")
+	t.Setenv("VULNETIX_API_KEY", "env-key")
+	t.Setenv("VULNETIX_ORG_ID", "env-org")
+	t.Setenv("VVD_ORG", "env-org")
+	t.Setenv("VVD_SECRET", "env-secret")
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.Method != DirectAPIKey || creds.APIKey != "env-key" || creds.Secret != "env-secret" {
+		t.Errorf("expected both ApiKey and secret, got %+v", creds)
+	}
+
+	t.Setenv("VVD_ORG", "other-org")
+	creds, err = LoadCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.Secret != "" {
+		t.Errorf("a secret for another org must not be attached, got %q", creds.Secret)
+	}
+}
+
+func TestSaveAndLoadDualCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	creds := &Credentials{OrgID: "test-org", APIKey: "test-key", Secret: "test-secret", Method: DirectAPIKey}
+	if err := SaveCredentials(creds, StoreProject); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+	loaded, err := loadFromFile(StoreProject)
+	if err != nil {
+		t.Fatalf("loadFromFile failed: %v", err)
+	}
+	if !loaded.HasMethod(DirectAPIKey) || !loaded.HasMethod(SigV4) {
+		t.Errorf("expected both methods after reload, got %+v", loaded)
+	}
+}
+
 func TestCredentialSource(t *testing.T) {
 	t.Setenv("VULNETIX_API_KEY", "k")
 	t.Setenv("VULNETIX_ORG_ID", "o")
@@ -123,6 +183,31 @@ func TestCredentialSource_None(t *testing.T) {
 	}
 }
 
+func TestCredentialSourceStore(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "")
+
+	if store := CredentialSourceStore(); store != "" {
+		t.Errorf("no credentials: store = %q", store)
+	}
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "home", Method: Token}, StoreHome); err != nil {
+		t.Fatal(err)
+	}
+	if store := CredentialSourceStore(); store != StoreHome {
+		t.Errorf("home file: store = %q, want %q", store, StoreHome)
+	}
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "project", Method: Token}, StoreProject); err != nil {
+		t.Fatal(err)
+	}
+	if store := CredentialSourceStore(); store != StoreProject {
+		t.Errorf("project file: store = %q, want %q", store, StoreProject)
+	}
+	t.Setenv("VULNETIX_API_TOKEN", "env")
+	if store := CredentialSourceStore(); store != "" {
+		t.Errorf("environment: store = %q, want none", store)
+	}
+}
+
 func TestAllSourceStatus(t *testing.T) {
 	t.Setenv("VULNETIX_API_KEY", "k")
 	t.Setenv("VULNETIX_ORG_ID", "o")
@@ -169,6 +254,69 @@ func TestSaveCredentialsInDirUsesCustomHomeDirectory(t *testing.T) {
 	}
 }
 
+func TestSaveCredentialsKeepsOriginalWhenRenameFails(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, credentialsFile)
+	if err := SaveCredentialsInDir(&Credentials{Token: "original-token", Method: Token}, StoreHome, dir); err != nil {
+		t.Fatalf("SaveCredentialsInDir: %v", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fail between writing the temp file and moving it into place, as a
+	// crash at that point would.
+	renames := 0
+	renameFile = func(string, string) error {
+		renames++
+		return errors.New("interrupted")
+	}
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	err = SaveCredentialsInDir(&Credentials{Token: "replacement-token", Method: Token}, StoreHome, dir)
+	if err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("expected the rename failure, got %v", err)
+	}
+	if renames != renameAttempts {
+		t.Errorf("rename attempted %d times, want %d", renames, renameAttempts)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(original) {
+		t.Errorf("credentials file changed after a failed write:\n%s", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temp file left behind: %v", entries)
+	}
+}
+
+func TestSaveCredentialsWritesOwnerOnlyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, credentialsFile)
+	// A pre-existing, looser file is replaced rather than rewritten in place,
+	// so it does not keep its old mode.
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveCredentialsInDir(&Credentials{Token: "token-value", Method: Token}, StoreHome, dir); err != nil {
+		t.Fatalf("SaveCredentialsInDir: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %o, want 0600", info.Mode().Perm())
+	}
+}
+
 func TestLoadFromFileAllowsTokenWithoutOrgID(t *testing.T) {
 	oldDir, _ := os.Getwd()
 	defer func() { _ = os.Chdir(oldDir) }()
@@ -280,3 +428,159 @@ func TestCredentialStatus(t *testing.T) {
 		t.Errorf("unexpected status: %q", status)
 	}
 }
+
+// writeCredentialsJSON writes a raw credentials file for store.
+func writeCredentialsJSON(t *testing.T, store CredentialStore, body string) {
+	t.Helper()
+	path, err := storePath(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// isolateCredentialSources clears the environment sources and points the
+// project and home stores at empty temporary directories.
+func isolateCredentialSources(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{"VULNETIX_API_TOKEN", "VULNETIX_API_KEY", "VULNETIX_ORG_ID", "VVD_ORG", "VVD_SECRET", "VVD_SESSION_TOKEN", CredentialsDirEnv, ProfileEnv, PassphraseEnv} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+}
+
+func captureWarnings(t *testing.T) *[]string {
+	t.Helper()
+	var got []string
+	orig := Warn
+	Warn = func(msg string) { got = append(got, msg) }
+	warned.Clear()
+	t.Cleanup(func() { Warn = orig; warned.Clear() })
+	return &got
+}
+
+func TestLoadCredentials_KeyringUnavailableFallsBackToFile(t *testing.T) {
+	keyring.MockInitWithError(errors.New("keychain is locked"))
+	t.Cleanup(keyring.MockInit)
+	isolateCredentialSources(t)
+	warnings := captureWarnings(t)
+
+	writeCredentialsJSON(t, StoreProject, `{"org_id":"org","method":"sigv4","hmac_in_keyring":true}`)
+	writeCredentialsJSON(t, StoreHome, `{"org_id":"org","api_key":"file-key","method":"apikey"}`)
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("expected the home file to be used, got %v", err)
+	}
+	if creds.APIKey != "file-key" || creds.Method != DirectAPIKey {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+	if len(*warnings) != 1 || !strings.Contains((*warnings)[0], "keychain is locked") || !strings.Contains((*warnings)[0], "falling back") {
+		t.Errorf("expected one fallback warning, got %q", *warnings)
+	}
+
+	// Loading again does not repeat the warning.
+	if _, err := LoadCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*warnings) != 1 {
+		t.Errorf("warning repeated: %q", *warnings)
+	}
+}
+
+func TestLoadCredentials_KeyringUnavailableUsesInlineSecret(t *testing.T) {
+	keyring.MockInitWithError(errors.New("no secret service"))
+	t.Cleanup(keyring.MockInit)
+	isolateCredentialSources(t)
+	warnings := captureWarnings(t)
+
+	writeCredentialsJSON(t, StoreHome, `{"org_id":"org","api_key":"inline-key","method":"apikey","hmac_in_keyring":true}`)
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.APIKey != "inline-key" || creds.Secret != "" {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+	if len(*warnings) != 1 || !strings.Contains((*warnings)[0], "no secret service") {
+		t.Errorf("expected a keychain warning, got %q", *warnings)
+	}
+}
+
+func TestLoadCredentials_KeyringUnavailableWithoutFallback(t *testing.T) {
+	keyring.MockInitWithError(errors.New("keychain is locked"))
+	t.Cleanup(keyring.MockInit)
+	isolateCredentialSources(t)
+	captureWarnings(t)
+
+	writeCredentialsJSON(t, StoreHome, `{"org_id":"org","method":"sigv4","hmac_in_keyring":true}`)
+
+	_, err := LoadCredentials()
+	var unavailable *KeyringUnavailableError
+	if !errors.As(err, &unavailable) {
+		t.Fatalf("expected a KeyringUnavailableError, got %v", err)
+	}
+}
+
+func TestLoadCredentials_WarnsOnConflictingEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantMethod AuthMethod
+		wantWarn   string
+	}{
+		{
+			name:       "api key and sigv4 for different orgs",
+			env:        map[string]string{"VULNETIX_API_KEY": "key", "VULNETIX_ORG_ID": "org-a", "VVD_ORG": "org-b", "VVD_SECRET": "secret"},
+			wantMethod: DirectAPIKey,
+			wantWarn:   "several credentials are set in the environment (VULNETIX_API_KEY + VULNETIX_ORG_ID, VVD_ORG + VVD_SECRET); using VULNETIX_API_KEY + VULNETIX_ORG_ID, which takes precedence",
+		},
+		{
+			name:       "token shadows sigv4",
+			env:        map[string]string{"VULNETIX_API_TOKEN": "tok", "VVD_ORG": "org-b", "VVD_SECRET": "secret"},
+			wantMethod: Token,
+			wantWarn:   "several credentials are set in the environment (VULNETIX_API_TOKEN, VVD_ORG + VVD_SECRET); using VULNETIX_API_TOKEN, which takes precedence",
+		},
+		{
+			name:       "api key and sigv4 for the same org are used together",
+			env:        map[string]string{"VULNETIX_API_KEY": "key", "VULNETIX_ORG_ID": "org-a", "VVD_ORG": "org-a", "VVD_SECRET": "secret"},
+			wantMethod: DirectAPIKey,
+		},
+		{
+			name:       "incomplete pair is not a source",
+			env:        map[string]string{"VULNETIX_API_KEY": "key", "VULNETIX_ORG_ID": "org-a", "VVD_SECRET": "secret"},
+			wantMethod: DirectAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateCredentialSources(t)
+			warnings := captureWarnings(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			creds, err := LoadCredentials()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.Method != tt.wantMethod {
+				t.Errorf("method = %s, want %s", creds.Method, tt.wantMethod)
+			}
+			var want []string
+			if tt.wantWarn != "" {
+				want = []string{tt.wantWarn}
+			}
+			if !reflect.DeepEqual(*warnings, want) {
+				t.Errorf("warnings = %q, want %q", *warnings, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnv supplies the passphrase for encrypted credentials files, for
+// unattended use where no prompt can be shown.
+const PassphraseEnv = "VULNETIX_CREDENTIALS_PASSPHRASE"
+
+// Envelope parameters. The scrypt cost is stored in each file so that it can
+// be raised later without breaking files already written.
+const (
+	envelopeVersion = 1
+	envelopeKDF     = "scrypt"
+	envelopeCipher  = "xchacha20poly1305"
+	scryptR         = 8
+	scryptP         = 1
+	saltSize        = 16
+)
+
+// Bounds on the scrypt cost a file may ask for. The parameters are read from
+// the file, so without them a crafted file could make opening it take hours
+// or gigabytes of memory.
+const (
+	maxScryptN      = 1 << 20
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30 // 128*N*r bytes
+)
+
+// scryptN is the scrypt cost for new files; tests lower it.
+var scryptN = 1 << 15
+
+// envelopeAAD binds the ciphertext to its use, so it cannot be passed off as
+// some other Vulnetix blob.
+var envelopeAAD = []byte("vulnetix-credentials-v1")
+
+// PassphrasePrompt, when set, asks for the passphrase of the encrypted
+// credentials file at path. The cmd layer sets it when stdin is a terminal;
+// otherwise $VULNETIX_CREDENTIALS_PASSPHRASE must be set.
+var PassphrasePrompt func(path string) (string, error)
+
+// ErrPassphraseRequired reports an encrypted credentials file read with no
+// passphrase available.
+var ErrPassphraseRequired = errors.New("no passphrase: set " + PassphraseEnv + " or run interactively to enter it")
+
+// ErrWrongPassphrase reports a passphrase that does not decrypt the file, or
+// a file that was altered after it was written.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted file")
+
+// EncryptedCredentialsError reports an encrypted credentials file that could
+// not be decrypted.
+type EncryptedCredentialsError struct {
+	Path string
+	Err  error
+}
+
+func (e *EncryptedCredentialsError) Error() string {
+	return fmt.Sprintf("credentials file %s is encrypted: %v", e.Path, e.Err)
+}
+
+func (e *EncryptedCredentialsError) Unwrap() error { return e.Err }
+
+// encryptedEnvelope is the on-disk form of an encrypted credentials file. The
+// plaintext is the whole credentials document, every profile included.
+type encryptedEnvelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// encryptedFile is the top-level layout of an encrypted credentials file.
+type encryptedFile struct {
+	Encrypted *encryptedEnvelope `json:"encrypted"`
+}
+
+// parseEnvelope returns the envelope of an encrypted credentials file, or nil
+// for a plain one.
+func parseEnvelope(data []byte) *encryptedEnvelope {
+	var f encryptedFile
+	if json.Unmarshal(data, &f) != nil {
+		return nil
+	}
+	return f.Encrypted
+}
+
+// passphrases remembers the passphrase used for each file in this process, so
+// a file loaded several times prompts once.
+var passphrases sync.Map
+
+// derivedKeys caches scrypt output by salt and passphrase; deriving a key is
+// deliberately slow and commands read the credentials file several times.
+var derivedKeys sync.Map
+
+// credentialsPassphrase returns the passphrase for the file at path: one
+// that already opened it in this process, else
+// $VULNETIX_CREDENTIALS_PASSPHRASE, else the answer to PassphrasePrompt.
+func credentialsPassphrase(path string) (string, error) {
+	if p, ok := passphrases.Load(path); ok {
+		return p.(string), nil
+	}
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+	if PassphrasePrompt == nil {
+		return "", ErrPassphraseRequired
+	}
+	p, err := PassphrasePrompt(path)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", ErrPassphraseRequired
+	}
+	return p, nil
+}
+
+// decryptDocument decrypts the credentials file at path, returning the
+// plaintext document and the passphrase that opened it. The passphrase is
+// remembered only once it has opened the file, so a mistyped one is asked
+// for again rather than reused.
+func decryptDocument(path string, env *encryptedEnvelope) ([]byte, string, error) {
+	passphrase, err := credentialsPassphrase(path)
+	if err != nil {
+		return nil, "", &EncryptedCredentialsError{Path: path, Err: err}
+	}
+	plain, err := openEnvelope(env, passphrase)
+	if err != nil {
+		return nil, "", &EncryptedCredentialsError{Path: path, Err: err}
+	}
+	passphrases.Store(path, passphrase)
+	return plain, passphrase, nil
+}
+
+// sealEnvelope encrypts plain with a key derived from passphrase and a fresh
+// salt.
+func sealEnvelope(plain []byte, passphrase string) (*encryptedEnvelope, error) {
+	env := &encryptedEnvelope{
+		Version: envelopeVersion,
+		KDF:     envelopeKDF,
+		N:       scryptN,
+		R:       scryptR,
+		P:       scryptP,
+		Salt:    make([]byte, saltSize),
+		Cipher:  envelopeCipher,
+		Nonce:   make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(env.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(env.Nonce); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(env, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	env.Ciphertext = aead.Seal(nil, env.Nonce, plain, envelopeAAD)
+	return env, nil
+}
+
+// openEnvelope decrypts env with passphrase.
+func openEnvelope(env *encryptedEnvelope, passphrase string) ([]byte, error) {
+	if err := env.check(); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(env, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, env.Nonce, env.Ciphertext, envelopeAAD)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+// check rejects envelopes this version cannot open.
+func (e *encryptedEnvelope) check() error {
+	switch {
+	case e.Version != envelopeVersion:
+		return fmt.Errorf("unsupported encryption version %d", e.Version)
+	case e.KDF != envelopeKDF:
+		return fmt.Errorf("unsupported key derivation %q", e.KDF)
+	case e.Cipher != envelopeCipher:
+		return fmt.Errorf("unsupported cipher %q", e.Cipher)
+	case len(e.Salt) == 0:
+		return errors.New("missing salt")
+	case len(e.Nonce) != chacha20poly1305.NonceSizeX:
+		return fmt.Errorf("nonce must be %d bytes", chacha20poly1305.NonceSizeX)
+	case e.N <= 1 || e.N > maxScryptN:
+		return fmt.Errorf("scrypt N must be between 2 and %d", maxScryptN)
+	case e.R <= 0 || e.R > maxScryptR:
+		return fmt.Errorf("scrypt r must be between 1 and %d", maxScryptR)
+	case e.P <= 0 || e.P > maxScryptP:
+		return fmt.Errorf("scrypt p must be between 1 and %d", maxScryptP)
+	case 128*int64(e.N)*int64(e.R) > maxScryptMemory:
+		return fmt.Errorf("scrypt parameters need more than %d MiB of memory", maxScryptMemory>>20)
+	}
+	return nil
+}
+
+func deriveKey(env *encryptedEnvelope, passphrase string) ([]byte, error) {
+	cacheKey := fmt.Sprintf("%x:%d:%d:%d:%s", env.Salt, env.N, env.R, env.P, passphrase)
+	if key, ok := derivedKeys.Load(cacheKey); ok {
+		return key.([]byte), nil
+	}
+	key, err := scrypt.Key([]byte(passphrase), env.Salt, env.N, env.R, env.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key derivation parameters: %w", err)
+	}
+	derivedKeys.Store(cacheKey, key)
+	return key, nil
+}
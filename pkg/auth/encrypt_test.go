@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useCheapScrypt keeps key derivation fast in tests.
+func useCheapScrypt(t *testing.T) {
+	t.Helper()
+	orig := scryptN
+	scryptN = 1 << 10
+	t.Cleanup(func() { scryptN = orig })
+}
+
+func usePassphrasePrompt(t *testing.T, prompt func(path string) (string, error)) {
+	t.Helper()
+	orig := PassphrasePrompt
+	PassphrasePrompt = prompt
+	t.Cleanup(func() { PassphrasePrompt = orig })
+}
+
+func TestEncryptedCredentialsRoundTrip(t *testing.T) {
+	isolateCredentialSources(t)
+	useCheapScrypt(t)
+	useProfile(t, "")
+
+	want := &Credentials{OrgID: "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718", APIKey: "secret-key", Method: DirectAPIKey}
+	if err := SaveEncryptedCredentialsInDir(want, StoreHome, "", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := storePath(StoreHome)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") || parseEnvelope(data) == nil {
+		t.Fatalf("credentials file is not encrypted:\n%s", data)
+	}
+
+	passphrases.Clear()
+	t.Setenv(PassphraseEnv, "hunter2")
+	got, err := LoadCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded %+v, want %+v", got, want)
+	}
+	if problems, err := ValidateCredentialsFile(path); err != nil || len(problems) != 0 {
+		t.Errorf("ValidateCredentialsFile = %v, %v", problems, err)
+	}
+}
+
+func TestEncryptedCredentialsStayEncryptedAcrossProfiles(t *testing.T) {
+	isolateCredentialSources(t)
+	useCheapScrypt(t)
+	useProfile(t, "")
+	t.Setenv(PassphraseEnv, "hunter2")
+
+	if err := SaveEncryptedCredentialsInDir(&Credentials{OrgID: "org", Token: "t1", Method: Token}, StoreHome, "", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	Profile = "staging"
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "t2", Method: Token}, StoreHome); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := storePath(StoreHome)
+	data, _ := os.ReadFile(path)
+	if parseEnvelope(data) == nil {
+		t.Fatal("adding a profile decrypted the file")
+	}
+	if err := RemoveCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	Profile = ""
+	if creds, err := LoadCredentials(); err != nil || creds.Token != "t1" {
+		t.Errorf("default profile = %+v, %v", creds, err)
+	}
+}
+
+func TestEncryptedCredentialsPrompt(t *testing.T) {
+	isolateCredentialSources(t)
+	useCheapScrypt(t)
+	useProfile(t, "")
+	if err := SaveEncryptedCredentialsInDir(&Credentials{OrgID: "org", Token: "tok", Method: Token}, StoreProject, "", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	passphrases.Clear()
+
+	prompts := 0
+	usePassphrasePrompt(t, func(string) (string, error) {
+		prompts++
+		return "hunter2", nil
+	})
+	for range 3 {
+		if _, err := LoadCredentials(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if prompts != 1 {
+		t.Errorf("prompted %d times, want once", prompts)
+	}
+}
+
+func TestEncryptedCredentialsPromptRetriesWrongPassphrase(t *testing.T) {
+	isolateCredentialSources(t)
+	useCheapScrypt(t)
+	useProfile(t, "")
+	if err := SaveEncryptedCredentialsInDir(&Credentials{OrgID: "org", Token: "tok", Method: Token}, StoreProject, "", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	passphrases.Clear()
+
+	answers := []string{"hunter3", "hunter2"}
+	usePassphrasePrompt(t, func(string) (string, error) {
+		p := answers[0]
+		answers = answers[1:]
+		return p, nil
+	})
+	if _, err := LoadCredentials(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("err = %v, want ErrWrongPassphrase", err)
+	}
+	// The mistyped passphrase was not remembered, so the next load asks again.
+	if creds, err := LoadCredentials(); err != nil || creds.Token != "tok" {
+		t.Fatalf("LoadCredentials() = %+v, %v", creds, err)
+	}
+	if len(answers) != 0 {
+		t.Errorf("%d prompt(s) left unanswered", len(answers))
+	}
+}
+
+func TestEnvelopeCheckBoundsScryptCost(t *testing.T) {
+	valid := func() *encryptedEnvelope {
+		return &encryptedEnvelope{
+			Version: envelopeVersion, KDF: envelopeKDF, Cipher: envelopeCipher,
+			N: 1 << 15, R: 8, P: 1,
+			Salt: make([]byte, saltSize), Nonce: make([]byte, 24),
+		}
+	}
+	if err := valid().check(); err != nil {
+		t.Fatalf("default parameters rejected: %v", err)
+	}
+	for name, edit := range map[string]func(*encryptedEnvelope){
+		"huge N":      func(e *encryptedEnvelope) { e.N = 1 << 30 },
+		"zero N":      func(e *encryptedEnvelope) { e.N = 0 },
+		"huge r":      func(e *encryptedEnvelope) { e.R = 1 << 20 },
+		"huge p":      func(e *encryptedEnvelope) { e.P = 1 << 20 },
+		"huge memory": func(e *encryptedEnvelope) { e.N, e.R = 1<<20, 16 },
+	} {
+		env := valid()
+		edit(env)
+		if err := env.check(); err == nil {
+			t.Errorf("%s: N=%d r=%d p=%d accepted", name, env.N, env.R, env.P)
+		}
+	}
+}
+
+func TestEncryptedCredentialsUnusableFallsBack(t *testing.T) {
+	isolateCredentialSources(t)
+	useCheapScrypt(t)
+	useProfile(t, "")
+	warnings := captureWarnings(t)
+	usePassphrasePrompt(t, nil)
+
+	if err := SaveEncryptedCredentialsInDir(&Credentials{OrgID: "org", Token: "tok", Method: Token}, StoreProject, "", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	passphrases.Clear()
+
+	_, err := LoadCredentials()
+	if !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("err = %v, want ErrPassphraseRequired", err)
+	}
+	if len(*warnings) != 1 || !strings.Contains((*warnings)[0], "is encrypted") {
+		t.Errorf("warnings = %q", *warnings)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := LoadCredentials(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("err = %v, want ErrWrongPassphrase", err)
+	}
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "other", Method: Token}, StoreProject); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("save over an unreadable encrypted file: err = %v", err)
+	}
+	if err := RemoveCredentials(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("logout: err = %v", err)
+	}
+	if path, _ := storePath(StoreProject); !fileExists(path) {
+		t.Error("logout deleted an encrypted file it could not read")
+	}
+}
+
+func TestValidateCredentialsJSON_Envelope(t *testing.T) {
+	problems := ValidateCredentialsJSON([]byte(`{"encrypted": {"version": 2}, "org_id": "x"}`))
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{"org_id: unknown field in an encrypted file", "encrypted: unsupported encryption version 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems = %q, want %q", got, want)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
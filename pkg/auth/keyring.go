@@ -71,14 +71,26 @@ func loadSecretFromKeyring(account string) (string, error) {
 	return secret, err
 }
 
+// keyringBackendError is a keychain failure other than a missing entry: the
+// backend is locked, or there is none (a headless host without a Secret
+// Service).
+type keyringBackendError struct{ err error }
+
+func (e *keyringBackendError) Error() string { return e.err.Error() }
+func (e *keyringBackendError) Unwrap() error { return e.err }
+
 // loadRequiredSecretFromKeyring retrieves a secret that metadata says must
-// exist in the keychain.
+// exist in the keychain. A backend failure is returned as a
+// *keyringBackendError.
 func loadRequiredSecretFromKeyring(account string) (string, error) {
 	secret, err := keyring.Get(keyringService, account)
 	if errors.Is(err, keyring.ErrNotFound) {
 		return "", fmt.Errorf("keyring entry %q not found", account)
 	}
-	return secret, err
+	if err != nil {
+		return "", &keyringBackendError{err: err}
+	}
+	return secret, nil
 }
 
 // removeSecretFromKeyring deletes a stored secret. A missing entry is not an error.
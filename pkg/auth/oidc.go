@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+)
+
+// OIDCEnv opts every command into GitHub Actions OIDC authentication, as
+// --oidc does for upload and gha upload.
+const OIDCEnv = "VULNETIX_OIDC"
+
+// OIDCAudience is the audience the GitHub OIDC token is requested for. The
+// Vulnetix API only exchanges tokens minted for it.
+const OIDCAudience = "https://api.vdb.vulnetix.com"
+
+// GitHub Actions sets these in jobs granted `permissions: id-token: write`.
+const (
+	githubIDTokenURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubIDTokenTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// oidcRefreshMargin is how long before expiry an exchanged token is replaced.
+const oidcRefreshMargin = time.Minute
+
+// UseOIDC makes LoadCredentials authenticate with the job's GitHub Actions
+// OIDC token. Set by the cmd layer from --oidc; see OIDCRequested.
+var UseOIDC bool
+
+// OIDCBaseURL is the API the OIDC token is exchanged with. Commands with a
+// --base-url point it at the same API.
+var OIDCBaseURL = "https://api.vdb.vulnetix.com/v1"
+
+// oidcCache holds this process's exchanged credentials so that commands
+// loading credentials several times exchange once.
+var oidcCache struct {
+	sync.Mutex
+	creds   *Credentials
+	expires time.Time
+}
+
+// OIDCRequested reports whether OIDC authentication was asked for, with
+// UseOIDC or a true $VULNETIX_OIDC.
+func OIDCRequested() bool {
+	if UseOIDC {
+		return true
+	}
+	on, _ := strconv.ParseBool(os.Getenv(OIDCEnv))
+	return on
+}
+
+// GitHubOIDCAvailable reports whether the job can request a GitHub Actions
+// OIDC token.
+func GitHubOIDCAvailable() bool {
+	return os.Getenv(githubIDTokenURLEnv) != "" && os.Getenv(githubIDTokenTokenEnv) != ""
+}
+
+// LoadOIDCCredentials exchanges the job's GitHub Actions OIDC token for a
+// short-lived Vulnetix Bearer token. The org is the one the API trusts the
+// workflow's repository for; $VULNETIX_ORG_ID, when set, asks for that org.
+// The result is reused until a minute before it expires and never stored.
+func LoadOIDCCredentials() (*Credentials, error) {
+	oidcCache.Lock()
+	defer oidcCache.Unlock()
+	if oidcCache.creds != nil && time.Now().Before(oidcCache.expires.Add(-oidcRefreshMargin)) {
+		creds := *oidcCache.creds
+		return &creds, nil
+	}
+	if !GitHubOIDCAvailable() {
+		return nil, fmt.Errorf("OIDC authentication needs a GitHub Actions job with `permissions: id-token: write` (%s and %s are not set)", githubIDTokenURLEnv, githubIDTokenTokenEnv)
+	}
+
+	ctx, cancel := httpx.WithTimeout(httpx.WithoutDump(context.Background()), httpx.AuthTimeout)
+	defer cancel()
+	idToken, err := requestGitHubIDToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	creds, expires, err := exchangeOIDCToken(ctx, idToken, os.Getenv("VULNETIX_ORG_ID"))
+	if err != nil {
+		return nil, err
+	}
+	oidcCache.creds, oidcCache.expires = creds, expires
+	out := *creds
+	return &out, nil
+}
+
+var oidcHTTPClient = &http.Client{Transport: httpx.DefaultTransport}
+
+// requestGitHubIDToken fetches the job's OIDC token for OIDCAudience.
+func requestGitHubIDToken(ctx context.Context) (string, error) {
+	u, err := url.Parse(os.Getenv(githubIDTokenURLEnv))
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubIDTokenURLEnv, err)
+	}
+	q := u.Query()
+	q.Set("audience", OIDCAudience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+os.Getenv(githubIDTokenTokenEnv))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", httpx.UserAgent())
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := doOIDCRequest(req, "GitHub OIDC token request", &out); err != nil {
+		return "", err
+	}
+	if out.Value == "" {
+		return "", fmt.Errorf("GitHub OIDC token request returned no token")
+	}
+	return out.Value, nil
+}
+
+// oidcExchangeResponse is the Vulnetix API's answer to an OIDC exchange.
+type oidcExchangeResponse struct {
+	Token     string    `json:"token"`
+	OrgID     string    `json:"orgId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// exchangeOIDCToken trades a GitHub OIDC token for Vulnetix credentials.
+func exchangeOIDCToken(ctx context.Context, idToken, orgID string) (*Credentials, time.Time, error) {
+	body, err := json.Marshal(map[string]string{"token": idToken, "orgId": orgID})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	endpoint := strings.TrimRight(OIDCBaseURL, "/") + "/auth/oidc/github"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", httpx.UserAgent())
+
+	var out oidcExchangeResponse
+	if err := doOIDCRequest(req, "OIDC token exchange", &out); err != nil {
+		return nil, time.Time{}, err
+	}
+	if out.Token == "" {
+		return nil, time.Time{}, fmt.Errorf("OIDC token exchange returned no token")
+	}
+	return &Credentials{OrgID: out.OrgID, Token: out.Token, Method: OIDC}, out.ExpiresAt, nil
+}
+
+func doOIDCRequest(req *http.Request, what string, out any) error {
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", what, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", what, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s failed (%d): %s", what, resp.StatusCode, httpx.APIErrorMessage(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s returned a malformed response: %w", what, err)
+	}
+	return nil
+}
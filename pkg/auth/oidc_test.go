@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeOIDCServer serves both the GitHub OIDC token endpoint (/token) and the
+// Vulnetix exchange (/v1/auth/oidc/github), recording the org each exchange
+// asks for.
+func fakeOIDCServer(t *testing.T, exchanges *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			if got := r.Header.Get("Authorization"); got != "Bearer request-token" {
+				t.Errorf("GitHub token request Authorization = %q", got)
+			}
+			if got := r.URL.Query().Get("audience"); got != OIDCAudience {
+				t.Errorf("audience = %q, want %q", got, OIDCAudience)
+			}
+			_, _ = w.Write([]byte(`{"value":"github-id-token"}`))
+		case "/v1/auth/oidc/github":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*exchanges = append(*exchanges, body["orgId"])
+			if body["token"] != "github-id-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid token"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"token":     "short-lived",
+				"orgId":     "oidc-org",
+				"expiresAt": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func useOIDC(t *testing.T, server *httptest.Server) {
+	t.Helper()
+	isolateCredentialSources(t)
+	t.Setenv(OIDCEnv, "true")
+	t.Setenv(githubIDTokenURLEnv, server.URL+"/token?api-version=2.0")
+	t.Setenv(githubIDTokenTokenEnv, "request-token")
+	origBase := OIDCBaseURL
+	OIDCBaseURL = server.URL + "/v1"
+	t.Cleanup(func() {
+		OIDCBaseURL = origBase
+		oidcCache.creds, oidcCache.expires = nil, time.Time{}
+	})
+}
+
+func TestLoadCredentials_OIDCExchange(t *testing.T) {
+	var exchanges []string
+	useOIDC(t, fakeOIDCServer(t, &exchanges))
+	// The API key is ignored in favour of OIDC; the org ID selects the org
+	// the exchange asks for.
+	t.Setenv("VULNETIX_API_KEY", "ignored-key")
+	t.Setenv("VULNETIX_ORG_ID", "requested-org")
+
+	for range 2 {
+		creds, err := LoadCredentials()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if creds.Method != OIDC || creds.Token != "short-lived" || creds.OrgID != "oidc-org" {
+			t.Fatalf("creds = %+v", creds)
+		}
+		if got := GetAuthHeader(creds); got != "Bearer short-lived" {
+			t.Errorf("GetAuthHeader = %q", got)
+		}
+	}
+	if len(exchanges) != 1 {
+		t.Errorf("exchanged %d times, want once per process", len(exchanges))
+	} else if exchanges[0] != "requested-org" {
+		t.Errorf("exchange asked for org %q, want VULNETIX_ORG_ID", exchanges[0])
+	}
+	if got := CredentialSource(); !strings.HasPrefix(got, "github-oidc") {
+		t.Errorf("CredentialSource = %q", got)
+	}
+}
+
+func TestLoadCredentials_OIDCOutsideGitHubActions(t *testing.T) {
+	useOIDC(t, fakeOIDCServer(t, new([]string)))
+	t.Setenv(githubIDTokenURLEnv, "")
+
+	_, err := LoadCredentials()
+	if err == nil || !strings.Contains(err.Error(), "id-token: write") {
+		t.Fatalf("err = %v, want a hint about id-token permissions", err)
+	}
+}
+
+func TestLoadCredentials_OIDCExchangeRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			_, _ = w.Write([]byte(`{"value":"github-id-token"}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"repository not trusted","details":"octo/app"}`))
+	}))
+	t.Cleanup(server.Close)
+	useOIDC(t, server)
+
+	_, err := LoadCredentials()
+	if err == nil || !strings.Contains(err.Error(), "(403): repository not trusted - octo/app") {
+		t.Fatalf("err = %v", err)
+	}
+}
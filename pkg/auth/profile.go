@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+)
+
+// ProfileEnv selects a credentials profile when --profile is not given.
+const ProfileEnv = "VULNETIX_PROFILE"
+
+// DefaultProfile names the credentials held at the top level of a
+// credentials file, which every version of the CLI reads.
+const DefaultProfile = "default"
+
+// Profile is the credentials profile requested with --profile. Set by the cmd
+// layer; see ActiveProfile.
+var Profile string
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidateProfile checks a profile name: letters, digits, '.', '_' and '-',
+// starting with a letter or digit, at most 64 characters.
+func ValidateProfile(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile %q: use letters, digits, '.', '_' and '-' (at most 64 characters)", name)
+	}
+	return nil
+}
+
+// ActiveProfile returns the named profile credentials are loaded from and
+// saved to: Profile, else $VULNETIX_PROFILE. It returns "" for the default
+// profile, including when "default" is named explicitly.
+func ActiveProfile() string {
+	name := Profile
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
+
+// checkActiveProfile validates ActiveProfile.
+func checkActiveProfile() (string, error) {
+	name := ActiveProfile()
+	if name == "" {
+		return "", nil
+	}
+	return name, ValidateProfile(name)
+}
+
+// credentialsDocument is the layout of a credentials file. The top-level
+// fields are the default profile, so files written before profiles existed
+// read unchanged; named profiles sit under "profiles".
+type credentialsDocument struct {
+	Credentials
+	Profiles map[string]*Credentials `json:"profiles,omitempty"`
+
+	// passphrase is set when the document was read from, or is to be
+	// written to, an encrypted file.
+	passphrase string
+}
+
+// profile returns the credentials stored under name ("" for the default
+// profile), or nil when the document has none.
+func (d *credentialsDocument) profile(name string) *Credentials {
+	if name != "" {
+		return d.Profiles[name]
+	}
+	if d.Credentials == (Credentials{}) {
+		return nil
+	}
+	creds := d.Credentials
+	return &creds
+}
+
+// setProfile stores creds under name, or removes the profile when creds is
+// nil.
+func (d *credentialsDocument) setProfile(name string, creds *Credentials) {
+	if name == "" {
+		if creds == nil {
+			d.Credentials = Credentials{}
+		} else {
+			d.Credentials = *creds
+		}
+		return
+	}
+	if creds == nil {
+		delete(d.Profiles, name)
+		return
+	}
+	if d.Profiles == nil {
+		d.Profiles = map[string]*Credentials{}
+	}
+	d.Profiles[name] = creds
+}
+
+// empty reports whether the document holds no profile at all.
+func (d *credentialsDocument) empty() bool {
+	return d.Credentials == (Credentials{}) && len(d.Profiles) == 0
+}
+
+// ProfileNames lists the profiles stored in store's credentials file,
+// "default" first when the file has top-level credentials.
+func ProfileNames(store CredentialStore) ([]string, error) {
+	doc, _, err := readCredentialsDocument(store)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	if doc.profile("") != nil {
+		names = append(names, DefaultProfile)
+	}
+	named := make([]string, 0, len(doc.Profiles))
+	for name := range doc.Profiles {
+		named = append(named, name)
+	}
+	sort.Strings(named)
+	return append(names, named...), nil
+}
+
+// ProfileOrgIDs returns the org IDs the active profile holds in the home and
+// project credentials files, without duplicates. Files that cannot be read
+// are skipped.
+func ProfileOrgIDs() []string {
+	profile, err := checkActiveProfile()
+	if err != nil {
+		return nil
+	}
+	var orgs []string
+	for _, store := range []CredentialStore{StoreHome, StoreProject} {
+		doc, _, err := readCredentialsDocument(store)
+		if err != nil {
+			continue
+		}
+		if creds := doc.profile(profile); creds != nil && creds.OrgID != "" && !slices.Contains(orgs, creds.OrgID) {
+			orgs = append(orgs, creds.OrgID)
+		}
+	}
+	return orgs
+}
+
+func readCredentialsDocument(store CredentialStore) (*credentialsDocument, string, error) {
+	path, err := storePath(store)
+	if err != nil {
+		return nil, "", err
+	}
+	doc, err := readDocumentAt(path)
+	return doc, path, err
+}
+
+func readDocumentAt(path string) (*credentialsDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc credentialsDocument
+	if env := parseEnvelope(data); env != nil {
+		if data, doc.passphrase, err = decryptDocument(path, env); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials from %s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// writeDocumentAt replaces the file at path with doc, encrypted when doc has
+// a passphrase.
+func writeDocumentAt(path string, doc *credentialsDocument) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	if doc.passphrase != "" {
+		env, err := sealEnvelope(data, doc.passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt credentials: %w", err)
+		}
+		if data, err = json.MarshalIndent(encryptedFile{Encrypted: env}, "", "  "); err != nil {
+			return fmt.Errorf("failed to marshal credentials: %w", err)
+		}
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write credentials to %s: %w", path, err)
+	}
+	return nil
+}
+
+// profileKeyringAccount scopes a keychain account name to a named profile, so
+// two profiles for one org keep separate secrets. The default profile keeps
+// the unscoped names earlier versions wrote.
+func profileKeyringAccount(account, profile string) string {
+	if profile == "" {
+		return account
+	}
+	return account + "@" + profile
+}
+
+// ProfileNotFoundError reports a requested profile that no credentials file
+// holds. It matches os.ErrNotExist.
+type ProfileNotFoundError struct {
+	Profile string
+	Path    string
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("credentials profile %q not found. Run 'vulnetix auth login --profile %s'", e.Profile, e.Profile)
+	}
+	return fmt.Sprintf("credentials profile %q not found in %s", e.Profile, e.Path)
+}
+
+func (e *ProfileNotFoundError) Is(target error) bool { return target == os.ErrNotExist }
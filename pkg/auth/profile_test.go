@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func useProfile(t *testing.T, name string) {
+	t.Helper()
+	orig := Profile
+	Profile = name
+	t.Cleanup(func() { Profile = orig })
+}
+
+func TestProfilesShareOneCredentialsFile(t *testing.T) {
+	isolateCredentialSources(t)
+	t.Setenv(ProfileEnv, "")
+
+	prod := &Credentials{OrgID: "prod-org", APIKey: "prod-key", Method: DirectAPIKey}
+	staging := &Credentials{OrgID: "staging-org", Secret: "staging-secret", Method: SigV4}
+	if err := SaveCredentials(prod, StoreHome); err != nil {
+		t.Fatal(err)
+	}
+	useProfile(t, "staging")
+	if err := SaveCredentials(staging, StoreHome); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("load staging: %v", err)
+	}
+	if !reflect.DeepEqual(got, staging) {
+		t.Errorf("staging = %+v, want %+v", got, staging)
+	}
+
+	Profile = DefaultProfile
+	got, err = LoadCredentials()
+	if err != nil {
+		t.Fatalf("load default: %v", err)
+	}
+	if !reflect.DeepEqual(got, prod) {
+		t.Errorf("default = %+v, want %+v", got, prod)
+	}
+
+	names, err := ProfileNames(StoreHome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"default", "staging"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ProfileNames = %q, want %q", names, want)
+	}
+}
+
+func TestProfileFromEnvironmentSkipsEnvCredentials(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "")
+	t.Setenv(ProfileEnv, "ci")
+	t.Setenv("VULNETIX_API_KEY", "env-key")
+	t.Setenv("VULNETIX_ORG_ID", "env-org")
+
+	writeCredentialsJSON(t, StoreProject, `{"profiles": {"ci": {"org_id": "ci-org", "token": "ci-token", "method": "token"}}}`)
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.Token != "ci-token" {
+		t.Errorf("loaded %+v, want the ci profile from the project file", creds)
+	}
+	if got := CredentialSource(); got != "project (.vulnetix/credentials.json)" {
+		t.Errorf("CredentialSource = %q", got)
+	}
+}
+
+func TestLoadCredentials_MissingProfile(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "staging")
+	t.Setenv("VULNETIX_API_KEY", "env-key")
+	t.Setenv("VULNETIX_ORG_ID", "env-org")
+	writeCredentialsJSON(t, StoreHome, `{"org_id": "org", "api_key": "key", "method": "apikey"}`)
+
+	_, err := LoadCredentials()
+	var notFound *ProfileNotFoundError
+	if !errors.As(err, &notFound) || notFound.Profile != "staging" {
+		t.Fatalf("err = %v, want ProfileNotFoundError for staging", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Error("ProfileNotFoundError should match os.ErrNotExist")
+	}
+	if got := CredentialSource(); got != "none" {
+		t.Errorf("CredentialSource = %q, want none", got)
+	}
+}
+
+func TestLoadCredentials_InvalidProfileName(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "../prod")
+	if _, err := LoadCredentials(); err == nil || !strings.Contains(err.Error(), "invalid profile") {
+		t.Fatalf("err = %v, want an invalid profile error", err)
+	}
+}
+
+func TestRemoveCredentialsKeepsOtherProfiles(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "")
+	writeCredentialsJSON(t, StoreHome, `{"org_id": "org", "api_key": "key", "method": "apikey",
+		"profiles": {"staging": {"org_id": "s-org", "api_key": "s-key", "method": "apikey"}}}`)
+
+	Profile = "staging"
+	if err := RemoveCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCredentials(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("staging still loads after logout: %v", err)
+	}
+	Profile = ""
+	if creds, err := LoadCredentials(); err != nil || creds.APIKey != "key" {
+		t.Errorf("default profile lost: %+v, %v", creds, err)
+	}
+
+	if err := RemoveCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	path, _ := storePath(StoreHome)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("credentials file kept with no profiles left: %v", err)
+	}
+}
+
+func TestSaveCredentialsKeepsUnreadableFile(t *testing.T) {
+	isolateCredentialSources(t)
+	useProfile(t, "staging")
+	corrupt := `{"org_id": "org", "profiles": {`
+	writeCredentialsJSON(t, StoreHome, corrupt)
+
+	err := SaveCredentials(&Credentials{OrgID: "s-org", APIKey: "s-key", Method: DirectAPIKey}, StoreHome)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse credentials") {
+		t.Fatalf("err = %v, want the parse error", err)
+	}
+	path, _ := storePath(StoreHome)
+	if data, _ := os.ReadFile(path); string(data) != corrupt {
+		t.Errorf("unreadable file overwritten: %s", data)
+	}
+}
+
+func TestProfilesKeepSeparateKeyringSecrets(t *testing.T) {
+	keyring.MockInit()
+	isolateCredentialSources(t)
+	useProfile(t, "")
+
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "default-token", Method: Token}, StoreKeyring); err != nil {
+		t.Fatal(err)
+	}
+	Profile = "staging"
+	if err := SaveCredentials(&Credentials{OrgID: "org", Token: "staging-token", Method: Token}, StoreKeyring); err != nil {
+		t.Fatal(err)
+	}
+
+	for profile, want := range map[string]string{"": "default-token", "staging": "staging-token"} {
+		Profile = profile
+		creds, err := LoadCredentials()
+		if err != nil {
+			t.Fatalf("profile %q: %v", profile, err)
+		}
+		if creds.Token != want {
+			t.Errorf("profile %q token = %q, want %q", profile, creds.Token, want)
+		}
+	}
+}
+
+func TestValidateCredentialsJSON_Profiles(t *testing.T) {
+	const org = "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718"
+	valid := `{"profiles": {"staging": {"org_id": "` + org + `", "api_key": "k", "method": "apikey"}}}`
+	if problems := ValidateCredentialsJSON([]byte(valid)); len(problems) != 0 {
+		t.Errorf("profiles-only file reported %v", problems)
+	}
+
+	invalid := `{"profiles": {"staging": {"org_id": "` + org + `", "method": "apikey"}, "bad name": {}, "ci": 1}}`
+	var got []string
+	for _, p := range ValidateCredentialsJSON([]byte(invalid)) {
+		got = append(got, p.Field)
+	}
+	want := []string{"profiles.bad name", "profiles.ci", "profiles.staging.api_key"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problem fields = %q, want %q", got, want)
+	}
+}
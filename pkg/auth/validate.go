@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// credentialFields lists every key a credentials file may contain, with the
+// JSON type it must have.
+var credentialFields = map[string]string{
+	"org_id":             "string",
+	"api_key":            "string",
+	"secret":             "string",
+	"token":              "string",
+	"session_token":      "string",
+	"method":             "string",
+	"hmac_in_keyring":    "boolean",
+	"token_in_keyring":   "boolean",
+	"api_key_in_keyring": "boolean",
+}
+
+// CredentialFileProblem is one schema violation found in a credentials file.
+// Field is empty for problems with the document as a whole.
+type CredentialFileProblem struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p CredentialFileProblem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return p.Field + ": " + p.Message
+}
+
+// ValidateCredentialsFile schema-checks the credentials file at path without
+// touching the keyring or the network. A nil result means the file is valid;
+// an error is returned only when the file cannot be read. The contents of an
+// encrypted file are checked too when $VULNETIX_CREDENTIALS_PASSPHRASE is
+// set; otherwise only its envelope is.
+func ValidateCredentialsFile(path string) ([]CredentialFileProblem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	problems := ValidateCredentialsJSON(data)
+	env := parseEnvelope(data)
+	passphrase := os.Getenv(PassphraseEnv)
+	if env == nil || len(problems) > 0 || passphrase == "" {
+		return problems, nil
+	}
+	plain, err := openEnvelope(env, passphrase)
+	if err != nil {
+		return []CredentialFileProblem{{Field: "encrypted", Message: "cannot be decrypted: " + err.Error()}}, nil
+	}
+	return ValidateCredentialsJSON(plain), nil
+}
+
+// ValidateCredentialsJSON schema-checks a credentials document: known keys
+// with the right types, a valid method, the fields that method requires and
+// a UUID org_id. Each named profile under "profiles" is checked the same way,
+// its problems reported as profiles.<name>.<field>; the top-level default
+// profile may then be absent. For an encrypted file only the envelope under
+// "encrypted" is checked.
+func ValidateCredentialsJSON(data []byte) []CredentialFileProblem {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []CredentialFileProblem{{Message: "invalid JSON: " + err.Error()}}
+	}
+	if raw == nil {
+		return []CredentialFileProblem{{Message: "expected a JSON object"}}
+	}
+	if _, ok := raw["encrypted"]; ok {
+		return validateEnvelope(data, raw)
+	}
+
+	profiles, hasProfiles := raw["profiles"]
+	delete(raw, "profiles")
+	var problems []CredentialFileProblem
+	if len(raw) > 0 || !hasProfiles {
+		problems = validateCredentialFields(raw, "")
+	}
+	if !hasProfiles {
+		return problems
+	}
+	named, ok := profiles.(map[string]any)
+	if !ok {
+		return append(problems, CredentialFileProblem{Field: "profiles", Message: "must be an object, got " + jsonTypeName(profiles)})
+	}
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prefix := "profiles." + name
+		if err := ValidateProfile(name); err != nil {
+			problems = append(problems, CredentialFileProblem{Field: prefix, Message: err.Error()})
+			continue
+		}
+		fields, ok := named[name].(map[string]any)
+		if !ok {
+			problems = append(problems, CredentialFileProblem{Field: prefix, Message: "must be an object, got " + jsonTypeName(named[name])})
+			continue
+		}
+		problems = append(problems, validateCredentialFields(fields, prefix+".")...)
+	}
+	return problems
+}
+
+// validateEnvelope checks an encrypted file: an "encrypted" envelope this
+// version can open, and nothing beside it.
+func validateEnvelope(data []byte, raw map[string]any) []CredentialFileProblem {
+	var problems []CredentialFileProblem
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		if k != "encrypted" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		problems = append(problems, CredentialFileProblem{Field: k, Message: "unknown field in an encrypted file"})
+	}
+	var f encryptedFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Encrypted == nil {
+		return append(problems, CredentialFileProblem{Field: "encrypted", Message: "malformed envelope"})
+	}
+	if err := f.Encrypted.check(); err != nil {
+		return append(problems, CredentialFileProblem{Field: "encrypted", Message: err.Error()})
+	}
+	if len(f.Encrypted.Ciphertext) == 0 {
+		problems = append(problems, CredentialFileProblem{Field: "encrypted.ciphertext", Message: "required field is missing"})
+	}
+	return problems
+}
+
+// validateCredentialFields checks one profile's fields, prefixing each
+// problem's field name with prefix.
+func validateCredentialFields(raw map[string]any, prefix string) []CredentialFileProblem {
+	var problems []CredentialFileProblem
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		want, known := credentialFields[k]
+		if !known {
+			problems = append(problems, CredentialFileProblem{Field: prefix + k, Message: "unknown field"})
+			continue
+		}
+		if got := jsonTypeName(raw[k]); got != want {
+			problems = append(problems, CredentialFileProblem{Field: prefix + k, Message: fmt.Sprintf("must be a %s, got %s", want, got)})
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	// The field types were checked above, so decoding cannot fail.
+	data, _ := json.Marshal(raw)
+	var creds Credentials
+	_ = json.Unmarshal(data, &creds)
+
+	if _, ok := raw["method"]; !ok {
+		problems = append(problems, CredentialFileProblem{Field: prefix + "method", Message: "required field is missing"})
+	} else if _, err := ValidateMethod(string(creds.Method)); err != nil {
+		problems = append(problems, CredentialFileProblem{Field: prefix + "method", Message: err.Error()})
+	}
+
+	orgRequired := creds.Method == DirectAPIKey || creds.Method == SigV4
+	switch {
+	case creds.OrgID == "" && orgRequired:
+		problems = append(problems, CredentialFileProblem{Field: prefix + "org_id", Message: fmt.Sprintf("required for method %s", creds.Method)})
+	case creds.OrgID != "":
+		if _, err := uuid.Parse(creds.OrgID); err != nil {
+			problems = append(problems, CredentialFileProblem{Field: prefix + "org_id", Message: fmt.Sprintf("%q is not a valid UUID", creds.OrgID)})
+		}
+	}
+
+	switch creds.Method {
+	case Token:
+		problems = append(problems, requireSecret(prefix, "token", "token_in_keyring", creds.Token, creds.TokenInKeyring)...)
+	case DirectAPIKey:
+		problems = append(problems, requireSecret(prefix, "api_key", "api_key_in_keyring", creds.APIKey, creds.APIKeyInKeyring)...)
+	case SigV4:
+		problems = append(problems, requireSecret(prefix, "secret", "hmac_in_keyring", creds.Secret, creds.HMACInKeyring)...)
+	}
+	return problems
+}
+
+// requireSecret checks that a method's secret is either inline or flagged as
+// held in the keyring.
+func requireSecret(prefix, field, keyringField, value string, inKeyring bool) []CredentialFileProblem {
+	if value == "" && !inKeyring {
+		return []CredentialFileProblem{{Field: prefix + field, Message: fmt.Sprintf("required unless %s is true", keyringField)}}
+	}
+	return nil
+}
+
+func jsonTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
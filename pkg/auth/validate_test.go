@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCredentialsJSON(t *testing.T) {
+	const org = "6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718"
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{"valid sigv4", `{"org_id":"` + org + `","secret":"s","method":"sigv4"}`, nil},
+		{"valid sigv4 session", `{"org_id":"` + org + `","secret":"s","session_token":"st","method":"sigv4"}`, nil},
+		{"valid keyring apikey", `{"org_id":"` + org + `","method":"apikey","api_key_in_keyring":true}`, nil},
+		{"valid token without org", `{"org_id":"","token":"t","method":"token"}`, nil},
+		{"missing secret", `{"org_id":"` + org + `","method":"sigv4"}`, []string{"secret: required unless hmac_in_keyring is true"}},
+		{"missing org", `{"api_key":"k","method":"apikey"}`, []string{"org_id: required for method apikey"}},
+		{"missing method", `{"org_id":"` + org + `","secret":"s"}`, []string{"method: required field is missing"}},
+		{"wrong method", `{"org_id":"` + org + `","secret":"s","method":"hmac"}`, []string{`method: invalid auth method "hmac"`}},
+		{"bad org", `{"org_id":"acme","token":"t","method":"token"}`, []string{`org_id: "acme" is not a valid UUID`}},
+		{"wrong type", `{"org_id":"` + org + `","method":"token","token":42}`, []string{"token: must be a string, got number"}},
+		{"unknown field", `{"org":"` + org + `","method":"token","token":"t"}`, []string{"org: unknown field"}},
+		{"not json", `{"org_id":`, []string{"invalid JSON"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := ValidateCredentialsJSON([]byte(tc.doc))
+			if len(problems) != len(tc.want) {
+				t.Fatalf("got %v, want %d problem(s) %v", problems, len(tc.want), tc.want)
+			}
+			for i, p := range problems {
+				if !strings.HasPrefix(p.String(), tc.want[i]) {
+					t.Errorf("problem %d = %q, want prefix %q", i, p, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if _, err := ValidateCredentialsFile(path); !os.IsNotExist(err) {
+		t.Fatalf("missing file: err = %v, want not-exist", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"method":"token","token":"t"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateCredentialsFile(path)
+	if err != nil || problems != nil {
+		t.Errorf("got %v, %v; want a valid file", problems, err)
+	}
+
+	// Temporary SigV4 credentials carry a session token.
+	temporary := `{"org_id":"6f1c2e4a-8b9d-4e7f-a1b2-c3d4e5f60718","secret":"s","session_token":"st","method":"sigv4"}`
+	if err := os.WriteFile(path, []byte(temporary), 0600); err != nil {
+		t.Fatal(err)
+	}
+	problems, err = ValidateCredentialsFile(path)
+	if err != nil || problems != nil {
+		t.Errorf("session token file: got %v, %v; want a valid file", problems, err)
+	}
+}
@@ -22,3 +22,13 @@ func StderrIsTerminal() bool {
 func StdoutIsTerminal() bool {
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
+
+// StdoutHeight returns the number of rows of the terminal on stdout, or 0
+// when stdout is not a terminal.
+func StdoutHeight() int {
+	_, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return h
+}
@@ -4,8 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
+	"sort"
 	"time"
 )
 
@@ -65,6 +65,44 @@ type VersionRecord struct {
 	Ecosystem string          `json:"ecosystem"`
 	Sources   []VersionSource `json:"sources"`
 	CVEIDs    []string        `json:"cveIds,omitempty"`
+	Published *RecordDate     `json:"published,omitempty"`
+	Modified  *RecordDate     `json:"modified,omitempty"`
+}
+
+// RecordDate is a date on a version record. The API sends RFC 3339
+// timestamps, plain dates or epoch milliseconds; a value in any other shape
+// decodes as the zero time rather than failing the whole response.
+type RecordDate struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts a string date or epoch milliseconds.
+func (d *RecordDate) UnmarshalJSON(data []byte) error {
+	d.Time = time.Time{}
+	var ms int64
+	if err := json.Unmarshal(data, &ms); err == nil {
+		d.Time = time.UnixMilli(ms).UTC()
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			d.Time = t
+			return nil
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes the date as RFC 3339, or null when it is unknown.
+func (d RecordDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(time.RFC3339))
 }
 
 // ProductVersionsResponse represents product versions with pagination
@@ -148,6 +186,14 @@ func (c *Client) GetCVE(cveID string) (*CVEInfo, error) {
 
 // GetEcosystems retrieves the list of available ecosystems
 func (c *Client) GetEcosystems() ([]Ecosystem, error) {
+	resp, err := c.getEcosystemsResponse()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ecosystems, nil
+}
+
+func (c *Client) getEcosystemsResponse() (*EcosystemsResponse, error) {
 	path := "/ecosystems"
 
 	respBody, err := c.DoRequestCached("GET", path, nil, StaticEnumTTL)
@@ -160,7 +206,47 @@ func (c *Client) GetEcosystems() ([]Ecosystem, error) {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return resp.Ecosystems, nil
+	return &resp, nil
+}
+
+// Stats is a coverage overview of the VDB: package counts per ecosystem, the
+// number of distinct vulnerability IDs, and when the ecosystem counts were
+// last refreshed.
+type Stats struct {
+	Ecosystems    []Ecosystem `json:"ecosystems"`
+	TotalPackages int         `json:"totalPackages"`
+	TotalCVEs     int         `json:"totalCves"`
+	LastUpdated   time.Time   `json:"lastUpdated"`
+}
+
+// GetStats combines /ecosystems and /summary into a coverage overview.
+// Ecosystems are sorted by package count, largest first.
+func (c *Client) GetStats() (*Stats, error) {
+	eco, err := c.getEcosystemsResponse()
+	if err != nil {
+		return nil, err
+	}
+	summary, err := c.GetSummary()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{Ecosystems: append([]Ecosystem(nil), eco.Ecosystems...)}
+	sort.SliceStable(stats.Ecosystems, func(i, j int) bool {
+		return stats.Ecosystems[i].Count > stats.Ecosystems[j].Count
+	})
+	for _, e := range stats.Ecosystems {
+		stats.TotalPackages += e.Count
+	}
+	if db, ok := summary["database"].(map[string]interface{}); ok {
+		if n, ok := db["distinctCveIds"].(float64); ok {
+			stats.TotalCVEs = int(n)
+		}
+	}
+	if eco.Timestamp > 0 {
+		stats.LastUpdated = time.Unix(eco.Timestamp, 0).UTC()
+	}
+	return stats, nil
 }
 
 // buildPaginationQuery constructs a query string for pagination parameters.
@@ -241,6 +327,92 @@ func (c *Client) GetPackageVulnerabilities(packageName string, limit, offset int
 	return &resp, nil
 }
 
+// StreamPackageVulnerabilities is GetPackageVulnerabilities for packages with
+// very large histories: the response is decoded token by token and each entry
+// of the versions (or vulnerabilities) array is handed to fn as soon as it is
+// read, so the full array is never held in memory. The returned response
+// carries the scalar fields only; Versions, Vulnerabilities and RawData are
+// left empty. An error from fn stops decoding and is returned as-is.
+func (c *Client) StreamPackageVulnerabilities(packageName string, limit, offset int, fn func(json.RawMessage) error) (*VulnerabilitiesResponse, error) {
+	path := fmt.Sprintf("/%s/vulns", url.PathEscape(packageName))
+	path += buildPaginationQuery(limit, offset)
+
+	body, err := c.DoRequestStream(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return decodeVulnerabilitiesStream(json.NewDecoder(body), fn)
+}
+
+// decodeVulnerabilitiesStream walks a /vulns response object, streaming the
+// record arrays through fn and decoding every other field into the result.
+func decodeVulnerabilitiesStream(dec *json.Decoder, fn func(json.RawMessage) error) (*VulnerabilitiesResponse, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	var resp VulnerabilitiesResponse
+	meta := map[string]json.RawMessage{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		key, _ := tok.(string)
+		if key != "versions" && key != "vulnerabilities" {
+			var v json.RawMessage
+			if err := dec.Decode(&v); err != nil {
+				return nil, fmt.Errorf("failed to parse response: %w", err)
+			}
+			meta[key] = v
+			continue
+		}
+		// A null array is as good as an empty one.
+		if tok, err = dec.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if tok == nil {
+			continue
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return nil, fmt.Errorf("failed to parse response: %q is not an array", key)
+		}
+		for dec.More() {
+			var item json.RawMessage
+			if err := dec.Decode(&item); err != nil {
+				return nil, fmt.Errorf("failed to parse response: %w", err)
+			}
+			if err := fn(item); err != nil {
+				return nil, err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	scalars, _ := json.Marshal(meta)
+	if err := json.Unmarshal(scalars, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("failed to parse response: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
 // GetHealth checks the API health endpoint (unauthenticated, root-level path).
 func (c *Client) GetHealth() (map[string]interface{}, error) {
 	u, err := url.Parse(c.BaseURL)
@@ -249,7 +421,7 @@ func (c *Client) GetHealth() (map[string]interface{}, error) {
 	}
 	healthURL := fmt.Sprintf("%s://%s/health", u.Scheme, u.Host)
 
-	resp, err := http.Get(healthURL) //nolint:noctx
+	resp, err := c.HTTPClient.Get(healthURL) //nolint:noctx
 	if err != nil {
 		return map[string]interface{}{"status": "unreachable", "error": err.Error()}, nil
 	}
@@ -0,0 +1,76 @@
+package vdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// RotatedAPIKey is the response from POST /auth/api-key/rotate.
+type RotatedAPIKey struct {
+	OrgID  string `json:"orgId"`
+	APIKey string `json:"apiKey"`
+	KeyID  string `json:"keyId"`
+	// PreviousKeyID identifies the key the request was made with. It stays
+	// valid until revoked with RevokeAPIKey.
+	PreviousKeyID string `json:"previousKeyId"`
+}
+
+// RotateAPIKey issues a new API key for the client's org. The key the client
+// authenticated with is not revoked.
+func (c *Client) RotateAPIKey() (*RotatedAPIKey, error) {
+	respBody, err := c.DoRequest("POST", "/auth/api-key/rotate", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp RotatedAPIKey
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.APIKey == "" || resp.KeyID == "" {
+		return nil, fmt.Errorf("API response missing apiKey or keyId")
+	}
+	return &resp, nil
+}
+
+// RevokeAPIKey revokes the org's API key with the given ID.
+func (c *Client) RevokeAPIKey(keyID string) error {
+	_, err := c.DoRequest("DELETE", "/auth/api-key/"+url.PathEscape(keyID), nil)
+	return err
+}
+
+// Identity is the response from GET /auth/whoami: the org the credentials
+// belong to and what they are allowed.
+type Identity struct {
+	OrgID        string   `json:"orgId"`
+	OrgName      string   `json:"orgName"`
+	Plan         string   `json:"plan"`
+	Entitlements []string `json:"entitlements"`
+	Scopes       []string `json:"scopes"`
+	// RateLimits is nil when the API did not describe the plan's limits.
+	RateLimits *IdentityRateLimits `json:"rateLimits"`
+}
+
+// IdentityRateLimits are the request allowances of the org's plan. A zero
+// limit means unlimited.
+type IdentityRateLimits struct {
+	PerMinute  int  `json:"perMinute"`
+	PerDay     int  `json:"perDay"`
+	SoftLimits bool `json:"softLimits"`
+}
+
+// WhoAmI describes the org and entitlements of the client's credentials.
+// The response's rate-limit headers are left in LastRateLimit.
+func (c *Client) WhoAmI() (*Identity, error) {
+	respBody, err := c.DoRequest("GET", "/auth/whoami", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Identity
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp, nil
+}
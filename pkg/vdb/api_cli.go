@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/vulnetix/cli/v3/internal/gitctx"
+	"github.com/vulnetix/cli/v3/internal/httpx"
 )
 
 // ─── Envelope types (mirror vdb-api/internal/handler/v2_cli_common.go) ────
@@ -374,6 +375,40 @@ type CliFinalizeResponse struct {
 	Persisted bool `json:"persisted"`
 }
 
+// CliTriageDecision is one finding's triage decision, as computed by
+// `vulnetix triage` and recorded server-side by /v2/cli.triage-decisions.
+type CliTriageDecision struct {
+	VulnID           string   `json:"vulnId"`
+	Package          string   `json:"package,omitempty"`
+	Ecosystem        string   `json:"ecosystem,omitempty"`
+	InstalledVersion string   `json:"installedVersion,omitempty"`
+	FixedVersion     string   `json:"fixedVersion,omitempty"`
+	Status           string   `json:"status"` // VEX status
+	Justification    string   `json:"justification,omitempty"`
+	Action           string   `json:"action,omitempty"`
+	Decision         string   `json:"decision,omitempty"`
+	SSVCDecision     string   `json:"ssvcDecision"` // Act | Attend | Track* | Track
+	Reasons          []string `json:"reasons,omitempty"`
+}
+
+// CliTriageDecisionsRequest records a batch of triage decisions.
+type CliTriageDecisionsRequest struct {
+	Decisions []CliTriageDecision `json:"decisions"`
+}
+
+// CliTriageDecisionsResponse is the success body. A decision the server
+// could not record is listed in Failed; the rest were applied.
+type CliTriageDecisionsResponse struct {
+	Applied int                       `json:"applied"`
+	Failed  []CliTriageDecisionFailed `json:"failed,omitempty"`
+}
+
+// CliTriageDecisionFailed is one decision the server rejected.
+type CliTriageDecisionFailed struct {
+	VulnID string `json:"vulnId"`
+	Error  string `json:"error"`
+}
+
 // CliSCAReachabilityResponse is the success body.
 type CliSCAReachabilityResponse struct {
 	Persisted   int    `json:"persisted"`
@@ -822,7 +857,7 @@ func cliPostWithEnvContext[T any](ctx context.Context, c *Client, route string,
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to marshal request body: %w", route, err)
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+c.APIVersion+"/"+route, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint("/"+route), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to create request: %w", route, err)
 	}
@@ -837,7 +872,7 @@ func cliPostWithEnvContext[T any](ctx context.Context, c *Client, route string,
 		req.URL.RawQuery = q.Encode()
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to execute request: %w", route, err)
 	}
@@ -852,13 +887,7 @@ func cliPostWithEnvContext[T any](ctx context.Context, c *Client, route string,
 		return nil, fmt.Errorf("%s: failed to read response: %w", route, err)
 	}
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		var msg string
-		if err := json.Unmarshal(raw, &errResp); err == nil {
-			msg = fmt.Sprintf("API error (%d): %s - %s", resp.StatusCode, errResp.Error, errResp.Details)
-		} else {
-			msg = fmt.Sprintf("API error (%d): %s", resp.StatusCode, string(raw))
-		}
+		msg := fmt.Sprintf("API error (%d): %s", resp.StatusCode, httpx.APIErrorMessage(raw))
 		if resp.StatusCode == http.StatusNotFound {
 			return nil, &NotFoundError{Message: msg}
 		}
@@ -928,6 +957,12 @@ func (c *Client) CliTriage(env CliEnv, req CliTriageRequest) (*CliResponse[map[s
 	return cliPostWithEnv[map[string]any](c, "cli.triage", env, req)
 }
 
+// CliTriageDecisions — POST /v2/cli.triage-decisions. Records the SSVC and
+// VEX decision for each finding.
+func (c *Client) CliTriageDecisions(env CliEnv, req CliTriageDecisionsRequest) (*CliResponse[CliTriageDecisionsResponse], error) {
+	return cliPostWithEnv[CliTriageDecisionsResponse](c, "cli.triage-decisions", env, req)
+}
+
 // CliVex — POST /v2/cli.vex. OpenVEX statements per CVE.
 func (c *Client) CliVex(env CliEnv, ids []string) (*CliResponse[map[string]any], error) {
 	return cliPostWithEnv[map[string]any](c, "cli.vex", env, CliIDsRequest{IDs: ids})
@@ -1032,6 +1067,29 @@ func (c *Client) CliQualityGateGet(env CliEnv) (*CliResponse[map[string]any], er
 	return cliPostWithEnv[map[string]any](c, "cli.quality-gate-get", env, struct{}{})
 }
 
+// CliOrgConfig is the org's default repository configuration. Nil / empty
+// fields are settings the org has not configured.
+type CliOrgConfig struct {
+	ProductionBranch string   `json:"productionBranch,omitempty"`
+	RequiredTools    []string `json:"requiredTools,omitempty"`
+	Severity         string   `json:"severity,omitempty"`
+	CVSSThreshold    *float64 `json:"cvssThreshold,omitempty"`
+	EPSSThreshold    *float64 `json:"epssThreshold,omitempty"`
+}
+
+// CliOrgConfigData is the data payload of cli.org-config-get. A nil Config
+// means the org has no defaults stored.
+type CliOrgConfigData struct {
+	Config *CliOrgConfig `json:"config"`
+}
+
+// CliOrgConfigGet — POST /v2/cli.org-config-get. Read-only: the org's default
+// repository settings that `vulnetix config pull` writes to vulnetix.yaml. Org
+// is resolved from the authenticated request, so the payload is empty.
+func (c *Client) CliOrgConfigGet(env CliEnv) (*CliResponse[CliOrgConfigData], error) {
+	return cliPostWithEnv[CliOrgConfigData](c, "cli.org-config-get", env, struct{}{})
+}
+
 // ─── Suppressions ("ignore" rules) ────────────────────────────────────────
 
 // CliSuppression mirrors the backend Suppression row (subset). Timestamps are
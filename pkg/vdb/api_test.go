@@ -2,7 +2,16 @@ package vdb
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/auth"
 )
 
 func TestCVEInfo(t *testing.T) {
@@ -148,3 +157,142 @@ func TestVulnerabilitiesResponse(t *testing.T) {
 		t.Errorf("unexpected values: %+v", r)
 	}
 }
+
+// The server sends the first page of records, then holds the connection open
+// until the client has handled them; a buffering client would never see them
+// before the body is complete and the server would time out waiting.
+func TestStreamPackageVulnerabilities_Incremental(t *testing.T) {
+	const head, total = 100, 10000
+	handledHead := make(chan struct{})
+	var timedOut atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"packageName":"lodash","totalCVEs":%d,"hasMore":false,"versions":[`, total)
+		for i := 0; i < total; i++ {
+			if i > 0 {
+				_, _ = w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"version":"1.0.%d","cveIds":["CVE-2024-%05d"]}`, i, i)
+			if i == head-1 {
+				w.(http.Flusher).Flush()
+				select {
+				case <-handledHead:
+				case <-time.After(5 * time.Second):
+					timedOut.Store(true)
+				}
+			}
+		}
+		_, _ = w.Write([]byte(`],"timestamp":1700000000}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("org", "")
+	c.BaseURL = srv.URL
+	c.APIVersion = "/v1"
+	c.AuthMethod = auth.Token
+	c.Token = "tok"
+
+	count := 0
+	resp, err := c.StreamPackageVulnerabilities("lodash", 0, 0, func(item json.RawMessage) error {
+		count++
+		if count == head {
+			close(handledHead)
+		}
+		if !strings.Contains(string(item), fmt.Sprintf(`"1.0.%d"`, count-1)) {
+			return fmt.Errorf("record %d out of order: %s", count, item)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPackageVulnerabilities: %v", err)
+	}
+	if timedOut.Load() {
+		t.Fatal("records were not handed over until the whole body had arrived")
+	}
+	if count != total {
+		t.Errorf("got %d records, want %d", count, total)
+	}
+	if resp.PackageName != "lodash" || resp.TotalCVEs != total || resp.Timestamp != 1700000000 {
+		t.Errorf("unexpected metadata: %+v", resp)
+	}
+	if len(resp.Versions) != 0 {
+		t.Errorf("streamed records should not be retained, got %d", len(resp.Versions))
+	}
+}
+
+func TestStreamPackageVulnerabilities_CallbackError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"vulnerabilities":[{"a":1},{"a":2}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("org", "")
+	c.BaseURL = srv.URL
+	c.AuthMethod = auth.Token
+	c.Token = "tok"
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	_, err := c.StreamPackageVulnerabilities("x", 0, 0, func(json.RawMessage) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected callback error after one record, got %v after %d", err, calls)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/ecosystems":
+			_, _ = w.Write([]byte(`{"timestamp":1760500000,"ecosystems":[{"name":"pypi","count":300},{"name":"npm","count":700}]}`))
+		case "/v1/summary":
+			_, _ = w.Write([]byte(`{"database":{"distinctCveIds":285000}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClientFromCredentials(&auth.Credentials{OrgID: "org", APIKey: "key", Method: auth.DirectAPIKey})
+	c.BaseURL = srv.URL
+	c.APIVersion = "/v1"
+	c.NoCache = true
+
+	stats, err := c.GetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Ecosystems) != 2 || stats.Ecosystems[0].Name != "npm" {
+		t.Errorf("expected ecosystems sorted by count, got %+v", stats.Ecosystems)
+	}
+	if stats.TotalPackages != 1000 || stats.TotalCVEs != 285000 {
+		t.Errorf("unexpected totals: packages=%d cves=%d", stats.TotalPackages, stats.TotalCVEs)
+	}
+	if !stats.LastUpdated.Equal(time.Unix(1760500000, 0)) {
+		t.Errorf("unexpected last update %v", stats.LastUpdated)
+	}
+}
+
+func TestGetHealthSendsRequestID(t *testing.T) {
+	var requestID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(httpx.RequestIDHeader)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("org", "")
+	c.BaseURL = srv.URL + "/vdb"
+	health, err := c.GetHealth()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health["status"] != "ok" {
+		t.Errorf("unexpected health %v", health)
+	}
+	if requestID != httpx.RequestID {
+		t.Errorf("X-Request-ID = %q, want %q", requestID, httpx.RequestID)
+	}
+}
@@ -465,7 +465,7 @@ func (c *Client) V2VulnExploits(id string) (map[string]interface{}, error) {
 // chain-of-custody-friendly name + integrity check.
 func (c *Client) V2ExploitPoC(exploitUUID string) (body []byte, filename, sha256, originalURL string, err error) {
 	path := fmt.Sprintf("/exploits/%s/poc", url.PathEscape(exploitUUID))
-	urlStr := c.BaseURL + c.APIVersion + path
+	urlStr := c.endpoint(path)
 	req, err := http.NewRequest("GET", urlStr, nil)
 	if err != nil {
 		return nil, "", "", "", fmt.Errorf("new request: %w", err)
@@ -474,7 +474,7 @@ func (c *Client) V2ExploitPoC(exploitUUID string) (body []byte, filename, sha256
 		return nil, "", "", "", err
 	}
 	req.Header.Set("Accept", "application/octet-stream")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, "", "", "", err
 	}
@@ -686,7 +686,7 @@ func (c *Client) V2RawSources() (map[string]interface{}, error) {
 // content-type + sha256.
 func (c *Client) V2RawArchive(source, cveID string) (body []byte, contentType, sha256, r2Path string, err error) {
 	path := fmt.Sprintf("/raw/%s/%s", url.PathEscape(source), url.PathEscape(cveID))
-	urlStr := c.BaseURL + c.APIVersion + path
+	urlStr := c.endpoint(path)
 	req, err := http.NewRequest("GET", urlStr, nil)
 	if err != nil {
 		return nil, "", "", "", fmt.Errorf("new request: %w", err)
@@ -694,7 +694,7 @@ func (c *Client) V2RawArchive(source, cveID string) (body []byte, contentType, s
 	if err := c.addAuthHeader(req); err != nil {
 		return nil, "", "", "", err
 	}
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, "", "", "", err
 	}
@@ -3,12 +3,15 @@ package vdb
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"strconv"
@@ -16,9 +19,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/vulnetix/cli/v3/internal/httpx"
 	"github.com/vulnetix/cli/v3/pkg/auth"
 	"github.com/vulnetix/cli/v3/pkg/cache"
 	"github.com/vulnetix/cli/v3/pkg/tty"
+	"golang.org/x/sync/semaphore"
 )
 
 // Verbose controls whether transient retry/backoff progress messages
@@ -38,8 +43,9 @@ const (
 )
 
 // RateLimitInfo holds rate limit data returned in API response headers.
-// Headers are informational — the CLI never enforces limits based on them;
-// only actual HTTP 429 responses trigger retry/backoff.
+// Headers are informational — within an invocation only actual HTTP 429
+// responses trigger retry/backoff. An exhausted quota is remembered for the
+// next invocation, which acts on it under RespectRateLimit.
 type RateLimitInfo struct {
 	DayLimit   int    // RateLimit-DayLimit  (0 = unlimited)
 	Remaining  int    // RateLimit-Remaining (-1 = unlimited)
@@ -55,29 +61,40 @@ type Client struct {
 	APIVersion      string
 	OrgID           string
 	SecretKey       string
+	SessionToken    string // temporary-credential session token, signed as X-Amz-Security-Token
 	AuthMethod      auth.AuthMethod
 	APIKey          string // hex digest for Direct API Key auth
 	Token           string // Authentik API token for Bearer auth
 	HTTPClient      *http.Client
 	LastRateLimit   *RateLimitInfo
 	LastCacheStatus string // "HIT", "MISS", "LOCAL", "REVALIDATED", or "" if no X-Cache header
-	// metaMu guards LastRateLimit/LastCacheStatus. These are informational
-	// (surfaced by the `vdb` subcommand) and irrelevant to scan/sca, but the
-	// cli.* POST path now fans out concurrently, so the writes must be serialized.
+	// metaMu guards LastRateLimit/LastCacheStatus and the switch to
+	// FallbackCreds. The former are informational (surfaced by the `vdb`
+	// subcommand) and irrelevant to scan/sca, but requests fan out
+	// concurrently, so the writes and the auth fields they swap must be
+	// serialized.
 	metaMu        sync.Mutex
 	Cache         *cache.DiskCache
 	NoCache       bool
 	RefreshCache  bool
 	FallbackCreds *auth.Credentials // community creds to use when quota exhausted; nil = disabled
 	UsingFallback bool              // true after client switched to fallback (readable by cmd layer)
-	token         *TokenCache
-	tokenMutex    sync.RWMutex
+	// TokenCacheFile, when set, persists JWTs so later invocations reuse
+	// them instead of exchanging credentials again (see TokenCachePath).
+	TokenCacheFile string
+	token          *TokenCache
+	tokenMutex     sync.RWMutex
+	// limiter caps in-flight requests (see SetMaxConcurrency); nil is unlimited.
+	limiter *semaphore.Weighted
+	// rateLimitOnce and rateLimitErr hold the outcome of awaitRateLimit.
+	rateLimitOnce sync.Once
+	rateLimitErr  error
 }
 
 // TokenCache stores the JWT token and its expiration
 type TokenCache struct {
-	Token     string
-	ExpiresAt time.Time
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
 
 // TokenResponse represents the JWT token response
@@ -112,6 +129,73 @@ func (e *NotFoundError) Error() string {
 	return e.Message
 }
 
+// APIKeyRejectedError is returned when a client using Direct API Key auth has
+// its ApiKey credentials refused by a VDB endpoint. Some endpoints only accept
+// SigV4, and the raw response (often a bare 500) gives no hint of that.
+type APIKeyRejectedError struct {
+	StatusCode int
+	Detail     string
+}
+
+func (e *APIKeyRejectedError) Error() string {
+	return fmt.Sprintf("the VDB API rejected ApiKey authentication (HTTP %d: %s)\n"+
+		"This endpoint requires SigV4 credentials. Configure them with:\n"+
+		"  vulnetix auth login --org-id <org-uuid> --secret <secret>\n"+
+		"or set VVD_ORG and VVD_SECRET (or pass --org-id and --secret)", e.StatusCode, e.Detail)
+}
+
+// apiKeyRejection returns an APIKeyRejectedError when an error response to a
+// Direct API Key request is an authentication failure rather than an ordinary
+// server error: any 401, or a 403/500 whose body blames the credentials.
+func (c *Client) apiKeyRejection(statusCode int, body []byte) error {
+	c.metaMu.Lock()
+	method := c.AuthMethod
+	c.metaMu.Unlock()
+	if method != auth.DirectAPIKey {
+		return nil
+	}
+	detail := httpx.APIErrorMessage(body)
+	switch statusCode {
+	case http.StatusUnauthorized:
+	case http.StatusForbidden, http.StatusInternalServerError:
+		lower := strings.ToLower(detail)
+		if !strings.Contains(lower, "apikey") && !strings.Contains(lower, "api key") &&
+			!strings.Contains(lower, "authoriz") && !strings.Contains(lower, "authenticat") {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &APIKeyRejectedError{StatusCode: statusCode, Detail: detail}
+}
+
+// APIError is returned when the VDB API responds with an HTTP status >= 400
+// that has no more specific error type, so callers can classify the failure
+// by StatusCode rather than by message.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// newAPIError describes an error response as "API error (NNN): detail".
+func newAPIError(statusCode int, body []byte) *APIError {
+	return &APIError{StatusCode: statusCode, Message: fmt.Sprintf("API error (%d): %s", statusCode, httpx.APIErrorMessage(body))}
+}
+
+// Retriable reports whether err, from any Client request, is worth
+// retrying: a transient transport failure or a 408, 429 or 5xx response.
+func Retriable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return httpx.Retriable(nil, apiErr.StatusCode)
+	}
+	return httpx.Retriable(err, 0)
+}
+
 // CliAPIError is returned by the cli.* endpoints when the API responds with an
 // HTTP status >= 400. It carries the status code (so callers can distinguish
 // retryable 5xx/429 from terminal 4xx) and any Retry-After hint parsed from the
@@ -131,50 +215,75 @@ func (e *CliAPIError) Error() string {
 // Proxy must be set explicitly: a zero-value http.Transport has a nil Proxy and
 // silently ignores HTTP_PROXY/HTTPS_PROXY/NO_PROXY, unlike http.DefaultTransport.
 // Corporate-proxy users would otherwise see VDB calls bypass the proxy entirely.
+// Likewise a custom TLSClientConfig turns off HTTP/2 unless it is forced.
 var sharedTransport = &http.Transport{
 	Proxy:               http.ProxyFromEnvironment,
+	TLSClientConfig:     httpx.ClientTLSConfig,
+	ForceAttemptHTTP2:   true,
 	MaxIdleConns:        20,
 	MaxIdleConnsPerHost: 10,
 	IdleConnTimeout:     90 * time.Second,
 }
 
+// tracedTransport stamps the invocation's X-Request-ID on top of
+// sharedTransport's pooling.
+var tracedTransport = &httpx.Transport{Base: sharedTransport}
+
 // NewClient creates a new VDB API client using SigV4 auth
 func NewClient(orgID, secretKey string) *Client {
-	return &Client{
+	c := &Client{
 		BaseURL:    DefaultBaseURL,
 		APIVersion: DefaultAPIVersion,
 		OrgID:      orgID,
 		SecretKey:  secretKey,
 		AuthMethod: auth.SigV4,
+		// Shared with every other client; see DefaultTokenCacheFile.
+		TokenCacheFile: DefaultTokenCacheFile,
 		HTTPClient: &http.Client{
 			Timeout:   30 * time.Second,
-			Transport: sharedTransport,
+			Transport: tracedTransport,
 		},
 	}
+	c.SetMaxConcurrency(MaxConcurrentRequests)
+	return c
 }
 
 // NewClientFromCredentials creates a VDB API client from centralized credentials
 func NewClientFromCredentials(creds *auth.Credentials) *Client {
-	return &Client{
-		BaseURL:    DefaultBaseURL,
-		APIVersion: DefaultAPIVersion,
-		OrgID:      creds.OrgID,
-		SecretKey:  creds.Secret,
-		AuthMethod: creds.Method,
-		APIKey:     creds.APIKey,
-		Token:      creds.Token,
+	// VDB prefers SigV4 when the credentials also hold an ApiKey.
+	creds = creds.Prefer(auth.SigV4)
+	c := &Client{
+		BaseURL:      DefaultBaseURL,
+		APIVersion:   DefaultAPIVersion,
+		OrgID:        creds.OrgID,
+		SecretKey:    creds.Secret,
+		SessionToken: creds.SessionToken,
+		AuthMethod:   creds.Method,
+		APIKey:       creds.APIKey,
+		Token:        creds.Token,
+		// Shared with every other client; see DefaultTokenCacheFile.
+		TokenCacheFile: DefaultTokenCacheFile,
 		HTTPClient: &http.Client{
 			Timeout:   30 * time.Second,
-			Transport: sharedTransport,
+			Transport: tracedTransport,
 		},
 	}
+	c.SetMaxConcurrency(MaxConcurrentRequests)
+	return c
+}
+
+// endpoint returns the URL of path under the client's API version. A trailing
+// slash on BaseURL, as in a pasted --base-url, is dropped so the URL never
+// holds "//".
+func (c *Client) endpoint(path string) string {
+	return strings.TrimRight(c.BaseURL, "/") + c.APIVersion + path
 }
 
 // GetToken retrieves a valid JWT token (from cache or by requesting a new one)
 func (c *Client) GetToken() (string, error) {
 	// Check if we have a valid cached token with read lock
 	c.tokenMutex.RLock()
-	if c.token != nil && time.Now().Before(c.token.ExpiresAt.Add(-3*time.Minute)) {
+	if c.token.fresh(time.Now()) {
 		token := c.token.Token
 		c.tokenMutex.RUnlock()
 		return token, nil
@@ -186,22 +295,75 @@ func (c *Client) GetToken() (string, error) {
 	defer c.tokenMutex.Unlock()
 
 	// Double-check after acquiring write lock (another goroutine may have refreshed)
-	if c.token != nil && time.Now().Before(c.token.ExpiresAt.Add(-3*time.Minute)) {
+	if c.token.fresh(time.Now()) {
 		return c.token.Token, nil
 	}
 
+	// A token persisted by an earlier invocation may be hours old, so it is
+	// checked against the current clock like any other before being reused.
+	if c.TokenCacheFile != "" {
+		if tc := loadDiskToken(c.TokenCacheFile, c.tokenCacheKey()); tc.fresh(time.Now()) {
+			c.token = tc
+			return tc.Token, nil
+		}
+	}
+
 	// Request a new token
-	return c.requestNewTokenLocked()
+	token, err := c.requestNewTokenLocked()
+	if err != nil {
+		return "", err
+	}
+	if c.TokenCacheFile != "" {
+		// Persisting is an optimisation; a failure only costs the next
+		// invocation a token exchange.
+		_ = saveDiskToken(c.TokenCacheFile, c.tokenCacheKey(), c.token)
+	}
+	return token, nil
 }
 
-// requestNewTokenLocked requests a new JWT token using AWS SigV4 authentication
+// TokenRetryBackoff is how long a failed token exchange waits before it is
+// retried, multiplied by the attempt number, plus up to TokenRetryBackoff of
+// jitter; tests lower it.
+var TokenRetryBackoff = BaseBackoff
+
+// retryJitter returns a random delay between 0 and max, added to retry
+// backoffs so that many clients failing together do not retry in lockstep.
+// Tests replace it.
+var retryJitter = func(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max + 1)
+}
+
+// requestNewTokenLocked requests a new JWT token using AWS SigV4
+// authentication, retrying transient failures up to MaxRetries times so a
+// network blip does not fail the command. Rejected credentials fail at once.
 // Caller must hold tokenMutex write lock
 func (c *Client) requestNewTokenLocked() (string, error) {
+	for attempt := 1; ; attempt++ {
+		token, err := c.exchangeTokenLocked()
+		if err == nil || attempt > MaxRetries || !Retriable(err) {
+			return token, err
+		}
+		if Verbose {
+			fmt.Fprintf(os.Stderr, "[vdb] token exchange retry %d/%d: %v\n", attempt, MaxRetries, err)
+		}
+		time.Sleep(TokenRetryBackoff*time.Duration(attempt) + retryJitter(TokenRetryBackoff))
+	}
+}
+
+// exchangeTokenLocked makes a single token exchange request.
+// Caller must hold tokenMutex write lock
+func (c *Client) exchangeTokenLocked() (string, error) {
 	path := "/auth/token"
-	url := c.BaseURL + c.APIVersion + path
+	url := c.endpoint(path)
 
-	// Create the request
-	req, err := http.NewRequest("GET", url, nil)
+	// Create the request; token exchange should be quick, so it gets its own
+	// short deadline regardless of the client's overall timeout
+	ctx, cancel := httpx.WithTimeout(httpx.WithoutDump(context.Background()), httpx.AuthTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -212,7 +374,7 @@ func (c *Client) requestNewTokenLocked() (string, error) {
 	}
 
 	// Execute the request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -226,11 +388,7 @@ func (c *Client) requestNewTokenLocked() (string, error) {
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(body, &errResp); err == nil {
-			return "", fmt.Errorf("API error (%d): %s - %s", resp.StatusCode, errResp.Error, errResp.Details)
-		}
-		return "", fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		return "", newAPIError(resp.StatusCode, body)
 	}
 
 	// Parse the response
@@ -257,7 +415,7 @@ func (c *Client) requestNewTokenLocked() (string, error) {
 // GetDerivedAPIKey retrieves the static API key derived from SigV4 credentials.
 func (c *Client) GetDerivedAPIKey() (*APIKeyResponse, error) {
 	path := "/auth/api-key"
-	url := c.BaseURL + c.APIVersion + path
+	url := c.endpoint(path)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -268,7 +426,7 @@ func (c *Client) GetDerivedAPIKey() (*APIKeyResponse, error) {
 		return nil, fmt.Errorf("failed to sign request: %w", err)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -280,15 +438,7 @@ func (c *Client) GetDerivedAPIKey() (*APIKeyResponse, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
-			return nil, fmt.Errorf("API error (%d): %s - %s", resp.StatusCode, errResp.Error, errResp.Details)
-		}
-		var apiKeyResp APIKeyResponse
-		if err := json.Unmarshal(body, &apiKeyResp); err == nil && apiKeyResp.Error != "" {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, apiKeyResp.Error)
-		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, httpx.APIErrorMessage(body))
 	}
 
 	var apiKeyResp APIKeyResponse
@@ -309,13 +459,20 @@ func (c *Client) signRequest(req *http.Request, path, body string) error {
 
 	// Add the X-Amz-Date header
 	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("User-Agent", httpx.UserAgent())
 
 	// Calculate payload hash
 	payloadHash := sha512Hash(body)
 
-	// Create canonical request
+	// Create canonical request. Temporary credentials also sign their
+	// session token; headers are listed in sorted order.
 	canonicalHeaders := fmt.Sprintf("x-amz-date:%s\n", amzDate)
 	signedHeaders := "x-amz-date"
+	if c.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.SessionToken)
+		canonicalHeaders += fmt.Sprintf("x-amz-security-token:%s\n", c.SessionToken)
+		signedHeaders += ";x-amz-security-token"
+	}
 	canonicalQueryString := "" // Empty for auth endpoint, can be extended for other endpoints
 
 	canonicalRequest := fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s",
@@ -355,11 +512,18 @@ func (c *Client) signRequest(req *http.Request, path, body string) error {
 
 // addAuthHeader resolves the authorization header and sets it on the request.
 func (c *Client) addAuthHeader(req *http.Request) error {
-	switch c.AuthMethod {
-	case auth.Token:
-		req.Header.Set("Authorization", "Bearer "+c.Token)
+	if err := c.awaitRateLimit(); err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", httpx.UserAgent())
+	c.metaMu.Lock()
+	method, orgID, apiKey, bearer := c.AuthMethod, c.OrgID, c.APIKey, c.Token
+	c.metaMu.Unlock()
+	switch method {
+	case auth.Token, auth.OIDC:
+		req.Header.Set("Authorization", "Bearer "+bearer)
 	case auth.DirectAPIKey:
-		req.Header.Set("Authorization", fmt.Sprintf("ApiKey %s:%s", c.OrgID, c.APIKey))
+		req.Header.Set("Authorization", fmt.Sprintf("ApiKey %s:%s", orgID, apiKey))
 	default:
 		token, err := c.GetToken()
 		if err != nil {
@@ -371,26 +535,82 @@ func (c *Client) addAuthHeader(req *http.Request) error {
 }
 
 // applyFallbackCreds copies FallbackCreds into the client's active auth fields.
-// Must only be called when FallbackCreds != nil.
+// Must only be called when FallbackCreds != nil, with metaMu held.
 func (c *Client) applyFallbackCreds() {
 	c.OrgID = c.FallbackCreds.OrgID
 	c.APIKey = c.FallbackCreds.APIKey
 	c.AuthMethod = c.FallbackCreds.Method
 	c.SecretKey = c.FallbackCreds.Secret
+	c.SessionToken = c.FallbackCreds.SessionToken
 	c.UsingFallback = true
 }
 
+// recordResponseMeta captures the rate-limit and cache headers of resp and
+// returns the rate limit.
+func (c *Client) recordResponseMeta(resp *http.Response) *RateLimitInfo {
+	rl := parseRateLimitHeaders(resp)
+	c.metaMu.Lock()
+	c.LastRateLimit = rl
+	c.LastCacheStatus = resp.Header.Get("X-Cache")
+	c.metaMu.Unlock()
+	return rl
+}
+
+// fallBack switches the client to FallbackCreds when rl shows the quota is
+// exhausted. It reports whether the client now uses them, including when a
+// concurrent request made the switch first, so the caller can re-authenticate
+// and retry.
+func (c *Client) fallBack(rl *RateLimitInfo) bool {
+	if rl == nil || rl.Remaining != 0 {
+		return false
+	}
+	return c.useFallback()
+}
+
+// useFallback switches the client to FallbackCreds, reporting false when
+// there are none.
+func (c *Client) useFallback() bool {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	if c.FallbackCreds == nil {
+		return false
+	}
+	if !c.UsingFallback {
+		c.applyFallbackCreds()
+	}
+	return true
+}
+
 // doRequestWithRetry executes an HTTP request with retry logic for transient errors.
 // It captures rate limit and cache headers from the response.
 func (c *Client) doRequestWithRetry(req *http.Request) ([]byte, error) {
+	resp, err := c.sendWithRetry(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return responseBody, nil
+}
+
+// sendWithRetry executes an HTTP request with retry logic for transient errors
+// and returns the successful response with its body unread, so callers can
+// either buffer it or decode it incrementally. Error responses are consumed
+// and turned into errors. The caller must close the returned body.
+func (c *Client) sendWithRetry(req *http.Request) (*http.Response, error) {
 	var lastErr error
 	var lastHeaders http.Header
 	skipBackoff := false
+	// fellBack limits the switch to FallbackCreds to once per request.
+	fellBack := false
 
 	for attempt := 0; attempt <= MaxRetries; attempt++ {
 		if attempt > 0 {
 			if !skipBackoff {
-				backoff := BaseBackoff * time.Duration(1<<(attempt-1))
+				backoff := BaseBackoff*time.Duration(1<<(attempt-1)) + retryJitter(BaseBackoff/2)
 				if ra := resolveRetryAfter(lastHeaders); ra > 0 {
 					backoff = ra
 				}
@@ -412,9 +632,9 @@ func (c *Client) doRequestWithRetry(req *http.Request) ([]byte, error) {
 			}
 		}
 
-		resp, err := c.HTTPClient.Do(req)
+		resp, err := c.send(req)
 		if err != nil {
-			if isRetryableError(err) && attempt < MaxRetries {
+			if httpx.Retriable(err, 0) && attempt < MaxRetries {
 				lastErr = err
 				continue
 			}
@@ -422,10 +642,13 @@ func (c *Client) doRequestWithRetry(req *http.Request) ([]byte, error) {
 		}
 
 		// Capture rate limit and cache headers
-		c.LastRateLimit = parseRateLimitHeaders(resp)
-		c.LastCacheStatus = resp.Header.Get("X-Cache")
+		rl := c.recordResponseMeta(resp)
 		lastHeaders = resp.Header
 
+		if resp.StatusCode < 400 {
+			return resp, nil
+		}
+
 		responseBody, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		if err != nil {
@@ -433,43 +656,33 @@ func (c *Client) doRequestWithRetry(req *http.Request) ([]byte, error) {
 		}
 
 		// Quota-exhausted fallback: switch to community and retry immediately (before normal retry logic).
-		if resp.StatusCode == http.StatusTooManyRequests && c.FallbackCreds != nil && !c.UsingFallback {
-			if rl := c.LastRateLimit; rl != nil && rl.Remaining == 0 {
-				c.applyFallbackCreds()
-				if authErr := c.addAuthHeader(req); authErr == nil {
-					if Verbose {
-						fmt.Fprintf(os.Stderr, "[vdb] quota exhausted — retrying as community\n")
-					}
-					lastErr = fmt.Errorf("HTTP %d (quota exhausted)", resp.StatusCode)
-					skipBackoff = true
-					attempt = 0 // reset: after attempt++, becomes 1 with skipBackoff=true
-					continue
+		if resp.StatusCode == http.StatusTooManyRequests && !fellBack && c.fallBack(rl) {
+			fellBack = true
+			if authErr := c.addAuthHeader(req); authErr == nil {
+				if Verbose {
+					fmt.Fprintf(os.Stderr, "[vdb] quota exhausted — retrying as community\n")
 				}
+				lastErr = fmt.Errorf("HTTP %d (quota exhausted)", resp.StatusCode)
+				skipBackoff = true
+				attempt = 0 // reset: after attempt++, becomes 1 with skipBackoff=true
+				continue
 			}
 		}
 
-		if isRetryableStatus(resp.StatusCode) && attempt < MaxRetries {
-			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
-			continue
+		if rejected := c.apiKeyRejection(resp.StatusCode, responseBody); rejected != nil {
+			return nil, rejected
 		}
 
-		if resp.StatusCode >= 400 {
-			var errResp ErrorResponse
-			if err := json.Unmarshal(responseBody, &errResp); err == nil {
-				msg := fmt.Sprintf("API error (%d): %s - %s", resp.StatusCode, errResp.Error, errResp.Details)
-				if resp.StatusCode == http.StatusNotFound {
-					return nil, &NotFoundError{Message: msg}
-				}
-				return nil, fmt.Errorf("%s", msg)
-			}
-			msg := fmt.Sprintf("API error (%d): %s", resp.StatusCode, string(responseBody))
-			if resp.StatusCode == http.StatusNotFound {
-				return nil, &NotFoundError{Message: msg}
-			}
-			return nil, fmt.Errorf("%s", msg)
+		if httpx.Retriable(nil, resp.StatusCode) && attempt < MaxRetries {
+			lastErr = &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("HTTP %d", resp.StatusCode)}
+			continue
 		}
 
-		return responseBody, nil
+		apiErr := newAPIError(resp.StatusCode, responseBody)
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, &NotFoundError{Message: apiErr.Message}
+		}
+		return nil, apiErr
 	}
 
 	return nil, fmt.Errorf("request failed after %d retries: %w", MaxRetries, lastErr)
@@ -487,7 +700,7 @@ func (c *Client) DoRequest(method, path string, body interface{}) ([]byte, error
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	url := c.BaseURL + c.APIVersion + path
+	url := c.endpoint(path)
 
 	req, err := http.NewRequest(method, url, bodyReader)
 	if err != nil {
@@ -520,6 +733,33 @@ func (c *Client) DoRequest(method, path string, body interface{}) ([]byte, error
 	return c.doRequestWithRetry(req)
 }
 
+// DoRequestStream performs an authenticated GET with the same retry policy as
+// DoRequest but returns the response body unread, for endpoints whose payload
+// is large enough that it should be decoded incrementally. The caller must
+// close the returned body.
+func (c *Client) DoRequestStream(path string) (io.ReadCloser, error) {
+	req, err := http.NewRequest("GET", c.endpoint(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := c.addAuthHeader(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.NoCache || c.RefreshCache {
+		req.Header.Set("Cache-Control", "no-cache")
+		q := req.URL.Query()
+		q.Set("_t", fmt.Sprintf("%d", time.Now().UnixMilli()))
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.sendWithRetry(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
 // DoRequestGzip is DoRequest with the body compressed.
 //
 // For an ordinary request this would be pointless ceremony. For one that carries the whole shape
@@ -542,7 +782,7 @@ func (c *Client) DoRequestGzip(method, path string, body interface{}) ([]byte, e
 	}
 	compressed := buf.Bytes()
 
-	req, err := http.NewRequest(method, c.BaseURL+c.APIVersion+path, bytes.NewReader(compressed))
+	req, err := http.NewRequest(method, c.endpoint(path), bytes.NewReader(compressed))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -565,11 +805,12 @@ func (c *Client) doRequestWithRetryFull(req *http.Request) (body []byte, statusC
 	var lastErr error
 	var lastHeaders http.Header
 	skipBackoff := false
+	fellBack := false
 
 	for attempt := 0; attempt <= MaxRetries; attempt++ {
 		if attempt > 0 {
 			if !skipBackoff {
-				backoff := BaseBackoff * time.Duration(1<<(attempt-1))
+				backoff := BaseBackoff*time.Duration(1<<(attempt-1)) + retryJitter(BaseBackoff/2)
 				if ra := resolveRetryAfter(lastHeaders); ra > 0 {
 					backoff = ra
 				}
@@ -590,17 +831,16 @@ func (c *Client) doRequestWithRetryFull(req *http.Request) (body []byte, statusC
 			}
 		}
 
-		resp, doErr := c.HTTPClient.Do(req)
+		resp, doErr := c.send(req)
 		if doErr != nil {
-			if isRetryableError(doErr) && attempt < MaxRetries {
+			if httpx.Retriable(doErr, 0) && attempt < MaxRetries {
 				lastErr = doErr
 				continue
 			}
 			return nil, 0, nil, fmt.Errorf("failed to execute request: %w", doErr)
 		}
 
-		c.LastRateLimit = parseRateLimitHeaders(resp)
-		c.LastCacheStatus = resp.Header.Get("X-Cache")
+		rl := c.recordResponseMeta(resp)
 
 		responseBody, readErr := io.ReadAll(resp.Body)
 		lastHeaders = resp.Header
@@ -610,23 +850,25 @@ func (c *Client) doRequestWithRetryFull(req *http.Request) (body []byte, statusC
 		}
 
 		// Quota-exhausted fallback: switch to community and retry immediately (before normal retry logic).
-		if resp.StatusCode == http.StatusTooManyRequests && c.FallbackCreds != nil && !c.UsingFallback {
-			if rl := c.LastRateLimit; rl != nil && rl.Remaining == 0 {
-				c.applyFallbackCreds()
-				if authErr := c.addAuthHeader(req); authErr == nil {
-					if Verbose {
-						fmt.Fprintf(os.Stderr, "[vdb] quota exhausted — retrying as community\n")
-					}
-					lastErr = fmt.Errorf("HTTP %d (quota exhausted)", resp.StatusCode)
-					skipBackoff = true
-					attempt = 0 // reset: after attempt++, becomes 1 with skipBackoff=true
-					continue
+		if resp.StatusCode == http.StatusTooManyRequests && !fellBack && c.fallBack(rl) {
+			fellBack = true
+			if authErr := c.addAuthHeader(req); authErr == nil {
+				if Verbose {
+					fmt.Fprintf(os.Stderr, "[vdb] quota exhausted — retrying as community\n")
 				}
+				lastErr = fmt.Errorf("HTTP %d (quota exhausted)", resp.StatusCode)
+				skipBackoff = true
+				attempt = 0 // reset: after attempt++, becomes 1 with skipBackoff=true
+				continue
 			}
 		}
 
-		if isRetryableStatus(resp.StatusCode) && attempt < MaxRetries {
-			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+		if rejected := c.apiKeyRejection(resp.StatusCode, responseBody); rejected != nil {
+			return nil, 0, nil, rejected
+		}
+
+		if httpx.Retriable(nil, resp.StatusCode) && attempt < MaxRetries {
+			lastErr = &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("HTTP %d", resp.StatusCode)}
 			continue
 		}
 
@@ -649,14 +891,16 @@ func (c *Client) DoRequestCached(method, path string, body interface{}, ttl time
 	// Check cache (unless forced refresh)
 	if !c.RefreshCache {
 		if entry, ok := c.Cache.Get(key); ok && entry.IsFresh() {
+			c.metaMu.Lock()
 			c.LastCacheStatus = "LOCAL"
 			c.LastRateLimit = nil
+			c.metaMu.Unlock()
 			return entry.Body, nil
 		}
 	}
 
 	// Build the request
-	url := c.BaseURL + c.APIVersion + path
+	url := c.endpoint(path)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -689,25 +933,19 @@ func (c *Client) DoRequestCached(method, path string, body interface{}, ttl time
 			entry.CachedAt = time.Now()
 			entry.TTL = ttl
 			c.Cache.Put(key, entry) //nolint:errcheck
+			c.metaMu.Lock()
 			c.LastCacheStatus = "REVALIDATED"
+			c.metaMu.Unlock()
 			return entry.Body, nil
 		}
 	}
 
 	if statusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			msg := fmt.Sprintf("API error (%d): %s - %s", statusCode, errResp.Error, errResp.Details)
-			if statusCode == http.StatusNotFound {
-				return nil, &NotFoundError{Message: msg}
-			}
-			return nil, fmt.Errorf("%s", msg)
-		}
-		msg := fmt.Sprintf("API error (%d): %s", statusCode, string(respBody))
+		apiErr := newAPIError(statusCode, respBody)
 		if statusCode == http.StatusNotFound {
-			return nil, &NotFoundError{Message: msg}
+			return nil, &NotFoundError{Message: apiErr.Message}
 		}
-		return nil, fmt.Errorf("%s", msg)
+		return nil, apiErr
 	}
 
 	// Never cache semantically empty responses (e.g. search with total: 0)
@@ -799,24 +1037,6 @@ func retryCurlHint(req *http.Request) string {
 	return fmt.Sprintf("  %s%s%s", dim, cmd, reset)
 }
 
-// isRetryableError returns true for timeout and temporary network errors.
-func isRetryableError(err error) bool {
-	if os.IsTimeout(err) {
-		return true
-	}
-	msg := err.Error()
-	return strings.Contains(msg, "deadline exceeded") ||
-		strings.Contains(msg, "connection refused") ||
-		strings.Contains(msg, "connection reset")
-}
-
-// isRetryableStatus returns true for transient server errors worth retrying.
-// Includes 429 (rate limit) so the existing backoff + Retry-After logic applies.
-func isRetryableStatus(code int) bool {
-	return code == http.StatusTooManyRequests ||
-		code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
-}
-
 // resolveRetryAfter parses response headers for a Retry-After value.
 // Returns the parsed duration if set, otherwise 0.
 // Accepts both delay-seconds (integer) and HTTP-date formats.
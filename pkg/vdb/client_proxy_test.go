@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/vulnetix/cli/v3/internal/httpx"
+	"github.com/vulnetix/cli/v3/pkg/auth"
 	"golang.org/x/net/http/httpproxy"
 )
 
@@ -79,13 +81,19 @@ func TestProxyEnvironmentSemantics(t *testing.T) {
 
 // Every client built by this package must route through sharedTransport, or it
 // inherits http.DefaultTransport and this fix silently does not apply to it.
+// The request-ID wrapper must sit directly on top of it.
 func TestClientsUseSharedTransport(t *testing.T) {
 	for name, client := range map[string]*Client{
-		"NewClient": NewClient("org", "secret"),
+		"NewClient":                NewClient("org", "secret"),
+		"NewClientFromCredentials": NewClientFromCredentials(&auth.Credentials{OrgID: "org", Method: auth.SigV4}),
 	} {
-		transport, ok := client.HTTPClient.Transport.(*http.Transport)
+		traced, ok := client.HTTPClient.Transport.(*httpx.Transport)
 		if !ok {
-			t.Fatalf("%s: HTTPClient.Transport is %T, want *http.Transport", name, client.HTTPClient.Transport)
+			t.Fatalf("%s: HTTPClient.Transport is %T, want *httpx.Transport", name, client.HTTPClient.Transport)
+		}
+		transport, ok := traced.Base.(*http.Transport)
+		if !ok {
+			t.Fatalf("%s: traced transport wraps %T, want *http.Transport", name, traced.Base)
 		}
 		if transport != sharedTransport {
 			t.Errorf("%s: does not use sharedTransport", name)
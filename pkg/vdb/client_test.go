@@ -1,10 +1,13 @@
 package vdb
 
 import (
+	"encoding/hex"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vulnetix/cli/v3/pkg/auth"
 )
@@ -43,6 +46,25 @@ func TestNewClientFromCredentials(t *testing.T) {
 	}
 }
 
+func TestNewClientFromCredentialsPrefersSigV4(t *testing.T) {
+	creds := &auth.Credentials{
+		OrgID:  "test-org",
+		APIKey: "test-key",
+		Secret: "test-secret",
+		Method: auth.DirectAPIKey,
+	}
+	c := NewClientFromCredentials(creds)
+	if c.AuthMethod != auth.SigV4 {
+		t.Errorf("expected SigV4 from dual credentials, got %q", c.AuthMethod)
+	}
+	if c.SecretKey != "test-secret" || c.APIKey != "test-key" {
+		t.Errorf("expected both secrets to be kept, got secret %q key %q", c.SecretKey, c.APIKey)
+	}
+	if creds.Method != auth.DirectAPIKey {
+		t.Errorf("caller's credentials were modified: %q", creds.Method)
+	}
+}
+
 func TestDefaultConstants(t *testing.T) {
 	if DefaultBaseURL == "" {
 		t.Error("expected non-empty DefaultBaseURL")
@@ -127,3 +149,236 @@ func TestGetDerivedAPIKey(t *testing.T) {
 		t.Fatalf("Authorization header missing credential org: %q", gotAuth)
 	}
 }
+
+func TestClientTrimsTrailingSlashFromBaseURL(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"orgId":"org","apiKey":"key"}`))
+	}))
+	defer srv.Close()
+
+	for _, base := range []string{srv.URL, srv.URL + "/", srv.URL + "//"} {
+		c := NewClient("org", "secret")
+		c.BaseURL = base
+		c.APIVersion = "/v2"
+		if _, err := c.GetDerivedAPIKey(); err != nil {
+			t.Fatalf("base %q: %v", base, err)
+		}
+		if got := c.tokenCacheKey(); got != "org@"+srv.URL+"/v2" {
+			t.Errorf("base %q: token cache key = %q", base, got)
+		}
+	}
+	for _, p := range paths {
+		if p != "/v2/auth/api-key" {
+			t.Errorf("request path = %q, want /v2/auth/api-key", p)
+		}
+	}
+}
+
+func TestDirectAPIKeyRejectedByVDB(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   auth.AuthMethod
+		status   int
+		body     string
+		rejected bool
+	}{
+		{"apikey 500 blaming credentials", auth.DirectAPIKey, 500, `{"success":false,"error":"Invalid ApiKey authorization"}`, true},
+		{"apikey 401", auth.DirectAPIKey, 401, `{"success":false,"error":"Unauthorized"}`, true},
+		{"apikey 403 plan limit", auth.DirectAPIKey, 403, `{"success":false,"error":"Pro subscription required"}`, false},
+		{"sigv4 401", auth.SigV4, 401, `{"success":false,"error":"Unauthorized"}`, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if strings.HasSuffix(r.URL.Path, "/auth/token") {
+					_, _ = w.Write([]byte(`{"token":"jwt","iss":"x","sub":"y","exp":9999999999}`))
+					return
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			creds := &auth.Credentials{OrgID: "org", APIKey: "key", Method: tc.method}
+			if tc.method == auth.SigV4 {
+				creds.Secret = "secret"
+			}
+			c := NewClientFromCredentials(creds)
+			c.BaseURL = srv.URL
+			c.APIVersion = "/v2"
+
+			_, err := c.GetCVE("CVE-2021-44228")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			var rejected *APIKeyRejectedError
+			if got := errors.As(err, &rejected); got != tc.rejected {
+				t.Fatalf("APIKeyRejectedError = %v, want %v (err: %v)", got, tc.rejected, err)
+			}
+			if tc.rejected {
+				if !strings.Contains(err.Error(), "requires SigV4") || !strings.Contains(err.Error(), "VVD_SECRET") {
+					t.Errorf("message does not explain how to configure SigV4: %v", err)
+				}
+				if calls != 1 {
+					t.Errorf("a credential rejection must not be retried, got %d calls", calls)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessages(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"structured with details", `{"success":false,"error":"invalid ecosystem","details":"supported: npm, pypi"}`, "API error (400): invalid ecosystem - supported: npm, pypi"},
+		{"structured without details", `{"success":false,"error":"invalid ecosystem"}`, "API error (400): invalid ecosystem"},
+		{"unstructured", "Bad Request\n", "API error (400): Bad Request"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/auth/token") {
+					_, _ = w.Write([]byte(`{"token":"jwt","iss":"x","sub":"y","exp":9999999999}`))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient("org", "secret")
+			c.BaseURL = srv.URL
+			c.APIVersion = "/v2"
+
+			_, err := c.GetCVE("CVE-2021-44228")
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+// expectedSignature recomputes the SigV4 signature for a signed request from
+// its headers, with the canonical headers rebuilt from SignedHeaders.
+func expectedSignature(t *testing.T, req *http.Request, path, secret string) string {
+	t.Helper()
+	amzDate := req.Header.Get("X-Amz-Date")
+	authz := req.Header.Get("Authorization")
+	_, after, ok := strings.Cut(authz, "SignedHeaders=")
+	if !ok {
+		t.Fatalf("Authorization has no SignedHeaders: %q", authz)
+	}
+	signedHeaders, _, _ := strings.Cut(after, ",")
+	var canonical strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		canonical.WriteString(name + ":" + req.Header.Get(name) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{req.Method, path, "", canonical.String(), signedHeaders, sha512Hash("")}, "\n")
+	scope := amzDate[:8] + "/" + Region + "/" + Service + "/aws4_request"
+	stringToSign := strings.Join([]string{Algorithm, amzDate, scope, sha512Hash(canonicalRequest)}, "\n")
+	return hex.EncodeToString(hmacSHA512(getSignatureKey(secret, amzDate[:8], Region, Service), stringToSign))
+}
+
+func TestSignRequestWithSessionToken(t *testing.T) {
+	c := NewClient("test-org", "test-secret")
+	c.SessionToken = "FwoGZXIvYXdzEXAMPLE"
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/v2/auth/token", nil)
+	if err := c.signRequest(req, "/v2/auth/token", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != c.SessionToken {
+		t.Errorf("X-Amz-Security-Token = %q, want the session token", got)
+	}
+	authz := req.Header.Get("Authorization")
+	if !strings.Contains(authz, "SignedHeaders=x-amz-date;x-amz-security-token,") {
+		t.Errorf("session token is not a signed header: %q", authz)
+	}
+	want := expectedSignature(t, req, "/v2/auth/token", "test-secret")
+	if !strings.HasSuffix(authz, "Signature="+want) {
+		t.Errorf("signature does not cover the session token:\n got %q\nwant Signature=%s", authz, want)
+	}
+
+	// Signing the same request under another session token changes the
+	// signature, so the token cannot be swapped in transit.
+	other := NewClient("test-org", "test-secret")
+	other.SessionToken = "a-different-token"
+	req2 := httptest.NewRequest(http.MethodGet, "https://api.example.com/v2/auth/token", nil)
+	if err := other.signRequest(req2, "/v2/auth/token", ""); err != nil {
+		t.Fatal(err)
+	}
+	req2.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	if strings.HasSuffix(req2.Header.Get("Authorization"), "Signature="+expectedSignature(t, req2, "/v2/auth/token", "test-secret")) {
+		t.Error("a swapped session token still verified")
+	}
+}
+
+func TestSignRequestWithoutSessionToken(t *testing.T) {
+	c := NewClient("test-org", "test-secret")
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/v2/auth/token", nil)
+	if err := c.signRequest(req, "/v2/auth/token", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := req.Header["X-Amz-Security-Token"]; ok {
+		t.Error("X-Amz-Security-Token set without a session token")
+	}
+	authz := req.Header.Get("Authorization")
+	if !strings.Contains(authz, "SignedHeaders=x-amz-date,") {
+		t.Errorf("unexpected signed headers: %q", authz)
+	}
+	if want := expectedSignature(t, req, "/v2/auth/token", "test-secret"); !strings.HasSuffix(authz, "Signature="+want) {
+		t.Errorf("signature mismatch: %q", authz)
+	}
+}
+
+func TestNewClientFromCredentialsKeepsSessionToken(t *testing.T) {
+	c := NewClientFromCredentials(&auth.Credentials{OrgID: "test-org", Secret: "s", SessionToken: "tok", Method: auth.SigV4})
+	if c.SessionToken != "tok" {
+		t.Errorf("SessionToken = %q, want tok", c.SessionToken)
+	}
+}
+
+func TestTokenExchangeRetryAddsJitter(t *testing.T) {
+	oldBackoff, oldJitter := TokenRetryBackoff, retryJitter
+	t.Cleanup(func() { TokenRetryBackoff, retryJitter = oldBackoff, oldJitter })
+	var bounds []time.Duration
+	TokenRetryBackoff = time.Millisecond
+	retryJitter = func(max time.Duration) time.Duration {
+		bounds = append(bounds, max)
+		return 50 * time.Millisecond
+	}
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"jwt","iss":"x","sub":"y","exp":9999999999}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("org", "secret")
+	c.BaseURL = srv.URL
+	c.APIVersion = "/v2"
+	started := time.Now()
+	if _, err := c.GetToken(); err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected one retry, got %d calls", calls)
+	}
+	if len(bounds) != 1 || bounds[0] != TokenRetryBackoff {
+		t.Errorf("jitter bounds = %v, want one draw bounded by the backoff", bounds)
+	}
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("retry waited %s, want the backoff plus the stubbed jitter", elapsed)
+	}
+}
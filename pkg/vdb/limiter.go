@@ -0,0 +1,64 @@
+package vdb
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// MaxConcurrentRequests caps the requests each new Client has in flight at
+// once, however many goroutines share it; 0 means no limit. Set by the cmd
+// layer from the --vdb-concurrency flag.
+var MaxConcurrentRequests int
+
+// SetMaxConcurrency caps the client's in-flight requests at n; n <= 0 removes
+// the cap. It must be called before the client is shared between goroutines.
+func (c *Client) SetMaxConcurrency(n int) {
+	if n <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = semaphore.NewWeighted(int64(n))
+}
+
+// send executes req through HTTPClient once a concurrency slot is free. The
+// slot is held until the response body is closed, so a streamed body counts
+// as in flight for as long as it is being read. Waiting for a slot honours
+// the request's context. An exhausted quota in the response is persisted
+// (see RateLimitStateFile).
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	limiter := c.limiter
+	if limiter == nil {
+		resp, err := c.HTTPClient.Do(req)
+		if err == nil {
+			c.recordRateLimit(resp)
+		}
+		return resp, err
+	}
+	if err := limiter.Acquire(req.Context(), 1); err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		limiter.Release(1)
+		return nil, err
+	}
+	c.recordRateLimit(resp)
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { limiter.Release(1) }}
+	return resp, nil
+}
+
+// releasingBody gives back a concurrency slot the first time it is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,69 @@
+package vdb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func TestMaxConcurrencyCapsInFlightRequests(t *testing.T) {
+	const limit, callers = 2, 12
+	var inFlight, peak, served atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		served.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	prev := MaxConcurrentRequests
+	MaxConcurrentRequests = limit
+	t.Cleanup(func() { MaxConcurrentRequests = prev })
+
+	c := NewClientFromCredentials(&auth.Credentials{OrgID: "org", APIKey: "key", Method: auth.DirectAPIKey})
+	c.BaseURL = srv.URL
+
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.DoRequest("GET", "/info", nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := served.Load(); got != callers {
+		t.Fatalf("served %d requests, want %d", got, callers)
+	}
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak in-flight requests = %d, want at most %d", got, limit)
+	}
+}
+
+func TestSetMaxConcurrencyZeroRemovesLimit(t *testing.T) {
+	c := NewClient("org", "secret")
+	c.SetMaxConcurrency(3)
+	if c.limiter == nil {
+		t.Fatal("limit of 3 not applied")
+	}
+	c.SetMaxConcurrency(0)
+	if c.limiter != nil {
+		t.Error("limit of 0 should remove the limiter")
+	}
+}
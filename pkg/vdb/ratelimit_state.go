@@ -0,0 +1,132 @@
+package vdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RespectRateLimit makes a client act on a daily quota that an earlier
+// invocation exhausted (see RateLimitStateFile) before sending its first
+// request: it switches to the community fallback when it has one, waits when
+// the quota resets within MaxRateLimitWait, and otherwise fails without
+// calling the API. Set by the cmd layer from --respect-rate-limit.
+var RespectRateLimit bool
+
+// MaxRateLimitWait is the longest RespectRateLimit waits for a quota reset.
+var MaxRateLimitWait = 5 * time.Minute
+
+// RateLimitStateFile, when set, is where clients record quotas they saw
+// exhausted, so the next invocation knows about them. Set by the cmd layer to
+// RateLimitStatePath.
+var RateLimitStateFile string
+
+// rateLimitStateMu serializes read-modify-write cycles of the state file
+// between the clients of one process.
+var rateLimitStateMu sync.Mutex
+
+// RateLimitStatePath returns the file exhausted quotas are persisted to
+// between invocations.
+func RateLimitStatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("rate limit state: user home dir: %w", err)
+	}
+	return filepath.Join(homeDir, ".vulnetix", "rate-limit.json"), nil
+}
+
+// exhaustedQuota is a quota seen at zero, keyed like the token cache by the
+// org and API it belongs to.
+type exhaustedQuota struct {
+	DayLimit int       `json:"dayLimit"`
+	Plan     string    `json:"plan,omitempty"`
+	Reset    time.Time `json:"reset"`
+}
+
+func readRateLimitState(path string) map[string]exhaustedQuota {
+	state := map[string]exhaustedQuota{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	if json.Unmarshal(data, &state) != nil {
+		return map[string]exhaustedQuota{}
+	}
+	return state
+}
+
+// recordRateLimit persists the quota reported by resp when it is exhausted.
+// Soft limits never block, so they are not recorded. Failures are ignored:
+// the state only saves a later run some 429s.
+func (c *Client) recordRateLimit(resp *http.Response) {
+	if RateLimitStateFile == "" {
+		return
+	}
+	rl := parseRateLimitHeaders(resp)
+	if rl == nil || rl.SoftLimits || rl.Remaining != 0 || rl.Reset == 0 {
+		return
+	}
+	reset := time.Unix(int64(rl.Reset), 0).UTC()
+	now := time.Now()
+	if !reset.After(now) {
+		return
+	}
+
+	rateLimitStateMu.Lock()
+	defer rateLimitStateMu.Unlock()
+	state := readRateLimitState(RateLimitStateFile)
+	for k, q := range state {
+		if !now.Before(q.Reset) {
+			delete(state, k)
+		}
+	}
+	state[c.tokenCacheKey()] = exhaustedQuota{DayLimit: rl.DayLimit, Plan: rl.Plan, Reset: reset}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(RateLimitStateFile), 0700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(RateLimitStateFile), ".rate-limit-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil && cerr == nil {
+		_ = os.Rename(tmp.Name(), RateLimitStateFile)
+	}
+}
+
+// awaitRateLimit applies RespectRateLimit once per client, before its first
+// request. It returns an error when the quota stays exhausted for longer than
+// MaxRateLimitWait and there is no fallback to switch to.
+func (c *Client) awaitRateLimit() error {
+	c.rateLimitOnce.Do(func() {
+		if !RespectRateLimit || RateLimitStateFile == "" {
+			return
+		}
+		q, ok := readRateLimitState(RateLimitStateFile)[c.tokenCacheKey()]
+		wait := time.Until(q.Reset)
+		if !ok || wait <= 0 {
+			return
+		}
+		switch {
+		case c.useFallback():
+			if Verbose {
+				fmt.Fprintf(os.Stderr, "[vdb] quota exhausted by an earlier run until %s — using community\n", q.Reset.Local().Format(time.Kitchen))
+			}
+		case wait <= MaxRateLimitWait:
+			countdownSleep(wait)
+		default:
+			c.rateLimitErr = fmt.Errorf("VDB daily quota for org %s was exhausted by an earlier run and resets at %s; retry then or pass --respect-rate-limit=false",
+				c.OrgID, q.Reset.Local().Format(time.RFC3339))
+		}
+	})
+	return c.rateLimitErr
+}
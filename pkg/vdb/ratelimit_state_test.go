@@ -0,0 +1,139 @@
+package vdb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+// useRateLimitState points RateLimitStateFile at a temp file and restores the
+// package settings afterwards.
+func useRateLimitState(t *testing.T, respect bool) {
+	t.Helper()
+	origFile, origRespect, origWait := RateLimitStateFile, RespectRateLimit, MaxRateLimitWait
+	t.Cleanup(func() { RateLimitStateFile, RespectRateLimit, MaxRateLimitWait = origFile, origRespect, origWait })
+	RateLimitStateFile = filepath.Join(t.TempDir(), "rate-limit.json")
+	RespectRateLimit = respect
+}
+
+// quotaServer answers every request with the given remaining quota, resetting
+// at reset, and counts the requests it saw and the last Authorization header.
+func quotaServer(t *testing.T, remaining int, reset time.Time) (*httptest.Server, *atomic.Int32, *atomic.Value) {
+	t.Helper()
+	var calls atomic.Int32
+	var lastAuth atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		lastAuth.Store(r.Header.Get("Authorization"))
+		w.Header().Set("RateLimit-DayLimit", "100")
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		_, _ = w.Write([]byte(`{"id":"CVE-2021-44228"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls, &lastAuth
+}
+
+func tokenClient(baseURL string) *Client {
+	c := NewClientFromCredentials(&auth.Credentials{OrgID: "org-1", Token: "tok", Method: auth.Token})
+	c.BaseURL = baseURL
+	c.APIVersion = "/v2"
+	return c
+}
+
+func TestExhaustedQuotaPersistsAcrossClients(t *testing.T) {
+	useRateLimitState(t, true)
+	reset := time.Now().Add(time.Hour)
+	srv, calls, _ := quotaServer(t, 0, reset)
+
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+	state := readRateLimitState(RateLimitStateFile)
+	if len(state) != 1 {
+		t.Fatalf("state = %v, want the exhausted quota recorded", state)
+	}
+
+	// A later invocation fails fast instead of running into a 429.
+	_, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228")
+	if err == nil || !strings.Contains(err.Error(), "exhausted by an earlier run") {
+		t.Fatalf("err = %v, want the persisted quota to stop the request", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server saw %d requests, want only the first", calls.Load())
+	}
+
+	// Without the toggle the client ignores the state.
+	RespectRateLimit = false
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatalf("with --respect-rate-limit off: %v", err)
+	}
+}
+
+func TestExhaustedQuotaSwitchesToFallback(t *testing.T) {
+	useRateLimitState(t, true)
+	srv, calls, lastAuth := quotaServer(t, 0, time.Now().Add(time.Hour))
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+
+	c := tokenClient(srv.URL)
+	c.FallbackCreds = &auth.Credentials{OrgID: "community", APIKey: "ck", Method: auth.DirectAPIKey}
+	if _, err := c.GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+	if !c.UsingFallback || lastAuth.Load() != "ApiKey community:ck" {
+		t.Errorf("UsingFallback = %v, Authorization = %v; want the community credentials", c.UsingFallback, lastAuth.Load())
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server saw %d requests, want 2", calls.Load())
+	}
+}
+
+func TestExhaustedQuotaWaitsForNearReset(t *testing.T) {
+	useRateLimitState(t, true)
+	srv, _, _ := quotaServer(t, 0, time.Now().Add(2*time.Second))
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 500*time.Millisecond {
+		t.Errorf("returned after %s, want a wait for the reset", time.Since(start))
+	}
+}
+
+func TestQuotaWithHeadroomIsNotPersisted(t *testing.T) {
+	useRateLimitState(t, true)
+	srv, _, _ := quotaServer(t, 40, time.Now().Add(time.Hour))
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+	if state := readRateLimitState(RateLimitStateFile); len(state) != 0 {
+		t.Errorf("state = %v, want nothing recorded", state)
+	}
+}
+
+func TestResponseWithoutRateLimitHeadersIsNotPersisted(t *testing.T) {
+	useRateLimitState(t, true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"CVE-2021-44228"}`))
+	}))
+	defer srv.Close()
+	if _, err := tokenClient(srv.URL).GetCVE("CVE-2021-44228"); err != nil {
+		t.Fatal(err)
+	}
+	if state := readRateLimitState(RateLimitStateFile); len(state) != 0 {
+		t.Errorf("state = %v, want nothing recorded", state)
+	}
+}
@@ -0,0 +1,121 @@
+package vdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SpecCacheTTL is how long a cached OpenAPI spec is trusted. The spec only
+// changes with API releases, so it is kept far longer than response caches.
+const SpecCacheTTL = 7 * 24 * time.Hour
+
+// cachedSpec is the on-disk form of the spec cache. BaseURL ties the entry to
+// the API it was fetched from so switching --base-url never reuses it.
+type cachedSpec struct {
+	FetchedAt time.Time      `json:"fetchedAt"`
+	BaseURL   string         `json:"baseUrl"`
+	Spec      map[string]any `json:"spec"`
+}
+
+// SpecCachePath returns the spec cache file, ~/.vulnetix/cache/vdb-spec.json.
+func SpecCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("spec cache: user home dir: %w", err)
+	}
+	return filepath.Join(homeDir, ".vulnetix", "cache", "vdb-spec.json"), nil
+}
+
+// LoadCachedSpec returns the spec cached at path for baseURL when it is
+// younger than ttl. Any read or decode problem is a miss.
+func LoadCachedSpec(path, baseURL string, ttl time.Duration) (map[string]any, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cachedSpec
+	if json.Unmarshal(data, &entry) != nil || entry.Spec == nil {
+		return nil, false
+	}
+	if entry.BaseURL != baseURL || time.Since(entry.FetchedAt) > ttl {
+		return nil, false
+	}
+	return entry.Spec, true
+}
+
+// SaveCachedSpec writes spec to path as fetched from baseURL now.
+func SaveCachedSpec(path, baseURL string, spec map[string]any) error {
+	data, err := json.Marshal(cachedSpec{FetchedAt: time.Now().UTC(), BaseURL: baseURL, Spec: spec})
+	if err != nil {
+		return fmt.Errorf("spec cache: marshal: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("spec cache: mkdir: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// apiVersionSegment matches a leading "/v1", "/v2", ... path segment.
+var apiVersionSegment = regexp.MustCompile(`^/v\d+(/|$)`)
+
+// RouteAPIVersion returns the leading API version segment of path, such as
+// "/v2" for "/v2/raw/ghsa/CVE-2024-1", or "" when it has none.
+func RouteAPIVersion(path string) string {
+	loc := apiVersionSegment.FindStringIndex(path)
+	if loc == nil {
+		return ""
+	}
+	return strings.TrimSuffix(path[:loc[1]], "/")
+}
+
+// SpecHasOperation reports whether spec declares method on a path template
+// matching path, e.g. "/v2/raw/ghsa/CVE-2024-1" against
+// "/raw/{source}/{cveId}". Specs list paths with or without the API version
+// prefix, so both forms of path are tried.
+func SpecHasOperation(spec map[string]any, method, path string) bool {
+	paths, _ := spec["paths"].(map[string]any)
+	candidates := []string{path}
+	if loc := apiVersionSegment.FindStringIndex(path); loc != nil {
+		candidates = append(candidates, "/"+strings.TrimPrefix(path[loc[1]:], "/"))
+	}
+	method = strings.ToLower(method)
+	for tmpl, item := range paths {
+		ops, _ := item.(map[string]any)
+		if _, ok := ops[method]; !ok {
+			continue
+		}
+		for _, p := range candidates {
+			if pathMatchesTemplate(tmpl, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathMatchesTemplate compares segment by segment; a "{param}" template
+// segment matches any non-empty segment.
+func pathMatchesTemplate(tmpl, path string) bool {
+	ts := strings.Split(strings.Trim(tmpl, "/"), "/")
+	ps := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ts) != len(ps) {
+		return false
+	}
+	for i, t := range ts {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if ps[i] == "" {
+				return false
+			}
+			continue
+		}
+		if t != ps[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package vdb
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testSpec() map[string]any {
+	return map[string]any{
+		"openapi": "3.1.0",
+		"paths": map[string]any{
+			"/raw/sources":          map[string]any{"get": map[string]any{}},
+			"/raw/{source}/{cveId}": map[string]any{"get": map[string]any{}},
+			"/v2/cli.upload":        map[string]any{"post": map[string]any{}},
+		},
+	}
+}
+
+func TestSpecCacheHitAndMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "vdb-spec.json")
+	const base = "https://api.vdb.vulnetix.com"
+
+	if _, ok := LoadCachedSpec(path, base, SpecCacheTTL); ok {
+		t.Fatal("expected a miss before anything is cached")
+	}
+	if err := SaveCachedSpec(path, base, testSpec()); err != nil {
+		t.Fatalf("SaveCachedSpec: %v", err)
+	}
+
+	spec, ok := LoadCachedSpec(path, base, SpecCacheTTL)
+	if !ok || spec["openapi"] != "3.1.0" {
+		t.Fatalf("expected a cache hit, got %v %v", spec, ok)
+	}
+	if _, ok := LoadCachedSpec(path, "http://localhost:8787", SpecCacheTTL); ok {
+		t.Error("a spec cached for another base URL must not be reused")
+	}
+
+	if _, ok := LoadCachedSpec(path, base, time.Nanosecond); ok {
+		t.Error("an expired spec must be a miss")
+	}
+}
+
+func TestSpecHasOperation(t *testing.T) {
+	spec := testSpec()
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/v2/raw/ghsa/CVE-2024-1", true},
+		{"GET", "/raw/ghsa/CVE-2024-1", true},
+		{"GET", "/v2/raw/sources", true},
+		{"POST", "/v2/cli.upload", true},
+		{"POST", "/v2/raw/ghsa/CVE-2024-1", false},
+		{"GET", "/v2/raws/ghsa/CVE-2024-1", false},
+		{"GET", "/v2/raw/ghsa", false},
+		{"GET", "/v2/raw//CVE-2024-1", false},
+	}
+	for _, tc := range tests {
+		if got := SpecHasOperation(spec, tc.method, tc.path); got != tc.want {
+			t.Errorf("SpecHasOperation(%s %s) = %v, want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+	if SpecHasOperation(map[string]any{}, "GET", "/v2/raw/sources") {
+		t.Error("a spec without paths declares nothing")
+	}
+}
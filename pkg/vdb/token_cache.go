@@ -0,0 +1,182 @@
+package vdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenRefreshBuffer is how long before its expiry a JWT stops being reused,
+// so a request never goes out with a token that lapses in flight.
+const TokenRefreshBuffer = 3 * time.Minute
+
+// DefaultTokenCacheFile is the TokenCacheFile of every client NewClient and
+// NewClientFromCredentials create, so all of a run's VDB clients, whichever
+// command made them, share persisted JWTs. Set by the cmd layer to
+// TokenCachePath.
+var DefaultTokenCacheFile string
+
+// tokenCacheMu serializes read-modify-write cycles of the token cache file
+// between the clients of one process.
+var tokenCacheMu sync.Mutex
+
+// TokenCachePath returns the file JWTs are persisted to between invocations.
+func TokenCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("token cache: user home dir: %w", err)
+	}
+	return filepath.Join(homeDir, ".vulnetix", "token-cache.json"), nil
+}
+
+// fresh reports whether the token can still be used at now.
+func (t *TokenCache) fresh(now time.Time) bool {
+	return t != nil && t.Token != "" && now.Before(t.ExpiresAt.Add(-TokenRefreshBuffer))
+}
+
+// tokenCacheKey identifies the credentials and API a token was issued for.
+func (c *Client) tokenCacheKey() string {
+	return c.OrgID + "@" + c.endpoint("")
+}
+
+// CachedTokenExpiry returns the expiry of the JWT cached in TokenCacheFile
+// for this client's credentials and API, when one is cached and still usable.
+func (c *Client) CachedTokenExpiry() (time.Time, bool) {
+	if c.TokenCacheFile == "" {
+		return time.Time{}, false
+	}
+	tc := loadDiskToken(c.TokenCacheFile, c.tokenCacheKey())
+	if !tc.fresh(time.Now()) {
+		return time.Time{}, false
+	}
+	return tc.ExpiresAt, true
+}
+
+// readTokenCache decodes the token cache file. Any read or decode problem
+// yields an empty cache.
+func readTokenCache(path string) map[string]TokenCache {
+	tokens := map[string]TokenCache{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tokens
+	}
+	if json.Unmarshal(data, &tokens) != nil {
+		return map[string]TokenCache{}
+	}
+	return tokens
+}
+
+// loadDiskToken returns the token cached at path under key, or nil. Expiry is
+// not checked here; the caller compares it against the current clock.
+func loadDiskToken(path, key string) *TokenCache {
+	tc, ok := readTokenCache(path)[key]
+	if !ok {
+		return nil
+	}
+	return &tc
+}
+
+// saveDiskToken stores tc under key, dropping entries that have expired.
+func saveDiskToken(path, key string, tc *TokenCache) error {
+	tokenCacheMu.Lock()
+	defer tokenCacheMu.Unlock()
+	now := time.Now()
+	tokens := readTokenCache(path)
+	for k, t := range tokens {
+		if !now.Before(t.ExpiresAt) {
+			delete(tokens, k)
+		}
+	}
+	tokens[key] = *tc
+	return writeTokenCache(path, tokens)
+}
+
+// writeTokenCache replaces the token cache file with tokens. The file holds
+// bearer tokens, so it is written 0600 via a temp file and rename.
+func writeTokenCache(path string, tokens map[string]TokenCache) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".token-cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// CachedToken describes one entry of the token cache file. The token itself
+// is never included.
+type CachedToken struct {
+	Key       string    `json:"key"`
+	OrgID     string    `json:"org"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ExpiredCachedTokens lists, sorted by key, the tokens cached at path that
+// had expired by now, across every org and API they were issued for.
+func ExpiredCachedTokens(path string, now time.Time) []CachedToken {
+	var expired []CachedToken
+	for key, t := range readTokenCache(path) {
+		if !now.Before(t.ExpiresAt) {
+			org, _, _ := strings.Cut(key, "@")
+			expired = append(expired, CachedToken{Key: key, OrgID: org, ExpiresAt: t.ExpiresAt})
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Key < expired[j].Key })
+	return expired
+}
+
+// PruneTokenCache removes the tokens cached at path that had expired by now
+// and returns them. Unexpired tokens are kept; the file is left untouched
+// when nothing has expired.
+func PruneTokenCache(path string, now time.Time) ([]CachedToken, error) {
+	return removeCachedTokens(path, func(t CachedToken) bool { return !now.Before(t.ExpiresAt) })
+}
+
+// PurgeOrgTokens removes every token cached at path for orgID, whichever API
+// it was issued for, and returns them. The file is left untouched when there
+// are none.
+func PurgeOrgTokens(path, orgID string) ([]CachedToken, error) {
+	return removeCachedTokens(path, func(t CachedToken) bool { return t.OrgID == orgID })
+}
+
+// removeCachedTokens removes the tokens cached at path that match, sorted by
+// key.
+func removeCachedTokens(path string, match func(CachedToken) bool) ([]CachedToken, error) {
+	tokenCacheMu.Lock()
+	defer tokenCacheMu.Unlock()
+	tokens := readTokenCache(path)
+	var removed []CachedToken
+	for key, t := range tokens {
+		org, _, _ := strings.Cut(key, "@")
+		if ct := (CachedToken{Key: key, OrgID: org, ExpiresAt: t.ExpiresAt}); match(ct) {
+			removed = append(removed, ct)
+			delete(tokens, key)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	if err := writeTokenCache(path, tokens); err != nil {
+		return nil, err
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Key < removed[j].Key })
+	return removed, nil
+}
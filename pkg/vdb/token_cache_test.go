@@ -0,0 +1,203 @@
+package vdb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vulnetix/cli/v3/pkg/auth"
+)
+
+func TestGetTokenUsesDiskCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  time.Duration
+		wantReused bool
+	}{
+		{"valid beyond refresh buffer", 10 * time.Minute, true},
+		{"inside refresh buffer", TokenRefreshBuffer - time.Minute, false},
+		{"expired during a long gap", -6 * time.Hour, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			exchanges := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/auth/token") {
+					exchanges++
+					_, _ = w.Write([]byte(`{"token":"fresh-jwt","exp":9999999999}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer srv.Close()
+
+			c := NewClient("org", "secret")
+			c.BaseURL = srv.URL
+			c.APIVersion = "/v2"
+			c.TokenCacheFile = filepath.Join(t.TempDir(), "token-cache.json")
+			cached := &TokenCache{Token: "disk-jwt", ExpiresAt: time.Now().Add(tc.expiresIn)}
+			if err := saveDiskToken(c.TokenCacheFile, c.tokenCacheKey(), cached); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := c.GetToken()
+			if err != nil {
+				t.Fatalf("GetToken: %v", err)
+			}
+			if tc.wantReused {
+				if got != "disk-jwt" || exchanges != 0 {
+					t.Errorf("got %q after %d exchanges, want the disk token reused", got, exchanges)
+				}
+				return
+			}
+			if got != "fresh-jwt" || exchanges != 1 {
+				t.Errorf("got %q after %d exchanges, want one refresh", got, exchanges)
+			}
+			if persisted := loadDiskToken(c.TokenCacheFile, c.tokenCacheKey()); persisted == nil || persisted.Token != "fresh-jwt" {
+				t.Errorf("refreshed token not persisted, got %+v", persisted)
+			}
+		})
+	}
+}
+
+func TestSaveDiskTokenKeepsOtherKeysAndPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-cache.json")
+	future := time.Now().Add(time.Hour)
+	if err := saveDiskToken(path, "old", &TokenCache{Token: "a", ExpiresAt: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveDiskToken(path, "org-1", &TokenCache{Token: "b", ExpiresAt: future}); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveDiskToken(path, "org-2", &TokenCache{Token: "c", ExpiresAt: future}); err != nil {
+		t.Fatal(err)
+	}
+
+	if loadDiskToken(path, "old") != nil {
+		t.Error("expired entries should be pruned on save")
+	}
+	if tc := loadDiskToken(path, "org-1"); tc == nil || tc.Token != "b" {
+		t.Errorf("org-1 = %+v, want token b", tc)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("token cache mode = %#o, want 0600", perm)
+	}
+}
+
+func TestCachedTokenExpiry(t *testing.T) {
+	c := NewClient("org", "secret")
+	if _, ok := c.CachedTokenExpiry(); ok {
+		t.Error("no cache file configured, want no expiry")
+	}
+	c.TokenCacheFile = filepath.Join(t.TempDir(), "token-cache.json")
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := saveDiskToken(c.TokenCacheFile, c.tokenCacheKey(), &TokenCache{Token: "jwt", ExpiresAt: exp}); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.CachedTokenExpiry(); !ok || !got.Equal(exp) {
+		t.Errorf("CachedTokenExpiry = %v, %v; want %v", got, ok, exp)
+	}
+}
+
+func TestPruneTokenCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-cache.json")
+	now := time.Now()
+	if err := writeTokenCache(path, map[string]TokenCache{
+		"org-a@https://api.vdb.vulnetix.com/v2": {Token: "a", ExpiresAt: now.Add(-time.Hour)},
+		"org-b@https://api.vdb.vulnetix.com/v1": {Token: "b", ExpiresAt: now.Add(-time.Minute)},
+		"org-c@https://api.vdb.vulnetix.com/v2": {Token: "c", ExpiresAt: now.Add(time.Hour)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expired := ExpiredCachedTokens(path, now)
+	if len(expired) != 2 || expired[0].OrgID != "org-a" || expired[1].OrgID != "org-b" {
+		t.Fatalf("ExpiredCachedTokens = %+v, want org-a and org-b", expired)
+	}
+	if len(readTokenCache(path)) != 3 {
+		t.Fatal("listing expired tokens must not modify the cache")
+	}
+
+	pruned, err := PruneTokenCache(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 2 {
+		t.Errorf("pruned %d tokens, want 2", len(pruned))
+	}
+	remaining := readTokenCache(path)
+	if len(remaining) != 1 || remaining["org-c@https://api.vdb.vulnetix.com/v2"].Token != "c" {
+		t.Errorf("remaining cache = %+v, want only org-c", remaining)
+	}
+
+	if pruned, err := PruneTokenCache(path, now); err != nil || len(pruned) != 0 {
+		t.Errorf("second prune = %v, %v; want nothing", pruned, err)
+	}
+}
+
+func TestClientsShareDefaultTokenCacheFile(t *testing.T) {
+	exchanges := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/auth/token") {
+			exchanges++
+			_, _ = w.Write([]byte(`{"token":"shared-jwt","exp":9999999999}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	orig := DefaultTokenCacheFile
+	DefaultTokenCacheFile = filepath.Join(t.TempDir(), "token-cache.json")
+	t.Cleanup(func() { DefaultTokenCacheFile = orig })
+
+	// One client per code path, as separate invocations would create them.
+	for _, c := range []*Client{
+		NewClient("org", "secret"),
+		NewClientFromCredentials(&auth.Credentials{OrgID: "org", Secret: "secret", APIKey: "key", Method: auth.DirectAPIKey}),
+	} {
+		c.BaseURL = srv.URL
+		c.APIVersion = "/v2"
+		got, err := c.GetToken()
+		if err != nil {
+			t.Fatalf("GetToken: %v", err)
+		}
+		if got != "shared-jwt" {
+			t.Errorf("token = %q", got)
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("%d token exchanges, want the second client to reuse the persisted token", exchanges)
+	}
+}
+
+func TestPurgeOrgTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-cache.json")
+	future := time.Now().Add(time.Hour)
+	if err := writeTokenCache(path, map[string]TokenCache{
+		"org-a@https://api.vdb.vulnetix.com/v1": {Token: "a", ExpiresAt: future},
+		"org-a@https://api.vdb.vulnetix.com/v2": {Token: "b", ExpiresAt: future},
+		"org-b@https://api.vdb.vulnetix.com/v2": {Token: "c", ExpiresAt: future},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := PurgeOrgTokens(path, "org-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(purged) != 2 || purged[0].Key != "org-a@https://api.vdb.vulnetix.com/v1" {
+		t.Errorf("purged = %+v, want both org-a tokens", purged)
+	}
+	if remaining := readTokenCache(path); len(remaining) != 1 || remaining["org-b@https://api.vdb.vulnetix.com/v2"].Token != "c" {
+		t.Errorf("remaining cache = %+v, want only org-b", remaining)
+	}
+}